@@ -0,0 +1,264 @@
+package api_tests
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/segmentio/ksuid"
+)
+
+func TestArea_HappyPath(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create areas
+	var areaIDs []string
+
+	for i := 0; i < 2; i++ {
+		area := e.POST("/user/areas/").
+			WithHeader("Authorization", "Bearer "+accessToken).
+			WithJSON(model.AreaRequestData{
+				Title: gofakeit.Word(),
+			}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().Object().Value(key.Data).Object()
+
+		area.Value("default_list_id").String().NotEmpty()
+
+		areaIDs = append(areaIDs, area.Value(key.AreaID).String().Raw())
+	}
+
+	// Create list in the first area
+	list := e.POST("/user/lists/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.ListRequestData{
+			Title:  gofakeit.Word(),
+			AreaID: areaIDs[0],
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	listID := list.Value(key.Data).Object().Value(key.ListID).String().Raw()
+
+	// Every list has its area_id
+	e.GET("/user/lists/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Array().
+		Find(func(_ int, value *httpexpect.Value) bool {
+			return value.Object().Value(key.ListID).String().Raw() == listID
+		}).
+		Object().Value(key.AreaID).String().IsEqual(areaIDs[0])
+
+	// Lists are nested under their area, the top level has only the default list
+	lists := e.GET("/user/lists/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithQuery(key.GroupBy, model.GroupByArea).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Object()
+
+	lists.Value("lists").Array().Length().IsEqual(1)
+	lists.Value("areas").Array().Length().IsEqual(2)
+	lists.Value("areas").Array().Value(0).Object().Value("lists").Array().
+		Value(0).Object().Value(key.ListID).String().IsEqual(listID)
+
+	// Move the second area to the top
+	e.PATCH("/user/areas/{area_id}/move", areaIDs[1]).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.AreaMoveRequestData{
+			Position: 0,
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Array().
+		Value(0).Object().Value(key.AreaID).String().IsEqual(areaIDs[1])
+
+	// Delete the first area, its list is moved to the top level
+	e.DELETE("/user/areas/{area_id}", areaIDs[0]).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK)
+
+	e.GET("/user/lists/{list_id}", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Object().NotContainsKey(key.AreaID)
+
+	// Move the list into the second area
+	e.PATCH("/user/lists/{list_id}/move", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.ListMoveRequestData{
+			AreaID: areaIDs[1],
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Object().Value(key.AreaID).String().IsEqual(areaIDs[1])
+
+	// Delete the second area with its lists archived
+	e.DELETE("/user/areas/{area_id}", areaIDs[1]).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithQuery(key.Lists, model.AreaListsArchive).
+		Expect().
+		Status(http.StatusOK)
+
+	e.GET("/user/lists/{list_id}", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusNotFound)
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestArea_FailCases(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create area
+	area := e.POST("/user/areas/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.AreaRequestData{
+			Title: gofakeit.Word(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object()
+
+	areaID := area.Value(key.AreaID).String().Raw()
+	areaDefaultListID := area.Value("default_list_id").String().Raw()
+
+	defaultListID := e.GET("/user/lists/default").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Object().Value(key.ListID).String().Raw()
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		id     string
+		query  string
+		body   any
+		status int
+	}{
+		{
+			name:   "Create area without title",
+			method: http.MethodPost,
+			path:   "/user/areas/",
+			body:   model.AreaRequestData{},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Get not existing area",
+			method: http.MethodGet,
+			path:   "/user/areas/{area_id}",
+			id:     ksuid.New().String(),
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Create list in not existing area",
+			method: http.MethodPost,
+			path:   "/user/lists/",
+			body:   model.ListRequestData{Title: gofakeit.Word(), AreaID: ksuid.New().String()},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Move default list",
+			method: http.MethodPatch,
+			path:   "/user/lists/{list_id}/move",
+			id:     defaultListID,
+			body:   model.ListMoveRequestData{AreaID: areaID},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Move default list of area",
+			method: http.MethodPatch,
+			path:   "/user/lists/{list_id}/move",
+			id:     areaDefaultListID,
+			body:   model.ListMoveRequestData{},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Move not existing area",
+			method: http.MethodPatch,
+			path:   "/user/areas/{area_id}/move",
+			id:     ksuid.New().String(),
+			body:   model.AreaMoveRequestData{},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Delete area with unknown lists action",
+			method: http.MethodDelete,
+			path:   "/user/areas/{area_id}",
+			id:     areaID,
+			query:  "trash",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var req *httpexpect.Request
+			if tc.id == "" {
+				req = e.Request(tc.method, tc.path)
+			} else {
+				req = e.Request(tc.method, tc.path, tc.id)
+			}
+
+			req = req.WithHeader("Authorization", "Bearer "+accessToken)
+			if tc.query != "" {
+				req = req.WithQuery(key.Lists, tc.query)
+			}
+			if tc.body != nil {
+				req = req.WithJSON(tc.body)
+			}
+
+			req.Expect().Status(tc.status)
+		})
+	}
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
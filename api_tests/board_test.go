@@ -0,0 +1,224 @@
+package api_tests
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/segmentio/ksuid"
+)
+
+func TestBoard_HappyPath(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create list
+	list := e.POST("/user/lists/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.ListRequestData{
+			Title: gofakeit.Word(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	listID := list.Value(key.Data).Object().Value(key.ListID).String().Raw()
+
+	// Create tasks
+	var taskIDs []string
+
+	for i := 0; i < 3; i++ {
+		task := e.POST("/user/lists/{list_id}/tasks", listID).
+			WithHeader("Authorization", "Bearer "+accessToken).
+			WithJSON(randomFakeTask(somedayTasks, listID, "")).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().Object()
+
+		taskIDs = append(taskIDs, task.Value(key.Data).Object().Value(key.TaskID).String().Raw())
+	}
+
+	// Get board, the columns are Not started, Planned and Completed
+	board := e.GET("/user/lists/{list_id}/board", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Array()
+
+	board.Length().IsEqual(3)
+	board.Value(0).Object().Value(key.StatusID).Number().IsEqual(statusNotStarted)
+	board.Value(0).Object().Value("tasks_count").Number().IsEqual(3)
+
+	// Move the last task to the top of the column
+	board = e.PATCH("/user/lists/{list_id}/board/move", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TaskBoardMoveRequestData{
+			ID:       taskIDs[2],
+			StatusID: statusNotStarted,
+			Position: 0,
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Array()
+
+	board.Value(0).Object().Value(key.Tasks).Array().
+		Value(0).Object().Value(key.TaskID).String().IsEqual(taskIDs[2])
+
+	// Move the first task to the Completed column
+	board = e.PATCH("/user/lists/{list_id}/board/move", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TaskBoardMoveRequestData{
+			ID:       taskIDs[0],
+			StatusID: statusCompleted,
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Array()
+
+	board.Value(0).Object().Value("tasks_count").Number().IsEqual(2)
+	board.Value(2).Object().Value("tasks_count").Number().IsEqual(1)
+	board.Value(2).Object().Value(key.Tasks).Array().
+		Value(0).Object().Value(key.TaskID).String().IsEqual(taskIDs[0])
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestBoard_FailCases(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create list
+	list := e.POST("/user/lists/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.ListRequestData{
+			Title: gofakeit.Word(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	listID := list.Value(key.Data).Object().Value(key.ListID).String().Raw()
+
+	// Create task
+	task := e.POST("/user/lists/{list_id}/tasks", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(randomFakeTask(somedayTasks, listID, "")).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	taskID := task.Value(key.Data).Object().Value(key.TaskID).String().Raw()
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		listID string
+		body   any
+		status int
+	}{
+		{
+			name:   "Get board of not existing list",
+			method: http.MethodGet,
+			path:   "/user/lists/{list_id}/board",
+			listID: ksuid.New().String(),
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Move not existing task",
+			method: http.MethodPatch,
+			path:   "/user/lists/{list_id}/board/move",
+			listID: listID,
+			body: model.TaskBoardMoveRequestData{
+				ID:       ksuid.New().String(),
+				StatusID: statusPlanned,
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Move task of another list",
+			method: http.MethodPatch,
+			path:   "/user/lists/{list_id}/board/move",
+			listID: ksuid.New().String(),
+			body: model.TaskBoardMoveRequestData{
+				ID:       taskID,
+				StatusID: statusPlanned,
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Move task to archived",
+			method: http.MethodPatch,
+			path:   "/user/lists/{list_id}/board/move",
+			listID: listID,
+			body: model.TaskBoardMoveRequestData{
+				ID:       taskID,
+				StatusID: statusArchived,
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Move task to negative position",
+			method: http.MethodPatch,
+			path:   "/user/lists/{list_id}/board/move",
+			listID: listID,
+			body: model.TaskBoardMoveRequestData{
+				ID:       taskID,
+				StatusID: statusPlanned,
+				Position: -1,
+			},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := e.Request(tc.method, tc.path, tc.listID).
+				WithHeader("Authorization", "Bearer "+accessToken)
+			if tc.body != nil {
+				req = req.WithJSON(tc.body)
+			}
+
+			req.Expect().Status(tc.status)
+		})
+	}
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
@@ -0,0 +1,212 @@
+package api_tests
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+func TestCompleteHeading_HappyPath(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create list
+	listID := e.POST("/user/lists/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.ListRequestData{
+			Title: gofakeit.Word(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object().Value(key.ListID).String().Raw()
+
+	// Create heading
+	headingID := e.POST("/user/lists/{list_id}/headings/", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.HeadingRequestData{
+			Title:  gofakeit.Word(),
+			ListID: listID,
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object().Value(key.HeadingID).String().Raw()
+
+	// Create tasks in the heading
+	for i := 0; i < 2; i++ {
+		e.POST("/user/lists/{list_id}/headings/{heading_id}", listID, headingID).
+			WithHeader("Authorization", "Bearer "+accessToken).
+			WithJSON(randomFakeTask(somedayTasks, listID, headingID)).
+			Expect().
+			Status(http.StatusCreated)
+	}
+
+	// Complete the heading with its open tasks
+	e.PATCH("/user/lists/{list_id}/headings/{heading_id}/complete", listID, headingID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Object().Value(key.HeadingID).String().IsEqual(headingID)
+
+	// The heading is shown in the logbook with its tasks
+	heading := e.GET("/user/tasks/completed").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Array().
+		Value(0).Object().Value("headings").Array().
+		Value(0).Object()
+
+	heading.Value(key.HeadingID).String().IsEqual(headingID)
+	heading.Value(key.Tasks).Array().Length().IsEqual(2)
+
+	// Restore the heading, its tasks are reopened
+	e.PATCH("/user/lists/{list_id}/headings/{heading_id}/restore", listID, headingID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK)
+
+	e.GET("/user/tasks/completed").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).IsNull()
+
+	// Complete the heading with its open tasks cancelled
+	e.PATCH("/user/lists/{list_id}/headings/{heading_id}/complete", listID, headingID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithQuery(key.Tasks, model.HeadingTasksCancel).
+		Expect().
+		Status(http.StatusOK)
+
+	e.GET("/user/tasks/archived").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Array().NotEmpty()
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestCompleteHeading_FailCases(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create list
+	listID := e.POST("/user/lists/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.ListRequestData{
+			Title: gofakeit.Word(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object().Value(key.ListID).String().Raw()
+
+	defaultHeadingID := e.GET("/user/lists/{list_id}/headings/", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Array().
+		Value(0).Object().Value(key.HeadingID).String().Raw()
+
+	// Create heading
+	headingID := e.POST("/user/lists/{list_id}/headings/", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.HeadingRequestData{
+			Title:  gofakeit.Word(),
+			ListID: listID,
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object().Value(key.HeadingID).String().Raw()
+
+	testCases := []struct {
+		name      string
+		path      string
+		headingID string
+		query     string
+		status    int
+	}{
+		{
+			name:      "Complete default heading",
+			path:      "/user/lists/{list_id}/headings/{heading_id}/complete",
+			headingID: defaultHeadingID,
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Complete heading with unknown tasks action",
+			path:      "/user/lists/{list_id}/headings/{heading_id}/complete",
+			headingID: headingID,
+			query:     "delete",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Restore not completed heading",
+			path:      "/user/lists/{list_id}/headings/{heading_id}/restore",
+			headingID: headingID,
+			status:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := e.PATCH(tc.path, listID, tc.headingID).
+				WithHeader("Authorization", "Bearer "+accessToken)
+			if tc.query != "" {
+				req = req.WithQuery(key.Tasks, tc.query)
+			}
+
+			req.Expect().Status(tc.status)
+		})
+	}
+
+	// Complete the heading twice
+	e.PATCH("/user/lists/{list_id}/headings/{heading_id}/complete", listID, headingID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK)
+
+	e.PATCH("/user/lists/{list_id}/headings/{heading_id}/complete", listID, headingID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
@@ -0,0 +1,216 @@
+package api_tests
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertHeading_HappyPath(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create list
+	listID := e.POST("/user/lists/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.ListRequestData{
+			Title: gofakeit.Word(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object().Value(key.ListID).String().Raw()
+
+	// Create heading
+	headingTitle := gofakeit.Word()
+
+	headingID := e.POST("/user/lists/{list_id}/headings/", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.HeadingRequestData{
+			Title:  headingTitle,
+			ListID: listID,
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object().Value(key.HeadingID).String().Raw()
+
+	// Create tasks in the heading
+	numberOfTasks := 3
+
+	for i := 0; i < numberOfTasks; i++ {
+		e.POST("/user/lists/{list_id}/headings/{heading_id}", listID, headingID).
+			WithHeader("Authorization", "Bearer "+accessToken).
+			WithJSON(randomFakeTask(somedayTasks, listID, headingID)).
+			Expect().
+			Status(http.StatusCreated)
+	}
+
+	// Promote the heading to a new list, the tasks are moved with it
+	newList := e.POST("/user/lists/{list_id}/headings/{heading_id}/promote", listID, headingID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object()
+
+	newList.Value(key.Title).String().IsEqual(headingTitle)
+	newListID := newList.Value(key.ListID).String().Raw()
+
+	tasks := e.GET("/user/lists/{list_id}/tasks", newListID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object()
+
+	require.Equal(t, numberOfTasks, countTasks(t, tasks, false))
+
+	e.GET("/user/lists/{list_id}/headings/{heading_id}", listID, headingID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusNotFound)
+
+	// Demote the new list back to a heading of the first list
+	newHeading := e.PATCH("/user/lists/{list_id}/demote", newListID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithQuery(key.ListID, listID).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Object()
+
+	newHeading.Value(key.Title).String().IsEqual(headingTitle)
+	newHeading.Value(key.ListID).String().IsEqual(listID)
+
+	tasks = e.GET("/user/lists/{list_id}/tasks", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object()
+
+	require.Equal(t, numberOfTasks, countTasks(t, tasks, false))
+
+	e.GET("/user/lists/{list_id}", newListID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusNotFound)
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestConvertHeading_FailCases(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create list
+	listID := e.POST("/user/lists/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.ListRequestData{
+			Title: gofakeit.Word(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object().Value(key.ListID).String().Raw()
+
+	defaultListID := e.GET("/user/lists/default").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Object().Value(key.ListID).String().Raw()
+
+	defaultHeadingID := e.GET("/user/lists/{list_id}/headings/", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Array().
+		Value(0).Object().Value(key.HeadingID).String().Raw()
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		ids    []any
+		query  string
+		status int
+	}{
+		{
+			name:   "Promote default heading",
+			method: http.MethodPost,
+			path:   "/user/lists/{list_id}/headings/{heading_id}/promote",
+			ids:    []any{listID, defaultHeadingID},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Demote default list",
+			method: http.MethodPatch,
+			path:   "/user/lists/{list_id}/demote",
+			ids:    []any{defaultListID},
+			query:  listID,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Demote list into itself",
+			method: http.MethodPatch,
+			path:   "/user/lists/{list_id}/demote",
+			ids:    []any{listID},
+			query:  listID,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Demote list without target list",
+			method: http.MethodPatch,
+			path:   "/user/lists/{list_id}/demote",
+			ids:    []any{listID},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := e.Request(tc.method, tc.path, tc.ids...).
+				WithHeader("Authorization", "Bearer "+accessToken)
+			if tc.query != "" {
+				req = req.WithQuery(key.ListID, tc.query)
+			}
+
+			req.Expect().Status(tc.status)
+		})
+	}
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
@@ -0,0 +1,228 @@
+package api_tests
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+func TestCustomStatus_HappyPath(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create list
+	list := e.POST("/user/lists/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.ListRequestData{
+			Title: gofakeit.Word(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	listID := list.Value(key.Data).Object().Value(key.ListID).String().Raw()
+
+	// Create status of the list
+	inReview := e.POST("/statuses/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.StatusRequestData{
+			Title:      "In review",
+			CategoryID: statusPlanned,
+			ListID:     listID,
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	inReviewID := int(inReview.Value(key.Data).Object().Value(key.StatusID).Number().Raw())
+
+	// The status is available only in the list
+	e.GET("/statuses/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Array().Length().IsEqual(4)
+
+	e.GET("/statuses/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithQuery(key.ListID, listID).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Array().Length().IsEqual(5)
+
+	// The task in review can only be completed
+	e.PUT("/statuses/transitions").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.StatusTransitionsRequestData{
+			ListID: listID,
+			Transitions: []model.StatusTransitionRequestData{
+				{FromStatusID: inReviewID, ToStatusIDs: []int{statusCompleted}},
+			},
+		}).
+		Expect().
+		Status(http.StatusOK)
+
+	// Create task
+	task := e.POST("/user/lists/{list_id}/tasks", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(randomFakeTask(somedayTasks, listID, "")).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	taskID := task.Value(key.Data).Object().Value(key.TaskID).String().Raw()
+
+	// Move task to review
+	movedTask := e.PATCH("/user/tasks/{task_id}/status", taskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TaskStatusRequestData{
+			StatusID: inReviewID,
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Object()
+
+	movedTask.Value(key.StatusID).Number().IsEqual(statusPlanned)
+	movedTask.Value("custom_status_id").Number().IsEqual(inReviewID)
+
+	// The task in review can't be archived
+	e.PATCH("/user/tasks/{task_id}/archive", taskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusConflict)
+
+	// Complete task
+	e.PATCH("/user/tasks/{task_id}/complete", taskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Object().Value(key.StatusID).Number().IsEqual(statusCompleted)
+
+	// Delete status
+	e.DELETE("/statuses/{status_id}", inReviewID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK)
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestCustomStatus_FailCases(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		id     any
+		body   any
+		status int
+	}{
+		{
+			name:   "Create status without category",
+			method: http.MethodPost,
+			path:   "/statuses/",
+			body:   model.StatusRequestData{Title: gofakeit.Word()},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Create status in archived category",
+			method: http.MethodPost,
+			path:   "/statuses/",
+			body:   model.StatusRequestData{Title: gofakeit.Word(), CategoryID: statusArchived},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Create status without title",
+			method: http.MethodPost,
+			path:   "/statuses/",
+			body:   model.StatusRequestData{CategoryID: statusPlanned},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Update built-in status",
+			method: http.MethodPatch,
+			path:   "/statuses/{status_id}",
+			id:     statusNotStarted,
+			body:   model.StatusRequestData{Title: gofakeit.Word()},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Delete built-in status",
+			method: http.MethodDelete,
+			path:   "/statuses/{status_id}",
+			id:     statusCompleted,
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Transitions from unknown status",
+			method: http.MethodPut,
+			path:   "/statuses/transitions",
+			body: model.StatusTransitionsRequestData{
+				Transitions: []model.StatusTransitionRequestData{
+					{FromStatusID: wrongStatusID, ToStatusIDs: []int{statusCompleted}},
+				},
+			},
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var req *httpexpect.Request
+			if tc.id == nil {
+				req = e.Request(tc.method, tc.path)
+			} else {
+				req = e.Request(tc.method, tc.path, tc.id)
+			}
+
+			req = req.WithHeader("Authorization", "Bearer "+accessToken)
+			if tc.body != nil {
+				req = req.WithJSON(tc.body)
+			}
+
+			req.Expect().Status(tc.status)
+		})
+	}
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
@@ -0,0 +1,256 @@
+package api_tests
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/segmentio/ksuid"
+)
+
+func TestFocusSession_HappyPath(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create task
+	task := e.POST("/user/lists/default").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(randomFakeTask(todayTasks, "", "")).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	taskID := task.Value(key.Data).Object().Value(key.TaskID).String().Raw()
+
+	// Start focus session
+	session := e.POST("/user/tasks/{task_id}/focus-sessions", taskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.FocusSessionRequestData{
+			DurationMinutes: 25,
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().
+		Value(key.Data).Object()
+
+	session.Value("status").String().IsEqual(model.FocusSessionRunning.String())
+	session.Value("remaining_seconds").Number().Gt(0)
+
+	sessionID := session.Value(key.FocusSessionID).String().Raw()
+
+	// Get current focus session
+	e.GET("/user/focus-sessions/current").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value(key.FocusSessionID).String().IsEqual(sessionID)
+
+	// Pause focus session
+	e.PATCH("/user/focus-sessions/{focus_session_id}/pause", sessionID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value("status").String().IsEqual(model.FocusSessionPaused.String())
+
+	// Resume focus session
+	e.PATCH("/user/focus-sessions/{focus_session_id}/resume", sessionID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value("status").String().IsEqual(model.FocusSessionRunning.String())
+
+	// Active session is shown alongside the tasks for today
+	e.GET("/user/tasks/today").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value("meta").Object().
+		Value("focus_sessions").Object().
+		Value("active").Object().
+		Value(key.FocusSessionID).String().IsEqual(sessionID)
+
+	// Abandon focus session
+	e.PATCH("/user/focus-sessions/{focus_session_id}/abandon", sessionID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value("status").String().IsEqual(model.FocusSessionAbandoned.String())
+
+	// Get focus session with its events
+	e.GET("/user/focus-sessions/{focus_session_id}", sessionID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value("events").Array().Length().IsEqual(4)
+
+	// Sessions today summary
+	summary := e.GET("/user/tasks/today").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value("meta").Object().
+		Value("focus_sessions").Object()
+
+	summary.Value("abandoned").Number().IsEqual(1)
+	summary.NotContainsKey("active")
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestFocusSession_FailCases(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create task
+	task := e.POST("/user/lists/default").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(randomFakeTask(todayTasks, "", "")).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	taskID := task.Value(key.Data).Object().Value(key.TaskID).String().Raw()
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		id     string
+		body   interface{}
+		status int
+	}{
+		{
+			name:   "Start focus session without duration",
+			method: http.MethodPost,
+			path:   "/user/tasks/{id}/focus-sessions",
+			id:     taskID,
+			body:   model.FocusSessionRequestData{},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Start focus session for non-existent task",
+			method: http.MethodPost,
+			path:   "/user/tasks/{id}/focus-sessions",
+			id:     ksuid.New().String(),
+			body: model.FocusSessionRequestData{
+				DurationMinutes: 25,
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Get non-existent focus session",
+			method: http.MethodGet,
+			path:   "/user/focus-sessions/{id}",
+			id:     ksuid.New().String(),
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Pause non-existent focus session",
+			method: http.MethodPatch,
+			path:   "/user/focus-sessions/{id}/pause",
+			id:     ksuid.New().String(),
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := e.Request(tc.method, tc.path, tc.id).
+				WithHeader("Authorization", "Bearer "+accessToken)
+			if tc.body != nil {
+				req = req.WithJSON(tc.body)
+			}
+
+			req.Expect().Status(tc.status)
+		})
+	}
+
+	// Start focus session twice
+	session := e.POST("/user/tasks/{task_id}/focus-sessions", taskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.FocusSessionRequestData{
+			DurationMinutes: 25,
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().
+		Value(key.Data).Object()
+
+	sessionID := session.Value(key.FocusSessionID).String().Raw()
+
+	e.POST("/user/tasks/{task_id}/focus-sessions", taskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.FocusSessionRequestData{
+			DurationMinutes: 25,
+		}).
+		Expect().
+		Status(http.StatusConflict)
+
+	// Resume running focus session
+	e.PATCH("/user/focus-sessions/{focus_session_id}/resume", sessionID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusConflict)
+
+	// Pause abandoned focus session
+	e.PATCH("/user/focus-sessions/{focus_session_id}/abandon", sessionID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK)
+
+	e.PATCH("/user/focus-sessions/{focus_session_id}/pause", sessionID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusConflict)
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
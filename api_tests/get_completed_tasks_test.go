@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 )
 
 func TestGetCompletedTasks_HappyPath(t *testing.T) {
@@ -62,6 +63,16 @@ func TestGetCompletedTasks_HappyPath(t *testing.T) {
 
 	require.Equal(t, numberOfTasks*numberOfLists, totalCompletedTasks)
 
+	// The next page starts before the month of the last group, there are no earlier months
+	month := completedTasks.Value(key.Data).Array().Value(0).Object().Value("month").String().AsDateTime(time.RFC3339).Raw()
+
+	e.GET("/user/tasks/completed").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithQuery(key.Cursor, month.Format(time.DateOnly)).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).IsNull()
+
 	// Cleanup the SSO gRPC service storage after testing
 	cleanupAuthService(e, user)
 }
@@ -221,3 +221,88 @@ func TestGetOverdueTasks_NotFound(t *testing.T) {
 	// Cleanup the SSO gRPC service storage after testing
 	cleanupAuthService(e, user)
 }
+
+func TestGetOverdueTasks_TimeZones(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	for _, timeZone := range []string{timeZoneAheadOfUTC, timeZoneBehindOfUTC} {
+		t.Run(timeZone, func(t *testing.T) {
+			// Register user
+			user := e.POST("/register").
+				WithJSON(model.UserRequestData{
+					Email:    gofakeit.Email(),
+					Password: randomFakePassword(),
+				}).
+				Expect().
+				Status(http.StatusCreated).
+				JSON().Object()
+
+			accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+			// Set user time zone
+			e.PATCH("/user/settings").
+				WithHeader("Authorization", "Bearer "+accessToken).
+				WithJSON(model.UserSettingsRequestData{
+					TimeZone: timeZone,
+				}).
+				Expect().
+				Status(http.StatusOK)
+
+			// Create overdue task and task with deadline tomorrow in the user time zone
+			overdueTask := randomFakeTask(somedayTasks, "", "")
+			overdueTask.Deadline = localDate(t, timeZone, -1)
+
+			overdueTaskID := e.POST("/user/lists/default").
+				WithHeader("Authorization", "Bearer "+accessToken).
+				WithJSON(overdueTask).
+				Expect().
+				Status(http.StatusCreated).
+				JSON().Object().
+				Value(key.Data).Object().Value(key.TaskID).String().Raw()
+
+			futureTask := randomFakeTask(somedayTasks, "", "")
+			futureTask.Deadline = localDate(t, timeZone, 1)
+
+			futureTaskID := e.POST("/user/lists/default").
+				WithHeader("Authorization", "Bearer "+accessToken).
+				WithJSON(futureTask).
+				Expect().
+				Status(http.StatusCreated).
+				JSON().Object().
+				Value(key.Data).Object().Value(key.TaskID).String().Raw()
+
+			// Check overdue flags
+			e.GET("/user/tasks/{task_id}", overdueTaskID).
+				WithHeader("Authorization", "Bearer "+accessToken).
+				Expect().
+				Status(http.StatusOK).
+				JSON().Object().
+				Value(key.Data).Object().
+				Value("overdue").Boolean().IsTrue()
+
+			e.GET("/user/tasks/{task_id}", futureTaskID).
+				WithHeader("Authorization", "Bearer "+accessToken).
+				Expect().
+				Status(http.StatusOK).
+				JSON().Object().
+				Value(key.Data).Object().
+				NotContainsKey("overdue")
+
+			// Get overdue tasks
+			tasks := e.GET("/user/tasks/overdue").
+				WithHeader("Authorization", "Bearer "+accessToken).
+				Expect().
+				Status(http.StatusOK).
+				JSON().Object()
+
+			require.Equal(t, 1, countTasksInGroups(t, tasks, false))
+
+			// Cleanup the SSO gRPC service storage after testing
+			cleanupAuthService(e, user)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/gavv/httpexpect/v2"
 	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/timezone"
 	"github.com/rshelekhov/reframed/internal/model"
 	"github.com/stretchr/testify/require"
 	"net/http"
@@ -84,3 +85,85 @@ func TestGetTasksForToday_NotFound(t *testing.T) {
 	// Cleanup the SSO gRPC service storage after testing
 	cleanupAuthService(e, user)
 }
+
+func TestGetTasksForToday_TimeZones(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	testCases := []struct {
+		name          string
+		timeZone      string
+		otherTimeZone string
+	}{
+		{
+			name:          "User ahead of UTC",
+			timeZone:      timeZoneAheadOfUTC,
+			otherTimeZone: timeZoneBehindOfUTC,
+		},
+		{
+			name:          "User behind of UTC",
+			timeZone:      timeZoneBehindOfUTC,
+			otherTimeZone: timeZoneAheadOfUTC,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Register user
+			user := e.POST("/register").
+				WithJSON(model.UserRequestData{
+					Email:    gofakeit.Email(),
+					Password: randomFakePassword(),
+				}).
+				Expect().
+				Status(http.StatusCreated).
+				JSON().Object()
+
+			accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+			// Set user time zone
+			e.PATCH("/user/settings").
+				WithHeader("Authorization", "Bearer "+accessToken).
+				WithJSON(model.UserSettingsRequestData{
+					TimeZone: tc.timeZone,
+				}).
+				Expect().
+				Status(http.StatusOK)
+
+			// Create task for today in the user time zone
+			fakeTask := randomFakeTask(somedayTasks, "", "")
+			fakeTask.StartDate = localDate(t, tc.timeZone, 0)
+
+			e.POST("/user/lists/default").
+				WithHeader("Authorization", "Bearer "+accessToken).
+				WithJSON(fakeTask).
+				Expect().
+				Status(http.StatusCreated)
+
+			// Get tasks for today in the user time zone
+			tasks := e.GET("/user/tasks/today").
+				WithHeader("Authorization", "Bearer "+accessToken).
+				Expect().
+				Status(http.StatusOK).
+				JSON().Object()
+
+			require.Equal(t, 1, countTasksInGroups(t, tasks, false))
+
+			// Get tasks for today in the time zone from the header
+			tasks = e.GET("/user/tasks/today").
+				WithHeader("Authorization", "Bearer "+accessToken).
+				WithHeader(timezone.Header, tc.otherTimeZone).
+				Expect().
+				Status(http.StatusOK).
+				JSON().Object()
+
+			require.Equal(t, 0, countTasksInGroups(t, tasks, false))
+
+			// Cleanup the SSO gRPC service storage after testing
+			cleanupAuthService(e, user)
+		})
+	}
+}
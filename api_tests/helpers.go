@@ -32,6 +32,16 @@ const (
 	statusID      = 1
 	emptyStatusID = 0
 	wrongStatusID = 100
+
+	// Ids of the built-in statuses
+	statusNotStarted = 1
+	statusPlanned    = 2
+	statusCompleted  = 3
+	statusArchived   = 4
+
+	// Time zones on both sides of UTC midnight, the local dates in them always differ
+	timeZoneAheadOfUTC  = "Pacific/Kiritimati"
+	timeZoneBehindOfUTC = "Pacific/Pago_Pago"
 )
 
 func randomFakePassword() string {
@@ -90,6 +100,16 @@ func randomDateTimeRange(isSet bool, start, end time.Time) string {
 	return ""
 }
 
+// localDate returns the date shifted by days from today in the time zone
+func localDate(t *testing.T, timeZone string, days int) string {
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		t.Fatalf("Error loading time zone %s: %v", timeZone, err)
+	}
+
+	return time.Now().In(location).AddDate(0, 0, days).Format(time.DateOnly)
+}
+
 func randomDays() time.Duration {
 	return time.Duration(rand.Int63n(30)) * (24 * time.Hour)
 }
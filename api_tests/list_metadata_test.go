@@ -0,0 +1,175 @@
+package api_tests
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+func TestListMetadata_HappyPath(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create list with the deadline in the past
+	listID := e.POST("/user/lists/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.ListRequestData{
+			Title:       gofakeit.Word(),
+			Description: gofakeit.Sentence(5),
+			Deadline:    time.Now().AddDate(0, 0, -3).Format(time.DateOnly),
+			Color:       "#ff8800",
+			Icon:        "rocket",
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object().Value(key.ListID).String().Raw()
+
+	// Create two tasks and complete one of them
+	var taskIDs []string
+	for i := 0; i < 2; i++ {
+		taskID := e.POST("/user/lists/{list_id}/tasks", listID).
+			WithHeader("Authorization", "Bearer "+accessToken).
+			WithJSON(model.TaskRequestData{
+				Title: gofakeit.Word(),
+			}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().Object().Value(key.Data).Object().Value(key.TaskID).String().Raw()
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	e.PATCH("/user/tasks/{task_id}/complete", taskIDs[0]).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK)
+
+	// Get list with its metadata and progress
+	list := e.GET("/user/lists/{list_id}", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Object()
+
+	list.Value("color").String().IsEqual("#ff8800")
+	list.Value("icon").String().IsEqual("rocket")
+
+	progress := list.Value("progress").Object()
+	progress.Value("completed_tasks").Number().IsEqual(1)
+	progress.Value("total_tasks").Number().IsEqual(2)
+	progress.Value("percent").Number().IsEqual(50)
+
+	// The list is shown among the overdue tasks since it still has an open task
+	groups := e.GET("/user/tasks/overdue").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Array()
+
+	groups.Length().IsEqual(1)
+	groups.Value(0).Object().Value("list").Object().Value(key.ListID).String().IsEqual(listID)
+
+	// Clear the metadata of the list
+	e.PATCH("/user/lists/{list_id}", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.ListRequestData{
+			Title: gofakeit.Word(),
+			Clear: []model.ListField{
+				model.ListFieldDescription,
+				model.ListFieldDeadline,
+				model.ListFieldColor,
+				model.ListFieldIcon,
+			},
+		}).
+		Expect().
+		Status(http.StatusOK)
+
+	list = e.GET("/user/lists/{list_id}", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Object()
+
+	list.NotContainsKey("description")
+	list.NotContainsKey("color")
+	list.NotContainsKey("icon")
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestListMetadata_FailCases(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	testCases := []struct {
+		name     string
+		deadline string
+		color    string
+		status   int
+	}{
+		{
+			name:     "Create list with invalid deadline",
+			deadline: "next week",
+			status:   http.StatusBadRequest,
+		},
+		{
+			name:   "Create list with invalid color",
+			color:  "orange",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e.POST("/user/lists/").
+				WithHeader("Authorization", "Bearer "+accessToken).
+				WithJSON(model.ListRequestData{
+					Title:    gofakeit.Word(),
+					Deadline: tc.deadline,
+					Color:    tc.color,
+				}).
+				Expect().
+				Status(tc.status)
+		})
+	}
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
@@ -0,0 +1,246 @@
+package api_tests
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/segmentio/ksuid"
+)
+
+func TestPersonalToken_HappyPath(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create personal token
+	token := e.POST("/user/tokens/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.PersonalTokenRequestData{
+			Name:          gofakeit.Word(),
+			ExpiresInDays: 30,
+			Scopes:        []string{model.ScopeRead, model.ScopeTasksWrite},
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object()
+
+	tokenID := token.Value(key.TokenID).String().Raw()
+	personalToken := token.Value("token").String().HasPrefix(jwtoken.PersonalTokenPrefix).Raw()
+
+	// Use the personal token instead of the access token
+	e.GET("/user/tasks/").
+		WithHeader("Authorization", "Bearer "+personalToken).
+		Expect().
+		Status(http.StatusOK)
+
+	// The personal token can't manage personal tokens
+	e.GET("/user/tokens/").
+		WithHeader("Authorization", "Bearer "+personalToken).
+		Expect().
+		Status(http.StatusForbidden)
+
+	// The list of tokens doesn't contain the token itself
+	tokens := e.GET("/user/tokens/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Array()
+
+	tokens.Length().IsEqual(1)
+	tokens.Value(0).Object().Value(key.TokenID).String().IsEqual(tokenID)
+	tokens.Value(0).Object().NotContainsKey("token")
+	tokens.Value(0).Object().Value("last_used_at").String().NotEmpty()
+
+	// Revoke the personal token
+	e.DELETE("/user/tokens/{token_id}", tokenID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK)
+
+	e.GET("/user/tasks/").
+		WithHeader("Authorization", "Bearer "+personalToken).
+		Expect().
+		Status(http.StatusUnauthorized)
+
+	e.GET("/user/tokens/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).IsNull()
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestPersonalToken_Scopes(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create personal token which can read everything and write only tasks
+	personalToken := e.POST("/user/tokens/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.PersonalTokenRequestData{
+			Name:          gofakeit.Word(),
+			ExpiresInDays: 1,
+			Scopes:        []string{model.ScopeRead, model.ScopeTasksWrite},
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object().Value("token").String().Raw()
+
+	e.GET("/user/lists/").
+		WithHeader("Authorization", "Bearer "+personalToken).
+		Expect().
+		Status(http.StatusOK)
+
+	e.POST("/user/tasks/quick-add").
+		WithHeader("Authorization", "Bearer "+personalToken).
+		WithJSON(model.TaskQuickAddRequestData{
+			Text: gofakeit.Word(),
+		}).
+		Expect().
+		Status(http.StatusCreated)
+
+	// Writing lists requires the lists:write scope
+	scopeError := e.POST("/user/lists/").
+		WithHeader("Authorization", "Bearer "+personalToken).
+		WithJSON(model.ListRequestData{
+			Title: gofakeit.Word(),
+		}).
+		Expect().
+		Status(http.StatusForbidden).
+		JSON().Object()
+
+	scopeError.Value("required_scopes").Array().ContainsOnly(model.ScopeListsWrite)
+	scopeError.Value("token_scopes").Array().ContainsOnly(model.ScopeRead, model.ScopeTasksWrite)
+
+	// Deleting the user requires a token with full access
+	e.DELETE("/user/").
+		WithHeader("Authorization", "Bearer "+personalToken).
+		Expect().
+		Status(http.StatusForbidden)
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestPersonalToken_FailCases(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	testCases := []struct {
+		name   string
+		body   model.PersonalTokenRequestData
+		status int
+	}{
+		{
+			name: "Create token without name",
+			body: model.PersonalTokenRequestData{
+				ExpiresInDays: 30,
+				Scopes:        []string{model.ScopeRead},
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "Create token without expiry",
+			body: model.PersonalTokenRequestData{
+				Name:   gofakeit.Word(),
+				Scopes: []string{model.ScopeRead},
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "Create token with too long expiry",
+			body: model.PersonalTokenRequestData{
+				Name:          gofakeit.Word(),
+				ExpiresInDays: 366,
+				Scopes:        []string{model.ScopeRead},
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "Create token with invalid scope",
+			body: model.PersonalTokenRequestData{
+				Name:          gofakeit.Word(),
+				ExpiresInDays: 30,
+				Scopes:        []string{"admin"},
+			},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e.POST("/user/tokens/").
+				WithHeader("Authorization", "Bearer "+accessToken).
+				WithJSON(tc.body).
+				Expect().
+				Status(tc.status)
+		})
+	}
+
+	// Revoke not existing token
+	e.DELETE("/user/tokens/{token_id}", ksuid.New().String()).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusNotFound)
+
+	// Use not existing personal token
+	e.GET("/user/tasks/").
+		WithHeader("Authorization", "Bearer "+jwtoken.PersonalTokenPrefix+ksuid.New().String()+"_secret").
+		Expect().
+		Status(http.StatusUnauthorized)
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
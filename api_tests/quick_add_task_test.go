@@ -0,0 +1,146 @@
+package api_tests
+
+import (
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestQuickAddTask_HappyPath(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create list
+	list := e.POST("/user/lists/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.ListRequestData{
+			Title: "Project X",
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	listID := list.Value(key.Data).Object().Value(key.ListID).String().Raw()
+
+	// Quick add task
+	task := e.POST("/user/tasks/quick-add").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TaskQuickAddRequestData{
+			Text:     "Call Anna tomorrow 3pm-4pm #work !deadline friday /Project X",
+			TimeZone: "Europe/Moscow",
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().
+		Value(key.Data).Object()
+
+	task.Value(key.Title).String().IsEqual("Call Anna")
+	task.Value(key.ListID).String().IsEqual(listID)
+	task.Value("tags").Array().ContainsOnly("work")
+	task.ContainsKey("start_date")
+	task.ContainsKey("deadline")
+	task.ContainsKey("start_time")
+	task.ContainsKey("end_time")
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestQuickAddTask_FailCases(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	testCases := []struct {
+		name     string
+		text     string
+		locale   string
+		timeZone string
+		status   int
+	}{
+		{
+			name:   "Quick add task with empty text",
+			text:   "",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Quick add task without title",
+			text:   "tomorrow 3pm #work",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:     "Quick add task with invalid time zone",
+			text:     "Buy milk",
+			timeZone: "Mars/Olympus",
+			status:   http.StatusBadRequest,
+		},
+		{
+			name:   "Quick add task with unsupported locale",
+			text:   "Buy milk",
+			locale: "xx",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Quick add task to non-existent list",
+			text:   "Buy milk /" + gofakeit.UUID(),
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Quick add task to non-existent heading",
+			text:   "Buy milk /Inbox/" + gofakeit.UUID(),
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Quick add task
+			e.POST("/user/tasks/quick-add").
+				WithHeader("Authorization", "Bearer "+accessToken).
+				WithJSON(model.TaskQuickAddRequestData{
+					Text:     tc.text,
+					Locale:   tc.locale,
+					TimeZone: tc.timeZone,
+				}).
+				Expect().
+				Status(tc.status)
+		})
+	}
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
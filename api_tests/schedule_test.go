@@ -0,0 +1,238 @@
+package api_tests
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/segmentio/ksuid"
+)
+
+func TestSchedule_HappyPath(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+	date := tomorrow.Format(time.DateOnly)
+
+	// Create planned task from 9:00 to 10:00
+	plannedTask := randomFakeTask(upcomingTasks, "", "")
+	plannedTask.StartDate = date
+
+	plannedTaskID := e.POST("/user/lists/default").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(plannedTask).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value(key.TaskID).String().Raw()
+
+	e.PATCH("/user/tasks/{task_id}/time", plannedTaskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TaskRequestTimeData{
+			StartTime: date + " 09:00:00",
+			EndTime:   date + " 10:00:00",
+		}).
+		Expect().
+		Status(http.StatusOK)
+
+	// Create unscheduled tasks
+	var taskIDs []string
+	for _, estimate := range []int{60, 30} {
+		fakeTask := randomFakeTask(upcomingTasks, "", "")
+		fakeTask.StartDate = date
+		fakeTask.EstimateMinutes = estimate
+
+		taskID := e.POST("/user/lists/default").
+			WithHeader("Authorization", "Bearer "+accessToken).
+			WithJSON(fakeTask).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().Object().
+			Value(key.Data).Object().
+			Value(key.TaskID).String().Raw()
+
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	// Propose schedule, the second task has a higher priority
+	proposal := e.GET("/user/tasks/schedule").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithQuery(key.Date, date).
+		WithQuery(key.WorkStart, "09:00").
+		WithQuery(key.WorkEnd, "18:00").
+		WithQuery(key.TaskIDs, taskIDs[1]).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object()
+
+	slots := proposal.Value("slots").Array()
+	slots.Length().IsEqual(2)
+	slots.Value(0).Object().Value(key.TaskID).String().IsEqual(taskIDs[1])
+	slots.Value(1).Object().Value(key.TaskID).String().IsEqual(taskIDs[0])
+	proposal.NotContainsKey("unscheduled")
+
+	// Accept schedule
+	e.POST("/user/tasks/schedule").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.ScheduleAcceptRequestData{
+			Slots: []model.TaskRequestTimeData{
+				{ID: taskIDs[1], StartTime: date + " 10:00:00", EndTime: date + " 10:30:00"},
+				{ID: taskIDs[0], StartTime: date + " 10:30:00", EndTime: date + " 11:30:00"},
+			},
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Array().Length().IsEqual(2)
+
+	// Nothing is left to schedule
+	e.GET("/user/tasks/schedule").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithQuery(key.Date, date).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value("slots").Array().IsEmpty()
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestSchedule_FailCases(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create task
+	taskID := e.POST("/user/lists/default").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(randomFakeTask(upcomingTasks, "", "")).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value(key.TaskID).String().Raw()
+
+	date := time.Now().UTC().AddDate(0, 0, 1).Format(time.DateOnly)
+
+	testCases := []struct {
+		name   string
+		method string
+		query  map[string]string
+		body   interface{}
+		status int
+	}{
+		{
+			name:   "Propose schedule with invalid date",
+			method: http.MethodGet,
+			query:  map[string]string{key.Date: "tomorrow"},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Propose schedule for a past date",
+			method: http.MethodGet,
+			query:  map[string]string{key.Date: time.Now().UTC().AddDate(0, 0, -2).Format(time.DateOnly)},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Propose schedule with working hours ending before start",
+			method: http.MethodGet,
+			query:  map[string]string{key.WorkStart: "18:00", key.WorkEnd: "09:00"},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Accept schedule without slots",
+			method: http.MethodPost,
+			body:   model.ScheduleAcceptRequestData{},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Accept schedule with overlapping slots",
+			method: http.MethodPost,
+			body: model.ScheduleAcceptRequestData{
+				Slots: []model.TaskRequestTimeData{
+					{ID: taskID, StartTime: date + " 10:00:00", EndTime: date + " 11:00:00"},
+					{ID: ksuid.New().String(), StartTime: date + " 10:30:00", EndTime: date + " 11:30:00"},
+				},
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Accept schedule with non-existent task",
+			method: http.MethodPost,
+			body: model.ScheduleAcceptRequestData{
+				Slots: []model.TaskRequestTimeData{
+					{ID: taskID, StartTime: date + " 10:00:00", EndTime: date + " 11:00:00"},
+					{ID: ksuid.New().String(), StartTime: date + " 11:00:00", EndTime: date + " 12:00:00"},
+				},
+			},
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := e.Request(tc.method, "/user/tasks/schedule").
+				WithHeader("Authorization", "Bearer "+accessToken)
+			if tc.body != nil {
+				req = req.WithJSON(tc.body)
+			}
+			for k, v := range tc.query {
+				req = req.WithQuery(k, v)
+			}
+
+			req.Expect().Status(tc.status)
+		})
+	}
+
+	// The existing task is left unscheduled after the failed accept
+	e.GET("/user/tasks/{task_id}", taskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value("start_time").String().IsEqual(time.Time{}.Format(time.RFC3339))
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
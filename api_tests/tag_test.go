@@ -0,0 +1,549 @@
+package api_tests
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/segmentio/ksuid"
+)
+
+func TestTag_HappyPath(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create task with tags
+	fakeTask := randomFakeTask(upcomingTasks, "", "")
+	fakeTask.Tags = []string{"home", "house"}
+
+	taskID := e.POST("/user/lists/default").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(fakeTask).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value(key.TaskID).String().Raw()
+
+	// Create tag
+	officeTagID := e.POST("/user/tags").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TagRequestData{
+			Title: "office",
+			Color: "#ff8800",
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value(key.TagID).String().Raw()
+
+	// Get tags with task counts
+	tags := e.GET("/user/tags").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Array()
+
+	tags.Length().IsEqual(3)
+
+	var homeTagID, houseTagID string
+	for _, tag := range tags.Iter() {
+		switch tag.Object().Value(key.Title).String().Raw() {
+		case "home":
+			homeTagID = tag.Object().Value(key.TagID).String().Raw()
+			tag.Object().Value("tasks_count").Number().IsEqual(1)
+		case "house":
+			houseTagID = tag.Object().Value(key.TagID).String().Raw()
+		case "office":
+			tag.Object().Value("color").String().IsEqual("#ff8800")
+			tag.Object().Value("tasks_count").Number().IsEqual(0)
+		}
+	}
+
+	// Rename tag
+	e.PATCH("/user/tags/{tag_id}", homeTagID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TagRequestData{
+			Title: "@home",
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value(key.Title).String().IsEqual("@home")
+
+	// The new title is shown in the task
+	e.GET("/user/tasks/{task_id}", taskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value("tags").Array().ContainsAll("@home", "house")
+
+	// Merge tag
+	e.POST("/user/tags/{tag_id}/merge", houseTagID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TagMergeRequestData{
+			TargetID: homeTagID,
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value("tasks_count").Number().IsEqual(1)
+
+	e.GET("/user/tags/{tag_id}", houseTagID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusNotFound)
+
+	// Delete tag
+	e.DELETE("/user/tags/{tag_id}", homeTagID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK)
+
+	// The tag is removed from the task
+	e.GET("/user/tasks/{task_id}", taskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		NotContainsKey("tags")
+
+	e.DELETE("/user/tags/{tag_id}", officeTagID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK)
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestTagTree_HappyPath(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Plain tag titles with a path create the parent tags
+	fakeTask := randomFakeTask(upcomingTasks, "", "")
+	fakeTask.Tags = []string{"work/clients/acme"}
+
+	e.POST("/user/lists/default").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(fakeTask).
+		Expect().
+		Status(http.StatusCreated)
+
+	fakeTask = randomFakeTask(upcomingTasks, "", "")
+	fakeTask.Tags = []string{"work"}
+
+	e.POST("/user/lists/default").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(fakeTask).
+		Expect().
+		Status(http.StatusCreated)
+
+	// Get the tree of tags, the parent tag counts the tasks of its child tags
+	tags := e.GET("/user/tags").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Array()
+
+	tags.Length().IsEqual(1)
+
+	workTag := tags.Value(0).Object()
+	workTag.Value(key.Title).String().IsEqual("work")
+	workTag.Value("tasks_count").Number().IsEqual(2)
+
+	clientsTag := workTag.Value("children").Array().Value(0).Object()
+	clientsTag.Value(key.Title).String().IsEqual("work/clients")
+	clientsTag.Value("name").String().IsEqual("clients")
+	clientsTag.Value("tasks_count").Number().IsEqual(1)
+
+	acmeTag := clientsTag.Value("children").Array().Value(0).Object()
+	acmeTag.Value(key.Title).String().IsEqual("work/clients/acme")
+
+	workTagID := workTag.Value(key.TagID).String().Raw()
+	clientsTagID := clientsTag.Value(key.TagID).String().Raw()
+
+	// Create tag under the parent tag
+	personalTagID := e.POST("/user/tags").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TagRequestData{
+			Title: "personal",
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value(key.TagID).String().Raw()
+
+	e.POST("/user/tags").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TagRequestData{
+			Title:    "family",
+			ParentID: personalTagID,
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value(key.Title).String().IsEqual("personal/family")
+
+	// Move the subtree under another tag
+	e.PATCH("/user/tags/{tag_id}/move", clientsTagID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TagMoveRequestData{
+			ParentID: personalTagID,
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value(key.Title).String().IsEqual("personal/clients")
+
+	e.GET("/user/tags/{tag_id}", workTagID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value("tasks_count").Number().IsEqual(1)
+
+	// The new path is shown in the task
+	e.GET("/user/tasks").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Array().
+		Filter(func(_ int, task *httpexpect.Value) bool {
+			return task.Object().Value("tags").Array().Value(0).String().Raw() != "work"
+		}).
+		Value(0).Object().
+		Value("tags").Array().ContainsOnly("personal/clients/acme")
+
+	// Move the subtree to the top level
+	e.PATCH("/user/tags/{tag_id}/move", clientsTagID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TagMoveRequestData{}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value(key.Title).String().IsEqual("clients")
+
+	// Deleting the parent tag deletes its child tags
+	e.DELETE("/user/tags/{tag_id}", clientsTagID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK)
+
+	e.GET("/user/tags").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Array().
+		Length().IsEqual(2)
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestTasksByTag_HappyPath(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create tasks with context tags
+	for _, tags := range [][]string{{"@home"}, {"@office"}, {"@home", "@office"}, {"@office/desk"}} {
+		fakeTask := randomFakeTask(todayTasks, "", "")
+		fakeTask.Tags = tags
+
+		e.POST("/user/lists/default").
+			WithHeader("Authorization", "Bearer "+accessToken).
+			WithJSON(fakeTask).
+			Expect().
+			Status(http.StatusCreated)
+	}
+
+	officeTagID := e.GET("/user/tags").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Array().
+		Filter(func(_ int, tag *httpexpect.Value) bool {
+			return tag.Object().Value(key.Title).String().Raw() == "@office"
+		}).
+		Value(0).Object().
+		Value(key.TagID).String().Raw()
+
+	// Get tasks for the tag, including the tasks of its child tags
+	taskGroups := e.GET("/user/tags/{tag_id}/tasks", officeTagID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Array()
+
+	taskGroups.Length().IsEqual(1)
+	taskGroups.Value(0).Object().Value("tasks").Array().Length().IsEqual(3)
+
+	// Get tasks for today grouped by tag
+	todayGroups := e.GET("/user/tasks/today").
+		WithQuery(key.GroupBy, model.GroupByTag).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Array()
+
+	todayGroups.Length().IsEqual(3)
+
+	for _, group := range todayGroups.Iter() {
+		switch group.Object().Value(key.Title).String().Raw() {
+		case "@home", "@office":
+			group.Object().Value("tasks").Array().Length().IsEqual(2)
+		case "@office/desk":
+			group.Object().Value("tasks").Array().Length().IsEqual(1)
+		}
+	}
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestTag_FailCases(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create tags
+	var tagIDs []string
+	for _, title := range []string{"work", "errands"} {
+		tagID := e.POST("/user/tags").
+			WithHeader("Authorization", "Bearer "+accessToken).
+			WithJSON(model.TagRequestData{
+				Title: title,
+			}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().Object().
+			Value(key.Data).Object().
+			Value(key.TagID).String().Raw()
+
+		tagIDs = append(tagIDs, tagID)
+	}
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		id     string
+		body   interface{}
+		status int
+	}{
+		{
+			name:   "Create tag without title",
+			method: http.MethodPost,
+			path:   "/user/tags",
+			body:   model.TagRequestData{},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Create tag with invalid color",
+			method: http.MethodPost,
+			path:   "/user/tags",
+			body:   model.TagRequestData{Title: "home", Color: "orange"},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Create tag that already exists",
+			method: http.MethodPost,
+			path:   "/user/tags",
+			body:   model.TagRequestData{Title: "work"},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "Create tag with an empty part of the path",
+			method: http.MethodPost,
+			path:   "/user/tags",
+			body:   model.TagRequestData{Title: "work//acme"},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Create tag under non-existent tag",
+			method: http.MethodPost,
+			path:   "/user/tags",
+			body:   model.TagRequestData{Title: "acme", ParentID: ksuid.New().String()},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Rename tag to the title of another tag",
+			method: http.MethodPatch,
+			path:   "/user/tags/{id}",
+			id:     tagIDs[1],
+			body:   model.TagRequestData{Title: "work"},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "Update non-existent tag",
+			method: http.MethodPatch,
+			path:   "/user/tags/{id}",
+			id:     ksuid.New().String(),
+			body:   model.TagRequestData{Title: "home"},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Rename tag to the path under itself",
+			method: http.MethodPatch,
+			path:   "/user/tags/{id}",
+			id:     tagIDs[0],
+			body:   model.TagRequestData{Title: "work/clients"},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Move tag under itself",
+			method: http.MethodPatch,
+			path:   "/user/tags/{id}/move",
+			id:     tagIDs[0],
+			body:   model.TagMoveRequestData{ParentID: tagIDs[0]},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Move tag under non-existent tag",
+			method: http.MethodPatch,
+			path:   "/user/tags/{id}/move",
+			id:     tagIDs[0],
+			body:   model.TagMoveRequestData{ParentID: ksuid.New().String()},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Merge tag into itself",
+			method: http.MethodPost,
+			path:   "/user/tags/{id}/merge",
+			id:     tagIDs[0],
+			body:   model.TagMergeRequestData{TargetID: tagIDs[0]},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Merge tag into non-existent tag",
+			method: http.MethodPost,
+			path:   "/user/tags/{id}/merge",
+			id:     tagIDs[0],
+			body:   model.TagMergeRequestData{TargetID: ksuid.New().String()},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Get tasks for non-existent tag",
+			method: http.MethodGet,
+			path:   "/user/tags/{id}/tasks",
+			id:     ksuid.New().String(),
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Delete non-existent tag",
+			method: http.MethodDelete,
+			path:   "/user/tags/{id}",
+			id:     ksuid.New().String(),
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := e.Request(tc.method, tc.path, tc.id).
+				WithHeader("Authorization", "Bearer "+accessToken)
+			if tc.id == "" {
+				req = e.Request(tc.method, tc.path).
+					WithHeader("Authorization", "Bearer "+accessToken)
+			}
+			if tc.body != nil {
+				req = req.WithJSON(tc.body)
+			}
+
+			req.Expect().Status(tc.status)
+		})
+	}
+
+	// Group tasks by unknown field
+	e.GET("/user/tasks/today").
+		WithQuery(key.GroupBy, "priority").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
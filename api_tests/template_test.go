@@ -0,0 +1,241 @@
+package api_tests
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/segmentio/ksuid"
+)
+
+func TestTemplate_HappyPath(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create list with a heading
+	listID := e.POST("/user/lists/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.ListRequestData{
+			Title: gofakeit.Word(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object().Value(key.ListID).String().Raw()
+
+	headingID := e.POST("/user/lists/{list_id}/headings/", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.HeadingRequestData{
+			Title:  gofakeit.Word(),
+			ListID: listID,
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object().Value(key.HeadingID).String().Raw()
+
+	// Create a task in the default heading and a task in the heading two days later
+	e.POST("/user/lists/{list_id}/tasks", listID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TaskRequestData{
+			Title:     gofakeit.Word(),
+			StartDate: "2024-03-01",
+		}).
+		Expect().
+		Status(http.StatusCreated)
+
+	taskID := e.POST("/user/lists/{list_id}/headings/{heading_id}", listID, headingID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TaskRequestData{
+			Title:    gofakeit.Word(),
+			Deadline: "2024-03-03",
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object().Value(key.TaskID).String().Raw()
+
+	// Save the list as a template, the dates are counted from the earliest one
+	listTemplate := e.POST("/user/templates/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TemplateRequestData{
+			ListID: listID,
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object()
+
+	listTemplate.Value("type").String().IsEqual(string(model.TemplateTypeList))
+	listTemplate.Value(key.Tasks).Array().Value(0).Object().Value("start_date_offset").Number().IsEqual(0)
+
+	heading := listTemplate.Value("headings").Array().Value(0).Object()
+	heading.Value(key.Tasks).Array().Value(0).Object().Value("deadline_offset").Number().IsEqual(2)
+
+	listTemplateID := listTemplate.Value(key.TemplateID).String().Raw()
+
+	// Save the task as a template
+	taskTemplateID := e.POST("/user/templates/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TemplateRequestData{
+			TaskID: taskID,
+			Title:  gofakeit.Word(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object().Value(key.TemplateID).String().Raw()
+
+	e.GET("/user/templates/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Array().Length().IsEqual(2)
+
+	// Instantiate the list template from the new anchor date
+	newListID := e.POST("/user/templates/{template_id}/instantiate", listTemplateID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TemplateInstantiateRequestData{
+			AnchorDate: "2025-01-10",
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object().Value("list").Object().Value(key.ListID).String().Raw()
+
+	e.GET("/user/lists/{list_id}/headings/", newListID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Array().Length().IsEqual(2)
+
+	e.GET("/user/lists/{list_id}/tasks", newListID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).Array().Length().IsEqual(2)
+
+	// Instantiate the task template into the new list
+	task := e.POST("/user/templates/{template_id}/instantiate", taskTemplateID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TemplateInstantiateRequestData{
+			AnchorDate: "2025-01-10",
+			ListID:     newListID,
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value(key.Data).Object().Value("task").Object()
+
+	task.Value(key.ListID).String().IsEqual(newListID)
+	task.Value("deadline").String().HasPrefix("2025-01-10")
+
+	// Delete the templates
+	for _, templateID := range []string{listTemplateID, taskTemplateID} {
+		e.DELETE("/user/templates/{template_id}", templateID).
+			WithHeader("Authorization", "Bearer "+accessToken).
+			Expect().
+			Status(http.StatusOK)
+	}
+
+	e.GET("/user/templates/").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value(key.Data).IsNull()
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestTemplate_FailCases(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	testCases := []struct {
+		name   string
+		path   string
+		body   any
+		status int
+	}{
+		{
+			name:   "Create template without list and task",
+			path:   "/user/templates/",
+			body:   model.TemplateRequestData{},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "Create template from both list and task",
+			path: "/user/templates/",
+			body: model.TemplateRequestData{
+				ListID: ksuid.New().String(),
+				TaskID: ksuid.New().String(),
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "Create template from not existing list",
+			path: "/user/templates/",
+			body: model.TemplateRequestData{
+				ListID: ksuid.New().String(),
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name: "Create template with invalid anchor date",
+			path: "/user/templates/",
+			body: model.TemplateRequestData{
+				ListID:     ksuid.New().String(),
+				AnchorDate: "tomorrow",
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Instantiate not existing template",
+			path:   "/user/templates/" + ksuid.New().String() + "/instantiate",
+			body:   model.TemplateInstantiateRequestData{},
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e.POST(tc.path).
+				WithHeader("Authorization", "Bearer "+accessToken).
+				WithJSON(tc.body).
+				Expect().
+				Status(tc.status)
+		})
+	}
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
@@ -0,0 +1,303 @@
+package api_tests
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/segmentio/ksuid"
+)
+
+func TestTimeTracking_HappyPath(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	fakeTask := randomFakeTask(todayTasks, "", "")
+	fakeTask.EstimateMinutes = 60
+
+	// Create task with estimate
+	task := e.POST("/user/lists/default").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(fakeTask).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().
+		Value(key.Data).Object()
+
+	task.Value("estimate_minutes").Number().IsEqual(60)
+
+	taskID := task.Value(key.TaskID).String().Raw()
+	listID := task.Value(key.ListID).String().Raw()
+
+	// Start timer
+	e.POST("/user/tasks/{task_id}/timer/start", taskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value("running").Boolean().IsTrue()
+
+	// Stop timer
+	e.POST("/user/tasks/{task_id}/timer/stop", taskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		NotContainsKey("running")
+
+	// Add time entry manually
+	now := time.Now().UTC()
+
+	entry := e.POST("/user/tasks/{task_id}/time-entries", taskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TimeEntryRequestData{
+			StartTime: now.Add(-2 * time.Hour).Format(time.DateTime),
+			EndTime:   now.Add(-90 * time.Minute).Format(time.DateTime),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().
+		Value(key.Data).Object()
+
+	entry.Value("minutes").Number().IsEqual(30)
+
+	entryID := entry.Value(key.TimeEntryID).String().Raw()
+
+	// Edit time entry
+	e.PATCH("/user/time-entries/{time_entry_id}", entryID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.TimeEntryRequestData{
+			EndTime: now.Add(-80 * time.Minute).Format(time.DateTime),
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value("minutes").Number().IsEqual(40)
+
+	// Get time entries
+	e.GET("/user/tasks/{task_id}/time-entries", taskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Array().Length().IsEqual(2)
+
+	// Tracked time is exposed in the task
+	e.GET("/user/tasks/{task_id}", taskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value("tracked_minutes").Number().Ge(40)
+
+	// Get time report
+	report := e.GET("/user/reports/time").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithQuery(key.From, now.AddDate(0, 0, -1).Format(time.DateOnly)).
+		WithQuery(key.To, now.AddDate(0, 0, 1).Format(time.DateOnly)).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object()
+
+	byList := report.Value("by_list").Array()
+	byList.Length().IsEqual(1)
+	byList.Value(0).Object().Value(key.ListID).String().IsEqual(listID)
+	byList.Value(0).Object().Value("estimated_minutes").Number().IsEqual(60)
+	byList.Value(0).Object().Value("tracked_minutes").Number().Ge(40)
+
+	report.Value("by_tag").Array().Length().IsEqual(len(fakeTask.Tags))
+	report.Value("by_day").Array().NotEmpty()
+
+	// Delete time entry
+	e.DELETE("/user/time-entries/{time_entry_id}", entryID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK)
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestTimeTracking_FailCases(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Create task
+	task := e.POST("/user/lists/default").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(randomFakeTask(todayTasks, "", "")).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	taskID := task.Value(key.Data).Object().Value(key.TaskID).String().Raw()
+
+	now := time.Now().UTC()
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		id     string
+		body   interface{}
+		query  map[string]string
+		status int
+	}{
+		{
+			name:   "Stop timer that isn't running",
+			method: http.MethodPost,
+			path:   "/user/tasks/{id}/timer/stop",
+			id:     taskID,
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Start timer for non-existent task",
+			method: http.MethodPost,
+			path:   "/user/tasks/{id}/timer/start",
+			id:     ksuid.New().String(),
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Add time entry with end before start",
+			method: http.MethodPost,
+			path:   "/user/tasks/{id}/time-entries",
+			id:     taskID,
+			body: model.TimeEntryRequestData{
+				StartTime: now.Format(time.DateTime),
+				EndTime:   now.Add(-time.Hour).Format(time.DateTime),
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Add time entry without end",
+			method: http.MethodPost,
+			path:   "/user/tasks/{id}/time-entries",
+			id:     taskID,
+			body: model.TimeEntryRequestData{
+				StartTime: now.Format(time.DateTime),
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Add time entry with invalid time format",
+			method: http.MethodPost,
+			path:   "/user/tasks/{id}/time-entries",
+			id:     taskID,
+			body: model.TimeEntryRequestData{
+				StartTime: now.Format(time.RFC1123),
+				EndTime:   now.Format(time.RFC1123),
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Update non-existent time entry",
+			method: http.MethodPatch,
+			path:   "/user/time-entries/{id}",
+			id:     ksuid.New().String(),
+			body: model.TimeEntryRequestData{
+				StartTime: now.Format(time.DateTime),
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Delete non-existent time entry",
+			method: http.MethodDelete,
+			path:   "/user/time-entries/{id}",
+			id:     ksuid.New().String(),
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Get time report with reversed date range",
+			method: http.MethodGet,
+			path:   "/user/reports/time",
+			query: map[string]string{
+				key.From: now.Format(time.DateOnly),
+				key.To:   now.AddDate(0, 0, -1).Format(time.DateOnly),
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Get time report with invalid date",
+			method: http.MethodGet,
+			path:   "/user/reports/time",
+			query: map[string]string{
+				key.From: "yesterday",
+			},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := e.Request(tc.method, tc.path, tc.id).
+				WithHeader("Authorization", "Bearer "+accessToken)
+			if tc.id == "" {
+				req = e.Request(tc.method, tc.path).
+					WithHeader("Authorization", "Bearer "+accessToken)
+			}
+			if tc.body != nil {
+				req = req.WithJSON(tc.body)
+			}
+			for k, v := range tc.query {
+				req = req.WithQuery(k, v)
+			}
+
+			req.Expect().Status(tc.status)
+		})
+	}
+
+	// Start timer twice
+	e.POST("/user/tasks/{task_id}/timer/start", taskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusCreated)
+
+	e.POST("/user/tasks/{task_id}/timer/start", taskID).
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusConflict)
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
@@ -0,0 +1,127 @@
+package api_tests
+
+import (
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/timezone"
+	"github.com/rshelekhov/reframed/internal/model"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestUserSettings_HappyPath(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	// Get default settings
+	e.GET("/user/settings").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value(key.TimeZone).String().IsEqual(timezone.Default)
+
+	// Update settings
+	e.PATCH("/user/settings").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(model.UserSettingsRequestData{
+			TimeZone: "Europe/Moscow",
+		}).
+		Expect().
+		Status(http.StatusOK)
+
+	// Get updated settings
+	e.GET("/user/settings").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		Value(key.Data).Object().
+		Value(key.TimeZone).String().IsEqual("Europe/Moscow")
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
+
+func TestUserSettings_FailCases(t *testing.T) {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	// Register user
+	user := e.POST("/register").
+		WithJSON(model.UserRequestData{
+			Email:    gofakeit.Email(),
+			Password: randomFakePassword(),
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+
+	accessToken := user.Value(jwtoken.AccessTokenKey).String().Raw()
+
+	testCases := []struct {
+		name     string
+		timeZone string
+		status   int
+	}{
+		{
+			name:     "Update settings with empty time zone",
+			timeZone: "",
+			status:   http.StatusBadRequest,
+		},
+		{
+			name:     "Update settings with invalid time zone",
+			timeZone: "Mars/Olympus",
+			status:   http.StatusBadRequest,
+		},
+		{
+			name:     "Update settings with server local time zone",
+			timeZone: "Local",
+			status:   http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e.PATCH("/user/settings").
+				WithHeader("Authorization", "Bearer "+accessToken).
+				WithJSON(model.UserSettingsRequestData{
+					TimeZone: tc.timeZone,
+				}).
+				Expect().
+				Status(tc.status)
+		})
+	}
+
+	// Invalid time zone in the header
+	e.GET("/user/tasks/today").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithHeader(timezone.Header, "Mars/Olympus").
+		Expect().
+		Status(http.StatusBadRequest)
+
+	// Cleanup the SSO gRPC service storage after testing
+	cleanupAuthService(e, user)
+}
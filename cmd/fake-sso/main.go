@@ -0,0 +1,43 @@
+// Package main runs the fake SSO gRPC service for local development,
+// it listens on SSO_CLIENT_ADDRESS and accepts the APP_ID from the same config as the app.
+package main
+
+import (
+	"log/slog"
+	"net"
+	"os"
+
+	"github.com/rshelekhov/reframed/internal/clients/sso/fake"
+	"github.com/rshelekhov/reframed/internal/config"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	ssov1 "github.com/rshelekhov/sso-protos/gen/go/sso"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	cfg := config.MustLoad()
+
+	log := logger.SetupLogger(cfg.AppEnv)
+
+	srv, err := fake.NewServer(cfg.AppData.ID, log)
+	if err != nil {
+		log.Error("failed to init fake sso server", logger.Err(err))
+		os.Exit(1)
+	}
+
+	lis, err := net.Listen("tcp", cfg.Clients.SSO.Address)
+	if err != nil {
+		log.Error("failed to listen", logger.Err(err))
+		os.Exit(1)
+	}
+
+	grpcServer := grpc.NewServer()
+	ssov1.RegisterAuthServer(grpcServer, srv)
+
+	log.Info("starting fake sso server", slog.String("address", cfg.Clients.SSO.Address))
+
+	if err = grpcServer.Serve(lis); err != nil {
+		log.Error("fake sso server stopped", logger.Err(err))
+		os.Exit(1)
+	}
+}
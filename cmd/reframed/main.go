@@ -4,6 +4,7 @@ package main
 import (
 	"context"
 	"log/slog"
+	"os"
 
 	"github.com/rshelekhov/reframed/internal/config"
 
@@ -13,6 +14,8 @@ import (
 	ssogrpc "github.com/rshelekhov/reframed/internal/clients/sso/grpc"
 	v1 "github.com/rshelekhov/reframed/internal/handler/http/v1"
 	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/mailer"
+	"github.com/rshelekhov/reframed/internal/port"
 	"github.com/rshelekhov/reframed/internal/storage/postgres"
 	"github.com/rshelekhov/reframed/internal/usecase"
 )
@@ -31,53 +34,146 @@ func main() {
 		slog.String("address", cfg.HTTPServer.Address))
 	log.Debug("logger debug mode enabled")
 
-	ssoClient, err := ssogrpc.New(
-		context.Background(),
-		log,
-		cfg.Clients.SSO.Address,
-		cfg.Clients.SSO.Timeout,
-		cfg.Clients.SSO.RetriesCount,
-	)
-	if err != nil {
-		log.Error("failed to init sso client", logger.Err(err))
-	}
-
-	tokenAuth := jwtoken.NewService(ssoClient, cfg.AppData.ID)
-
 	// Storage
 	pg, err := postgres.NewStorage(cfg)
 	if err != nil {
 		log.Error("failed to init storage", logger.Err(err))
+		os.Exit(1)
 	}
 
 	log.Debug("storage initiated")
 
+	// Mailer
+	var emailSender port.Mailer
+
+	switch cfg.Mailer.Type {
+	case config.MailerTypeSMTP:
+		emailSender = mailer.NewSMTPMailer(
+			cfg.Mailer.SMTP.Host,
+			cfg.Mailer.SMTP.Port,
+			cfg.Mailer.SMTP.Username,
+			cfg.Mailer.SMTP.Password,
+			cfg.Mailer.From,
+		)
+	case config.MailerTypeFile:
+		emailSender, err = mailer.NewFileMailer(cfg.Mailer.Dir, cfg.Mailer.From)
+		if err != nil {
+			log.Error("failed to init file mailer", logger.Err(err))
+			os.Exit(1)
+		}
+	case config.MailerTypeLog, "":
+		emailSender = mailer.NewLogMailer(log)
+	default:
+		log.Error("unknown mailer type", slog.String("type", cfg.Mailer.Type))
+		os.Exit(1)
+	}
+
+	// Auth provider, the sessions of the users are managed only with the local one
+	var (
+		authProvider   port.AuthProvider
+		sessionUsecase port.SessionUsecase
+	)
+
+	switch cfg.Auth.Provider {
+	case config.AuthProviderLocal:
+		localAuthStorage := postgres.NewLocalAuthStorage(pg)
+
+		localAuthProvider, err := usecase.NewLocalAuthProvider(cfg, log, localAuthStorage, emailSender)
+		if err != nil {
+			log.Error("failed to init local auth provider", logger.Err(err))
+			os.Exit(1)
+		}
+
+		authProvider = localAuthProvider
+		sessionUsecase = usecase.NewSessionUsecase(localAuthProvider)
+	case config.AuthProviderSSO, "":
+		ssoClient, err := ssogrpc.New(
+			context.Background(),
+			log,
+			cfg.Clients.SSO.Address,
+			cfg.Clients.SSO.Timeout,
+			cfg.Clients.SSO.RetriesCount,
+		)
+		if err != nil {
+			log.Error("failed to init sso client", logger.Err(err))
+			os.Exit(1)
+		}
+
+		authProvider = ssoClient.Api
+	default:
+		log.Error("unknown auth provider", slog.String("provider", cfg.Auth.Provider))
+		os.Exit(1)
+	}
+
+	log.Debug("auth provider initiated", slog.String("provider", cfg.Auth.Provider))
+
+	tokenAuth := jwtoken.NewService(authProvider, cfg.AppData.ID, cfg.Clients.SSO.JWKS, cfg.HTTPServer.WebAuth, log)
+
+	// Keep the keys of the auth provider fresh, so that the requests don't wait for them
+	tokenAuth.RefreshJWKSInBackground(context.Background())
+
 	userStorage := postgres.NewUserStorage(pg)
 	headingStorage := postgres.NewHeadingStorage(pg)
 	listStorage := postgres.NewListStorage(pg)
+	areaStorage := postgres.NewAreaStorage(pg)
 	taskStorage := postgres.NewTaskStorage(pg)
 	tagStorage := postgres.NewTagStorage(pg)
 	statusStorage := postgres.NewStatusStorage(pg)
+	timeEntryStorage := postgres.NewTimeEntryStorage(pg)
+	focusSessionStorage := postgres.NewFocusSessionStorage(pg)
+	templateStorage := postgres.NewTemplateStorage(pg)
+	personalTokenStorage := postgres.NewPersonalTokenStorage(pg)
+	digestStorage := postgres.NewDigestStorage(pg)
 
 	// Usecases
 	userUsecase := usecase.NewUserUsecase(userStorage)
-	authUsecase := usecase.NewAuthUsecase(cfg, ssoClient, tokenAuth)
+	authUsecase := usecase.NewAuthUsecase(cfg, authProvider, tokenAuth)
 	headingUsecase := usecase.NewHeadingUsecase(headingStorage)
 	listUsecase := usecase.NewListUsecase(listStorage)
+	areaUsecase := usecase.NewAreaUsecase(areaStorage)
 	tagUsecase := usecase.NewTagUsecase(tagStorage)
 	taskUsecase := usecase.NewTaskUsecase(taskStorage)
 	statusUsecase := usecase.NewStatusUsecase(statusStorage)
+	timeEntryUsecase := usecase.NewTimeEntryUsecase(timeEntryStorage)
+	focusSessionUsecase := usecase.NewFocusSessionUsecase(focusSessionStorage)
+	templateUsecase := usecase.NewTemplateUsecase(templateStorage)
+	personalTokenUsecase := usecase.NewPersonalTokenUsecase(cfg, personalTokenStorage)
+	digestUsecase := usecase.NewDigestUsecase(cfg, log, digestStorage, emailSender)
 
 	authUsecase.UserUsecase = userUsecase
 	authUsecase.ListUsecase = listUsecase
 	authUsecase.HeadingUsecase = headingUsecase
 	headingUsecase.ListUsecase = listUsecase
 	headingUsecase.TaskUsecase = taskUsecase
+	listUsecase.AreaUsecase = areaUsecase
 	listUsecase.HeadingUsecase = headingUsecase
 	listUsecase.TaskUsecase = taskUsecase
+	areaUsecase.ListUsecase = listUsecase
 	taskUsecase.HeadingUsecase = headingUsecase
 	taskUsecase.TagUsecase = tagUsecase
 	taskUsecase.ListUsecase = listUsecase
+	taskUsecase.UserUsecase = userUsecase
+	taskUsecase.FocusSessionUsecase = focusSessionUsecase
+	taskUsecase.StatusUsecase = statusUsecase
+	statusUsecase.ListUsecase = listUsecase
+	timeEntryUsecase.TaskUsecase = taskUsecase
+	timeEntryUsecase.UserUsecase = userUsecase
+	focusSessionUsecase.TaskUsecase = taskUsecase
+	focusSessionUsecase.UserUsecase = userUsecase
+	templateUsecase.AreaUsecase = areaUsecase
+	templateUsecase.ListUsecase = listUsecase
+	templateUsecase.HeadingUsecase = headingUsecase
+	templateUsecase.TaskUsecase = taskUsecase
+	templateUsecase.TagUsecase = tagUsecase
+	templateUsecase.UserUsecase = userUsecase
+	digestUsecase.TaskUsecase = taskUsecase
+	digestUsecase.UserUsecase = userUsecase
+
+	// Personal access tokens are accepted by the verifier alongside the access tokens of the SSO service
+	tokenAuth.PersonalTokens = personalTokenUsecase
+
+	// Send the daily and weekly digests at the local time of the users
+	digestUsecase.SendDigestsInBackground(context.Background())
 
 	// HTTP Server
 	log.Info("starting httpserver", slog.String("address", cfg.HTTPServer.Address))
@@ -88,10 +184,18 @@ func main() {
 		tokenAuth,
 		authUsecase,
 		listUsecase,
+		areaUsecase,
 		headingUsecase,
 		taskUsecase,
 		tagUsecase,
 		statusUsecase,
+		userUsecase,
+		timeEntryUsecase,
+		focusSessionUsecase,
+		templateUsecase,
+		personalTokenUsecase,
+		sessionUsecase,
+		digestUsecase,
 	)
 
 	srv := httpserver.NewServer(cfg, log, tokenAuth, router)
@@ -0,0 +1,55 @@
+package fake
+
+import (
+	"context"
+	"log/slog"
+	"net"
+
+	ssov1 "github.com/rshelekhov/sso-protos/gen/go/sso"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	ssogrpc "github.com/rshelekhov/reframed/internal/clients/sso/grpc"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+)
+
+const bufconnSize = 1024 * 1024
+
+// StartInProcess serves the fake SSO service through an in-memory listener and returns the client connected to it,
+// the returned function stops the server and closes the connection
+func StartInProcess(appID string, log *slog.Logger) (*Server, *ssogrpc.Client, func(), error) {
+	srv, err := NewServer(appID, log)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	lis := bufconn.Listen(bufconnSize)
+
+	grpcServer := grpc.NewServer()
+	ssov1.RegisterAuthServer(grpcServer, srv)
+
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Error("fake sso server stopped", logger.Err(err))
+		}
+	}()
+
+	cc, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		grpcServer.Stop()
+		return nil, nil, nil, err
+	}
+
+	stop := func() {
+		_ = cc.Close()
+		grpcServer.Stop()
+	}
+
+	return srv, ssogrpc.NewWithConn(cc, log), stop, nil
+}
@@ -0,0 +1,488 @@
+// Package fake provides an in-memory implementation of the SSO gRPC service,
+// it is used by the tests and for local development instead of the real SSO service.
+package fake
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	ssov1 "github.com/rshelekhov/sso-protos/gen/go/sso"
+)
+
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 30 * 24 * time.Hour
+	JWKSTTL         = time.Hour
+)
+
+type user struct {
+	id           string
+	email        string
+	passwordHash []byte
+	verified     bool
+	updatedAt    time.Time
+}
+
+// session is a refresh token issued to the device of the user
+type session struct {
+	userID    string
+	device    string
+	expiresAt time.Time
+}
+
+// Server implements ssov1.AuthServer for a single app, the verification and reset password tokens
+// are not emailed, they are logged and can be read with VerificationToken and ResetPasswordToken
+type Server struct {
+	ssov1.UnimplementedAuthServer
+
+	appID  string
+	log    *slog.Logger
+	signer *jwtoken.Signer
+
+	mu                 sync.Mutex
+	users              map[string]*user
+	userIDsByEmail     map[string]string
+	sessions           map[string]session
+	verificationTokens map[string]string
+	resetTokens        map[string]string
+}
+
+func NewServer(appID string, log *slog.Logger) (*Server, error) {
+	signer, err := jwtoken.GenerateSigner()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		appID:              appID,
+		log:                log,
+		signer:             signer,
+		users:              make(map[string]*user),
+		userIDsByEmail:     make(map[string]string),
+		sessions:           make(map[string]session),
+		verificationTokens: make(map[string]string),
+		resetTokens:        make(map[string]string),
+	}, nil
+}
+
+func (s *Server) RegisterApp(_ context.Context, _ *ssov1.RegisterAppRequest) (*ssov1.Empty, error) {
+	return &ssov1.Empty{}, nil
+}
+
+func (s *Server) RegisterUser(_ context.Context, req *ssov1.RegisterUserRequest) (*ssov1.RegisterUserResponse, error) {
+	if err := s.checkAppID(req.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	if req.GetEmail() == "" || req.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email and password are required")
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.GetPassword()), bcrypt.MinCost)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.userIDsByEmail[req.GetEmail()]; ok {
+		return nil, status.Error(codes.AlreadyExists, "user already exists")
+	}
+
+	newUser := &user{
+		id:           ksuid.New().String(),
+		email:        req.GetEmail(),
+		passwordHash: passwordHash,
+		updatedAt:    time.Now(),
+	}
+
+	s.users[newUser.id] = newUser
+	s.userIDsByEmail[newUser.email] = newUser.id
+
+	verificationToken := ksuid.New().String()
+	s.verificationTokens[verificationToken] = newUser.id
+	s.log.Info("email verification link", slog.String(key.Email, newUser.email), slog.String("url", req.GetVerificationURL()+verificationToken))
+
+	tokenData, err := s.issueTokens(newUser, req.GetUserDeviceData())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssov1.RegisterUserResponse{TokenData: tokenData}, nil
+}
+
+func (s *Server) VerifyEmail(_ context.Context, req *ssov1.VerifyEmailRequest) (*ssov1.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	userID, ok := s.verificationTokens[req.GetVerificationToken()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "verification token not found")
+	}
+
+	delete(s.verificationTokens, req.GetVerificationToken())
+
+	if u, ok := s.users[userID]; ok {
+		u.verified = true
+	}
+
+	return &ssov1.Empty{}, nil
+}
+
+func (s *Server) Login(_ context.Context, req *ssov1.LoginRequest) (*ssov1.LoginResponse, error) {
+	if err := s.checkAppID(req.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.userByEmail(req.GetEmail())
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	if bcrypt.CompareHashAndPassword(u.passwordHash, []byte(req.GetPassword())) != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+
+	tokenData, err := s.issueTokens(u, req.GetUserDeviceData())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssov1.LoginResponse{TokenData: tokenData}, nil
+}
+
+func (s *Server) ResetPassword(_ context.Context, req *ssov1.ResetPasswordRequest) (*ssov1.Empty, error) {
+	if err := s.checkAppID(req.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.userByEmail(req.GetEmail())
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	resetToken := ksuid.New().String()
+	s.resetTokens[resetToken] = u.id
+	s.log.Info("reset password link", slog.String(key.Email, u.email), slog.String("url", req.GetConfirmChangePasswordURL()+resetToken))
+
+	return &ssov1.Empty{}, nil
+}
+
+func (s *Server) ChangePassword(_ context.Context, req *ssov1.ChangePasswordRequest) (*ssov1.Empty, error) {
+	if err := s.checkAppID(req.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	userID, ok := s.resetTokens[req.GetResetPasswordToken()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "reset password token not found")
+	}
+
+	u, ok := s.users[userID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	if bcrypt.CompareHashAndPassword(u.passwordHash, []byte(req.GetUpdatedPassword())) == nil {
+		return nil, status.Error(codes.InvalidArgument, "updated password must not match the current password")
+	}
+
+	if err := u.setPassword(req.GetUpdatedPassword()); err != nil {
+		return nil, err
+	}
+
+	delete(s.resetTokens, req.GetResetPasswordToken())
+
+	return &ssov1.Empty{}, nil
+}
+
+func (s *Server) Logout(ctx context.Context, req *ssov1.LogoutRequest) (*ssov1.Empty, error) {
+	if err := s.checkAppID(req.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, err := s.userFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	device := deviceID(req.GetUserDeviceData())
+
+	for refreshToken, sess := range s.sessions {
+		if sess.userID == u.id && sess.device == device {
+			delete(s.sessions, refreshToken)
+		}
+	}
+
+	return &ssov1.Empty{}, nil
+}
+
+func (s *Server) Refresh(_ context.Context, req *ssov1.RefreshRequest) (*ssov1.RefreshResponse, error) {
+	if err := s.checkAppID(req.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.sessions[req.GetRefreshToken()]
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "session not found")
+	}
+
+	// Refresh tokens are used only once
+	delete(s.sessions, req.GetRefreshToken())
+
+	if time.Now().After(sess.expiresAt) {
+		return nil, status.Error(codes.Unauthenticated, "session expired")
+	}
+
+	u, ok := s.users[sess.userID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	tokenData, err := s.issueTokens(u, req.GetUserDeviceData())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssov1.RefreshResponse{TokenData: tokenData}, nil
+}
+
+func (s *Server) GetJWKS(_ context.Context, req *ssov1.GetJWKSRequest) (*ssov1.GetJWKSResponse, error) {
+	if err := s.checkAppID(req.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	return &ssov1.GetJWKSResponse{
+		Jwks: []*ssov1.JWK{s.signer.JWK()},
+		Ttl:  durationpb.New(JWKSTTL),
+	}, nil
+}
+
+func (s *Server) GetUser(ctx context.Context, req *ssov1.GetUserRequest) (*ssov1.GetUserResponse, error) {
+	if err := s.checkAppID(req.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, err := s.userFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssov1.GetUserResponse{
+		Email:     u.email,
+		Verified:  u.verified,
+		UpdatedAt: timestamppb.New(u.updatedAt),
+	}, nil
+}
+
+func (s *Server) UpdateUser(ctx context.Context, req *ssov1.UpdateUserRequest) (*ssov1.Empty, error) {
+	if err := s.checkAppID(req.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, err := s.userFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetEmail() != "" && req.GetEmail() != u.email {
+		if _, ok := s.userIDsByEmail[req.GetEmail()]; ok {
+			return nil, status.Error(codes.AlreadyExists, "email already taken")
+		}
+	}
+
+	if req.GetUpdatedPassword() != "" {
+		if bcrypt.CompareHashAndPassword(u.passwordHash, []byte(req.GetCurrentPassword())) != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid current password")
+		}
+
+		if req.GetUpdatedPassword() == req.GetCurrentPassword() {
+			return nil, status.Error(codes.InvalidArgument, "updated password must not match the current password")
+		}
+
+		if err = u.setPassword(req.GetUpdatedPassword()); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.GetEmail() != "" && req.GetEmail() != u.email {
+		delete(s.userIDsByEmail, u.email)
+		s.userIDsByEmail[req.GetEmail()] = u.id
+		u.email = req.GetEmail()
+	}
+
+	u.updatedAt = time.Now()
+
+	return &ssov1.Empty{}, nil
+}
+
+func (s *Server) DeleteUser(ctx context.Context, req *ssov1.DeleteUserRequest) (*ssov1.Empty, error) {
+	if err := s.checkAppID(req.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, err := s.userFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(s.users, u.id)
+	delete(s.userIDsByEmail, u.email)
+
+	for refreshToken, sess := range s.sessions {
+		if sess.userID == u.id {
+			delete(s.sessions, refreshToken)
+		}
+	}
+
+	return &ssov1.Empty{}, nil
+}
+
+// VerificationToken returns the last email verification token sent to the user
+func (s *Server) VerificationToken(email string) (string, bool) {
+	return s.tokenByEmail(s.verificationTokens, email)
+}
+
+// ResetPasswordToken returns the last reset password token sent to the user
+func (s *Server) ResetPasswordToken(email string) (string, bool) {
+	return s.tokenByEmail(s.resetTokens, email)
+}
+
+func (s *Server) tokenByEmail(tokens map[string]string, email string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.userByEmail(email)
+	if !ok {
+		return "", false
+	}
+
+	// ksuids are sortable by the time of creation
+	var lastToken string
+
+	for token, userID := range tokens {
+		if userID == u.id && token > lastToken {
+			lastToken = token
+		}
+	}
+
+	return lastToken, lastToken != ""
+}
+
+func (s *Server) checkAppID(appID string) error {
+	if appID != s.appID {
+		return status.Error(codes.Unauthenticated, "app ID does not exist")
+	}
+	return nil
+}
+
+func (s *Server) userByEmail(email string) (*user, bool) {
+	userID, ok := s.userIDsByEmail[email]
+	if !ok {
+		return nil, false
+	}
+
+	u, ok := s.users[userID]
+
+	return u, ok
+}
+
+// userFromMetadata returns the owner of the access token sent in the metadata of the request
+func (s *Server) userFromMetadata(ctx context.Context) (*user, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(jwtoken.AccessTokenKey)) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "access token not found in metadata")
+	}
+
+	userID, err := s.signer.ParseAccessToken(md.Get(jwtoken.AccessTokenKey)[0])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	u, ok := s.users[userID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	return u, nil
+}
+
+// issueTokens signs a new access token and opens a new session for the device
+func (s *Server) issueTokens(u *user, device *ssov1.UserDeviceData) (*ssov1.TokenData, error) {
+	accessToken, err := s.signer.SignAccessToken(u.id, s.appID, u.email, "", AccessTokenTTL)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	refreshToken := ksuid.New().String()
+	expiresAt := time.Now().Add(RefreshTokenTTL)
+
+	s.sessions[refreshToken] = session{
+		userID:    u.id,
+		device:    deviceID(device),
+		expiresAt: expiresAt,
+	}
+
+	return &ssov1.TokenData{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		Domain:       "localhost",
+		Path:         "/",
+		ExpiresAt:    timestamppb.New(expiresAt),
+		HttpOnly:     true,
+	}, nil
+}
+
+func (u *user) setPassword(password string) error {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	u.passwordHash = passwordHash
+	u.updatedAt = time.Now()
+
+	return nil
+}
+
+func deviceID(device *ssov1.UserDeviceData) string {
+	return strings.Join([]string{device.GetUserAgent(), device.GetIp()}, "|")
+}
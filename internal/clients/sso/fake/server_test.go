@@ -0,0 +1,146 @@
+package fake_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/rshelekhov/reframed/internal/clients/sso/fake"
+	"github.com/rshelekhov/reframed/internal/config"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+	"github.com/rshelekhov/reframed/internal/usecase"
+)
+
+const appID = "test_app"
+
+// listUsecase records the default lists created for the registered users
+type listUsecase struct {
+	port.ListUsecase
+
+	defaultListUserIDs []string
+}
+
+func (u *listUsecase) CreateDefaultList(_ context.Context, userID string) error {
+	u.defaultListUserIDs = append(u.defaultListUserIDs, userID)
+	return nil
+}
+
+func newAuthUsecase(t *testing.T) (*usecase.AuthUsecase, *fake.Server, *listUsecase) {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	srv, ssoClient, stop, err := fake.StartInProcess(appID, log)
+	if err != nil {
+		t.Fatalf("Failed to start fake SSO: %v", err)
+	}
+
+	t.Cleanup(stop)
+
+	cfg := &config.ServerSettings{
+		AppData: config.AppDataSettings{ID: appID},
+	}
+
+	jwt := jwtoken.NewService(ssoClient.Api, appID, config.JWKSSettings{}, config.WebAuthSettings{}, log)
+	lists := &listUsecase{}
+
+	authUsecase := usecase.NewAuthUsecase(cfg, ssoClient.Api, jwt)
+	authUsecase.ListUsecase = lists
+
+	return authUsecase, srv, lists
+}
+
+func TestFakeSSO_RegisterLoginRefresh(t *testing.T) {
+	authUsecase, _, lists := newAuthUsecase(t)
+	ctx := context.Background()
+	device := model.UserDeviceRequestData{UserAgent: "test", IP: "127.0.0.1"}
+	user := &model.UserRequestData{Email: "alice@example.com", Password: "Password1!"}
+
+	tokenData, userID, err := authUsecase.RegisterNewUser(ctx, user, device)
+	if err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+
+	if len(lists.defaultListUserIDs) != 1 || lists.defaultListUserIDs[0] != userID {
+		t.Errorf("Expected the default list for user %s, got %v", userID, lists.defaultListUserIDs)
+	}
+
+	if _, _, err = authUsecase.RegisterNewUser(ctx, user, device); !errors.Is(err, le.ErrUserAlreadyExists) {
+		t.Errorf("Expected %v, got %v", le.ErrUserAlreadyExists, err)
+	}
+
+	_, loginUserID, err := authUsecase.LoginUser(ctx, user, device)
+	if err != nil {
+		t.Fatalf("Failed to login user: %v", err)
+	}
+
+	if loginUserID != userID {
+		t.Errorf("Expected user %s, got %s", userID, loginUserID)
+	}
+
+	if _, _, err = authUsecase.LoginUser(ctx, &model.UserRequestData{Email: user.Email, Password: "wrong"}, device); err == nil {
+		t.Errorf("Expected an error for the wrong password")
+	}
+
+	// Refresh tokens can be used only once
+	if _, _, err = authUsecase.RefreshTokens(ctx, tokenData.GetRefreshToken(), device); err != nil {
+		t.Fatalf("Failed to refresh tokens: %v", err)
+	}
+
+	if _, _, err = authUsecase.RefreshTokens(ctx, tokenData.GetRefreshToken(), device); err == nil {
+		t.Errorf("Expected an error for the used refresh token")
+	}
+}
+
+func TestFakeSSO_VerifyEmailAndResetPassword(t *testing.T) {
+	authUsecase, srv, _ := newAuthUsecase(t)
+	ctx := context.Background()
+	device := model.UserDeviceRequestData{UserAgent: "test", IP: "127.0.0.1"}
+	user := &model.UserRequestData{Email: "bob@example.com", Password: "Password1!"}
+
+	tokenData, _, err := authUsecase.RegisterNewUser(ctx, user, device)
+	if err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+
+	verificationToken, ok := srv.VerificationToken(user.Email)
+	if !ok {
+		t.Fatalf("Expected the verification token for %s", user.Email)
+	}
+
+	if err = authUsecase.VerifyEmail(ctx, verificationToken); err != nil {
+		t.Fatalf("Failed to verify email: %v", err)
+	}
+
+	userCtx := context.WithValue(ctx, jwtoken.AccessTokenKey, tokenData.GetAccessToken())
+
+	if _, err = authUsecase.GetUserByID(userCtx); err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+
+	if err = authUsecase.RequestResetPassword(ctx, user.Email); err != nil {
+		t.Fatalf("Failed to request reset password: %v", err)
+	}
+
+	resetToken, ok := srv.ResetPasswordToken(user.Email)
+	if !ok {
+		t.Fatalf("Expected the reset password token for %s", user.Email)
+	}
+
+	if err = authUsecase.ChangePassword(ctx, user.Password, resetToken); !errors.Is(err, le.ErrUpdatedPasswordMustNotMatchTheCurrent) {
+		t.Errorf("Expected %v, got %v", le.ErrUpdatedPasswordMustNotMatchTheCurrent, err)
+	}
+
+	if err = authUsecase.ChangePassword(ctx, "NewPassword1!", resetToken); err != nil {
+		t.Fatalf("Failed to change password: %v", err)
+	}
+
+	if _, _, err = authUsecase.LoginUser(ctx, &model.UserRequestData{Email: user.Email, Password: "NewPassword1!"}, device); err != nil {
+		t.Errorf("Failed to login with the changed password: %v", err)
+	}
+}
@@ -14,6 +14,11 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+// TODO: add the calls for the sessions of the user (list the devices with the last seen time,
+// revoke a session, revoke all the other sessions) when the SSO service exposes them.
+// The Auth service of sso-protos v0.0.35 has no session RPCs, the device data is only sent
+// with Login, Logout and Refresh, so the /user/sessions routes are served with the local
+// auth provider only
 type Client struct {
 	Api    ssov1.AuthClient
 	logger *slog.Logger
@@ -51,10 +56,15 @@ func New(
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	return NewWithConn(cc, log), nil
+}
+
+// NewWithConn creates the client on top of an existing connection, e.g. to the fake SSO service in tests
+func NewWithConn(cc grpc.ClientConnInterface, log *slog.Logger) *Client {
 	return &Client{
 		Api:    ssov1.NewAuthClient(cc),
 		logger: log,
-	}, nil
+	}
 }
 
 func interceptorLogger(l *slog.Logger) grpclog.Logger {
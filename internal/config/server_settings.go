@@ -8,20 +8,92 @@ type ServerSettings struct {
 	HTTPServer HTTPServerSettings `mapstructure:",squash"`
 	Postgres   PostgresSettings   `mapstructure:",squash"`
 	Clients    ClientsSettings    `mapstructure:",squash"`
+	Auth       AuthSettings       `mapstructure:",squash"`
+	Mailer     MailerSettings     `mapstructure:",squash"`
+	Digest     DigestSettings     `mapstructure:",squash"`
 }
 
 type AppDataSettings struct {
-	ID      string `mapstructure:"APP_ID"`
-	Name    string `mapstructure:"APP_NAME"`
-	Secret  string `mapstructure:"APP_SECRET"`
-	BaseURL string `mapstructure:"APP_BASE_URL"`
+	ID                string `mapstructure:"APP_ID"`
+	Name              string `mapstructure:"APP_NAME"`
+	Secret            string `mapstructure:"APP_SECRET"`
+	BaseURL           string `mapstructure:"APP_BASE_URL"`
+	VerifyEmailURL    string `mapstructure:"APP_VERIFY_EMAIL_URL"`
+	ChangePasswordURL string `mapstructure:"APP_CHANGE_PASSWORD_URL"`
+}
+
+// Pages of the web app the links in the auth emails lead to when their URLs are not set,
+// the token is appended to the URLs
+const (
+	DefaultVerifyEmailPath    = "/verify-email/?token="
+	DefaultChangePasswordPath = "/password/change?token="
+)
+
+// VerificationURL returns the URL of the email verification link without the token
+func (s AppDataSettings) VerificationURL() string {
+	if s.VerifyEmailURL != "" {
+		return s.VerifyEmailURL
+	}
+	return s.BaseURL + DefaultVerifyEmailPath
+}
+
+// ConfirmChangePasswordURL returns the URL of the reset password link without the token
+func (s AppDataSettings) ConfirmChangePasswordURL() string {
+	if s.ChangePasswordURL != "" {
+		return s.ChangePasswordURL
+	}
+	return s.BaseURL + DefaultChangePasswordPath
 }
 
 type HTTPServerSettings struct {
-	Address          string        `mapstructure:"HTTP_SERVER_ADDRESS"`
-	Timeout          time.Duration `mapstructure:"HTTP_SERVER_TIMEOUT" envDefault:"10s"`
-	IdleTimeout      time.Duration `mapstructure:"HTTP_SERVER_IDLE_TIMEOUT" envDefault:"60s"`
-	RequestLimitByIP int           `mapstructure:"HTTP_SERVER_REQUEST_LIMIT_BY_IP" envDefault:"100"`
+	Address          string           `mapstructure:"HTTP_SERVER_ADDRESS"`
+	Timeout          time.Duration    `mapstructure:"HTTP_SERVER_TIMEOUT" envDefault:"10s"`
+	IdleTimeout      time.Duration    `mapstructure:"HTTP_SERVER_IDLE_TIMEOUT" envDefault:"60s"`
+	RequestLimitByIP int              `mapstructure:"HTTP_SERVER_REQUEST_LIMIT_BY_IP" envDefault:"100"`
+	WebAuth          WebAuthSettings  `mapstructure:",squash"`
+	CORS             CORSSettings     `mapstructure:",squash"`
+	Security         SecuritySettings `mapstructure:",squash"`
+}
+
+const (
+	CookieSameSiteLax    = "lax"
+	CookieSameSiteStrict = "strict"
+	CookieSameSiteNone   = "none"
+)
+
+// WebAuthSettings protect the web clients authenticated with the cookies. The requests changing the data
+// with the cookies must send the CSRF token in the X-CSRF-Token header or come from a trusted origin.
+// The tokens in the query string can be limited to the routes opened without the headers, e.g. the calendar feed.
+// The cookies get the domain and the path of the auth provider unless they are set here
+type WebAuthSettings struct {
+	CookieSameSite     string   `mapstructure:"HTTP_COOKIE_SAME_SITE" envDefault:"lax"`
+	CookieSecure       bool     `mapstructure:"HTTP_COOKIE_SECURE"`
+	CookieDomain       string   `mapstructure:"HTTP_COOKIE_DOMAIN"`
+	CookiePath         string   `mapstructure:"HTTP_COOKIE_PATH"`
+	CSRFTrustedOrigins []string `mapstructure:"HTTP_CSRF_TRUSTED_ORIGINS"`
+	DisableQueryTokens bool     `mapstructure:"HTTP_DISABLE_QUERY_TOKENS"`
+	QueryTokenRoutes   []string `mapstructure:"HTTP_QUERY_TOKEN_ROUTES"`
+}
+
+// CORSSettings allow the browser clients from other origins to call the API, CORS is disabled without
+// the allowed origins. The origins may contain wildcards, e.g. https://*.reframedapp.com, "*" allows any origin
+// but never with credentials, so the clients sending the cookies need the exact origins
+type CORSSettings struct {
+	AllowedOrigins   []string      `mapstructure:"HTTP_CORS_ALLOWED_ORIGINS"`
+	AllowedMethods   []string      `mapstructure:"HTTP_CORS_ALLOWED_METHODS" envDefault:"GET,POST,PUT,PATCH,DELETE"`
+	AllowedHeaders   []string      `mapstructure:"HTTP_CORS_ALLOWED_HEADERS" envDefault:"Accept,Authorization,Content-Type,X-CSRF-Token,X-Time-Zone,X-Request-Id"`
+	ExposedHeaders   []string      `mapstructure:"HTTP_CORS_EXPOSED_HEADERS"`
+	AllowCredentials bool          `mapstructure:"HTTP_CORS_ALLOW_CREDENTIALS"`
+	MaxAge           time.Duration `mapstructure:"HTTP_CORS_MAX_AGE" envDefault:"10m"`
+}
+
+// SecuritySettings set the security headers of the responses. HSTS is sent when its max age is set,
+// the Content-Security-Policy is sent with the HTML responses only
+type SecuritySettings struct {
+	HSTSMaxAge            time.Duration `mapstructure:"HTTP_HSTS_MAX_AGE"`
+	HSTSIncludeSubdomains bool          `mapstructure:"HTTP_HSTS_INCLUDE_SUBDOMAINS"`
+	FrameOptions          string        `mapstructure:"HTTP_FRAME_OPTIONS" envDefault:"DENY"`
+	ContentSecurityPolicy string        `mapstructure:"HTTP_CONTENT_SECURITY_POLICY" envDefault:"default-src 'none'; frame-ancestors 'none'; base-uri 'none'; form-action 'self'"`
 }
 
 type PostgresSettings struct {
@@ -50,6 +122,57 @@ type Client struct {
 	Address      string        `mapstructure:"SSO_CLIENT_ADDRESS"`
 	Timeout      time.Duration `mapstructure:"SSO_CLIENT_TIMEOUT"`
 	RetriesCount int           `mapstructure:"SSO_CLIENT_RETRIES_COUNT"`
+	JWKS         JWKSSettings  `mapstructure:",squash"`
 	// TODO: implement secure transport
 	// Insecure     bool          `mapstructure:"SSO_CLIENT_INSECURE"`
 }
+
+// JWKSSettings control how the public keys of the SSO service are kept up to date
+type JWKSSettings struct {
+	RefreshBefore      time.Duration `mapstructure:"SSO_JWKS_REFRESH_BEFORE" envDefault:"1m"`
+	StaleGracePeriod   time.Duration `mapstructure:"SSO_JWKS_STALE_GRACE_PERIOD" envDefault:"1h"`
+	MinRefreshInterval time.Duration `mapstructure:"SSO_JWKS_MIN_REFRESH_INTERVAL" envDefault:"10s"`
+}
+
+const (
+	AuthProviderSSO   = "sso"
+	AuthProviderLocal = "local"
+)
+
+// AuthSettings select who keeps the users. With the local provider the users are stored in Postgres
+// and the tokens are signed by the service itself, the SSO settings are not used then
+type AuthSettings struct {
+	Provider        string        `mapstructure:"AUTH_PROVIDER" envDefault:"sso"`
+	PrivateKeyPath  string        `mapstructure:"AUTH_PRIVATE_KEY_PATH"`
+	AccessTokenTTL  time.Duration `mapstructure:"AUTH_ACCESS_TOKEN_TTL" envDefault:"15m"`
+	RefreshTokenTTL time.Duration `mapstructure:"AUTH_REFRESH_TOKEN_TTL" envDefault:"720h"`
+	EmailTokenTTL   time.Duration `mapstructure:"AUTH_EMAIL_TOKEN_TTL" envDefault:"24h"`
+}
+
+const (
+	MailerTypeLog  = "log"
+	MailerTypeSMTP = "smtp"
+	MailerTypeFile = "file"
+)
+
+// MailerSettings select how the emails are sent: written to the log, sent through the SMTP server
+// or written as .eml files to the directory
+type MailerSettings struct {
+	Type string       `mapstructure:"MAILER_TYPE" envDefault:"log"`
+	From string       `mapstructure:"MAILER_FROM"`
+	Dir  string       `mapstructure:"MAILER_DIR"`
+	SMTP SMTPSettings `mapstructure:",squash"`
+}
+
+type SMTPSettings struct {
+	Host     string `mapstructure:"SMTP_HOST"`
+	Port     int    `mapstructure:"SMTP_PORT" envDefault:"587"`
+	Username string `mapstructure:"SMTP_USERNAME"`
+	Password string `mapstructure:"SMTP_PASSWORD"`
+}
+
+// DigestSettings control the job sending the daily and weekly digests, the digest of the user is sent
+// on the first check after the send time chosen by the user
+type DigestSettings struct {
+	CheckInterval time.Duration `mapstructure:"DIGEST_CHECK_INTERVAL" envDefault:"5m"`
+}
@@ -0,0 +1,242 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type areaHandler struct {
+	logger  *slog.Logger
+	jwt     *jwtoken.TokenService
+	usecase port.AreaUsecase
+}
+
+func newAreaHandler(
+	log *slog.Logger,
+	jwt *jwtoken.TokenService,
+	usecase port.AreaUsecase,
+) *areaHandler {
+	return &areaHandler{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+}
+
+func (h *areaHandler) CreateArea() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "area.handler.CreateArea"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		areaInput := &model.AreaRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, areaInput); err != nil {
+			return
+		}
+
+		areaInput.UserID = userID
+
+		area, err := h.usecase.CreateArea(ctx, areaInput)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToCreateArea, err)
+			return
+		}
+
+		handleResponseCreated(w, r, log, "area created", area, slog.String(key.AreaID, area.ID))
+	}
+}
+
+func (h *areaHandler) GetAreaByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "area.handler.GetAreaByID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		areaID := chi.URLParam(r, key.AreaID)
+
+		areaInput := model.AreaRequestData{
+			ID:     areaID,
+			UserID: userID,
+		}
+
+		areaResp, err := h.usecase.GetAreaByID(ctx, areaInput)
+
+		switch {
+		case errors.Is(err, le.ErrAreaNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAreaNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "area received", areaResp, slog.String(key.AreaID, areaID))
+	}
+}
+
+func (h *areaHandler) GetAreasByUserID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "area.handler.GetAreasByUserID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		areasResp, err := h.usecase.GetAreasByUserID(ctx, userID)
+
+		switch {
+		case errors.Is(err, le.ErrNoAreasFound):
+			handleResponseSuccess(w, r, log, "no areas found", nil)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetAreas, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "areas found", areasResp)
+	}
+}
+
+func (h *areaHandler) UpdateArea() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "area.handler.UpdateArea"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		areaID := chi.URLParam(r, key.AreaID)
+
+		areaInput := &model.AreaRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, areaInput); err != nil {
+			return
+		}
+
+		areaInput.ID = areaID
+		areaInput.UserID = userID
+
+		areaResp, err := h.usecase.UpdateArea(ctx, areaInput)
+
+		switch {
+		case errors.Is(err, le.ErrAreaNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAreaNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateArea, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "area updated", areaResp, slog.String(key.AreaID, areaResp.ID))
+	}
+}
+
+func (h *areaHandler) MoveArea() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "area.handler.MoveArea"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		areaID := chi.URLParam(r, key.AreaID)
+
+		areaInput := &model.AreaMoveRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, areaInput); err != nil {
+			return
+		}
+
+		areaInput.ID = areaID
+		areaInput.UserID = userID
+
+		areasResp, err := h.usecase.MoveArea(ctx, areaInput)
+
+		switch {
+		case errors.Is(err, le.ErrAreaNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAreaNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToMoveArea, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "area moved", areasResp, slog.String(key.AreaID, areaID))
+	}
+}
+
+func (h *areaHandler) DeleteArea() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "area.handler.DeleteArea"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		areaID := chi.URLParam(r, key.AreaID)
+
+		// The lists of the area are moved to the top level unless ?lists=archive is set
+		listsAction := model.AreaListsAction(r.URL.Query().Get(key.Lists))
+		if listsAction == "" {
+			listsAction = model.AreaListsMove
+		}
+
+		areaInput := model.AreaDeleteRequestData{
+			ID:     areaID,
+			Lists:  listsAction,
+			UserID: userID,
+		}
+
+		err = h.usecase.DeleteArea(ctx, areaInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidAreaListsAction):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidAreaListsAction)
+			return
+		case errors.Is(err, le.ErrAreaNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAreaNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteArea, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "area deleted", areaID, slog.String(key.AreaID, areaID))
+	}
+}
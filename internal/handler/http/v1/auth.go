@@ -6,7 +6,6 @@ import (
 	"log/slog"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
 
@@ -69,7 +68,7 @@ func (h *authHandler) Register() http.HandlerFunc {
 			slog.String(key.UserID, userID),
 			slog.Any(key.AccessToken, tokenData.GetAccessToken()),
 			slog.Any(key.RefreshToken, tokenData.GetRefreshToken()))
-		jwtoken.SendTokensToWeb(w, tokenData, http.StatusCreated)
+		h.jwt.SendTokensToWeb(w, tokenData, http.StatusCreated)
 	}
 }
 
@@ -144,7 +143,7 @@ func (h *authHandler) LoginWithPassword() http.HandlerFunc {
 			slog.String(key.UserID, userID),
 			slog.Any(key.AccessToken, tokenData.AccessToken),
 			slog.Any(key.RefreshToken, tokenData.RefreshToken))
-		jwtoken.SendTokensToWeb(w, tokenData, http.StatusOK)
+		h.jwt.SendTokensToWeb(w, tokenData, http.StatusOK)
 	}
 }
 
@@ -160,14 +159,8 @@ func (h *authHandler) RequestResetPassword() http.HandlerFunc {
 			return
 		}
 
-		err := h.usecase.RequestResetPassword(ctx, userInput.Email)
-
-		switch {
-		case errors.Is(err, le.ErrUserNotFound):
-			handleResponseError(w, r, log, http.StatusNotFound, le.ErrUserNotFound,
-				slog.String(key.Email, userInput.Email))
-			return
-		case err != nil:
+		// The response is the same for the unknown emails
+		if err := h.usecase.RequestResetPassword(ctx, userInput.Email); err != nil {
 			handleInternalServerError(w, r, log, le.ErrFailedToRequestResetPassword, err)
 			return
 		}
@@ -242,7 +235,7 @@ func (h *authHandler) RefreshTokens() http.HandlerFunc {
 			slog.String(key.UserID, userID),
 			slog.String(key.AccessToken, tokenData.AccessToken),
 			slog.String(key.RefreshToken, tokenData.RefreshToken))
-		jwtoken.SendTokensToWeb(w, tokenData, http.StatusOK)
+		h.jwt.SendTokensToWeb(w, tokenData, http.StatusOK)
 	}
 }
 
@@ -270,14 +263,7 @@ func (h *authHandler) Logout() http.HandlerFunc {
 			return
 		}
 
-		http.SetCookie(w, &http.Cookie{
-			Name:     "refreshToken",
-			Value:    "",
-			Path:     "/",
-			Expires:  time.Unix(0, 0),
-			HttpOnly: true,
-			MaxAge:   -1,
-		})
+		h.jwt.DeleteTokenCookies(w)
 
 		w.WriteHeader(http.StatusOK)
 		_, err = w.Write([]byte("Logged out successfully"))
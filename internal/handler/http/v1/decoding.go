@@ -22,6 +22,16 @@ func decodeJSON(w http.ResponseWriter, r *http.Request, log *slog.Logger, data a
 		err = decodeTaskRequestData(r, v)
 	case *model.TaskRequestTimeData:
 		err = decodeTaskRequestTimeData(r, v)
+	case *model.ScheduleAcceptRequestData:
+		err = decodeScheduleAcceptRequestData(r, v)
+	case *model.TimeEntryRequestData:
+		err = decodeTimeEntryRequestData(r, v)
+	case *model.ListRequestData:
+		err = decodeListRequestData(r, v)
+	case *model.TemplateRequestData:
+		err = decodeTemplateRequestData(r, v)
+	case *model.TemplateInstantiateRequestData:
+		err = decodeTemplateInstantiateRequestData(r, v)
 	default:
 		err = render.DecodeJSON(r.Body, &data)
 	}
@@ -94,6 +104,25 @@ func decodeTaskRequestTimeData(r *http.Request, data *model.TaskRequestTimeData)
 		return err
 	}
 
+	return parseTaskRequestTimeData(data)
+}
+
+func decodeScheduleAcceptRequestData(r *http.Request, data *model.ScheduleAcceptRequestData) error {
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&data); err != nil {
+		return err
+	}
+
+	for i := range data.Slots {
+		if err := parseTaskRequestTimeData(&data.Slots[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseTaskRequestTimeData(data *model.TaskRequestTimeData) error {
 	// Manually parse time fields
 	var err error
 
@@ -114,6 +143,87 @@ func decodeTaskRequestTimeData(r *http.Request, data *model.TaskRequestTimeData)
 	return nil
 }
 
+func decodeTimeEntryRequestData(r *http.Request, data *model.TimeEntryRequestData) error {
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&data); err != nil {
+		return err
+	}
+
+	// Manually parse time fields
+	var err error
+
+	data.StartTimeParsed, err = parseIfNotEmpty(data.StartTime, func(v string) (time.Time, error) {
+		return time.Parse(time.DateTime, v)
+	})
+	if err != nil {
+		return fmt.Errorf("invalid start_time format, got %s, need to use the following format: %s", data.StartTime, time.DateTime)
+	}
+
+	data.EndTimeParsed, err = parseIfNotEmpty(data.EndTime, func(v string) (time.Time, error) {
+		return time.Parse(time.DateTime, v)
+	})
+	if err != nil {
+		return fmt.Errorf("invalid end_time format, got %s, need to use the following format: %s", data.EndTime, time.DateTime)
+	}
+
+	return nil
+}
+
+func decodeListRequestData(r *http.Request, data *model.ListRequestData) error {
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&data); err != nil {
+		return err
+	}
+
+	// Manually parse time fields
+	var err error
+
+	data.DeadlineParsed, err = parseIfNotEmpty(data.Deadline, func(v string) (time.Time, error) {
+		return time.Parse(time.DateOnly, v)
+	})
+	if err != nil {
+		return fmt.Errorf("invalid deadline format, got %s, need to use the following format: %s", data.Deadline, time.DateOnly)
+	}
+
+	return nil
+}
+
+func decodeTemplateRequestData(r *http.Request, data *model.TemplateRequestData) error {
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&data); err != nil {
+		return err
+	}
+
+	var err error
+
+	data.AnchorDateParsed, err = parseIfNotEmpty(data.AnchorDate, func(v string) (time.Time, error) {
+		return time.Parse(time.DateOnly, v)
+	})
+	if err != nil {
+		return fmt.Errorf("invalid anchor_date format, got %s, need to use the following format: %s", data.AnchorDate, time.DateOnly)
+	}
+
+	return nil
+}
+
+func decodeTemplateInstantiateRequestData(r *http.Request, data *model.TemplateInstantiateRequestData) error {
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&data); err != nil {
+		return err
+	}
+
+	var err error
+
+	data.AnchorDateParsed, err = parseIfNotEmpty(data.AnchorDate, func(v string) (time.Time, error) {
+		return time.Parse(time.DateOnly, v)
+	})
+	if err != nil {
+		return fmt.Errorf("invalid anchor_date format, got %s, need to use the following format: %s", data.AnchorDate, time.DateOnly)
+	}
+
+	return nil
+}
+
 func parseIfNotEmpty(value string, parseFunc func(string) (time.Time, error)) (time.Time, error) {
 	if value == "" {
 		return time.Time{}, nil
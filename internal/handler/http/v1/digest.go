@@ -0,0 +1,181 @@
+package v1
+
+import (
+	"errors"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type digestHandler struct {
+	logger  *slog.Logger
+	jwt     *jwtoken.TokenService
+	usecase port.DigestUsecase
+}
+
+func newDigestHandler(
+	log *slog.Logger,
+	jwt *jwtoken.TokenService,
+	usecase port.DigestUsecase,
+) *digestHandler {
+	return &digestHandler{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+}
+
+func (h *digestHandler) GetDigestSettings() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "digest.handler.GetDigestSettings"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		settingsResp, err := h.usecase.GetDigestSettings(ctx, userID)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetDigestSettings, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "digest settings received", settingsResp)
+	}
+}
+
+func (h *digestHandler) UpdateDigestSettings() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "digest.handler.UpdateDigestSettings"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		settingsInput := &model.DigestSettingsRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, settingsInput); err != nil {
+			return
+		}
+
+		settingsInput.UserID = userID
+
+		// The digests are sent to the email from the access token. Personal access tokens
+		// don't carry the email, so the email saved with the settings is used for them
+		if claims, err := h.jwt.GetClaimsFromToken(ctx); err == nil {
+			if email, ok := claims[key.Email].(string); ok {
+				settingsInput.Email = email
+			}
+		}
+
+		settingsResp, err := h.usecase.UpdateDigestSettings(ctx, settingsInput)
+
+		switch {
+		case errors.Is(err, le.ErrDigestEmailNotFound):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrDigestEmailNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateDigestSettings, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "digest settings updated", settingsResp)
+	}
+}
+
+// unsubscribePage asks to confirm the unsubscription opened from the link in the digest email,
+// the form is posted to the same URL with the token. Mail scanners only open the links, so they don't unsubscribe
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Email digests</title>
+</head>
+<body>
+{{if .Unsubscribed}}
+<p>You have been unsubscribed from the email digests.</p>
+{{else}}
+<p>Do you want to unsubscribe from the email digests?</p>
+<form method="post">
+<button type="submit">Unsubscribe</button>
+</form>
+{{end}}
+</body>
+</html>
+`))
+
+// ConfirmUnsubscribeFromDigests renders the confirmation page for the link in the digest email, nothing is changed
+func (h *digestHandler) ConfirmUnsubscribeFromDigests() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "digest.handler.ConfirmUnsubscribeFromDigests"
+
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		if r.URL.Query().Get(key.Token) == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrDigestUnsubscribeTokenNotFoundInQuery)
+			return
+		}
+
+		renderUnsubscribePage(w, log, false)
+	}
+}
+
+// UnsubscribeFromDigests is posted from the confirmation page or by the mail client with the one-click
+// List-Unsubscribe header, in both cases it's authorized by the token from the link
+func (h *digestHandler) UnsubscribeFromDigests() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "digest.handler.UnsubscribeFromDigests"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		unsubscribeToken := r.URL.Query().Get(key.Token)
+		if unsubscribeToken == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrDigestUnsubscribeTokenNotFoundInQuery)
+			return
+		}
+
+		err := h.usecase.UnsubscribeFromDigests(ctx, unsubscribeToken)
+
+		switch {
+		case errors.Is(err, le.ErrDigestUnsubscribeTokenNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrDigestUnsubscribeTokenNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUnsubscribeFromDigests, err)
+			return
+		}
+
+		// The form of the confirmation page is answered with the page, the mail clients get JSON
+		if strings.Contains(r.Header.Get("Accept"), "text/html") {
+			log.Info("unsubscribed from digests")
+			renderUnsubscribePage(w, log, true)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "unsubscribed from digests", nil)
+	}
+}
+
+func renderUnsubscribePage(w http.ResponseWriter, log *slog.Logger, unsubscribed bool) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := unsubscribePage.Execute(w, struct{ Unsubscribed bool }{unsubscribed}); err != nil {
+		log.Error("failed to render unsubscribe page", logger.Err(err))
+	}
+}
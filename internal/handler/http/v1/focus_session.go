@@ -0,0 +1,191 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type focusSessionHandler struct {
+	logger  *slog.Logger
+	jwt     *jwtoken.TokenService
+	usecase port.FocusSessionUsecase
+}
+
+func newFocusSessionHandler(
+	log *slog.Logger,
+	jwt *jwtoken.TokenService,
+	usecase port.FocusSessionUsecase,
+) *focusSessionHandler {
+	return &focusSessionHandler{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+}
+
+func (h *focusSessionHandler) StartFocusSession() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "focus_session.handler.StartFocusSession"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+
+		sessionInput := &model.FocusSessionRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, sessionInput); err != nil {
+			return
+		}
+
+		sessionInput.TaskID = taskID
+		sessionInput.UserID = userID
+
+		sessionResp, err := h.usecase.StartFocusSession(ctx, sessionInput)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrFocusSessionAlreadyActive):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrFocusSessionAlreadyActive)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToStartFocusSession, err)
+			return
+		}
+
+		handleResponseCreated(w, r, log, "focus session started", sessionResp,
+			slog.String(key.FocusSessionID, sessionResp.ID))
+	}
+}
+
+func (h *focusSessionHandler) GetActiveFocusSession() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "focus_session.handler.GetActiveFocusSession"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		sessionResp, err := h.usecase.GetActiveFocusSession(ctx, userID)
+
+		switch {
+		case errors.Is(err, le.ErrNoActiveFocusSession):
+			handleResponseSuccess(w, r, log, "no active focus session", nil)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "active focus session found", sessionResp,
+			slog.String(key.FocusSessionID, sessionResp.ID))
+	}
+}
+
+func (h *focusSessionHandler) GetFocusSessionByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "focus_session.handler.GetFocusSessionByID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		sessionID := chi.URLParam(r, key.FocusSessionID)
+
+		sessionInput := model.FocusSessionRequestData{
+			ID:     sessionID,
+			UserID: userID,
+		}
+
+		sessionResp, err := h.usecase.GetFocusSessionByID(ctx, sessionInput)
+
+		switch {
+		case errors.Is(err, le.ErrFocusSessionNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrFocusSessionNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "focus session received", sessionResp,
+			slog.String(key.FocusSessionID, sessionResp.ID))
+	}
+}
+
+func (h *focusSessionHandler) PauseFocusSession() http.HandlerFunc {
+	return h.changeFocusSession("focus_session.handler.PauseFocusSession", "focus session paused", h.usecase.PauseFocusSession)
+}
+
+func (h *focusSessionHandler) ResumeFocusSession() http.HandlerFunc {
+	return h.changeFocusSession("focus_session.handler.ResumeFocusSession", "focus session resumed", h.usecase.ResumeFocusSession)
+}
+
+func (h *focusSessionHandler) AbandonFocusSession() http.HandlerFunc {
+	return h.changeFocusSession("focus_session.handler.AbandonFocusSession", "focus session abandoned", h.usecase.AbandonFocusSession)
+}
+
+// changeFocusSession handles the requests that move the focus session to another state
+func (h *focusSessionHandler) changeFocusSession(
+	op, message string,
+	change func(ctx context.Context, data model.FocusSessionRequestData) (model.FocusSessionResponseData, error),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		sessionID := chi.URLParam(r, key.FocusSessionID)
+
+		sessionInput := model.FocusSessionRequestData{
+			ID:     sessionID,
+			UserID: userID,
+		}
+
+		sessionResp, err := change(ctx, sessionInput)
+
+		switch {
+		case errors.Is(err, le.ErrFocusSessionNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrFocusSessionNotFound)
+			return
+		case errors.Is(err, le.ErrInvalidFocusSessionTransition):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrInvalidFocusSessionTransition)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateFocusSession, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, message, sessionResp,
+			slog.String(key.FocusSessionID, sessionResp.ID))
+	}
+}
@@ -221,6 +221,92 @@ func (h *headingHandler) MoveHeadingToAnotherList() http.HandlerFunc {
 	}
 }
 
+func (h *headingHandler) PromoteHeadingToList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "heading.handler.PromoteHeadingToList"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		headingInput := &model.HeadingRequestData{
+			ID:     chi.URLParam(r, key.HeadingID),
+			ListID: chi.URLParam(r, key.ListID),
+			UserID: userID,
+		}
+
+		listResponse, err := h.usecase.PromoteHeadingToList(ctx, headingInput)
+
+		switch {
+		case errors.Is(err, le.ErrHeadingNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHeadingNotFound)
+			return
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrCannotPromoteDefaultHeading):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotPromoteDefaultHeading)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToPromoteHeading, err)
+			return
+		}
+
+		handleResponseCreated(w, r, log, "heading promoted to list", listResponse, slog.String(key.ListID, listResponse.ID))
+	}
+}
+
+func (h *headingHandler) DemoteListToHeading() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "heading.handler.DemoteListToHeading"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+
+		targetListID := r.URL.Query().Get(key.ListID)
+		if targetListID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		}
+
+		listInput := &model.ListDemoteRequestData{
+			ID:           listID,
+			TargetListID: targetListID,
+			UserID:       userID,
+		}
+
+		headingResponse, err := h.usecase.DemoteListToHeading(ctx, listInput)
+
+		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrCannotDemoteDefaultList):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotDemoteDefaultList)
+			return
+		case errors.Is(err, le.ErrCannotDemoteListIntoItself):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotDemoteListIntoItself)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDemoteList, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "list demoted to heading", headingResponse, slog.String(key.HeadingID, headingResponse.ID))
+	}
+}
+
 func (h *headingHandler) DeleteHeading() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "heading.handler.DeleteHeading"
@@ -254,3 +340,92 @@ func (h *headingHandler) DeleteHeading() http.HandlerFunc {
 		handleResponseSuccess(w, r, log, "heading deleted", headingID, slog.String(key.HeadingID, headingID))
 	}
 }
+
+func (h *headingHandler) CompleteHeading() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "heading.handler.CompleteHeading"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		headingID := chi.URLParam(r, key.HeadingID)
+
+		// The open tasks of the heading are completed unless ?tasks=cancel is set
+		tasksAction := model.HeadingTasksAction(r.URL.Query().Get(key.Tasks))
+		if tasksAction == "" {
+			tasksAction = model.HeadingTasksComplete
+		}
+
+		headingInput := model.HeadingCompleteRequestData{
+			ID:     headingID,
+			ListID: chi.URLParam(r, key.ListID),
+			Tasks:  tasksAction,
+			UserID: userID,
+		}
+
+		headingResponse, err := h.usecase.CompleteHeading(ctx, headingInput)
+
+		switch {
+		case errors.Is(err, le.ErrHeadingNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHeadingNotFound)
+			return
+		case errors.Is(err, le.ErrInvalidHeadingTasksAction):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidHeadingTasksAction)
+			return
+		case errors.Is(err, le.ErrCannotCompleteDefaultHeading):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotCompleteDefaultHeading)
+			return
+		case errors.Is(err, le.ErrHeadingAlreadyCompleted):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrHeadingAlreadyCompleted)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCompleteHeading, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "heading completed", headingResponse, slog.String(key.HeadingID, headingID))
+	}
+}
+
+func (h *headingHandler) RestoreHeading() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "heading.handler.RestoreHeading"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		headingID := chi.URLParam(r, key.HeadingID)
+
+		headingInput := model.HeadingRequestData{
+			ID:     headingID,
+			ListID: chi.URLParam(r, key.ListID),
+			UserID: userID,
+		}
+
+		headingResponse, err := h.usecase.RestoreHeading(ctx, headingInput)
+
+		switch {
+		case errors.Is(err, le.ErrHeadingNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHeadingNotFound)
+			return
+		case errors.Is(err, le.ErrHeadingNotCompleted):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrHeadingNotCompleted)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToRestoreHeading, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "heading restored", headingResponse, slog.String(key.HeadingID, headingID))
+	}
+}
@@ -80,12 +80,14 @@ func responseSuccess(
 	statusCode int,
 	message string,
 	data any,
+	meta any,
 ) {
 	response := model.Response{
 		Code:        statusCode,
 		StatusText:  http.StatusText(statusCode),
 		Description: message,
 		Data:        data,
+		Meta:        meta,
 	}
 
 	render.Status(r, statusCode)
@@ -102,7 +104,21 @@ func handleResponseSuccess(
 	addLogData ...any,
 ) {
 	log.Info(message, addLogData...)
-	responseSuccess(w, r, http.StatusOK, message, data)
+	responseSuccess(w, r, http.StatusOK, message, data, nil)
+}
+
+// handleResponseSuccessWithMeta renders a success response with data and additional information about it
+func handleResponseSuccessWithMeta(
+	w http.ResponseWriter,
+	r *http.Request,
+	log *slog.Logger,
+	message string,
+	data any,
+	meta any,
+	addLogData ...any,
+) {
+	log.Info(message, addLogData...)
+	responseSuccess(w, r, http.StatusOK, message, data, meta)
 }
 
 // handleResponseCreated renders a created response with status code and data
@@ -115,7 +131,7 @@ func handleResponseCreated(
 	addLogData ...any,
 ) {
 	log.Info(message, addLogData...)
-	responseSuccess(w, r, http.StatusCreated, message, data)
+	responseSuccess(w, r, http.StatusCreated, message, data, nil)
 }
 
 type errorResponse struct {
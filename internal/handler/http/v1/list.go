@@ -54,7 +54,12 @@ func (h *listHandler) CreateList() http.HandlerFunc {
 		listInput.UserID = userID
 
 		list, err := h.usecase.CreateList(ctx, listInput)
-		if err != nil {
+
+		switch {
+		case errors.Is(err, le.ErrAreaNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAreaNotFound)
+			return
+		case err != nil:
 			handleInternalServerError(w, r, log, le.ErrFailedToCreateList, err)
 			return
 		}
@@ -147,7 +152,17 @@ func (h *listHandler) GetListsByUserID() http.HandlerFunc {
 			return
 		}
 
-		listsResp, err := h.usecase.GetListsByUserID(ctx, userID)
+		var listsResp any
+
+		switch r.URL.Query().Get(key.GroupBy) {
+		case "":
+			listsResp, err = h.usecase.GetListsByUserID(ctx, userID)
+		case model.GroupByArea:
+			listsResp, err = h.usecase.GetListsGroupedByArea(ctx, userID)
+		default:
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidListsGroupBy)
+			return
+		}
 
 		switch {
 		case errors.Is(err, le.ErrNoListsFound):
@@ -199,6 +214,49 @@ func (h *listHandler) UpdateList() http.HandlerFunc {
 	}
 }
 
+func (h *listHandler) MoveList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list.handler.MoveList"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+
+		listInput := &model.ListMoveRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, listInput); err != nil {
+			return
+		}
+
+		listInput.ID = listID
+		listInput.UserID = userID
+
+		listResponse, err := h.usecase.MoveList(ctx, listInput)
+
+		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrAreaNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAreaNotFound)
+			return
+		case errors.Is(err, le.ErrCannotMoveDefaultList):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotMoveDefaultList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToMoveList, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "list moved", listResponse, slog.String(key.ListID, listResponse.ID))
+	}
+}
+
 func (h *listHandler) DeleteList() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "list.handler.DeleteList"
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/rshelekhov/reframed/internal/lib/constant/le"
 	"github.com/segmentio/ksuid"
 
@@ -75,3 +76,12 @@ func ParseLimitAndAfterDate(r *http.Request) (model.Pagination, error) {
 		CursorDate: afterDate,
 	}, nil
 }
+
+func ParseStatusID(r *http.Request) (int, error) {
+	statusID, err := strconv.Atoi(chi.URLParam(r, key.StatusID))
+	if err != nil || statusID <= 0 {
+		return 0, le.ErrInvalidStatusID
+	}
+
+	return statusID, nil
+}
@@ -0,0 +1,124 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type personalTokenHandler struct {
+	logger  *slog.Logger
+	jwt     *jwtoken.TokenService
+	usecase port.PersonalTokenUsecase
+}
+
+func newPersonalTokenHandler(
+	log *slog.Logger,
+	jwt *jwtoken.TokenService,
+	usecase port.PersonalTokenUsecase,
+) *personalTokenHandler {
+	return &personalTokenHandler{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+}
+
+func (h *personalTokenHandler) CreatePersonalToken() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "personal_token.handler.CreatePersonalToken"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		tokenInput := &model.PersonalTokenRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, tokenInput); err != nil {
+			return
+		}
+
+		tokenInput.UserID = userID
+
+		tokenResp, err := h.usecase.CreatePersonalToken(ctx, tokenInput)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToCreatePersonalToken, err)
+			return
+		}
+
+		handleResponseCreated(w, r, log, "personal token created", tokenResp, slog.String(key.TokenID, tokenResp.ID))
+	}
+}
+
+func (h *personalTokenHandler) GetPersonalTokensByUserID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "personal_token.handler.GetPersonalTokensByUserID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		tokensResp, err := h.usecase.GetPersonalTokensByUserID(ctx, userID)
+
+		switch {
+		case errors.Is(err, le.ErrNoPersonalTokensFound):
+			handleResponseSuccess(w, r, log, "no personal tokens found", nil)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetPersonalTokens, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "personal tokens found", tokensResp)
+	}
+}
+
+func (h *personalTokenHandler) RevokePersonalToken() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "personal_token.handler.RevokePersonalToken"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		tokenID := chi.URLParam(r, key.TokenID)
+
+		tokenInput := model.PersonalTokenRequestData{
+			ID:     tokenID,
+			UserID: userID,
+		}
+
+		err = h.usecase.RevokePersonalToken(ctx, tokenInput)
+
+		switch {
+		case errors.Is(err, le.ErrPersonalTokenNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrPersonalTokenNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToRevokePersonalToken, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "personal token revoked", tokenID, slog.String(key.TokenID, tokenID))
+	}
+}
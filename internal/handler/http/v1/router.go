@@ -15,10 +15,18 @@ type AppRouter struct {
 	*jwtoken.TokenService
 	*authHandler
 	*listHandler
+	*areaHandler
 	*headingHandler
 	*taskHandler
 	*tagHandler
 	*statusHandler
+	*userHandler
+	*timeEntryHandler
+	*focusSessionHandler
+	*templateHandler
+	*personalTokenHandler
+	*sessionHandler
+	*digestHandler
 }
 
 func NewRouter(
@@ -27,21 +35,41 @@ func NewRouter(
 	jwt *jwtoken.TokenService,
 	authUsecase port.AuthUsecase,
 	listUsecase port.ListUsecase,
+	areaUsecase port.AreaUsecase,
 	headingUsecase port.HeadingUsecase,
 	taskUsecase port.TaskUsecase,
 	tagUsecase port.TagUsecase,
 	statusUsecase port.StatusUsecase,
+	userUsecase port.UserUsecase,
+	timeEntryUsecase port.TimeEntryUsecase,
+	focusSessionUsecase port.FocusSessionUsecase,
+	templateUsecase port.TemplateUsecase,
+	personalTokenUsecase port.PersonalTokenUsecase,
+	sessionUsecase port.SessionUsecase,
+	digestUsecase port.DigestUsecase,
 ) *chi.Mux {
 	ar := &AppRouter{
-		ServerSettings: cfg,
-		Logger:         log,
-		TokenService:   jwt,
-		authHandler:    newAuthHandler(log, jwt, authUsecase),
-		listHandler:    newListHandler(log, jwt, listUsecase),
-		headingHandler: newHeadingHandler(log, jwt, headingUsecase),
-		taskHandler:    newTaskHandler(log, jwt, taskUsecase),
-		tagHandler:     newTagHandler(log, jwt, tagUsecase),
-		statusHandler:  newStatusHandler(log, jwt, statusUsecase),
+		ServerSettings:       cfg,
+		Logger:               log,
+		TokenService:         jwt,
+		authHandler:          newAuthHandler(log, jwt, authUsecase),
+		listHandler:          newListHandler(log, jwt, listUsecase),
+		areaHandler:          newAreaHandler(log, jwt, areaUsecase),
+		headingHandler:       newHeadingHandler(log, jwt, headingUsecase),
+		taskHandler:          newTaskHandler(log, jwt, taskUsecase),
+		tagHandler:           newTagHandler(log, jwt, tagUsecase),
+		statusHandler:        newStatusHandler(log, jwt, statusUsecase),
+		userHandler:          newUserHandler(log, jwt, userUsecase),
+		timeEntryHandler:     newTimeEntryHandler(log, jwt, timeEntryUsecase),
+		focusSessionHandler:  newFocusSessionHandler(log, jwt, focusSessionUsecase),
+		templateHandler:      newTemplateHandler(log, jwt, templateUsecase),
+		personalTokenHandler: newPersonalTokenHandler(log, jwt, personalTokenUsecase),
+		digestHandler:        newDigestHandler(log, jwt, digestUsecase),
+	}
+
+	// The session routes are registered only when the auth provider manages the sessions
+	if sessionUsecase != nil {
+		ar.sessionHandler = newSessionHandler(log, jwt, sessionUsecase)
 	}
 
 	return ar.initRoutes()
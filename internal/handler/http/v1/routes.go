@@ -9,6 +9,9 @@ import (
 	"github.com/go-chi/render"
 	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
 	mwlogger "github.com/rshelekhov/reframed/internal/lib/middleware/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/security"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/timezone"
+	"github.com/rshelekhov/reframed/internal/model"
 )
 
 func (ar *AppRouter) initRoutes() *chi.Mux {
@@ -33,6 +36,12 @@ func (ar *AppRouter) initRoutes() *chi.Mux {
 	// the application should not crash.
 	r.Use(middleware.Recoverer)
 
+	// Security headers of all responses, including the errors of the middlewares below
+	r.Use(security.Headers(ar.ServerSettings.HTTPServer.Security))
+
+	// Answer the CORS preflight requests before the rate limiter and the authentication
+	r.Use(security.CORS(ar.ServerSettings.HTTPServer.CORS))
+
 	// Parser of incoming request URLs
 	r.Use(middleware.URLFormat)
 
@@ -50,11 +59,16 @@ func (ar *AppRouter) initRoutes() *chi.Mux {
 		r.Post("/login", ar.LoginWithPassword())
 		r.Post("/register", ar.Register())
 		r.Post("/verify-email", ar.VerifyEmail())
-		r.Post("/refresh-tokens", ar.RefreshTokens())
+		r.With(ar.CSRFProtector()).Post("/refresh-tokens", ar.RefreshTokens()) // the refresh token is read from the cookie for the web clients
 		r.Route("/password", func(r chi.Router) {
 			r.Get("/reset", ar.RequestResetPassword())
 			r.Post("/change", ar.ChangePassword())
 		})
+
+		// Opened from the link in the digest email, authorized by ?token= from the link.
+		// GET only asks to confirm, the settings are changed with POST
+		r.Get("/digest/unsubscribe", ar.ConfirmUnsubscribeFromDigests())
+		r.Post("/digest/unsubscribe", ar.UnsubscribeFromDigests())
 	})
 
 	// Protected routes
@@ -62,44 +76,119 @@ func (ar *AppRouter) initRoutes() *chi.Mux {
 		r.Use(jwtoken.Verifier(ar.TokenService))
 		r.Use(jwtoken.Authenticator())
 
-		r.Post("/logout", ar.Logout())
+		// The requests authenticated with the cookies must carry the CSRF token or come from a trusted origin
+		r.Use(ar.CSRFProtector())
+
+		// Override the user's time zone for a single request with the X-Time-Zone header
+		r.Use(timezone.Detector())
+
+		// Routes declare the scopes required from the limited tokens, e.g. personal access tokens.
+		// The read scope allows the safe methods, the write scopes allow the other ones
+		r.With(ar.RequireFullAccess()).Post("/logout", ar.Logout())
 
 		r.Route("/statuses", func(r chi.Router) {
-			r.Get("/", ar.GetStatuses())
-			r.Get("/{status_id}", ar.GetStatusByID())
+			r.Use(ar.RequireScopes(model.ScopeRead, model.ScopeStatusesWrite))
+
+			r.Get("/", ar.GetStatuses()) // built-in and custom statuses, ?list_id= adds the statuses of the list
+			r.Post("/", ar.CreateStatus())
+			r.Put("/transitions", ar.UpdateStatusTransitions())
+
+			r.Route("/{status_id}", func(r chi.Router) {
+				r.Get("/", ar.GetStatusByID())
+				r.Patch("/", ar.UpdateStatus())
+				r.Delete("/", ar.DeleteStatus())
+			})
 		})
 
 		r.Route("/user", func(r chi.Router) {
-			r.Get("/", ar.GetUser())
-			r.Patch("/", ar.UpdateUser())
-			r.Delete("/", ar.DeleteUser())
+			r.With(ar.RequireScopes(model.ScopeRead, model.ScopeSettingsWrite)).Get("/", ar.GetUser())
+			r.With(ar.RequireScopes(model.ScopeRead, model.ScopeSettingsWrite)).Patch("/", ar.UpdateUser())
+			r.With(ar.RequireFullAccess()).Delete("/", ar.DeleteUser())
+
+			r.Route("/settings", func(r chi.Router) {
+				r.Use(ar.RequireScopes(model.ScopeRead, model.ScopeSettingsWrite))
+
+				r.Get("/", ar.GetUserSettings())
+				r.Patch("/", ar.UpdateUserSettings())
+				r.Get("/digest", ar.GetDigestSettings())
+				r.Patch("/digest", ar.UpdateDigestSettings()) // the digests are sent to the email from the access token
+			})
+
+			r.Route("/tokens", func(r chi.Router) {
+				r.Use(ar.RequireFullAccess())
 
+				r.Get("/", ar.GetPersonalTokensByUserID())
+				r.Post("/", ar.CreatePersonalToken()) // the token is returned only once
+				r.Delete("/{token_id}", ar.RevokePersonalToken())
+			})
+
+			if ar.sessionHandler != nil {
+				r.Route("/sessions", func(r chi.Router) {
+					r.Use(ar.RequireFullAccess())
+
+					r.Get("/", ar.GetSessions())
+					r.Delete("/", ar.RevokeOtherSessions()) // the current session is kept
+					r.Delete("/{session_id}", ar.RevokeSession())
+				})
+			}
+
+			r.Route("/areas", func(r chi.Router) {
+				r.Use(ar.RequireScopes(model.ScopeRead, model.ScopeListsWrite))
+
+				r.Get("/", ar.GetAreasByUserID())
+				r.Post("/", ar.CreateArea())
+
+				r.Route("/{area_id}", func(r chi.Router) {
+					r.Get("/", ar.GetAreaByID()) // tasks added directly to the area are in its default_list_id
+					r.Patch("/", ar.UpdateArea())
+					r.Patch("/move", ar.MoveArea())
+					r.Delete("/", ar.DeleteArea()) // moves the lists to the top level, or archives them with ?lists=archive
+				})
+			})
+
+			// Creating and moving tasks inside a list requires both lists:write and tasks:write
 			r.Route("/lists", func(r chi.Router) {
-				r.Get("/", ar.GetListsByUserID())
+				r.Use(ar.RequireScopes(model.ScopeRead, model.ScopeListsWrite))
+
+				r.Get("/", ar.GetListsByUserID()) // lists with their area_id, or areas with their lists with ?group_by=area
 				r.Post("/", ar.CreateList())
 				r.Get("/default", ar.GetDefaultList())
-				r.Post("/default", ar.CreateTaskInDefaultList())
+				r.With(ar.RequireScopes(model.ScopeRead, model.ScopeTasksWrite)).Post("/default", ar.CreateTaskInDefaultList())
 
 				r.Route("/{list_id}", func(r chi.Router) {
 					r.Get("/", ar.GetListByID())
 					r.Patch("/", ar.UpdateList())
+					r.Patch("/move", ar.MoveList())              // into an area, or to the top level, at the position
+					r.Patch("/demote", ar.DemoteListToHeading()) // into a heading of the list from ?list_id=
 					r.Delete("/", ar.DeleteList())
 
 					r.Route("/tasks", func(r chi.Router) {
+						r.Use(ar.RequireScopes(model.ScopeRead, model.ScopeTasksWrite))
+
 						r.Get("/", ar.GetTasksByListID())
 						r.Post("/", ar.CreateTask())
 					})
 
+					r.Route("/board", func(r chi.Router) {
+						r.Use(ar.RequireScopes(model.ScopeRead, model.ScopeTasksWrite))
+
+						r.Get("/", ar.GetBoard())              // columns are the statuses available in the list
+						r.Patch("/move", ar.MoveTaskOnBoard()) // changes status and position of the task at once
+					})
+
 					r.Route("/headings", func(r chi.Router) {
 						r.Post("/", ar.CreateHeading())
 						r.Get("/", ar.GetHeadingsByListID())
 						r.Get("/tasks", ar.GetTasksGroupedByHeadings())
 
 						r.Route("/{heading_id}", func(r chi.Router) {
-							r.Post("/", ar.CreateTask())
+							r.With(ar.RequireScopes(model.ScopeRead, model.ScopeTasksWrite)).Post("/", ar.CreateTask())
 							r.Get("/", ar.GetHeadingByID())
 							r.Patch("/", ar.UpdateHeading())
 							r.Patch("/move", ar.MoveHeadingToAnotherList())
+							r.Post("/promote", ar.PromoteHeadingToList()) // into a new list in the same area
+							r.Patch("/complete", ar.CompleteHeading())    // completes the open tasks, or cancels them with ?tasks=cancel
+							r.Patch("/restore", ar.RestoreHeading())      // reopens the tasks closed together with the heading
 							r.Delete("/", ar.DeleteHeading())
 						})
 					})
@@ -107,13 +196,18 @@ func (ar *AppRouter) initRoutes() *chi.Mux {
 			})
 
 			r.Route("/tasks", func(r chi.Router) {
+				r.Use(ar.RequireScopes(model.ScopeRead, model.ScopeTasksWrite))
+
 				r.Get("/", ar.GetTasksByUserID())
-				r.Get("/today", ar.GetTasksForToday())      // grouped by list title
-				r.Get("/upcoming", ar.GetUpcomingTasks())   // grouped by start_date
+				r.Post("/quick-add", ar.QuickAddTask())     // parses title, dates, tags and list from text
+				r.Get("/today", ar.GetTasksForToday())      // grouped by list title, or by tag with ?group_by=tag
+				r.Get("/upcoming", ar.GetUpcomingTasks())   // grouped by start_date, or by tag with ?group_by=tag
 				r.Get("/overdue", ar.GetOverdueTasks())     // grouped by list title
-				r.Get("/someday", ar.GetTasksForSomeday())  // tasks without start_date, grouped by list title
+				r.Get("/someday", ar.GetTasksForSomeday())  // tasks without start_date, grouped by list title or by tag
 				r.Get("/completed", ar.GetCompletedTasks()) // grouped by month
 				r.Get("/archived", ar.GetArchivedTasks())   // grouped by month
+				r.Get("/schedule", ar.ProposeSchedule())    // places unscheduled tasks of the day into free slots
+				r.Post("/schedule", ar.AcceptSchedule())    // applies the time of all slots at once
 
 				r.Route("/{task_id}", func(r chi.Router) {
 					r.Get("/", ar.GetTaskByID())
@@ -121,12 +215,72 @@ func (ar *AppRouter) initRoutes() *chi.Mux {
 					r.Patch("/time", ar.UpdateTaskTime())
 					r.Patch("/move/list", ar.MoveTaskToAnotherList())
 					r.Patch("/move/heading", ar.MoveTaskToAnotherHeading())
+					r.Patch("/status", ar.ChangeTaskStatus())
 					r.Patch("/complete", ar.CompleteTask())
 					r.Patch("/archive", ar.ArchiveTask())
+
+					r.Post("/timer/start", ar.StartTimer()) // stops the timer running for another task
+					r.Post("/timer/stop", ar.StopTimer())
+
+					r.Route("/time-entries", func(r chi.Router) {
+						r.Get("/", ar.GetTimeEntriesByTaskID())
+						r.Post("/", ar.CreateTimeEntry())
+					})
+
+					r.Post("/focus-sessions", ar.StartFocusSession())
 				})
 			})
 
-			r.Get("/tags", ar.GetTagsByUserID())
+			r.Route("/time-entries/{time_entry_id}", func(r chi.Router) {
+				r.Use(ar.RequireScopes(model.ScopeRead, model.ScopeTasksWrite))
+
+				r.Patch("/", ar.UpdateTimeEntry())
+				r.Delete("/", ar.DeleteTimeEntry())
+			})
+
+			r.Route("/focus-sessions", func(r chi.Router) {
+				r.Use(ar.RequireScopes(model.ScopeRead, model.ScopeTasksWrite))
+
+				r.Get("/current", ar.GetActiveFocusSession())
+
+				r.Route("/{focus_session_id}", func(r chi.Router) {
+					r.Get("/", ar.GetFocusSessionByID())
+					r.Patch("/pause", ar.PauseFocusSession())
+					r.Patch("/resume", ar.ResumeFocusSession())
+					r.Patch("/abandon", ar.AbandonFocusSession())
+				})
+			})
+
+			r.Route("/templates", func(r chi.Router) {
+				r.Use(ar.RequireScopes(model.ScopeRead, model.ScopeListsWrite))
+
+				r.Get("/", ar.GetTemplatesByUserID())
+				r.Post("/", ar.CreateTemplate()) // from the list with list_id, or from the task with task_id
+
+				r.Route("/{template_id}", func(r chi.Router) {
+					r.Get("/", ar.GetTemplateByID())
+					r.Post("/instantiate", ar.InstantiateTemplate()) // dates are counted from anchor_date, today by default
+					r.Delete("/", ar.DeleteTemplate())
+				})
+			})
+
+			r.With(ar.RequireScopes(model.ScopeRead, model.ScopeTasksWrite)).Get("/reports/time", ar.GetTimeReport()) // tracked vs estimated time per list, tag and day
+
+			r.Route("/tags", func(r chi.Router) {
+				r.Use(ar.RequireScopes(model.ScopeRead, model.ScopeTagsWrite))
+
+				r.Get("/", ar.GetTagsByUserID())
+				r.Post("/", ar.CreateTag())
+
+				r.Route("/{tag_id}", func(r chi.Router) {
+					r.Get("/", ar.GetTagByID())
+					r.Get("/tasks", ar.GetTasksByTagID()) // includes the tasks of the child tags, grouped by list
+					r.Patch("/", ar.UpdateTag())
+					r.Patch("/move", ar.MoveTag())   // moves the tag with its child tags under another parent
+					r.Post("/merge", ar.MergeTags()) // moves the tasks to the target tag and deletes the tag
+					r.Delete("/", ar.DeleteTag())
+				})
+			})
 		})
 	})
 
@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type sessionHandler struct {
+	logger  *slog.Logger
+	jwt     *jwtoken.TokenService
+	usecase port.SessionUsecase
+}
+
+func newSessionHandler(
+	log *slog.Logger,
+	jwt *jwtoken.TokenService,
+	usecase port.SessionUsecase,
+) *sessionHandler {
+	return &sessionHandler{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+}
+
+func (h *sessionHandler) GetSessions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "session.handler.GetSessions"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		currentSessionID, err := h.getCurrentSessionID(w, r, log)
+		if err != nil {
+			return
+		}
+
+		sessionsResp, err := h.usecase.GetSessions(ctx, currentSessionID)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetSessions, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "sessions found", sessionsResp, slog.String(key.UserID, userID))
+	}
+}
+
+func (h *sessionHandler) RevokeSession() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "session.handler.RevokeSession"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		if _, err := getUserIDFromContext(ctx, w, r, h.jwt, log); err != nil {
+			return
+		}
+
+		sessionID := chi.URLParam(r, key.SessionID)
+
+		err := h.usecase.RevokeSession(ctx, sessionID)
+
+		switch {
+		case errors.Is(err, le.ErrSessionNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrSessionNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToRevokeSession, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "session revoked", sessionID, slog.String(key.SessionID, sessionID))
+	}
+}
+
+// RevokeOtherSessions logs the user out of all the sessions except the one the request is sent from
+func (h *sessionHandler) RevokeOtherSessions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "session.handler.RevokeOtherSessions"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		currentSessionID, err := h.getCurrentSessionID(w, r, log)
+		if err != nil {
+			return
+		}
+
+		if err = h.usecase.RevokeOtherSessions(ctx, currentSessionID); err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToRevokeSession, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "other sessions revoked", nil, slog.String(key.UserID, userID))
+	}
+}
+
+// getCurrentSessionID returns the ID of the session the access token was issued for,
+// the tokens issued before the sessions were added don't have it and have to be refreshed
+func (h *sessionHandler) getCurrentSessionID(w http.ResponseWriter, r *http.Request, log *slog.Logger) (string, error) {
+	sessionID, err := h.jwt.GetSessionID(r.Context())
+
+	switch {
+	case errors.Is(err, jwtoken.ErrSessionIDNotFoundInCtx):
+		handleResponseError(w, r, log, http.StatusUnauthorized, le.ErrCurrentSessionNotFound)
+		return "", err
+	case err != nil:
+		handleInternalServerError(w, r, log, le.ErrFailedToGetSessions, err)
+		return "", err
+	}
+
+	return sessionID, nil
+}
@@ -4,13 +4,12 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
-	"strconv"
 
-	"github.com/go-chi/chi/v5"
 	"github.com/rshelekhov/reframed/internal/lib/constant/key"
 	"github.com/rshelekhov/reframed/internal/lib/constant/le"
 	"github.com/rshelekhov/reframed/internal/lib/logger"
 	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
 	"github.com/rshelekhov/reframed/internal/port"
 )
 
@@ -32,6 +31,43 @@ func newStatusHandler(
 	}
 }
 
+func (h *statusHandler) CreateStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "status.handler.CreateStatus"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		statusInput := &model.StatusRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, statusInput); err != nil {
+			return
+		}
+
+		statusInput.UserID = userID
+
+		statusResp, err := h.usecase.CreateStatus(ctx, statusInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidStatusCategory):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidStatusCategory)
+			return
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCreateStatus, err)
+			return
+		}
+
+		handleResponseCreated(w, r, log, "status created", statusResp, slog.Int(key.StatusID, statusResp.ID))
+	}
+}
+
 func (h *statusHandler) GetStatuses() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "status.handler.GetStatuses"
@@ -39,9 +75,19 @@ func (h *statusHandler) GetStatuses() http.HandlerFunc {
 		ctx := r.Context()
 		log := logger.LogWithRequest(h.logger, op, r)
 
-		statuses, err := h.usecase.GetStatuses(ctx)
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		listID := r.URL.Query().Get(key.ListID)
+
+		statuses, err := h.usecase.GetStatuses(ctx, userID, listID)
 
 		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
 		case errors.Is(err, le.ErrNoStatusesFound):
 			handleResponseSuccess(w, r, log, "no statuses found", nil)
 			return
@@ -56,24 +102,28 @@ func (h *statusHandler) GetStatuses() http.HandlerFunc {
 
 func (h *statusHandler) GetStatusByID() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		const op = "status.handler.GetStatusName"
+		const op = "status.handler.GetStatusByID"
 
 		ctx := r.Context()
 		log := logger.LogWithRequest(h.logger, op, r)
 
-		rawStatusID := chi.URLParam(r, key.StatusID)
-
-		statusID, err := strconv.Atoi(rawStatusID)
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
 		if err != nil {
-			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrFailedToConvertStatusIDtoInt)
 			return
 		}
-		if statusID <= 0 {
+
+		statusID, err := ParseStatusID(r)
+		if err != nil {
 			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidStatusID)
 			return
 		}
 
-		status, err := h.usecase.GetStatusByID(ctx, statusID)
+		statusInput := model.StatusRequestData{
+			ID:     statusID,
+			UserID: userID,
+		}
+
+		status, err := h.usecase.GetStatusByID(ctx, statusInput)
 
 		switch {
 		case errors.Is(err, le.ErrStatusNotFound):
@@ -87,3 +137,119 @@ func (h *statusHandler) GetStatusByID() http.HandlerFunc {
 		handleResponseSuccess(w, r, log, "status received", status, slog.Int(key.StatusID, status.ID))
 	}
 }
+
+func (h *statusHandler) UpdateStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "status.handler.UpdateStatus"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		statusID, err := ParseStatusID(r)
+		if err != nil {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidStatusID)
+			return
+		}
+
+		statusInput := &model.StatusRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, statusInput); err != nil {
+			return
+		}
+
+		statusInput.ID = statusID
+		statusInput.UserID = userID
+
+		statusResp, err := h.usecase.UpdateStatus(ctx, statusInput)
+
+		switch {
+		case errors.Is(err, le.ErrStatusNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrStatusNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateStatus, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "status updated", statusResp, slog.Int(key.StatusID, statusResp.ID))
+	}
+}
+
+func (h *statusHandler) UpdateStatusTransitions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "status.handler.UpdateStatusTransitions"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		transitionsInput := &model.StatusTransitionsRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, transitionsInput); err != nil {
+			return
+		}
+
+		transitionsInput.UserID = userID
+
+		statuses, err := h.usecase.UpdateStatusTransitions(ctx, transitionsInput)
+
+		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrStatusNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrStatusNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateStatusTransitions, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "status transitions updated", statuses)
+	}
+}
+
+func (h *statusHandler) DeleteStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "status.handler.DeleteStatus"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		statusID, err := ParseStatusID(r)
+		if err != nil {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidStatusID)
+			return
+		}
+
+		statusInput := model.StatusRequestData{
+			ID:     statusID,
+			UserID: userID,
+		}
+
+		err = h.usecase.DeleteStatus(ctx, statusInput)
+
+		switch {
+		case errors.Is(err, le.ErrStatusNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrStatusNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteStatus, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "status deleted", statusID, slog.Int(key.StatusID, statusID))
+	}
+}
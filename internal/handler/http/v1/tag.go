@@ -5,10 +5,14 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
+
 	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
 
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
 	"github.com/rshelekhov/reframed/internal/lib/constant/le"
 	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/model"
 	"github.com/rshelekhov/reframed/internal/port"
 )
 
@@ -30,6 +34,80 @@ func newTagHandler(
 	}
 }
 
+func (h *tagHandler) CreateTag() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "tag.handler.CreateTag"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		tagInput := &model.TagRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, tagInput); err != nil {
+			return
+		}
+
+		tagInput.UserID = userID
+
+		tagResp, err := h.usecase.CreateTag(ctx, tagInput)
+
+		switch {
+		case errors.Is(err, le.ErrTagNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTagNotFound)
+			return
+		case errors.Is(err, le.ErrInvalidTagTitle):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidTagTitle)
+			return
+		case errors.Is(err, le.ErrTagAlreadyExists):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrTagAlreadyExists)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCreateTag, err)
+			return
+		}
+
+		handleResponseCreated(w, r, log, "tag created", tagResp, slog.String(key.TagID, tagResp.ID))
+	}
+}
+
+func (h *tagHandler) GetTagByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "tag.handler.GetTagByID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		tagID := chi.URLParam(r, key.TagID)
+
+		tagInput := model.TagRequestData{
+			ID:     tagID,
+			UserID: userID,
+		}
+
+		tagResp, err := h.usecase.GetTagByID(ctx, tagInput)
+
+		switch {
+		case errors.Is(err, le.ErrTagNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTagNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "tag received", tagResp, slog.String(key.TagID, tagResp.ID))
+	}
+}
+
 func (h *tagHandler) GetTagsByUserID() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "tag.handler.GetTagsByUserID"
@@ -56,3 +134,172 @@ func (h *tagHandler) GetTagsByUserID() http.HandlerFunc {
 		handleResponseSuccess(w, r, log, "tags found", tagsResp)
 	}
 }
+
+func (h *tagHandler) UpdateTag() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "tag.handler.UpdateTag"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		tagID := chi.URLParam(r, key.TagID)
+
+		tagInput := &model.TagRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, tagInput); err != nil {
+			return
+		}
+
+		tagInput.ID = tagID
+		tagInput.UserID = userID
+
+		tagResp, err := h.usecase.UpdateTag(ctx, tagInput)
+
+		switch {
+		case errors.Is(err, le.ErrTagNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTagNotFound)
+			return
+		case errors.Is(err, le.ErrInvalidTagTitle):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidTagTitle)
+			return
+		case errors.Is(err, le.ErrInvalidTagParent):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidTagParent)
+			return
+		case errors.Is(err, le.ErrTagAlreadyExists):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrTagAlreadyExists)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateTag, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "tag updated", tagResp, slog.String(key.TagID, tagResp.ID))
+	}
+}
+
+func (h *tagHandler) MoveTag() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "tag.handler.MoveTag"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		tagID := chi.URLParam(r, key.TagID)
+
+		moveInput := &model.TagMoveRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, moveInput); err != nil {
+			return
+		}
+
+		moveInput.ID = tagID
+		moveInput.UserID = userID
+
+		tagResp, err := h.usecase.MoveTag(ctx, moveInput)
+
+		switch {
+		case errors.Is(err, le.ErrTagNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTagNotFound)
+			return
+		case errors.Is(err, le.ErrInvalidTagParent):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidTagParent)
+			return
+		case errors.Is(err, le.ErrTagAlreadyExists):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrTagAlreadyExists)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToMoveTag, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "tag moved", tagResp, slog.String(key.TagID, tagResp.ID))
+	}
+}
+
+func (h *tagHandler) MergeTags() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "tag.handler.MergeTags"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		tagID := chi.URLParam(r, key.TagID)
+
+		mergeInput := &model.TagMergeRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, mergeInput); err != nil {
+			return
+		}
+
+		mergeInput.ID = tagID
+		mergeInput.UserID = userID
+
+		tagResp, err := h.usecase.MergeTags(ctx, mergeInput)
+
+		switch {
+		case errors.Is(err, le.ErrTagNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTagNotFound)
+			return
+		case errors.Is(err, le.ErrCannotMergeTagIntoItself):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotMergeTagIntoItself)
+			return
+		case errors.Is(err, le.ErrCannotMergeTagIntoChild):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotMergeTagIntoChild)
+			return
+		case errors.Is(err, le.ErrTagAlreadyExists):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrTagAlreadyExists)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToMergeTags, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "tags merged", tagResp, slog.String(key.TagID, tagResp.ID))
+	}
+}
+
+func (h *tagHandler) DeleteTag() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "tag.handler.DeleteTag"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		tagID := chi.URLParam(r, key.TagID)
+
+		tagInput := model.TagRequestData{
+			ID:     tagID,
+			UserID: userID,
+		}
+
+		err = h.usecase.DeleteTag(ctx, tagInput)
+
+		switch {
+		case errors.Is(err, le.ErrTagNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTagNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteTag, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "tag deleted", tagID, slog.String(key.TagID, tagID))
+	}
+}
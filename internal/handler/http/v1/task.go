@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
 
@@ -116,6 +117,55 @@ func (h *taskHandler) CreateTaskInDefaultList() http.HandlerFunc {
 	}
 }
 
+func (h *taskHandler) QuickAddTask() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.handler.QuickAddTask"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		taskInput := &model.TaskQuickAddRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, taskInput); err != nil {
+			return
+		}
+
+		taskInput.UserID = userID
+
+		taskResponse, err := h.usecase.QuickAddTask(ctx, taskInput)
+
+		switch {
+		case errors.Is(err, le.ErrEmptyTaskTitle):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyTaskTitle)
+			return
+		case errors.Is(err, le.ErrInvalidTimeZone):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidTimeZone)
+			return
+		case errors.Is(err, le.ErrUnsupportedLocale):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrUnsupportedLocale)
+			return
+		case errors.Is(err, le.ErrDefaultListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrDefaultListNotFound)
+			return
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrHeadingNotFound), errors.Is(err, le.ErrDefaultHeadingNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHeadingNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCreateTask, err)
+			return
+		}
+
+		handleResponseCreated(w, r, log, "task created", taskResponse, slog.String(key.TaskID, taskResponse.ID))
+	}
+}
+
 func (h *taskHandler) GetTaskByID() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "task.handler.GetTaskByID"
@@ -217,6 +267,49 @@ func (h *taskHandler) GetTasksByListID() http.HandlerFunc {
 	}
 }
 
+func (h *taskHandler) GetTasksByTagID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.handler.GetTasksByTagID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		pagination, err := ParseLimitAndCursor(r)
+		if err != nil {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidCursor)
+			return
+		}
+
+		tagID := chi.URLParam(r, key.TagID)
+
+		tagInput := model.TagRequestData{
+			ID:     tagID,
+			UserID: userID,
+		}
+
+		tasksResp, err := h.usecase.GetTasksByTagID(ctx, tagInput, pagination)
+
+		switch {
+		case errors.Is(err, le.ErrTagNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTagNotFound)
+			return
+		case errors.Is(err, le.ErrNoTasksFound):
+			handleResponseSuccess(w, r, log, "no tasks found for the tag", nil)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "tasks for the tag found", tasksResp, slog.String(key.TagID, tagID))
+	}
+}
+
 func (h *taskHandler) GetTasksGroupedByHeadings() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "task.handler.GetTasksGroupedByHeading"
@@ -251,6 +344,89 @@ func (h *taskHandler) GetTasksGroupedByHeadings() http.HandlerFunc {
 	}
 }
 
+func (h *taskHandler) GetBoard() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.handler.GetBoard"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+
+		tasksInput := model.TaskRequestData{
+			ListID: listID,
+			UserID: userID,
+		}
+
+		boardResp, err := h.usecase.GetBoard(ctx, tasksInput)
+
+		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "board received", boardResp, slog.String(key.ListID, listID))
+	}
+}
+
+func (h *taskHandler) MoveTaskOnBoard() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.handler.MoveTaskOnBoard"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+
+		moveInput := &model.TaskBoardMoveRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, moveInput); err != nil {
+			return
+		}
+
+		moveInput.ListID = listID
+		moveInput.UserID = userID
+
+		boardResp, err := h.usecase.MoveTaskOnBoard(ctx, moveInput)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrStatusNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrStatusNotFound)
+			return
+		case errors.Is(err, le.ErrStatusNotOnBoard):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrStatusNotOnBoard)
+			return
+		case errors.Is(err, le.ErrStatusNotAvailableForList):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrStatusNotAvailableForList)
+			return
+		case errors.Is(err, le.ErrStatusTransitionNotAllowed):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrStatusTransitionNotAllowed)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToMoveTask, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "task moved on board", boardResp, slog.String(key.TaskID, moveInput.ID))
+	}
+}
+
 func (h *taskHandler) GetTasksForToday() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "task.handler.GetTasksForToday"
@@ -263,18 +439,34 @@ func (h *taskHandler) GetTasksForToday() http.HandlerFunc {
 			return
 		}
 
-		tasksResp, err := h.usecase.GetTasksForToday(ctx, userID)
+		todaySummary, err := h.usecase.GetTodaySummary(ctx, userID)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetFocusSessionSummary, err)
+			return
+		}
+
+		var tasksResp any
+
+		switch r.URL.Query().Get(key.GroupBy) {
+		case "":
+			tasksResp, err = h.usecase.GetTasksForToday(ctx, userID)
+		case model.GroupByTag:
+			tasksResp, err = h.usecase.GetTasksForTodayGroupedByTag(ctx, userID)
+		default:
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidGroupBy)
+			return
+		}
 
 		switch {
 		case errors.Is(err, le.ErrNoTasksFound):
-			handleResponseSuccess(w, r, log, "no tasks found for today", nil)
+			handleResponseSuccessWithMeta(w, r, log, "no tasks found for today", nil, todaySummary)
 			return
 		case err != nil:
 			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
 			return
 		}
 
-		handleResponseSuccess(w, r, log, "tasks for today found", tasksResp)
+		handleResponseSuccessWithMeta(w, r, log, "tasks for today found", tasksResp, todaySummary)
 	}
 }
 
@@ -296,7 +488,17 @@ func (h *taskHandler) GetUpcomingTasks() http.HandlerFunc {
 			return
 		}
 
-		tasksResp, err := h.usecase.GetUpcomingTasks(ctx, userID, pagination)
+		var tasksResp any
+
+		switch r.URL.Query().Get(key.GroupBy) {
+		case "":
+			tasksResp, err = h.usecase.GetUpcomingTasks(ctx, userID, pagination)
+		case model.GroupByTag:
+			tasksResp, err = h.usecase.GetUpcomingTasksGroupedByTag(ctx, userID, pagination)
+		default:
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidGroupBy)
+			return
+		}
 
 		switch {
 		case errors.Is(err, le.ErrNoTasksFound):
@@ -362,7 +564,17 @@ func (h *taskHandler) GetTasksForSomeday() http.HandlerFunc {
 			return
 		}
 
-		tasksResp, err := h.usecase.GetTasksForSomeday(ctx, userID, pagination)
+		var tasksResp any
+
+		switch r.URL.Query().Get(key.GroupBy) {
+		case "":
+			tasksResp, err = h.usecase.GetTasksForSomeday(ctx, userID, pagination)
+		case model.GroupByTag:
+			tasksResp, err = h.usecase.GetTasksForSomedayGroupedByTag(ctx, userID, pagination)
+		default:
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidGroupBy)
+			return
+		}
 
 		switch {
 		case errors.Is(err, le.ErrNoTasksFound):
@@ -520,6 +732,96 @@ func (h *taskHandler) UpdateTaskTime() http.HandlerFunc {
 	}
 }
 
+func (h *taskHandler) ProposeSchedule() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.handler.ProposeSchedule"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		scheduleInput := model.ScheduleRequestData{
+			Date:      r.URL.Query().Get(key.Date),
+			WorkStart: r.URL.Query().Get(key.WorkStart),
+			WorkEnd:   r.URL.Query().Get(key.WorkEnd),
+			UserID:    userID,
+		}
+
+		if taskIDs := r.URL.Query().Get(key.TaskIDs); taskIDs != "" {
+			scheduleInput.TaskIDs = strings.Split(taskIDs, ",")
+		}
+
+		proposal, err := h.usecase.ProposeSchedule(ctx, scheduleInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidScheduleDate):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidScheduleDate)
+			return
+		case errors.Is(err, le.ErrInvalidWorkingHours):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidWorkingHours)
+			return
+		case errors.Is(err, le.ErrScheduleDateInPast):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrScheduleDateInPast)
+			return
+		case errors.Is(err, le.ErrWorkingHoursAreOver):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrWorkingHoursAreOver)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToProposeSchedule, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "schedule proposed", proposal)
+	}
+}
+
+func (h *taskHandler) AcceptSchedule() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.handler.AcceptSchedule"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		scheduleInput := &model.ScheduleAcceptRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, scheduleInput); err != nil {
+			return
+		}
+
+		scheduleInput.UserID = userID
+
+		tasksResp, err := h.usecase.AcceptSchedule(ctx, scheduleInput)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrInvalidTaskTimeRange):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidTaskTimeRange)
+			return
+		case errors.Is(err, le.ErrScheduleSlotsOverlap):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrScheduleSlotsOverlap)
+			return
+		case errors.Is(err, le.ErrTaskScheduledTwice):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrTaskScheduledTwice)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToApplySchedule, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "schedule applied", tasksResp)
+	}
+}
+
 func (h *taskHandler) MoveTaskToAnotherList() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "task.handler.MoveTaskToAnotherList"
@@ -608,6 +910,52 @@ func (h *taskHandler) MoveTaskToAnotherHeading() http.HandlerFunc {
 	}
 }
 
+func (h *taskHandler) ChangeTaskStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.handler.ChangeTaskStatus"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+
+		taskInput := &model.TaskStatusRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, taskInput); err != nil {
+			return
+		}
+
+		taskInput.ID = taskID
+		taskInput.UserID = userID
+
+		taskResponse, err := h.usecase.ChangeTaskStatus(ctx, taskInput)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrStatusNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrStatusNotFound)
+			return
+		case errors.Is(err, le.ErrStatusNotAvailableForList):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrStatusNotAvailableForList)
+			return
+		case errors.Is(err, le.ErrStatusTransitionNotAllowed):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrStatusTransitionNotAllowed)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToChangeTaskStatus, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "task status changed", taskResponse, slog.String(key.TaskID, taskID))
+	}
+}
+
 func (h *taskHandler) CompleteTask() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "task.handler.CompleteTask"
@@ -633,6 +981,9 @@ func (h *taskHandler) CompleteTask() http.HandlerFunc {
 		case errors.Is(err, le.ErrTaskNotFound):
 			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
 			return
+		case errors.Is(err, le.ErrStatusTransitionNotAllowed):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrStatusTransitionNotAllowed)
+			return
 		case err != nil:
 			handleInternalServerError(w, r, log, le.ErrFailedToCompleteTask, err)
 			return
@@ -667,6 +1018,9 @@ func (h *taskHandler) ArchiveTask() http.HandlerFunc {
 		case errors.Is(err, le.ErrTaskNotFound):
 			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
 			return
+		case errors.Is(err, le.ErrStatusTransitionNotAllowed):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrStatusTransitionNotAllowed)
+			return
 		case err != nil:
 			handleInternalServerError(w, r, log, le.ErrFailedToArchiveTask, err)
 			return
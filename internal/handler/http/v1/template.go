@@ -0,0 +1,212 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type templateHandler struct {
+	logger  *slog.Logger
+	jwt     *jwtoken.TokenService
+	usecase port.TemplateUsecase
+}
+
+func newTemplateHandler(
+	log *slog.Logger,
+	jwt *jwtoken.TokenService,
+	usecase port.TemplateUsecase,
+) *templateHandler {
+	return &templateHandler{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+}
+
+func (h *templateHandler) CreateTemplate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "template.handler.CreateTemplate"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		templateInput := &model.TemplateRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, templateInput); err != nil {
+			return
+		}
+
+		templateInput.UserID = userID
+
+		templateResp, err := h.usecase.CreateTemplate(ctx, templateInput)
+
+		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCreateTemplate, err)
+			return
+		}
+
+		handleResponseCreated(w, r, log, "template created", templateResp, slog.String(key.TemplateID, templateResp.ID))
+	}
+}
+
+func (h *templateHandler) GetTemplateByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "template.handler.GetTemplateByID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		templateID := chi.URLParam(r, key.TemplateID)
+
+		templateInput := model.TemplateRequestData{
+			ID:     templateID,
+			UserID: userID,
+		}
+
+		templateResp, err := h.usecase.GetTemplateByID(ctx, templateInput)
+
+		switch {
+		case errors.Is(err, le.ErrTemplateNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTemplateNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "template received", templateResp, slog.String(key.TemplateID, templateID))
+	}
+}
+
+func (h *templateHandler) GetTemplatesByUserID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "template.handler.GetTemplatesByUserID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		templatesResp, err := h.usecase.GetTemplatesByUserID(ctx, userID)
+
+		switch {
+		case errors.Is(err, le.ErrNoTemplatesFound):
+			handleResponseSuccess(w, r, log, "no templates found", nil)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetTemplates, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "templates found", templatesResp)
+	}
+}
+
+func (h *templateHandler) InstantiateTemplate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "template.handler.InstantiateTemplate"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		templateID := chi.URLParam(r, key.TemplateID)
+
+		templateInput := &model.TemplateInstantiateRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, templateInput); err != nil {
+			return
+		}
+
+		templateInput.ID = templateID
+		templateInput.UserID = userID
+
+		instanceResp, err := h.usecase.InstantiateTemplate(ctx, templateInput)
+
+		switch {
+		case errors.Is(err, le.ErrTemplateNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTemplateNotFound)
+			return
+		case errors.Is(err, le.ErrAreaNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAreaNotFound)
+			return
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrHeadingNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHeadingNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToInstantiateTemplate, err)
+			return
+		}
+
+		handleResponseCreated(w, r, log, "template instantiated", instanceResp, slog.String(key.TemplateID, templateID))
+	}
+}
+
+func (h *templateHandler) DeleteTemplate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "template.handler.DeleteTemplate"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		templateID := chi.URLParam(r, key.TemplateID)
+
+		templateInput := model.TemplateRequestData{
+			ID:     templateID,
+			UserID: userID,
+		}
+
+		err = h.usecase.DeleteTemplate(ctx, templateInput)
+
+		switch {
+		case errors.Is(err, le.ErrTemplateNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTemplateNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteTemplate, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "template deleted", templateID, slog.String(key.TemplateID, templateID))
+	}
+}
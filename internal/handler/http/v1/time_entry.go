@@ -0,0 +1,288 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type timeEntryHandler struct {
+	logger  *slog.Logger
+	jwt     *jwtoken.TokenService
+	usecase port.TimeEntryUsecase
+}
+
+func newTimeEntryHandler(
+	log *slog.Logger,
+	jwt *jwtoken.TokenService,
+	usecase port.TimeEntryUsecase,
+) *timeEntryHandler {
+	return &timeEntryHandler{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+}
+
+func (h *timeEntryHandler) StartTimer() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "time_entry.handler.StartTimer"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+
+		timerInput := model.TimeEntryRequestData{
+			TaskID: taskID,
+			UserID: userID,
+		}
+
+		entryResp, err := h.usecase.StartTimer(ctx, timerInput)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrTimerAlreadyRunning):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrTimerAlreadyRunning)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToStartTimer, err)
+			return
+		}
+
+		handleResponseCreated(w, r, log, "timer started", entryResp,
+			slog.String(key.TimeEntryID, entryResp.ID))
+	}
+}
+
+func (h *timeEntryHandler) StopTimer() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "time_entry.handler.StopTimer"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+
+		timerInput := model.TimeEntryRequestData{
+			TaskID: taskID,
+			UserID: userID,
+		}
+
+		entryResp, err := h.usecase.StopTimer(ctx, timerInput)
+
+		switch {
+		case errors.Is(err, le.ErrTimerNotRunning):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTimerNotRunning)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToStopTimer, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "timer stopped", entryResp, slog.String(key.TimeEntryID, entryResp.ID))
+	}
+}
+
+func (h *timeEntryHandler) CreateTimeEntry() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "time_entry.handler.CreateTimeEntry"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+
+		entryInput := &model.TimeEntryRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, entryInput); err != nil {
+			return
+		}
+
+		entryInput.TaskID = taskID
+		entryInput.UserID = userID
+
+		entryResp, err := h.usecase.CreateTimeEntry(ctx, entryInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidTimeEntryRange):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidTimeEntryRange)
+			return
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCreateTimeEntry, err)
+			return
+		}
+
+		handleResponseCreated(w, r, log, "time entry created", entryResp,
+			slog.String(key.TimeEntryID, entryResp.ID))
+	}
+}
+
+func (h *timeEntryHandler) GetTimeEntriesByTaskID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "time_entry.handler.GetTimeEntriesByTaskID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+
+		entriesInput := model.TimeEntryRequestData{
+			TaskID: taskID,
+			UserID: userID,
+		}
+
+		entriesResp, err := h.usecase.GetTimeEntriesByTaskID(ctx, entriesInput)
+
+		switch {
+		case errors.Is(err, le.ErrNoTimeEntriesFound):
+			handleResponseSuccess(w, r, log, "no time entries found", nil)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "time entries found", entriesResp)
+	}
+}
+
+func (h *timeEntryHandler) UpdateTimeEntry() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "time_entry.handler.UpdateTimeEntry"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		entryID := chi.URLParam(r, key.TimeEntryID)
+
+		entryInput := &model.TimeEntryRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, entryInput); err != nil {
+			return
+		}
+
+		entryInput.ID = entryID
+		entryInput.UserID = userID
+
+		entryResp, err := h.usecase.UpdateTimeEntry(ctx, entryInput)
+
+		switch {
+		case errors.Is(err, le.ErrTimeEntryNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTimeEntryNotFound)
+			return
+		case errors.Is(err, le.ErrInvalidTimeEntryRange):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidTimeEntryRange)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateTimeEntry, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "time entry updated", entryResp, slog.String(key.TimeEntryID, entryResp.ID))
+	}
+}
+
+func (h *timeEntryHandler) DeleteTimeEntry() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "time_entry.handler.DeleteTimeEntry"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		entryID := chi.URLParam(r, key.TimeEntryID)
+
+		entryInput := model.TimeEntryRequestData{
+			ID:     entryID,
+			UserID: userID,
+		}
+
+		err = h.usecase.DeleteTimeEntry(ctx, entryInput)
+
+		switch {
+		case errors.Is(err, le.ErrTimeEntryNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTimeEntryNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteTimeEntry, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "time entry deleted", entryID, slog.String(key.TimeEntryID, entryID))
+	}
+}
+
+func (h *timeEntryHandler) GetTimeReport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "time_entry.handler.GetTimeReport"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		reportInput := model.TimeReportRequestData{
+			From:   r.URL.Query().Get(key.From),
+			To:     r.URL.Query().Get(key.To),
+			UserID: userID,
+		}
+
+		reportResp, err := h.usecase.GetTimeReport(ctx, reportInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidReportDateRange):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidReportDateRange)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetTimeReport, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "time report found", reportResp)
+	}
+}
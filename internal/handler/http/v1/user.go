@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type userHandler struct {
+	logger  *slog.Logger
+	jwt     *jwtoken.TokenService
+	usecase port.UserUsecase
+}
+
+func newUserHandler(
+	log *slog.Logger,
+	jwt *jwtoken.TokenService,
+	usecase port.UserUsecase,
+) *userHandler {
+	return &userHandler{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+}
+
+func (h *userHandler) GetUserSettings() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "user.handler.GetUserSettings"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		settingsResp, err := h.usecase.GetUserSettings(ctx, userID)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserSettings, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "user settings received", settingsResp)
+	}
+}
+
+func (h *userHandler) UpdateUserSettings() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "user.handler.UpdateUserSettings"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(h.logger, op, r)
+
+		userID, err := getUserIDFromContext(ctx, w, r, h.jwt, log)
+		if err != nil {
+			return
+		}
+
+		settingsInput := &model.UserSettingsRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, settingsInput); err != nil {
+			return
+		}
+
+		settingsInput.UserID = userID
+
+		settingsResp, err := h.usecase.UpdateUserSettings(ctx, settingsInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidTimeZone):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidTimeZone)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateUserSettings, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "user settings updated", settingsResp, slog.String(key.TimeZone, settingsResp.TimeZone))
+	}
+}
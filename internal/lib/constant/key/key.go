@@ -9,6 +9,7 @@ const (
 	Data        = "data"
 	Title       = "title"
 	Tasks       = "tasks"
+	Lists       = "lists"
 	Description = "description"
 
 	// ===========================================================================
@@ -26,12 +27,20 @@ const (
 	//  entities keys
 	// ===========================================================================
 
-	UserID    = "user_id"
-	Email     = "email"
-	ListID    = "list_id"
-	TaskID    = "task_id"
-	HeadingID = "heading_id"
-	StatusID  = "status_id"
+	UserID         = "user_id"
+	Email          = "email"
+	ListID         = "list_id"
+	AreaID         = "area_id"
+	TaskID         = "task_id"
+	HeadingID      = "heading_id"
+	TagID          = "tag_id"
+	StatusID       = "status_id"
+	TimeZone       = "time_zone"
+	TimeEntryID    = "time_entry_id"
+	FocusSessionID = "focus_session_id"
+	TemplateID     = "template_id"
+	TokenID        = "token_id"
+	SessionID      = "session_id"
 
 	// ===========================================================================
 	//  pagination keys
@@ -40,4 +49,21 @@ const (
 	Cursor    = "cursor"
 	AfterDate = "after_date"
 	Limit     = "limit"
+	GroupBy   = "group_by"
+
+	// ===========================================================================
+	//  report keys
+	// ===========================================================================
+
+	From = "from"
+	To   = "to"
+
+	// ===========================================================================
+	//  schedule keys
+	// ===========================================================================
+
+	Date      = "date"
+	WorkStart = "work_start"
+	WorkEnd   = "work_end"
+	TaskIDs   = "task_ids"
 )
@@ -23,6 +23,12 @@ const (
 	ErrFailedToRefreshTokens        LocalError = "failed to refresh tokens"
 	ErrFailedGoGetClaimsFromToken   LocalError = "failed to get claims from token"
 	ErrFailedToLogout               LocalError = "failed to logout"
+	ErrSessionNotFound              LocalError = "session not found"
+	ErrCurrentSessionNotFound       LocalError = "access token is not bound to a session"
+	ErrFailedToGetSessions          LocalError = "failed to get sessions"
+	ErrFailedToRevokeSession        LocalError = "failed to revoke session"
+	ErrAuthTokenNotFound            LocalError = "auth token not found"
+	ErrFailedToSendEmail            LocalError = "failed to send email"
 
 	ErrEmailVerificationTokenExpiredWithEmailResent LocalError = "verification token expired, a new email with a new token has been sent to the user"
 	ErrEmailVerificationTokenNotFound               LocalError = "email verification token not found"
@@ -35,6 +41,13 @@ const (
 	ErrFailedToChangePassword                   LocalError = "failed to change password"
 	ErrUpdatedPasswordMustNotMatchTheCurrent    LocalError = "updated password must not match the current password"
 
+	ErrNoPersonalTokensFound       LocalError = "no personal tokens found"
+	ErrPersonalTokenNotFound       LocalError = "personal token not found"
+	ErrPersonalTokenExpired        LocalError = "personal token is expired"
+	ErrFailedToCreatePersonalToken LocalError = "failed to create personal token"
+	ErrFailedToGetPersonalTokens   LocalError = "failed to get personal tokens"
+	ErrFailedToRevokePersonalToken LocalError = "failed to revoke personal token"
+
 	// ===========================================================================
 	//   handler errors
 	// ===========================================================================
@@ -47,6 +60,7 @@ const (
 	ErrFailedToValidateData     LocalError = "failed to validate data"
 	ErrFailedToParseQueryParams LocalError = "failed to parse query params"
 	ErrInvalidCursor            LocalError = "invalid format for cursor, expected object id type string or YYYY-MM-DD"
+	ErrInvalidGroupBy           LocalError = "invalid group_by, expected tag"
 
 	// ===========================================================================
 	//   user errors
@@ -58,6 +72,18 @@ const (
 	ErrFailedToUpdateUser LocalError = "failed to update user"
 	ErrFailedToDeleteUser LocalError = "failed to delete user"
 
+	ErrUserSettingsNotFound       LocalError = "user settings not found"
+	ErrFailedToGetUserSettings    LocalError = "failed to get user settings"
+	ErrFailedToUpdateUserSettings LocalError = "failed to update user settings"
+
+	ErrDigestSettingsNotFound                LocalError = "digest settings not found"
+	ErrDigestEmailNotFound                   LocalError = "email for the digests not found, sign in with the email to enable them"
+	ErrDigestUnsubscribeTokenNotFound        LocalError = "unsubscribe token not found"
+	ErrDigestUnsubscribeTokenNotFoundInQuery LocalError = "unsubscribe token not found in query"
+	ErrFailedToGetDigestSettings             LocalError = "failed to get digest settings"
+	ErrFailedToUpdateDigestSettings          LocalError = "failed to update digest settings"
+	ErrFailedToUnsubscribeFromDigests        LocalError = "failed to unsubscribe from digests"
+
 	// ===========================================================================
 	//   list errors
 	// ===========================================================================
@@ -71,20 +97,47 @@ const (
 	ErrFailedToDeleteList      LocalError = "failed to delete list"
 	ErrCannotDeleteDefaultList LocalError = "cannot delete default list"
 	ErrEmptyQueryListID        LocalError = "list_id is empty in query"
+	ErrCannotMoveDefaultList   LocalError = "cannot move default list"
+	ErrFailedToMoveList        LocalError = "failed to move list"
+	ErrInvalidListsGroupBy     LocalError = "invalid group_by, expected area"
+
+	// ===========================================================================
+	//   area errors
+	// ===========================================================================
+
+	ErrNoAreasFound           LocalError = "no areas found"
+	ErrAreaNotFound           LocalError = "area not found"
+	ErrInvalidAreaListsAction LocalError = "lists of the area can be either moved or archived"
+	ErrFailedToCreateArea     LocalError = "failed to create area"
+	ErrFailedToGetAreas       LocalError = "failed to get areas"
+	ErrFailedToUpdateArea     LocalError = "failed to update area"
+	ErrFailedToMoveArea       LocalError = "failed to move area"
+	ErrFailedToDeleteArea     LocalError = "failed to delete area"
 
 	// ===========================================================================
 	//   heading errors
 	// ===========================================================================
 
-	ErrNoHeadingsFound             LocalError = "no headings found"
-	ErrHeadingNotFound             LocalError = "heading not found"
-	ErrDefaultHeadingNotFound      LocalError = "default heading not found"
-	ErrFailedToCreateHeading       LocalError = "failed to create heading"
-	ErrFailedToGetHeadingsByListID LocalError = "failed to get headings by list ID"
-	ErrFailedToUpdateHeading       LocalError = "failed to update heading"
-	ErrFailedToMoveHeading         LocalError = "failed to move heading"
-	ErrFailedToDeleteHeading       LocalError = "failed to delete heading"
-	ErrEmptyQueryHeadingID         LocalError = "heading_id is empty in query"
+	ErrNoHeadingsFound              LocalError = "no headings found"
+	ErrHeadingNotFound              LocalError = "heading not found"
+	ErrDefaultHeadingNotFound       LocalError = "default heading not found"
+	ErrFailedToCreateHeading        LocalError = "failed to create heading"
+	ErrFailedToGetHeadingsByListID  LocalError = "failed to get headings by list ID"
+	ErrFailedToUpdateHeading        LocalError = "failed to update heading"
+	ErrFailedToMoveHeading          LocalError = "failed to move heading"
+	ErrFailedToDeleteHeading        LocalError = "failed to delete heading"
+	ErrCannotPromoteDefaultHeading  LocalError = "cannot promote default heading"
+	ErrCannotDemoteDefaultList      LocalError = "cannot demote default list"
+	ErrCannotDemoteListIntoItself   LocalError = "cannot demote list into itself"
+	ErrFailedToPromoteHeading       LocalError = "failed to promote heading"
+	ErrFailedToDemoteList           LocalError = "failed to demote list"
+	ErrCannotCompleteDefaultHeading LocalError = "cannot complete default heading"
+	ErrHeadingAlreadyCompleted      LocalError = "heading is already completed"
+	ErrHeadingNotCompleted          LocalError = "heading is not completed"
+	ErrInvalidHeadingTasksAction    LocalError = "open tasks of the heading can be either completed or cancelled"
+	ErrFailedToCompleteHeading      LocalError = "failed to complete heading"
+	ErrFailedToRestoreHeading       LocalError = "failed to restore heading"
+	ErrEmptyQueryHeadingID          LocalError = "heading_id is empty in query"
 
 	// ===========================================================================
 	//   task errors
@@ -99,22 +152,93 @@ const (
 	ErrFailedToMoveTask     LocalError = "failed to move task"
 	ErrFailedToArchiveTask  LocalError = "failed to archive task"
 	ErrInvalidTaskTimeRange LocalError = "invalid task time range"
+	ErrEmptyTaskTitle       LocalError = "task title is empty"
+	ErrInvalidTimeZone      LocalError = "invalid time zone"
+	ErrUnsupportedLocale    LocalError = "unsupported locale"
+
+	ErrInvalidScheduleDate     LocalError = "invalid schedule date, expected YYYY-MM-DD"
+	ErrInvalidWorkingHours     LocalError = "invalid working hours, expected HH:MM with the end after the start"
+	ErrScheduleDateInPast      LocalError = "schedule date is in the past"
+	ErrWorkingHoursAreOver     LocalError = "working hours of the day are over"
+	ErrScheduleSlotsOverlap    LocalError = "scheduled time slots overlap"
+	ErrTaskScheduledTwice      LocalError = "task is scheduled more than once"
+	ErrFailedToProposeSchedule LocalError = "failed to propose schedule"
+	ErrFailedToApplySchedule   LocalError = "failed to apply schedule"
+
+	// ===========================================================================
+	//   template errors
+	// ===========================================================================
+
+	ErrNoTemplatesFound            LocalError = "no templates found"
+	ErrTemplateNotFound            LocalError = "template not found"
+	ErrFailedToCreateTemplate      LocalError = "failed to create template"
+	ErrFailedToGetTemplates        LocalError = "failed to get templates"
+	ErrFailedToInstantiateTemplate LocalError = "failed to instantiate template"
+	ErrFailedToDeleteTemplate      LocalError = "failed to delete template"
+
+	// ===========================================================================
+	//   time entry errors
+	// ===========================================================================
+
+	ErrTimeEntryNotFound       LocalError = "time entry not found"
+	ErrNoTimeEntriesFound      LocalError = "no time entries found"
+	ErrTimerNotRunning         LocalError = "timer is not running for this task"
+	ErrTimerAlreadyRunning     LocalError = "timer is already running for this task"
+	ErrInvalidTimeEntryRange   LocalError = "invalid time entry range"
+	ErrInvalidReportDateRange  LocalError = "invalid report date range"
+	ErrFailedToStartTimer      LocalError = "failed to start timer"
+	ErrFailedToStopTimer       LocalError = "failed to stop timer"
+	ErrFailedToCreateTimeEntry LocalError = "failed to create time entry"
+	ErrFailedToUpdateTimeEntry LocalError = "failed to update time entry"
+	ErrFailedToDeleteTimeEntry LocalError = "failed to delete time entry"
+	ErrFailedToGetTimeReport   LocalError = "failed to get time report"
+
+	// ===========================================================================
+	//   focus session errors
+	// ===========================================================================
+
+	ErrFocusSessionNotFound           LocalError = "focus session not found"
+	ErrNoActiveFocusSession           LocalError = "no active focus session"
+	ErrFocusSessionAlreadyActive      LocalError = "another focus session is already active"
+	ErrInvalidFocusSessionTransition  LocalError = "focus session can't change to this state"
+	ErrFailedToStartFocusSession      LocalError = "failed to start focus session"
+	ErrFailedToUpdateFocusSession     LocalError = "failed to update focus session"
+	ErrFailedToGetFocusSessionSummary LocalError = "failed to get focus session summary"
 
 	// ===========================================================================
 	//   tag errors
 	// ===========================================================================
 
-	ErrTagNotFound LocalError = "tag not found"
-	ErrNoTagsFound LocalError = "no tags found"
+	ErrTagNotFound              LocalError = "tag not found"
+	ErrNoTagsFound              LocalError = "no tags found"
+	ErrTagAlreadyExists         LocalError = "tag with this title already exists"
+	ErrCannotMergeTagIntoItself LocalError = "cannot merge tag into itself"
+	ErrCannotMergeTagIntoChild  LocalError = "cannot merge tag into its child tag"
+	ErrInvalidTagTitle          LocalError = "tag title can't contain empty parts of the path"
+	ErrInvalidTagParent         LocalError = "tag can't be moved under itself or its child tag"
+	ErrFailedToCreateTag        LocalError = "failed to create tag"
+	ErrFailedToUpdateTag        LocalError = "failed to update tag"
+	ErrFailedToDeleteTag        LocalError = "failed to delete tag"
+	ErrFailedToMergeTags        LocalError = "failed to merge tags"
+	ErrFailedToMoveTag          LocalError = "failed to move tag"
 
 	// ===========================================================================
 	//   status errors
 	// ===========================================================================
 
-	ErrNoStatusesFound              LocalError = "no statuses found"
-	ErrStatusNotFound               LocalError = "task status not found"
-	ErrFailedToConvertStatusIDtoInt LocalError = "failed to convert status_id to int"
-	ErrInvalidStatusID              LocalError = "invalid status_id"
+	ErrNoStatusesFound                 LocalError = "no statuses found"
+	ErrStatusNotFound                  LocalError = "task status not found"
+	ErrFailedToConvertStatusIDtoInt    LocalError = "failed to convert status_id to int"
+	ErrInvalidStatusID                 LocalError = "invalid status_id"
+	ErrInvalidStatusCategory           LocalError = "category must be one of the built-in statuses except archived"
+	ErrStatusNotAvailableForList       LocalError = "status is not available for the list of the task"
+	ErrStatusNotOnBoard                LocalError = "archived tasks are not shown on the board"
+	ErrStatusTransitionNotAllowed      LocalError = "status transition is not allowed"
+	ErrFailedToCreateStatus            LocalError = "failed to create status"
+	ErrFailedToUpdateStatus            LocalError = "failed to update status"
+	ErrFailedToDeleteStatus            LocalError = "failed to delete status"
+	ErrFailedToUpdateStatusTransitions LocalError = "failed to update status transitions"
+	ErrFailedToChangeTaskStatus        LocalError = "failed to change task status"
 
 	// ===========================================================================
 	//   other errors
@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+// FileMailer writes every email to a new .eml file in the directory instead of sending it,
+// it is used in the tests and for local development
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &FileMailer{
+		dir:  dir,
+		from: from,
+	}, nil
+}
+
+// SendEmail names the files by ksuid, so they are sorted by the time the emails were sent
+func (m *FileMailer) SendEmail(_ context.Context, email model.Email) error {
+	const op = "mailer.file.SendEmail"
+
+	msg, err := buildMessage(m.from, email)
+	if err != nil {
+		return fmt.Errorf("%s: failed to build message: %w", op, err)
+	}
+
+	path := filepath.Join(m.dir, ksuid.New().String()+".eml")
+
+	if err = os.WriteFile(path, msg, 0o644); err != nil {
+		return fmt.Errorf("%s: failed to write email: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+// Package mailer provides the implementations of port.Mailer
+package mailer
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+// LogMailer doesn't send the emails, it writes them to the log. It is used when no mail server is configured
+type LogMailer struct {
+	log *slog.Logger
+}
+
+func NewLogMailer(log *slog.Logger) *LogMailer {
+	return &LogMailer{
+		log: log,
+	}
+}
+
+func (m *LogMailer) SendEmail(ctx context.Context, email model.Email) error {
+	m.log.InfoContext(ctx, "email is not sent, no mail server configured",
+		slog.String("to", email.To),
+		slog.String("subject", email.Subject),
+		slog.String("text", email.Text),
+	)
+	return nil
+}
@@ -0,0 +1,136 @@
+package mailer_test
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rshelekhov/reframed/internal/lib/mailer"
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type templateData struct {
+	AppName string
+	URL     string
+}
+
+func TestNewEmail(t *testing.T) {
+	email, err := mailer.NewEmail("user@example.com", "Verify your email", mailer.TemplateVerifyEmail, templateData{
+		AppName: "Reframed",
+		URL:     "https://example.com/verify-email/?token=a&b",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(email.Text, "https://example.com/verify-email/?token=a&b") {
+		t.Errorf("text body has no link: %q", email.Text)
+	}
+
+	// The values are escaped in the HTML body only
+	if !strings.Contains(email.HTML, `href="https://example.com/verify-email/?token=a&amp;b"`) {
+		t.Errorf("HTML body has no escaped link: %q", email.HTML)
+	}
+}
+
+func TestNewEmail_UnknownTemplate(t *testing.T) {
+	if _, err := mailer.NewEmail("user@example.com", "Subject", mailer.Template("unknown"), templateData{}); err == nil {
+		t.Error("expected error for unknown template")
+	}
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+
+	m, err := mailer.NewFileMailer(dir, "Reframed <no-reply@example.com>")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err = m.SendEmail(context.Background(), model.Email{
+		To:      "user@example.com",
+		Subject: "Привет",
+		Text:    "text body",
+		HTML:    "<p>html body</p>",
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<https://example.com/digest/unsubscribe?token=a>",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click\r\nBcc: attacker@example.com",
+		},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one .eml file, got %v (%v)", files, err)
+	}
+
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	msg, err := mail.ReadMessage(f)
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != "Привет" {
+		t.Errorf("expected subject %q, got %q (%v)", "Привет", subject, err)
+	}
+
+	if msg.Header.Get("To") != "user@example.com" {
+		t.Errorf("unexpected To header: %q", msg.Header.Get("To"))
+	}
+
+	if msg.Header.Get("List-Unsubscribe") != "<https://example.com/digest/unsubscribe?token=a>" {
+		t.Errorf("unexpected List-Unsubscribe header: %q", msg.Header.Get("List-Unsubscribe"))
+	}
+
+	// The line breaks in the values are dropped
+	if msg.Header.Get("Bcc") != "" {
+		t.Errorf("unexpected Bcc header: %q", msg.Header.Get("Bcc"))
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("unexpected content type: %q (%v)", mediaType, err)
+	}
+
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+
+	for _, expected := range []struct {
+		contentType string
+		body        string
+	}{
+		{contentType: "text/plain; charset=utf-8", body: "text body"},
+		{contentType: "text/html; charset=utf-8", body: "<p>html body</p>"},
+	} {
+		part, err := parts.NextRawPart()
+		if err != nil {
+			t.Fatalf("failed to read part: %v", err)
+		}
+
+		if part.Header.Get("Content-Type") != expected.contentType {
+			t.Errorf("expected content type %q, got %q", expected.contentType, part.Header.Get("Content-Type"))
+		}
+
+		body, err := io.ReadAll(quotedprintable.NewReader(part))
+		if err != nil {
+			t.Fatalf("failed to read body: %v", err)
+		}
+
+		if string(body) != expected.body {
+			t.Errorf("expected body %q, got %q", expected.body, body)
+		}
+	}
+}
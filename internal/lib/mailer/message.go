@@ -0,0 +1,119 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+// buildMessage returns the email in the RFC 5322 format. When the email has the HTML body,
+// it is sent together with the text one as multipart/alternative
+func buildMessage(from string, email model.Email) ([]byte, error) {
+	var msg bytes.Buffer
+
+	writeHeader(&msg, "From", from)
+	writeHeader(&msg, "To", email.To)
+	writeHeader(&msg, "Subject", mime.QEncoding.Encode("utf-8", email.Subject))
+	writeHeader(&msg, "Date", time.Now().Format(time.RFC1123Z))
+	writeHeader(&msg, "Message-ID", fmt.Sprintf("<%s@%s>", ksuid.New().String(), domainOf(from)))
+	writeHeader(&msg, "MIME-Version", "1.0")
+
+	names := make([]string, 0, len(email.Headers))
+	for name := range email.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		writeHeader(&msg, textproto.CanonicalMIMEHeaderKey(name), email.Headers[name])
+	}
+
+	if email.HTML == "" {
+		writeHeader(&msg, "Content-Type", "text/plain; charset=utf-8")
+		writeHeader(&msg, "Content-Transfer-Encoding", "quoted-printable")
+		msg.WriteString("\r\n")
+
+		if err := writeQuotedPrintable(&msg, email.Text); err != nil {
+			return nil, err
+		}
+
+		return msg.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&msg)
+
+	writeHeader(&msg, "Content-Type", "multipart/alternative; boundary="+parts.Boundary())
+	msg.WriteString("\r\n")
+
+	// The last part is the preferred one
+	for _, part := range []struct {
+		contentType string
+		body        string
+	}{
+		{contentType: "text/plain; charset=utf-8", body: email.Text},
+		{contentType: "text/html; charset=utf-8", body: email.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err = writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	return msg.Bytes(), nil
+}
+
+// writeHeader drops the line breaks, so a value can't add other headers
+func writeHeader(msg *bytes.Buffer, name, value string) {
+	value = strings.NewReplacer("\r", "", "\n", "").Replace(value)
+	msg.WriteString(name + ": " + value + "\r\n")
+}
+
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+
+	return qp.Close()
+}
+
+func domainOf(from string) string {
+	if address, err := mail.ParseAddress(from); err == nil {
+		if _, domain, ok := strings.Cut(address.Address, "@"); ok {
+			return domain
+		}
+	}
+	return "localhost"
+}
+
+// envelopeAddress returns the bare address for the SMTP envelope, the From header may have the name as well
+func envelopeAddress(from string) string {
+	if address, err := mail.ParseAddress(from); err == nil {
+		return address.Address
+	}
+	return from
+}
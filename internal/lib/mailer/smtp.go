@@ -0,0 +1,53 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+const defaultSMTPPort = 587
+
+// SMTPMailer sends the emails through the mail server, the connection is upgraded with STARTTLS
+// when the server supports it
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer uses the PLAIN authentication when the username is set
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPMailer{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		auth: auth,
+		from: from,
+	}
+}
+
+func (m *SMTPMailer) SendEmail(_ context.Context, email model.Email) error {
+	const op = "mailer.smtp.SendEmail"
+
+	msg, err := buildMessage(m.from, email)
+	if err != nil {
+		return fmt.Errorf("%s: failed to build message: %w", op, err)
+	}
+
+	if err = smtp.SendMail(m.addr, m.auth, envelopeAddress(m.from), []string{email.To}, msg); err != nil {
+		return fmt.Errorf("%s: failed to send email: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+// Template is the name of the embedded templates of the email,
+// every template has the text version in name.txt and the HTML version in name.html
+type Template string
+
+const (
+	TemplateVerifyEmail   Template = "verify_email"
+	TemplateResetPassword Template = "reset_password"
+	TemplateDailyDigest   Template = "digest_daily"
+	TemplateWeeklyDigest  Template = "digest_weekly"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+var (
+	textTemplates = texttemplate.Must(texttemplate.ParseFS(templatesFS, "templates/*.txt"))
+	htmlTemplates = htmltemplate.Must(htmltemplate.ParseFS(templatesFS, "templates/*.html"))
+)
+
+// NewEmail renders both bodies of the email from the template, the values in the HTML body are escaped
+func NewEmail(to, subject string, name Template, data any) (model.Email, error) {
+	var text, html bytes.Buffer
+
+	if err := textTemplates.ExecuteTemplate(&text, string(name)+".txt", data); err != nil {
+		return model.Email{}, err
+	}
+
+	if err := htmlTemplates.ExecuteTemplate(&html, string(name)+".html", data); err != nil {
+		return model.Email{}, err
+	}
+
+	return model.Email{
+		To:      to,
+		Subject: subject,
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
@@ -38,6 +38,9 @@ func PasswordMatch(hash, password string, salt []byte) (bool, error) {
 	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
 		return false, nil
 	}
+	if err != nil {
+		return false, err
+	}
 
 	return true, nil
 }
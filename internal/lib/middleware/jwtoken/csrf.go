@@ -0,0 +1,119 @@
+package jwtoken
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/rshelekhov/reframed/internal/config"
+)
+
+const (
+	CSRFTokenKey    = "csrf_token"
+	CSRFTokenHeader = "X-CSRF-Token"
+
+	// csrfTokenSize is the number of random bytes in the CSRF token
+	csrfTokenSize = 32
+)
+
+var ErrCSRFCheckFailed = errors.New("CSRF token is missing or invalid, and the request doesn't come from a trusted origin")
+
+// CSRFProtector protects the requests authenticated with the cookies. The unsafe methods are allowed
+// when the X-CSRF-Token header matches the CSRF token from the cookie (double submit),
+// or when the Origin or the Referer header point to the host of the API or to a trusted origin.
+// The requests without the cookies, e.g. from the mobile apps with the Authorization header, are not checked
+func (j *TokenService) CSRFProtector() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isSafeMethod(r.Method) || !hasTokenCookies(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if validCSRFToken(r) || j.isTrustedOrigin(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			http.Error(w, ErrCSRFCheckFailed.Error(), http.StatusForbidden)
+		})
+	}
+}
+
+// hasTokenCookies reports whether the browser sent the cookies the request can be authenticated with
+func hasTokenCookies(r *http.Request) bool {
+	return GetTokenFromCookie(r) != "" || hasCookie(r, RefreshTokenKey)
+}
+
+func hasCookie(r *http.Request, name string) bool {
+	cookie, err := r.Cookie(name)
+	return err == nil && cookie.Value != ""
+}
+
+func validCSRFToken(r *http.Request) bool {
+	headerToken := r.Header.Get(CSRFTokenHeader)
+	if headerToken == "" {
+		return false
+	}
+
+	cookie, err := r.Cookie(CSRFTokenKey)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(headerToken), []byte(cookie.Value)) == 1
+}
+
+// isTrustedOrigin checks the Origin header, or the Referer header when the browser doesn't send the Origin
+func (j *TokenService) isTrustedOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		origin = r.Header.Get("Referer")
+	}
+
+	if origin == "" || origin == "null" {
+		return false
+	}
+
+	originURL, err := url.Parse(origin)
+	if err != nil || originURL.Host == "" {
+		return false
+	}
+
+	if strings.EqualFold(originURL.Host, r.Host) {
+		return true
+	}
+
+	return slices.ContainsFunc(j.webAuth.CSRFTrustedOrigins, func(trusted string) bool {
+		return strings.EqualFold(strings.TrimSuffix(strings.TrimSpace(trusted), "/"), originURL.Scheme+"://"+originURL.Host)
+	})
+}
+
+func newCSRFToken() (string, error) {
+	tokenBytes := make([]byte, csrfTokenSize)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
+
+// parseSameSite falls back to SameSite=Lax for the empty and the unknown values
+func (j *TokenService) parseSameSite(value string) http.SameSite {
+	switch strings.ToLower(value) {
+	case config.CookieSameSiteStrict:
+		return http.SameSiteStrictMode
+	case config.CookieSameSiteNone:
+		return http.SameSiteNoneMode
+	case config.CookieSameSiteLax, "":
+		return http.SameSiteLaxMode
+	default:
+		j.log.Warn("unknown SameSite value of the cookies, Lax is used", slog.String("same_site", value))
+		return http.SameSiteLaxMode
+	}
+}
@@ -0,0 +1,220 @@
+package jwtoken
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ssov1 "github.com/rshelekhov/sso-protos/gen/go/sso"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rshelekhov/reframed/internal/config"
+)
+
+func newTestWebService(settings config.WebAuthSettings) *TokenService {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	return NewService(&fakeJWKSClient{}, "app_id", config.JWKSSettings{}, settings, log)
+}
+
+func TestCSRFProtector(t *testing.T) {
+	j := newTestWebService(config.WebAuthSettings{
+		CSRFTrustedOrigins: []string{"https://app.reframed.test/"},
+	})
+
+	handler := j.CSRFProtector()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name    string
+		method  string
+		cookies map[string]string
+		headers map[string]string
+		status  int
+	}{
+		{
+			name:    "safe method with cookies",
+			method:  http.MethodGet,
+			cookies: map[string]string{AccessTokenKey: "token"},
+			status:  http.StatusOK,
+		},
+		{
+			name:    "unsafe method without cookies",
+			method:  http.MethodPost,
+			headers: map[string]string{"Authorization": "Bearer token"},
+			status:  http.StatusOK,
+		},
+		{
+			name:    "unsafe method with cookies and without CSRF token",
+			method:  http.MethodPost,
+			cookies: map[string]string{RefreshTokenKey: "token"},
+			status:  http.StatusForbidden,
+		},
+		{
+			name:    "matching CSRF token",
+			method:  http.MethodPatch,
+			cookies: map[string]string{AccessTokenKey: "token", CSRFTokenKey: "csrf"},
+			headers: map[string]string{CSRFTokenHeader: "csrf"},
+			status:  http.StatusOK,
+		},
+		{
+			name:    "mismatching CSRF token",
+			method:  http.MethodDelete,
+			cookies: map[string]string{AccessTokenKey: "token", CSRFTokenKey: "csrf"},
+			headers: map[string]string{CSRFTokenHeader: "other"},
+			status:  http.StatusForbidden,
+		},
+		{
+			name:    "same origin",
+			method:  http.MethodPost,
+			cookies: map[string]string{AccessTokenKey: "token"},
+			headers: map[string]string{"Origin": "https://api.reframed.test"},
+			status:  http.StatusOK,
+		},
+		{
+			name:    "trusted origin",
+			method:  http.MethodPost,
+			cookies: map[string]string{AccessTokenKey: "token"},
+			headers: map[string]string{"Origin": "https://app.reframed.test"},
+			status:  http.StatusOK,
+		},
+		{
+			name:    "trusted referer",
+			method:  http.MethodPost,
+			cookies: map[string]string{AccessTokenKey: "token"},
+			headers: map[string]string{"Referer": "https://app.reframed.test/lists"},
+			status:  http.StatusOK,
+		},
+		{
+			name:    "untrusted origin",
+			method:  http.MethodPost,
+			cookies: map[string]string{AccessTokenKey: "token"},
+			headers: map[string]string{"Origin": "https://evil.test"},
+			status:  http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "https://api.reframed.test/user/tasks", nil)
+			for name, value := range tt.cookies {
+				r.AddCookie(&http.Cookie{Name: name, Value: value})
+			}
+			for name, value := range tt.headers {
+				r.Header.Set(name, value)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, w.Code)
+			}
+		})
+	}
+}
+
+func TestGetTokenFromAllowedQuery(t *testing.T) {
+	j := newTestWebService(config.WebAuthSettings{
+		DisableQueryTokens: true,
+		QueryTokenRoutes:   []string{"/user/calendar/*"},
+	})
+
+	tests := []struct {
+		target string
+		token  string
+	}{
+		{target: "/user/calendar/feed.ics?access_token=token", token: "token"},
+		{target: "/user/tasks?access_token=token", token: ""},
+		{target: "/user/calendar/feed.ics", token: ""},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+
+		if token := j.getTokenFromAllowedQuery(r); token != tt.token {
+			t.Errorf("%s: expected token %q, got %q", tt.target, tt.token, token)
+		}
+	}
+
+	enabled := newTestWebService(config.WebAuthSettings{})
+
+	r := httptest.NewRequest(http.MethodGet, "/user/tasks?access_token=token", nil)
+	if token := enabled.getTokenFromAllowedQuery(r); token != "token" {
+		t.Errorf("expected the query token to be accepted when the query tokens are enabled, got %q", token)
+	}
+}
+
+func TestSetRefreshTokenCookie_SameSiteNoneIsSecure(t *testing.T) {
+	j := newTestWebService(config.WebAuthSettings{CookieSameSite: config.CookieSameSiteNone})
+
+	w := httptest.NewRecorder()
+	j.SetRefreshTokenCookie(w, "refresh", "", "/", time.Now().Add(time.Hour), true)
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("expected 1 cookie, got %d", len(cookies))
+	}
+
+	if cookies[0].SameSite != http.SameSiteNoneMode || !cookies[0].Secure {
+		t.Errorf("expected SameSite=None with the Secure attribute, got SameSite=%v Secure=%v", cookies[0].SameSite, cookies[0].Secure)
+	}
+}
+
+func TestDeleteTokenCookies_SameScopeAsSet(t *testing.T) {
+	tokenData := &ssov1.TokenData{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		Domain:       "auth.example.com",
+		Path:         "/api",
+		ExpiresAt:    timestamppb.New(time.Now().Add(time.Hour)),
+		HttpOnly:     true,
+	}
+
+	tests := []struct {
+		name   string
+		cfg    config.WebAuthSettings
+		domain string
+		path   string
+	}{
+		{
+			name:   "provider scope",
+			domain: "auth.example.com",
+			path:   "/api",
+		},
+		{
+			name:   "scope from the settings",
+			cfg:    config.WebAuthSettings{CookieDomain: "example.com", CookiePath: "/"},
+			domain: "example.com",
+			path:   "/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newTestWebService(tt.cfg)
+
+			w := httptest.NewRecorder()
+			j.SendTokensToWeb(w, tokenData, http.StatusOK)
+
+			w = httptest.NewRecorder()
+			j.DeleteTokenCookies(w)
+
+			cookies := w.Result().Cookies()
+			if len(cookies) != 2 {
+				t.Fatalf("expected 2 cookies, got %d", len(cookies))
+			}
+
+			for _, cookie := range cookies {
+				if cookie.Domain != tt.domain || cookie.Path != tt.path || cookie.MaxAge >= 0 {
+					t.Errorf("expected %s to be deleted for %s%s, got Domain=%q Path=%q MaxAge=%d",
+						cookie.Name, tt.domain, tt.path, cookie.Domain, cookie.Path, cookie.MaxAge)
+				}
+			}
+		})
+	}
+}
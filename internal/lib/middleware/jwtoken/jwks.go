@@ -0,0 +1,232 @@
+package jwtoken
+
+import (
+	"context"
+	"expvar"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/config"
+	"github.com/rshelekhov/reframed/internal/lib/cache"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	ssov1 "github.com/rshelekhov/sso-protos/gen/go/sso"
+)
+
+// jwksMetrics counts the updates of the JWKS, the counters are published by expvar under the jwks name
+var jwksMetrics = expvar.NewMap("jwks")
+
+const (
+	metricJWKSRefreshes           = "refreshes"
+	metricJWKSRefreshErrors       = "refresh_errors"
+	metricJWKSRotations           = "rotations"
+	metricJWKSStaleServed         = "stale_served"
+	metricJWKSUnknownKidRefetches = "unknown_kid_refetches"
+)
+
+const (
+	defaultJWKSRefreshBefore      = time.Minute
+	defaultJWKSStaleGracePeriod   = time.Hour
+	defaultJWKSMinRefreshInterval = 10 * time.Second
+)
+
+// jwksState is the last JWKS received from the SSO service
+type jwksState struct {
+	keys      []*ssov1.JWK
+	expiresAt time.Time
+}
+
+func withJWKSDefaults(settings config.JWKSSettings) config.JWKSSettings {
+	if settings.RefreshBefore <= 0 {
+		settings.RefreshBefore = defaultJWKSRefreshBefore
+	}
+
+	if settings.StaleGracePeriod <= 0 {
+		settings.StaleGracePeriod = defaultJWKSStaleGracePeriod
+	}
+
+	if settings.MinRefreshInterval <= 0 {
+		settings.MinRefreshInterval = defaultJWKSMinRefreshInterval
+	}
+
+	return settings
+}
+
+// GetJWKS returns the cached keys and refetches the expired ones. When the SSO service is unreachable,
+// the expired keys are served for the grace period
+func (j *TokenService) GetJWKS(ctx context.Context) ([]*ssov1.JWK, error) {
+	j.mu.RLock()
+	state := j.jwks
+	j.mu.RUnlock()
+
+	now := time.Now()
+
+	if state.keys != nil && now.Before(state.expiresAt) {
+		return slices.Clone(state.keys), nil
+	}
+
+	err := ErrJWKSRefreshRateLimited
+
+	if state.keys == nil || j.allowJWKSRefresh() {
+		var keys []*ssov1.JWK
+
+		keys, err = j.refreshJWKS(ctx)
+		if err == nil {
+			return keys, nil
+		}
+	}
+
+	if state.keys != nil && now.Before(state.expiresAt.Add(j.jwksSettings.StaleGracePeriod)) {
+		jwksMetrics.Add(metricJWKSStaleServed, 1)
+		j.log.Warn("serving stale JWKS", slog.Time("expired_at", state.expiresAt), logger.Err(err))
+
+		return slices.Clone(state.keys), nil
+	}
+
+	return nil, err
+}
+
+// RefreshJWKSInBackground refreshes the keys before they expire, so that the requests don't wait
+// for the SSO service. It stops when the context is canceled
+func (j *TokenService) RefreshJWKSInBackground(ctx context.Context) {
+	go func() {
+		for {
+			timer := time.NewTimer(j.nextJWKSRefresh())
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			// The errors are logged by refreshJWKS, the stale keys are kept until the next attempt
+			_, _ = j.refreshJWKS(ctx)
+		}
+	}()
+}
+
+// nextJWKSRefresh returns the time left until the keys should be refreshed,
+// the refreshes are not done more often than the min refresh interval, also after the failed ones
+func (j *TokenService) nextJWKSRefresh() time.Duration {
+	j.mu.RLock()
+	expiresAt := j.jwks.expiresAt
+	lastRefresh := j.lastJWKSRefresh
+	j.mu.RUnlock()
+
+	wait := time.Until(expiresAt.Add(-j.jwksSettings.RefreshBefore))
+
+	if minWait := time.Until(lastRefresh.Add(j.jwksSettings.MinRefreshInterval)); wait < minWait {
+		wait = minWait
+	}
+
+	return wait
+}
+
+// getJWK refetches the keys when the token is signed with a key that is not cached yet, e.g. after
+// the key rotation. The refetches are rate limited, so the tokens with random kids can't flood the SSO service
+func (j *TokenService) getJWK(ctx context.Context, jwks []*ssov1.JWK, kid string) (*ssov1.JWK, error) {
+	jwk, err := getJWKByKid(jwks, kid)
+	if err == nil {
+		return jwk, nil
+	}
+
+	if !j.allowJWKSRefresh() {
+		return nil, err
+	}
+
+	jwksMetrics.Add(metricJWKSUnknownKidRefetches, 1)
+	j.log.Info("refetching JWKS for unknown kid", slog.String(key.Kid, kid))
+
+	keys, refreshErr := j.refreshJWKS(ctx)
+	if refreshErr != nil {
+		return nil, err
+	}
+
+	return getJWKByKid(keys, kid)
+}
+
+func (j *TokenService) allowJWKSRefresh() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if time.Since(j.lastJWKSRefresh) < j.jwksSettings.MinRefreshInterval {
+		return false
+	}
+
+	j.lastJWKSRefresh = time.Now()
+
+	return true
+}
+
+// refreshJWKS fetches the keys from the SSO service, the concurrent callers share a single request
+func (j *TokenService) refreshJWKS(ctx context.Context) ([]*ssov1.JWK, error) {
+	result, err, _ := j.jwksGroup.Do(CacheJWKS, func() (interface{}, error) {
+		j.mu.Lock()
+		j.lastJWKSRefresh = time.Now()
+		j.mu.Unlock()
+
+		// The request is shared by all the callers, so it must not be canceled together with the first of them
+		jwksResponse, err := j.jwksProvider.GetJWKS(context.WithoutCancel(ctx), &ssov1.GetJWKSRequest{
+			AppID: j.AppID,
+		})
+		if err != nil {
+			jwksMetrics.Add(metricJWKSRefreshErrors, 1)
+			j.log.Error("failed to refresh JWKS", logger.Err(err))
+
+			return nil, err
+		}
+
+		keys := jwksResponse.GetJwks()
+
+		ttl := time.Duration(jwksResponse.GetTtl().GetSeconds()) * time.Second
+		if ttl <= 0 {
+			ttl = cache.DefaultExpiration
+		}
+
+		j.setJWKS(keys, ttl)
+
+		return keys, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Clone(result.([]*ssov1.JWK)), nil
+}
+
+func (j *TokenService) setJWKS(keys []*ssov1.JWK, ttl time.Duration) {
+	j.mu.Lock()
+	previousKeys := j.jwks.keys
+	j.jwks = jwksState{
+		keys:      keys,
+		expiresAt: time.Now().Add(ttl),
+	}
+	j.mu.Unlock()
+
+	jwksMetrics.Add(metricJWKSRefreshes, 1)
+
+	added, removed := diffKids(previousKeys, keys)
+	if previousKeys != nil && (len(added) > 0 || len(removed) > 0) {
+		jwksMetrics.Add(metricJWKSRotations, 1)
+		j.log.Info("JWKS keys rotated", slog.Any("added_kids", added), slog.Any("removed_kids", removed))
+	}
+}
+
+// diffKids returns the kids of the keys added to and removed from the JWKS
+func diffKids(previous, current []*ssov1.JWK) (added, removed []string) {
+	for _, jwk := range current {
+		if _, err := getJWKByKid(previous, jwk.GetKid()); err != nil {
+			added = append(added, jwk.GetKid())
+		}
+	}
+
+	for _, jwk := range previous {
+		if _, err := getJWKByKid(current, jwk.GetKid()); err != nil {
+			removed = append(removed, jwk.GetKid())
+		}
+	}
+
+	return added, removed
+}
@@ -0,0 +1,130 @@
+package jwtoken
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/config"
+	ssov1 "github.com/rshelekhov/sso-protos/gen/go/sso"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var errSSOUnavailable = errors.New("sso is unavailable")
+
+// fakeJWKSClient returns the configured keys, the other methods of the client are not used
+type fakeJWKSClient struct {
+	ssov1.AuthClient
+
+	calls atomic.Int32
+	delay time.Duration
+	kids  []string
+	err   error
+}
+
+func (c *fakeJWKSClient) GetJWKS(_ context.Context, _ *ssov1.GetJWKSRequest, _ ...grpc.CallOption) (*ssov1.GetJWKSResponse, error) {
+	c.calls.Add(1)
+	time.Sleep(c.delay)
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	var jwks []*ssov1.JWK
+	for _, kid := range c.kids {
+		jwks = append(jwks, &ssov1.JWK{Kid: kid})
+	}
+
+	return &ssov1.GetJWKSResponse{
+		Jwks: jwks,
+		Ttl:  durationpb.New(time.Hour),
+	}, nil
+}
+
+func newTestService(client *fakeJWKSClient) *TokenService {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	return NewService(client, "app_id", config.JWKSSettings{}, config.WebAuthSettings{}, log)
+}
+
+func TestGetJWKS_SingleRequestForConcurrentCalls(t *testing.T) {
+	client := &fakeJWKSClient{kids: []string{"a"}, delay: 50 * time.Millisecond}
+	j := newTestService(client)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := j.GetJWKS(context.Background()); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if calls := client.calls.Load(); calls != 1 {
+		t.Errorf("Expected 1 request to SSO, got %d", calls)
+	}
+}
+
+func TestGetJWK_RefetchOnUnknownKid(t *testing.T) {
+	client := &fakeJWKSClient{kids: []string{"a", "b"}}
+	j := newTestService(client)
+	j.setJWKS([]*ssov1.JWK{{Kid: "a"}}, time.Hour)
+
+	jwk, err := j.getJWK(context.Background(), []*ssov1.JWK{{Kid: "a"}}, "b")
+	if err != nil {
+		t.Fatalf("Expected the rotated key to be found, got %v", err)
+	}
+
+	if jwk.GetKid() != "b" {
+		t.Errorf("Expected kid b, got %s", jwk.GetKid())
+	}
+
+	// The next refetch is rate limited
+	if _, err = j.getJWK(context.Background(), []*ssov1.JWK{{Kid: "a"}}, "c"); err == nil {
+		t.Errorf("Expected an error for the unknown kid")
+	}
+
+	if calls := client.calls.Load(); calls != 1 {
+		t.Errorf("Expected 1 request to SSO, got %d", calls)
+	}
+}
+
+func TestGetJWKS_StaleKeysOnError(t *testing.T) {
+	client := &fakeJWKSClient{err: errSSOUnavailable}
+	j := newTestService(client)
+
+	j.jwks = jwksState{
+		keys:      []*ssov1.JWK{{Kid: "a"}},
+		expiresAt: time.Now().Add(-time.Minute),
+	}
+
+	jwks, err := j.GetJWKS(context.Background())
+	if err != nil {
+		t.Fatalf("Expected the stale keys, got %v", err)
+	}
+
+	if len(jwks) != 1 || jwks[0].GetKid() != "a" {
+		t.Errorf("Expected the stale key a, got %v", jwks)
+	}
+
+	// The keys are not served after the grace period
+	j.jwks.expiresAt = time.Now().Add(-2 * defaultJWKSStaleGracePeriod)
+	j.lastJWKSRefresh = time.Time{}
+
+	if _, err = j.GetJWKS(context.Background()); !errors.Is(err, errSSOUnavailable) {
+		t.Errorf("Expected %v, got %v", errSSOUnavailable, err)
+	}
+}
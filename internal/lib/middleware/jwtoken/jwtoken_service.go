@@ -7,42 +7,79 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"math/big"
 	"net/http"
+	"path"
 	"strings"
 	"sync"
 	"time"
 
-	ssogrpc "github.com/rshelekhov/reframed/internal/clients/sso/grpc"
-	"github.com/rshelekhov/reframed/internal/lib/cache"
+	"github.com/rshelekhov/reframed/internal/config"
 	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
 	"github.com/golang-jwt/jwt/v5"
 	ssov1 "github.com/rshelekhov/sso-protos/gen/go/sso"
 )
 
-type TokenService struct {
-	ssoClient *ssogrpc.Client
-	jwksCache *cache.Cache
-	mu        sync.RWMutex
-	AppID     string
-	Cookie    cookie
+// JWKSProvider returns the public keys the access tokens are verified with,
+// it is the SSO client or the local auth provider
+type JWKSProvider interface {
+	GetJWKS(ctx context.Context, in *ssov1.GetJWKSRequest, opts ...grpc.CallOption) (*ssov1.GetJWKSResponse, error)
 }
 
-type cookie struct {
-	Domain    string
-	Path      string
-	ExpiresAt time.Time
-	HTTPOnly  bool
+type TokenService struct {
+	jwksProvider JWKSProvider
+	log          *slog.Logger
+	AppID        string
+
+	// webAuth holds the settings of the cookies, the CSRF protection and the tokens in the query string
+	webAuth  config.WebAuthSettings
+	sameSite http.SameSite
+
+	// cookieMu guards the domain and the path of the last cookies issued by the auth provider,
+	// the cookies are deleted with them when the settings don't set the domain and the path
+	cookieMu     sync.RWMutex
+	cookieDomain string
+	cookiePath   string
+
+	// mu guards the JWKS and the time of its last refresh
+	mu              sync.RWMutex
+	jwks            jwksState
+	jwksGroup       singleflight.Group
+	jwksSettings    config.JWKSSettings
+	lastJWKSRefresh time.Time
+
+	// PersonalTokens verifies the personal access tokens accepted alongside the JWTs
+	PersonalTokens PersonalTokenVerifier
 }
 
-func NewService(ssoClient *ssogrpc.Client, appID string) *TokenService {
-	return &TokenService{
-		ssoClient: ssoClient,
-		jwksCache: cache.New(),
-		AppID:     appID,
+func NewService(
+	jwksProvider JWKSProvider,
+	appID string,
+	jwksSettings config.JWKSSettings,
+	webAuthSettings config.WebAuthSettings,
+	log *slog.Logger,
+) *TokenService {
+	j := &TokenService{
+		jwksProvider: jwksProvider,
+		log:          log,
+		AppID:        appID,
+		jwksSettings: withJWKSDefaults(jwksSettings),
+		webAuth:      webAuthSettings,
+	}
+
+	j.sameSite = j.parseSameSite(webAuthSettings.CookieSameSite)
+
+	// Browsers reject the cookies with SameSite=None without the Secure attribute
+	if j.sameSite == http.SameSiteNoneMode {
+		j.webAuth.CookieSecure = true
 	}
+
+	return j
 }
 
 type TokenData struct {
@@ -62,21 +99,27 @@ var (
 	ErrUnexpectedSigningMethod  = errors.New("unexpected signing method")
 	ErrTokenNotFoundInCtx       = errors.New("token not found in context")
 	ErrUserIDNotFoundInCtx      = errors.New("user id not found in context")
+	ErrSessionIDNotFoundInCtx   = errors.New("session id not found in context")
 	ErrAccessTokenNotFoundInCtx = errors.New("access token not found in context")
 	ErrFailedToParseTokenClaims = errors.New("failed to parse token claims from context")
 	ErrKidNotFoundInTokenHeader = errors.New("kid not found in token header")
 	ErrKidIsNotAString          = errors.New("kid is not a string")
+	ErrJWKSRefreshRateLimited   = errors.New("JWKS refresh is rate limited")
+	ErrInsufficientScope        = errors.New("token doesn't have the required scope")
+	ErrFullAccessRequired       = errors.New("token with full access is required")
 )
 
 const (
 	AccessTokenKey  = "access_token"
 	RefreshTokenKey = "refresh_token"
 	ContextUserID   = "user_id"
+	ContextScope    = "scope"
+	ContextScopes   = "scopes"
 	CacheJWKS       = "jwks"
 )
 
 func Verifier(j *TokenService) func(http.Handler) http.Handler {
-	return j.Verify(GetTokenFromHeader, GetTokenFromCookie, GetTokenFromQuery)
+	return j.Verify(GetTokenFromHeader, GetTokenFromCookie, j.getTokenFromAllowedQuery)
 }
 
 func (j *TokenService) Verify(findTokenFns ...func(r *http.Request) string) func(http.Handler) http.Handler {
@@ -140,6 +183,11 @@ func FindRefreshToken(r *http.Request) (string, error) {
 }
 
 func (j *TokenService) VerifyToken(ctx context.Context, accessTokenString string) error {
+	if IsPersonalToken(accessTokenString) {
+		_, err := j.VerifyPersonalToken(ctx, accessTokenString)
+		return err
+	}
+
 	token, err := j.ParseToken(ctx, accessTokenString)
 	if err != nil {
 		return Errors(err)
@@ -170,7 +218,7 @@ func (j *TokenService) ParseToken(ctx context.Context, accessTokenString string)
 			return nil, ErrKidIsNotAString
 		}
 
-		jwk, err := getJWKByKid(jwks, kid)
+		jwk, err := j.getJWK(ctx, jwks, kid)
 		if err != nil {
 			return nil, err
 		}
@@ -202,40 +250,6 @@ func (j *TokenService) ParseToken(ctx context.Context, accessTokenString string)
 	return tokenParsed, nil
 }
 
-func (j *TokenService) GetJWKS(ctx context.Context) ([]*ssov1.JWK, error) {
-	var jwks []*ssov1.JWK
-
-	j.mu.RLock()
-	cacheValue, found := j.jwksCache.Get(CacheJWKS)
-	j.mu.RUnlock()
-
-	if found {
-		cachedJWKS, ok := cacheValue.([]*ssov1.JWK)
-		if !ok {
-			return nil, errors.New("invalid JWKS cache value type")
-		}
-
-		jwks = make([]*ssov1.JWK, len(cachedJWKS))
-		copy(jwks, cachedJWKS)
-	} else {
-		jwksResponse, err := j.ssoClient.Api.GetJWKS(ctx, &ssov1.GetJWKSRequest{
-			AppID: j.AppID,
-		})
-		if err != nil {
-			return nil, err
-		}
-
-		jwks = jwksResponse.GetJwks()
-		ttl := time.Duration(jwksResponse.GetTtl().Seconds) * time.Second
-
-		j.mu.Lock()
-		j.jwksCache.Set(CacheJWKS, jwks, ttl)
-		j.mu.Unlock()
-	}
-
-	return jwks, nil
-}
-
 func getJWKByKid(jwks []*ssov1.JWK, kid string) (*ssov1.JWK, error) {
 	for _, jwk := range jwks {
 		if jwk.GetKid() == kid {
@@ -314,6 +328,26 @@ func GetTokenFromQuery(r *http.Request) string {
 	return r.URL.Query().Get(AccessTokenKey)
 }
 
+// getTokenFromAllowedQuery ignores the token in the query string when the query tokens are disabled,
+// except on the routes matching the patterns from the settings, e.g. /user/calendar/*
+func (j *TokenService) getTokenFromAllowedQuery(r *http.Request) string {
+	if j.webAuth.DisableQueryTokens && !j.isQueryTokenRoute(r.URL.Path) {
+		return ""
+	}
+
+	return GetTokenFromQuery(r)
+}
+
+func (j *TokenService) isQueryTokenRoute(urlPath string) bool {
+	for _, pattern := range j.webAuth.QueryTokenRoutes {
+		if matched, err := path.Match(strings.TrimSpace(pattern), urlPath); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
 func GetTokenFromContext(ctx context.Context) (string, error) {
 	token, ok := ctx.Value(AccessTokenKey).(string)
 	if !ok {
@@ -343,6 +377,20 @@ func (j *TokenService) GetClaimsFromToken(ctx context.Context) (map[string]inter
 }
 
 func (j *TokenService) GetUserID(ctx context.Context) (string, error) {
+	accessToken, err := GetTokenFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if IsPersonalToken(accessToken) {
+		personalToken, err := j.VerifyPersonalToken(ctx, accessToken)
+		if err != nil {
+			return "", err
+		}
+
+		return personalToken.UserID, nil
+	}
+
 	claims, err := j.GetClaimsFromToken(ctx)
 	if err != nil {
 		return "", err
@@ -356,6 +404,31 @@ func (j *TokenService) GetUserID(ctx context.Context) (string, error) {
 	return userID.(string), nil
 }
 
+// GetSessionID returns the ID of the session the access token was issued for.
+// Only the tokens issued by the local auth provider have it
+func (j *TokenService) GetSessionID(ctx context.Context) (string, error) {
+	accessToken, err := GetTokenFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if IsPersonalToken(accessToken) {
+		return "", ErrSessionIDNotFoundInCtx
+	}
+
+	claims, err := j.GetClaimsFromToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	sessionID, ok := claims[key.SessionID].(string)
+	if !ok || sessionID == "" {
+		return "", ErrSessionIDNotFoundInCtx
+	}
+
+	return sessionID, nil
+}
+
 func AddAccessTokenToMetadata(ctx context.Context) (context.Context, error) {
 	accessToken, ok := ctx.Value(AccessTokenKey).(string)
 	if !ok {
@@ -369,7 +442,7 @@ func AddAccessTokenToMetadata(ctx context.Context) (context.Context, error) {
 	return newCtx, nil
 }
 
-func SetTokenCookie(w http.ResponseWriter, name, value, domain, path string, expiresAt time.Time, httpOnly bool) {
+func (j *TokenService) SetTokenCookie(w http.ResponseWriter, name, value, domain, path string, expiresAt time.Time, httpOnly bool) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     name,
 		Value:    value,
@@ -377,25 +450,94 @@ func SetTokenCookie(w http.ResponseWriter, name, value, domain, path string, exp
 		Path:     path,
 		Expires:  expiresAt,
 		HttpOnly: httpOnly,
+		Secure:   j.webAuth.CookieSecure,
+		SameSite: j.sameSite,
 	})
 }
 
-func SetRefreshTokenCookie(w http.ResponseWriter, refreshToken, domain, path string, expiresAt time.Time, httpOnly bool) {
-	SetTokenCookie(w, RefreshTokenKey, refreshToken, domain, path, expiresAt, httpOnly)
+func (j *TokenService) SetRefreshTokenCookie(w http.ResponseWriter, refreshToken, domain, path string, expiresAt time.Time, httpOnly bool) {
+	j.SetTokenCookie(w, RefreshTokenKey, refreshToken, domain, path, expiresAt, httpOnly)
 }
 
-func SendTokensToWeb(w http.ResponseWriter, data *ssov1.TokenData, httpStatus int) {
-	SetRefreshTokenCookie(w,
+// tokenCookieScope returns the domain and the path of the token cookies, the settings take precedence
+// over the ones issued by the auth provider. The browsers delete the cookie only with the same domain and path
+func (j *TokenService) tokenCookieScope(data *ssov1.TokenData) (domain, path string) {
+	if data != nil {
+		j.cookieMu.Lock()
+		j.cookieDomain, j.cookiePath = data.GetDomain(), data.GetPath()
+		j.cookieMu.Unlock()
+	}
+
+	j.cookieMu.RLock()
+	domain, path = j.cookieDomain, j.cookiePath
+	j.cookieMu.RUnlock()
+
+	if j.webAuth.CookieDomain != "" {
+		domain = j.webAuth.CookieDomain
+	}
+	if j.webAuth.CookiePath != "" {
+		path = j.webAuth.CookiePath
+	}
+	if path == "" {
+		path = "/"
+	}
+
+	return domain, path
+}
+
+// DeleteTokenCookies removes the cookies with the refresh token and the CSRF token, e.g. on logout
+func (j *TokenService) DeleteTokenCookies(w http.ResponseWriter) {
+	domain, path := j.tokenCookieScope(nil)
+
+	for _, name := range []string{RefreshTokenKey, CSRFTokenKey} {
+		http.SetCookie(w, &http.Cookie{
+			Name:     name,
+			Value:    "",
+			Domain:   domain,
+			Path:     path,
+			Expires:  time.Unix(0, 0),
+			MaxAge:   -1,
+			HttpOnly: name == RefreshTokenKey,
+			Secure:   j.webAuth.CookieSecure,
+			SameSite: j.sameSite,
+		})
+	}
+}
+
+// SendTokensToWeb sets the refresh token and a new CSRF token to the cookies. The CSRF token is returned
+// in the body as well, so that the web clients on another domain can send it in the X-CSRF-Token header
+func (j *TokenService) SendTokensToWeb(w http.ResponseWriter, data *ssov1.TokenData, httpStatus int) {
+	// The token is generated before any cookie is set, so the error response doesn't carry the refresh token
+	csrfToken, err := newCSRFToken()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	domain, path := j.tokenCookieScope(data)
+
+	j.SetRefreshTokenCookie(w,
 		data.GetRefreshToken(),
-		data.GetDomain(),
-		data.GetPath(),
+		domain,
+		path,
 		data.GetExpiresAt().AsTime(),
 		data.GetHttpOnly(),
 	)
+
+	// The CSRF token must be readable by the scripts of the web client, so it's never HTTP only
+	j.SetTokenCookie(w,
+		CSRFTokenKey,
+		csrfToken,
+		domain,
+		path,
+		data.GetExpiresAt().AsTime(),
+		false,
+	)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(httpStatus)
 
-	responseBody := map[string]string{AccessTokenKey: data.AccessToken}
+	responseBody := map[string]string{AccessTokenKey: data.AccessToken, CSRFTokenKey: csrfToken}
 
 	if len(data.AdditionalFields) > 0 {
 		for key, value := range data.AdditionalFields {
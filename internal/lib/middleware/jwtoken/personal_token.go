@@ -0,0 +1,34 @@
+package jwtoken
+
+import (
+	"context"
+	"strings"
+)
+
+// PersonalTokenPrefix marks the personal access tokens, they are checked by the PersonalTokenVerifier
+// instead of the JWKS of the SSO service
+const PersonalTokenPrefix = "rfd_"
+
+// PersonalToken is the owner and the scopes of the verified personal access token
+type PersonalToken struct {
+	ID     string
+	UserID string
+	Scopes []string
+}
+
+type PersonalTokenVerifier interface {
+	VerifyPersonalToken(ctx context.Context, token string) (PersonalToken, error)
+}
+
+func IsPersonalToken(token string) bool {
+	return strings.HasPrefix(token, PersonalTokenPrefix)
+}
+
+// VerifyPersonalToken returns the owner and the scopes of the personal access token
+func (j *TokenService) VerifyPersonalToken(ctx context.Context, token string) (PersonalToken, error) {
+	if j.PersonalTokens == nil {
+		return PersonalToken{}, ErrInvalidToken
+	}
+
+	return j.PersonalTokens.VerifyPersonalToken(ctx, token)
+}
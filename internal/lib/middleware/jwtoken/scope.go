@@ -0,0 +1,142 @@
+package jwtoken
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-chi/render"
+)
+
+// ScopeErrorResponse is rendered with the 403 status when the token doesn't have the scope required by the route
+type ScopeErrorResponse struct {
+	Error          string    `json:"error"`
+	StatusCode     int       `json:"status_code"`
+	RequiredScopes []string  `json:"required_scopes,omitempty"`
+	TokenScopes    []string  `json:"token_scopes"`
+	Time           time.Time `json:"time"`
+}
+
+// RequireScopes allows the safe methods to the tokens with the read scope and the other methods
+// to the tokens with the write scope. Nested routes add their requirements to the ones of the parent routes
+func (j *TokenService) RequireScopes(read, write string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scopes, limited, err := j.GetScopes(r.Context())
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			required := write
+			if isSafeMethod(r.Method) {
+				required = read
+			}
+
+			if limited && !slices.Contains(scopes, required) {
+				renderScopeError(w, r, ErrInsufficientScope, []string{required}, scopes)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireFullAccess allows only the tokens without scopes, e.g. for deleting the user
+func (j *TokenService) RequireFullAccess() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scopes, limited, err := j.GetScopes(r.Context())
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			if limited {
+				renderScopeError(w, r, ErrFullAccessRequired, nil, scopes)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// GetScopes returns the scopes of the token from the context. The tokens without scopes are issued
+// by the SSO service for the user's own sessions and are not limited
+func (j *TokenService) GetScopes(ctx context.Context) (scopes []string, limited bool, err error) {
+	accessToken, err := GetTokenFromContext(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if IsPersonalToken(accessToken) {
+		personalToken, err := j.VerifyPersonalToken(ctx, accessToken)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return personalToken.Scopes, true, nil
+	}
+
+	claims, err := j.GetClaimsFromToken(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return scopesFromClaims(claims)
+}
+
+// scopesFromClaims reads the space-delimited scope claim of OAuth 2.0 or the scopes claim as an array
+func scopesFromClaims(claims map[string]interface{}) (scopes []string, limited bool, err error) {
+	if scope, ok := claims[ContextScope]; ok {
+		scopeString, ok := scope.(string)
+		if !ok {
+			return nil, false, ErrFailedToParseTokenClaims
+		}
+
+		return strings.Fields(scopeString), true, nil
+	}
+
+	if scopeList, ok := claims[ContextScopes]; ok {
+		items, ok := scopeList.([]interface{})
+		if !ok {
+			return nil, false, ErrFailedToParseTokenClaims
+		}
+
+		for _, item := range items {
+			scope, ok := item.(string)
+			if !ok {
+				return nil, false, ErrFailedToParseTokenClaims
+			}
+
+			scopes = append(scopes, scope)
+		}
+
+		return scopes, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+func renderScopeError(w http.ResponseWriter, r *http.Request, err error, required, scopes []string) {
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	render.Status(r, http.StatusForbidden)
+	render.JSON(w, r, ScopeErrorResponse{
+		Error:          err.Error(),
+		StatusCode:     http.StatusForbidden,
+		RequiredScopes: required,
+		TokenScopes:    scopes,
+		Time:           time.Now(),
+	})
+}
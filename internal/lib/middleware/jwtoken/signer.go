@@ -0,0 +1,134 @@
+package jwtoken
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	ssov1 "github.com/rshelekhov/sso-protos/gen/go/sso"
+)
+
+const signerKeySize = 2048
+
+var ErrInvalidPrivateKey = errors.New("invalid RSA private key")
+
+// Signer issues RS256 access tokens and publishes its public key as JWK. It is used by the auth providers
+// which sign the tokens themselves instead of the SSO service
+type Signer struct {
+	privateKey *rsa.PrivateKey
+	kid        string
+}
+
+func NewSigner(privateKey *rsa.PrivateKey) *Signer {
+	// The kid is derived from the public key, so it stays the same after restarts
+	thumbprint := sha256.Sum256(privateKey.PublicKey.N.Bytes())
+
+	return &Signer{
+		privateKey: privateKey,
+		kid:        base64.RawURLEncoding.EncodeToString(thumbprint[:16]),
+	}
+}
+
+// GenerateSigner creates the signer with a new key, the tokens signed with it are not valid after restarts
+func GenerateSigner() (*Signer, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, signerKeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSigner(privateKey), nil
+}
+
+// LoadSigner reads the RSA private key in the PEM format, both PKCS #1 and PKCS #8 are supported
+func LoadSigner(path string) (*Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	if privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return NewSigner(privateKey), nil
+	}
+
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	privateKey, ok := parsedKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	return NewSigner(privateKey), nil
+}
+
+// SignAccessToken returns the token with the same claims as the ones issued by the SSO service
+func (s *Signer) SignAccessToken(userID, appID, email, sessionID string, ttl time.Duration) (string, error) {
+	currentTime := time.Now()
+
+	claims := jwt.MapClaims{
+		key.UserID: userID,
+		key.AppID:  appID,
+		key.Email:  email,
+		"iat":      currentTime.Unix(),
+		"exp":      currentTime.Add(ttl).Unix(),
+	}
+	if sessionID != "" {
+		claims[key.SessionID] = sessionID
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header[key.Kid] = s.kid
+
+	return token.SignedString(s.privateKey)
+}
+
+// ParseAccessToken returns the user ID from the token signed by this signer
+func (s *Signer) ParseAccessToken(accessToken string) (string, error) {
+	token, err := jwt.Parse(accessToken, func(_ *jwt.Token) (interface{}, error) {
+		return &s.privateKey.PublicKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
+	if err != nil {
+		return "", Errors(err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", ErrFailedToParseTokenClaims
+	}
+
+	userID, ok := claims[key.UserID].(string)
+	if !ok {
+		return "", ErrUserIDNotFoundInCtx
+	}
+
+	return userID, nil
+}
+
+func (s *Signer) JWK() *ssov1.JWK {
+	publicKey := s.privateKey.PublicKey
+
+	return &ssov1.JWK{
+		Alg: jwt.SigningMethodRS256.Alg(),
+		Kty: "RSA",
+		Use: "sig",
+		Kid: s.kid,
+		N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+	}
+}
@@ -0,0 +1,191 @@
+package security
+
+import (
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/config"
+)
+
+const (
+	defaultCORSMaxAge = 10 * time.Minute
+
+	// wildcard allows any origin (never with credentials) or any header
+	wildcard = "*"
+)
+
+var (
+	defaultCORSAllowedMethods = []string{
+		http.MethodGet,
+		http.MethodPost,
+		http.MethodPut,
+		http.MethodPatch,
+		http.MethodDelete,
+	}
+
+	defaultCORSAllowedHeaders = []string{
+		"Accept",
+		"Authorization",
+		"Content-Type",
+		"X-CSRF-Token",
+		"X-Time-Zone",
+		"X-Request-Id",
+	}
+)
+
+// CORS answers the preflight requests and adds the CORS headers to the responses for the allowed origins.
+// The requests from the other origins are passed without the headers, so the browser blocks their responses
+func CORS(settings config.CORSSettings) func(http.Handler) http.Handler {
+	settings = withCORSDefaults(settings)
+
+	allowedMethods := strings.Join(settings.AllowedMethods, ", ")
+	exposedHeaders := strings.Join(settings.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(settings.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		// CORS is disabled until the allowed origins are set
+		if len(settings.AllowedOrigins) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+
+			// The response depends on the origin, so the caches must keep them apart
+			w.Header().Add("Vary", "Origin")
+
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			if origin == "" || !isAllowedOrigin(settings.AllowedOrigins, origin) {
+				if preflight {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			allowOrigin, allowCredentials := origin, settings.AllowCredentials
+
+			// The browsers reject the wildcard with credentials, so the credentials are never allowed for it
+			if slices.Contains(settings.AllowedOrigins, wildcard) {
+				allowOrigin, allowCredentials = wildcard, false
+			}
+
+			w.Header().Set("Access-Control-Allow-Origin", allowOrigin)
+			if allowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if !preflight {
+				if exposedHeaders != "" {
+					w.Header().Set("Access-Control-Expose-Headers", exposedHeaders)
+				}
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+
+			requestedMethod := r.Header.Get("Access-Control-Request-Method")
+			requestedHeaders := r.Header.Get("Access-Control-Request-Headers")
+
+			if isAllowedMethod(settings.AllowedMethods, requestedMethod) &&
+				areAllowedHeaders(settings.AllowedHeaders, requestedHeaders) {
+				w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+				if requestedHeaders != "" {
+					w.Header().Set("Access-Control-Allow-Headers", requestedHeaders)
+				}
+				w.Header().Set("Access-Control-Max-Age", maxAge)
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+func withCORSDefaults(settings config.CORSSettings) config.CORSSettings {
+	settings.AllowedOrigins = trimValues(settings.AllowedOrigins)
+	settings.AllowedMethods = trimValues(settings.AllowedMethods)
+	settings.AllowedHeaders = trimValues(settings.AllowedHeaders)
+	settings.ExposedHeaders = trimValues(settings.ExposedHeaders)
+
+	if len(settings.AllowedMethods) == 0 {
+		settings.AllowedMethods = defaultCORSAllowedMethods
+	}
+
+	if len(settings.AllowedHeaders) == 0 {
+		settings.AllowedHeaders = defaultCORSAllowedHeaders
+	}
+
+	if settings.MaxAge <= 0 {
+		settings.MaxAge = defaultCORSMaxAge
+	}
+
+	return settings
+}
+
+// isAllowedOrigin matches the origin with the allowed ones, they may contain wildcards, e.g. https://*.reframedapp.com
+func isAllowedOrigin(allowedOrigins []string, origin string) bool {
+	origin = strings.ToLower(origin)
+
+	for _, allowed := range allowedOrigins {
+		if allowed == wildcard {
+			return true
+		}
+
+		if matched, err := path.Match(strings.ToLower(allowed), origin); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAllowedMethod(allowedMethods []string, method string) bool {
+	return slices.ContainsFunc(allowedMethods, func(allowed string) bool {
+		return strings.EqualFold(allowed, method)
+	})
+}
+
+// areAllowedHeaders checks the comma separated headers from Access-Control-Request-Headers
+func areAllowedHeaders(allowedHeaders []string, requestedHeaders string) bool {
+	if slices.Contains(allowedHeaders, wildcard) {
+		return true
+	}
+
+	for _, header := range strings.Split(requestedHeaders, ",") {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+
+		if !slices.ContainsFunc(allowedHeaders, func(allowed string) bool {
+			return strings.EqualFold(allowed, header)
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// trimValues drops the spaces and the empty values left by the comma separated settings
+func trimValues(values []string) []string {
+	var trimmed []string
+
+	for _, value := range values {
+		if value = strings.TrimSuffix(strings.TrimSpace(value), "/"); value != "" {
+			trimmed = append(trimmed, value)
+		}
+	}
+
+	return trimmed
+}
@@ -0,0 +1,87 @@
+// Package security contains the middlewares setting the CORS and the security headers of the responses
+package security
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rshelekhov/reframed/internal/config"
+)
+
+const (
+	defaultFrameOptions          = "DENY"
+	defaultContentSecurityPolicy = "default-src 'none'; frame-ancestors 'none'; base-uri 'none'; form-action 'self'"
+)
+
+// Headers sets the security headers to every response. The Content-Security-Policy is added
+// to the HTML responses only, since it has no effect on the JSON ones
+func Headers(settings config.SecuritySettings) func(http.Handler) http.Handler {
+	frameOptions := settings.FrameOptions
+	if frameOptions == "" {
+		frameOptions = defaultFrameOptions
+	}
+
+	contentSecurityPolicy := settings.ContentSecurityPolicy
+	if contentSecurityPolicy == "" {
+		contentSecurityPolicy = defaultContentSecurityPolicy
+	}
+
+	var strictTransportSecurity string
+	if settings.HSTSMaxAge > 0 {
+		strictTransportSecurity = "max-age=" + strconv.Itoa(int(settings.HSTSMaxAge.Seconds()))
+		if settings.HSTSIncludeSubdomains {
+			strictTransportSecurity += "; includeSubDomains"
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Content-Type-Options", "nosniff")
+			w.Header().Set("X-Frame-Options", frameOptions)
+
+			if strictTransportSecurity != "" {
+				w.Header().Set("Strict-Transport-Security", strictTransportSecurity)
+			}
+
+			next.ServeHTTP(&htmlPolicyWriter{ResponseWriter: w, policy: contentSecurityPolicy}, r)
+		})
+	}
+}
+
+// htmlPolicyWriter adds the Content-Security-Policy header when the response turns out to be HTML
+type htmlPolicyWriter struct {
+	http.ResponseWriter
+	policy      string
+	wroteHeader bool
+}
+
+func (w *htmlPolicyWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+
+		if strings.HasPrefix(w.Header().Get("Content-Type"), "text/html") {
+			w.Header().Set("Content-Security-Policy", w.policy)
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *htmlPolicyWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		// The same sniffing as http.ResponseWriter does for the responses without the content type
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush the response
+func (w *htmlPolicyWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,181 @@
+package security_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/config"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/security"
+)
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestCORS_Preflight(t *testing.T) {
+	handler := security.CORS(config.CORSSettings{
+		AllowedOrigins:   []string{"https://app.reframed.test", "https://*.preview.reframed.test"},
+		AllowCredentials: true,
+	})(okHandler)
+
+	tests := []struct {
+		name        string
+		origin      string
+		method      string
+		headers     string
+		allowOrigin string
+		allowed     bool
+	}{
+		{
+			name:        "allowed origin",
+			origin:      "https://app.reframed.test",
+			method:      http.MethodPatch,
+			headers:     "Authorization, Content-Type, X-CSRF-Token",
+			allowOrigin: "https://app.reframed.test",
+			allowed:     true,
+		},
+		{
+			name:        "wildcard origin",
+			origin:      "https://pr-1.preview.reframed.test",
+			method:      http.MethodDelete,
+			allowOrigin: "https://pr-1.preview.reframed.test",
+			allowed:     true,
+		},
+		{
+			name:   "unknown origin",
+			origin: "https://evil.test",
+			method: http.MethodPost,
+		},
+		{
+			name:        "header not allowed",
+			origin:      "https://app.reframed.test",
+			method:      http.MethodPost,
+			headers:     "X-Unknown",
+			allowOrigin: "https://app.reframed.test",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodOptions, "/user/tasks", nil)
+			r.Header.Set("Origin", tt.origin)
+			r.Header.Set("Access-Control-Request-Method", tt.method)
+			if tt.headers != "" {
+				r.Header.Set("Access-Control-Request-Headers", tt.headers)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != http.StatusNoContent {
+				t.Errorf("expected status %d, got %d", http.StatusNoContent, w.Code)
+			}
+
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.allowOrigin {
+				t.Errorf("expected Access-Control-Allow-Origin %q, got %q", tt.allowOrigin, got)
+			}
+
+			if got := w.Header().Get("Access-Control-Allow-Methods") != ""; got != tt.allowed {
+				t.Errorf("expected the request to be allowed: %v, got %v", tt.allowed, got)
+			}
+
+			if tt.allowed && w.Header().Get("Access-Control-Allow-Credentials") != "true" {
+				t.Error("expected the credentials to be allowed")
+			}
+		})
+	}
+}
+
+func TestCORS_AnyOriginWithoutCredentials(t *testing.T) {
+	handler := security.CORS(config.CORSSettings{
+		AllowedOrigins:   []string{"*"},
+		AllowCredentials: true,
+	})(okHandler)
+
+	r := httptest.NewRequest(http.MethodGet, "/health", nil)
+	r.Header.Set("Origin", "https://any.test")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("expected Access-Control-Allow-Origin *, got %q", got)
+	}
+
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("expected no credentials for any origin, got %q", got)
+	}
+}
+
+func TestCORS_DisabledWithoutOrigins(t *testing.T) {
+	handler := security.CORS(config.CORSSettings{})(okHandler)
+
+	r := httptest.NewRequest(http.MethodGet, "/health", nil)
+	r.Header.Set("Origin", "https://app.reframed.test")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected no CORS headers, got Access-Control-Allow-Origin %q", got)
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	settings := config.SecuritySettings{
+		HSTSMaxAge:            365 * 24 * time.Hour,
+		HSTSIncludeSubdomains: true,
+	}
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		csp     bool
+	}{
+		{
+			name:    "json response",
+			handler: okHandler,
+		},
+		{
+			name: "html response",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.WriteHeader(http.StatusOK)
+			},
+			csp: true,
+		},
+		{
+			name: "sniffed html response",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("<!DOCTYPE html><html></html>"))
+			},
+			csp: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			security.Headers(settings)(tt.handler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			expected := map[string]string{
+				"X-Content-Type-Options":    "nosniff",
+				"X-Frame-Options":           "DENY",
+				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+			}
+
+			for name, value := range expected {
+				if got := w.Header().Get(name); got != value {
+					t.Errorf("expected %s %q, got %q", name, value, got)
+				}
+			}
+
+			if got := w.Header().Get("Content-Security-Policy") != ""; got != tt.csp {
+				t.Errorf("expected Content-Security-Policy to be set: %v, got %v", tt.csp, got)
+			}
+		})
+	}
+}
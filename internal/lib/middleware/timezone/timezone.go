@@ -0,0 +1,85 @@
+package timezone
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+)
+
+const (
+	// Header overrides the user's stored time zone for a single request
+	Header  = "X-Time-Zone"
+	Default = "UTC"
+)
+
+// ctxKey is unexported, so the location can be set only by Detector
+type ctxKey struct{}
+
+var ErrInvalidTimeZone = errors.New("invalid time zone")
+
+// ErrorResponse is rendered with the 400 status when the X-Time-Zone header is not a valid time zone
+type ErrorResponse struct {
+	Error      string    `json:"error"`
+	StatusCode int       `json:"status_code"`
+	Time       time.Time `json:"time"`
+}
+
+// Load returns the location for an IANA time zone name, e.g. Europe/Moscow
+func Load(name string) (*time.Location, error) {
+	// time.LoadLocation treats "" as UTC and "Local" as the server zone,
+	// neither of them is a valid user time zone
+	if name == "" || name == "Local" {
+		return nil, ErrInvalidTimeZone
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidTimeZone
+	}
+
+	return location, nil
+}
+
+// Detector puts the location from the X-Time-Zone header into the request context
+func Detector() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			name := r.Header.Get(Header)
+			if name == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			location, err := Load(name)
+			if err != nil {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, ErrorResponse{
+					Error:      err.Error(),
+					StatusCode: http.StatusBadRequest,
+					Time:       time.Now(),
+				})
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), ctxKey{}, location)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// FromContext returns the location set by Detector
+func FromContext(ctx context.Context) (*time.Location, bool) {
+	location, ok := ctx.Value(ctxKey{}).(*time.Location)
+	return location, ok
+}
+
+// Today returns the current date in the location as midnight UTC,
+// the same way dates from requests are stored
+func Today(location *time.Location) time.Time {
+	year, month, day := time.Now().In(location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,82 @@
+package timezone_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rshelekhov/reframed/internal/lib/middleware/timezone"
+)
+
+func TestDetector(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		status   int
+		location string
+	}{
+		{
+			name:   "no header",
+			status: http.StatusOK,
+		},
+		{
+			name:     "valid time zone",
+			header:   "Europe/Berlin",
+			status:   http.StatusOK,
+			location: "Europe/Berlin",
+		},
+		{
+			name:   "invalid time zone",
+			header: "Mars/Olympus_Mons",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "server time zone",
+			header: "Local",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var location string
+
+			handler := timezone.Detector()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if l, ok := timezone.FromContext(r.Context()); ok {
+					location = l.String()
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/user/tasks/today", nil)
+			if tt.header != "" {
+				req.Header.Set(timezone.Header, tt.header)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+
+			if location != tt.location {
+				t.Errorf("Expected location %q, got %q", tt.location, location)
+			}
+
+			if tt.status != http.StatusBadRequest {
+				return
+			}
+
+			var resp timezone.ErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("Expected a JSON error, got %q: %v", rec.Body.String(), err)
+			}
+
+			if resp.Error != timezone.ErrInvalidTimeZone.Error() || resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("Unexpected error response %+v", resp)
+			}
+		})
+	}
+}
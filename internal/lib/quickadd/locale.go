@@ -0,0 +1,179 @@
+package quickadd
+
+import (
+	"strings"
+	"time"
+)
+
+// locale holds the words the parser recognizes for a language
+type locale struct {
+	relativeDays  map[string]int
+	next          map[string]bool
+	in            map[string]bool
+	dayUnits      map[string]bool
+	weekUnits     map[string]bool
+	fillers       map[string]bool
+	rangeWords    map[string]bool
+	deadlineWords map[string]bool
+	weekdays      map[string]time.Weekday
+	months        map[string]time.Month
+
+	// weekdayMarkers are the fillers after which a bare weekday is a date
+	weekdayMarkers map[string]bool
+
+	// monthFirst is true when numeric dates like 5/12 are written as month/day
+	monthFirst bool
+}
+
+func lookupLocale(tag string) (*locale, error) {
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+
+	lang, region, _ := strings.Cut(tag, "-")
+
+	switch lang {
+	case "", "en":
+		l := newEnglishLocale()
+		l.monthFirst = region == "" || region == "us"
+		return l, nil
+	case "ru":
+		return newRussianLocale(), nil
+	default:
+		return nil, ErrUnsupportedLocale
+	}
+}
+
+func newEnglishLocale() *locale {
+	return &locale{
+		relativeDays: map[string]int{
+			"today":    0,
+			"tonight":  0,
+			"tomorrow": 1,
+			"tmr":      1,
+		},
+		next:          set("next"),
+		in:            set("in"),
+		dayUnits:      set("day", "days"),
+		weekUnits:     set("week", "weeks"),
+		fillers:       set("at", "on", "by"),
+		rangeWords:    set("-", "to", "till", "until"),
+		deadlineWords: set("deadline", "due"),
+		weekdays: map[string]time.Weekday{
+			"monday":    time.Monday,
+			"mon":       time.Monday,
+			"tuesday":   time.Tuesday,
+			"tue":       time.Tuesday,
+			"tues":      time.Tuesday,
+			"wednesday": time.Wednesday,
+			"wed":       time.Wednesday,
+			"thursday":  time.Thursday,
+			"thu":       time.Thursday,
+			"thur":      time.Thursday,
+			"thurs":     time.Thursday,
+			"friday":    time.Friday,
+			"fri":       time.Friday,
+			"saturday":  time.Saturday,
+			"sat":       time.Saturday,
+			"sunday":    time.Sunday,
+			"sun":       time.Sunday,
+		},
+		months: map[string]time.Month{
+			"january":   time.January,
+			"jan":       time.January,
+			"february":  time.February,
+			"feb":       time.February,
+			"march":     time.March,
+			"mar":       time.March,
+			"april":     time.April,
+			"apr":       time.April,
+			"may":       time.May,
+			"june":      time.June,
+			"jun":       time.June,
+			"july":      time.July,
+			"jul":       time.July,
+			"august":    time.August,
+			"aug":       time.August,
+			"september": time.September,
+			"sep":       time.September,
+			"sept":      time.September,
+			"october":   time.October,
+			"oct":       time.October,
+			"november":  time.November,
+			"nov":       time.November,
+			"december":  time.December,
+			"dec":       time.December,
+		},
+		weekdayMarkers: set("on", "by"),
+	}
+}
+
+func newRussianLocale() *locale {
+	return &locale{
+		relativeDays: map[string]int{
+			"сегодня":     0,
+			"завтра":      1,
+			"послезавтра": 2,
+		},
+		next:          set("следующий", "следующая", "следующую", "следующее", "следующей", "след"),
+		in:            set("через"),
+		dayUnits:      set("день", "дня", "дней"),
+		weekUnits:     set("неделю", "недели", "недель"),
+		fillers:       set("в", "во", "к"),
+		rangeWords:    set("-", "до"),
+		deadlineWords: set("дедлайн", "срок"),
+		weekdays: map[string]time.Weekday{
+			"понедельник": time.Monday,
+			"пн":          time.Monday,
+			"вторник":     time.Tuesday,
+			"вт":          time.Tuesday,
+			"среда":       time.Wednesday,
+			"среду":       time.Wednesday,
+			"ср":          time.Wednesday,
+			"четверг":     time.Thursday,
+			"чт":          time.Thursday,
+			"пятница":     time.Friday,
+			"пятницу":     time.Friday,
+			"пт":          time.Friday,
+			"суббота":     time.Saturday,
+			"субботу":     time.Saturday,
+			"сб":          time.Saturday,
+			"воскресенье": time.Sunday,
+			"вс":          time.Sunday,
+		},
+		months: map[string]time.Month{
+			"январь":   time.January,
+			"января":   time.January,
+			"февраль":  time.February,
+			"февраля":  time.February,
+			"март":     time.March,
+			"марта":    time.March,
+			"апрель":   time.April,
+			"апреля":   time.April,
+			"май":      time.May,
+			"мая":      time.May,
+			"июнь":     time.June,
+			"июня":     time.June,
+			"июль":     time.July,
+			"июля":     time.July,
+			"август":   time.August,
+			"августа":  time.August,
+			"сентябрь": time.September,
+			"сентября": time.September,
+			"октябрь":  time.October,
+			"октября":  time.October,
+			"ноябрь":   time.November,
+			"ноября":   time.November,
+			"декабрь":  time.December,
+			"декабря":  time.December,
+		},
+		weekdayMarkers: set("в", "во", "к"),
+		monthFirst:     false,
+	}
+}
+
+func set(words ...string) map[string]bool {
+	m := make(map[string]bool, len(words))
+	for _, w := range words {
+		m[w] = true
+	}
+	return m
+}
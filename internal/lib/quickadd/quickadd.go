@@ -0,0 +1,596 @@
+// Package quickadd parses free-form task descriptions like
+// "Call Anna tomorrow 3pm-4pm #work !deadline friday /Project X"
+// into the fields of a task.
+//
+// Supported syntax:
+//   - #tag adds a tag;
+//   - !deadline <date> (or !<date>) sets the deadline;
+//   - /List or /List/Heading sets the target list and heading. The reference
+//     runs until the next #tag or !deadline marker, or the end of the input;
+//   - the first date (today, tomorrow, on friday, next monday, in 3 days,
+//     may 5, 2024-05-05, 5.05, ...) becomes the start date. A weekday is a date
+//     only after a marker like "on" or "next", so "Review Friday notes" keeps
+//     its title, and a dotted date needs a two-digit month, so "1.5" stays a
+//     number;
+//   - the first time or time range (3pm, 15:30, 3pm-4pm, 9:00-10:30) sets the
+//     start and end time.
+//
+// Everything else becomes the title.
+package quickadd
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyInput        = errors.New("input is empty")
+	ErrEmptyTitle        = errors.New("task title is empty")
+	ErrUnsupportedLocale = errors.New("unsupported locale")
+)
+
+// DefaultDuration is used for the end time when only a start time is given
+const DefaultDuration = time.Hour
+
+const (
+	tagPrefix      = '#'
+	listPrefix     = '/'
+	deadlinePrefix = '!'
+)
+
+// Options configures how relative dates and times are resolved
+type Options struct {
+	// Now is the reference point for relative dates, time.Now() if empty
+	Now time.Time
+	// Location is the user's time zone, UTC if nil
+	Location *time.Location
+	// Locale is a language tag such as "en", "en-GB" or "ru", "en" if empty
+	Locale string
+}
+
+// Result contains the task fields extracted from the input.
+// StartDate and Deadline are calendar dates at midnight UTC, in the same
+// form the JSON API uses for YYYY-MM-DD values. StartTime and EndTime are
+// instants in the user's time zone.
+type Result struct {
+	Title     string
+	StartDate time.Time
+	Deadline  time.Time
+	StartTime time.Time
+	EndTime   time.Time
+	Tags      []string
+	List      string
+	Heading   string
+}
+
+type clock struct {
+	hour   int
+	minute int
+}
+
+type parser struct {
+	loc      *locale
+	now      time.Time
+	location *time.Location
+	tokens   []string
+	pos      int
+
+	title    []string
+	result   Result
+	start    *clock
+	end      *clock
+	hasList  bool
+	seenTags map[string]bool
+}
+
+// Parse extracts task fields from the input
+func Parse(input string, opts Options) (Result, error) {
+	if strings.TrimSpace(input) == "" {
+		return Result{}, ErrEmptyInput
+	}
+
+	loc, err := lookupLocale(opts.Locale)
+	if err != nil {
+		return Result{}, err
+	}
+
+	location := opts.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	p := &parser{
+		loc:      loc,
+		now:      now.In(location),
+		location: location,
+		tokens:   strings.Fields(input),
+		seenTags: make(map[string]bool),
+	}
+
+	p.run()
+
+	return p.finish()
+}
+
+func (p *parser) run() {
+	for p.pos < len(p.tokens) {
+		token := p.tokens[p.pos]
+
+		switch {
+		case len(token) > 1 && token[0] == tagPrefix:
+			p.addTag(token[1:])
+			p.pos++
+		case len(token) > 1 && token[0] == listPrefix && !p.hasList:
+			p.parseListReference()
+		case len(token) > 1 && token[0] == deadlinePrefix && p.parseDeadline():
+		case p.result.StartDate.IsZero() && p.parseStartDate():
+		case p.start == nil && p.parseTime():
+		default:
+			p.title = append(p.title, token)
+			p.pos++
+		}
+	}
+}
+
+func (p *parser) finish() (Result, error) {
+	p.result.Title = strings.TrimSpace(strings.Join(p.title, " "))
+	if p.result.Title == "" {
+		return Result{}, ErrEmptyTitle
+	}
+
+	if p.start != nil {
+		if p.result.StartDate.IsZero() {
+			p.result.StartDate = p.today()
+		}
+
+		year, month, day := p.result.StartDate.Date()
+
+		p.result.StartTime = time.Date(year, month, day, p.start.hour, p.start.minute, 0, 0, p.location)
+
+		if p.end != nil {
+			p.result.EndTime = time.Date(year, month, day, p.end.hour, p.end.minute, 0, 0, p.location)
+			if !p.result.EndTime.After(p.result.StartTime) {
+				// The range goes past midnight
+				p.result.EndTime = p.result.EndTime.AddDate(0, 0, 1)
+			}
+		} else {
+			p.result.EndTime = p.result.StartTime.Add(DefaultDuration)
+		}
+	}
+
+	return p.result, nil
+}
+
+func (p *parser) addTag(raw string) {
+	tag := strings.TrimRight(raw, ".,;:!?")
+	if tag == "" || p.seenTags[tag] {
+		return
+	}
+
+	p.seenTags[tag] = true
+	p.result.Tags = append(p.result.Tags, tag)
+}
+
+// parseListReference reads "/List name/Heading name" up to the next marker
+func (p *parser) parseListReference() {
+	parts := []string{p.tokens[p.pos][1:]}
+	p.pos++
+
+	for p.pos < len(p.tokens) {
+		token := p.tokens[p.pos]
+		if token[0] == tagPrefix || token[0] == deadlinePrefix {
+			break
+		}
+
+		parts = append(parts, token)
+		p.pos++
+	}
+
+	list, heading, _ := strings.Cut(strings.Join(parts, " "), string(listPrefix))
+
+	p.result.List = strings.TrimSpace(list)
+	p.result.Heading = strings.TrimSpace(heading)
+	p.hasList = true
+}
+
+// parseDeadline handles both "!deadline friday" and "!friday"
+func (p *parser) parseDeadline() bool {
+	marker := normalize(p.tokens[p.pos][1:])
+
+	if p.loc.deadlineWords[marker] {
+		date, n, ok := p.matchDate(p.tokens[p.pos+1:], true)
+		if !ok {
+			return false
+		}
+
+		p.result.Deadline = date
+		p.pos += n + 1
+		return true
+	}
+
+	words := append([]string{p.tokens[p.pos][1:]}, p.tokens[p.pos+1:]...)
+
+	date, n, ok := p.matchDate(words, true)
+	if !ok {
+		return false
+	}
+
+	p.result.Deadline = date
+	p.pos += n
+	return true
+}
+
+func (p *parser) parseStartDate() bool {
+	date, n, ok := p.matchDate(p.tokens[p.pos:], false)
+	if !ok {
+		return false
+	}
+
+	p.result.StartDate = date
+	p.pos += n
+	return true
+}
+
+func (p *parser) parseTime() bool {
+	start, end, n, ok := p.matchTime(p.tokens[p.pos:])
+	if !ok {
+		return false
+	}
+
+	p.start = start
+	p.end = end
+	p.pos += n
+	return true
+}
+
+// matchDate returns the date at the beginning of words and the number of words it spans.
+// A bare weekday is matched only when it follows a marker such as "on" or "!deadline",
+// otherwise it is a part of the title.
+func (p *parser) matchDate(words []string, afterMarker bool) (time.Time, int, bool) {
+	if len(words) == 0 {
+		return time.Time{}, 0, false
+	}
+
+	word := normalize(words[0])
+
+	if p.loc.fillers[word] {
+		date, n, ok := p.matchDate(words[1:], afterMarker || p.loc.weekdayMarkers[word])
+		if !ok {
+			return time.Time{}, 0, false
+		}
+		return date, n + 1, true
+	}
+
+	if days, ok := p.loc.relativeDays[word]; ok {
+		return p.today().AddDate(0, 0, days), 1, true
+	}
+
+	if weekday, ok := p.loc.weekdays[word]; ok && afterMarker {
+		return p.upcomingWeekday(weekday), 1, true
+	}
+
+	if p.loc.next[word] && len(words) > 1 {
+		unit := normalize(words[1])
+
+		if weekday, ok := p.loc.weekdays[unit]; ok {
+			return p.nextWeekStart().AddDate(0, 0, isoWeekday(weekday)-1), 2, true
+		}
+		if p.loc.weekUnits[unit] {
+			return p.nextWeekStart(), 2, true
+		}
+	}
+
+	if p.loc.in[word] && len(words) > 2 {
+		count, err := strconv.Atoi(normalize(words[1]))
+		if err == nil && count >= 0 {
+			unit := normalize(words[2])
+
+			if p.loc.dayUnits[unit] {
+				return p.today().AddDate(0, 0, count), 3, true
+			}
+			if p.loc.weekUnits[unit] {
+				return p.today().AddDate(0, 0, 7*count), 3, true
+			}
+		}
+	}
+
+	if date, ok := p.parseNumericDate(word); ok {
+		return date, 1, true
+	}
+
+	if len(words) > 1 {
+		next := normalize(words[1])
+
+		// "may 5"
+		if month, ok := p.loc.months[word]; ok {
+			if day, err := strconv.Atoi(strings.TrimSuffix(next, "th")); err == nil {
+				if date, ok := p.calendarDate(month, day); ok {
+					return date, 2, true
+				}
+			}
+		}
+
+		// "5 may"
+		if month, ok := p.loc.months[next]; ok {
+			if day, err := strconv.Atoi(strings.TrimSuffix(word, "th")); err == nil {
+				if date, ok := p.calendarDate(month, day); ok {
+					return date, 2, true
+				}
+			}
+		}
+	}
+
+	return time.Time{}, 0, false
+}
+
+// parseNumericDate handles 2024-05-05, 5.12, 5.12.2024, 5/12 and 5/12/2024.
+// Decimals like 1.5 and version numbers like 1.5.2 are not dates.
+func (p *parser) parseNumericDate(word string) (time.Time, bool) {
+	if date, err := time.Parse(time.DateOnly, word); err == nil {
+		return date, true
+	}
+
+	var (
+		parts      []string
+		monthFirst bool
+		dotted     bool
+	)
+
+	switch {
+	case strings.Contains(word, "."):
+		parts = strings.Split(word, ".")
+		dotted = true
+	case strings.Contains(word, "/"):
+		parts = strings.Split(word, "/")
+		monthFirst = p.loc.monthFirst
+	default:
+		return time.Time{}, false
+	}
+
+	if len(parts) != 2 && len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	for i, part := range parts {
+		switch {
+		case !isDigits(part):
+			return time.Time{}, false
+		case i < 2 && len(part) > 2:
+			return time.Time{}, false
+		case i == 2 && len(part) != 2 && len(part) != 4:
+			return time.Time{}, false
+		}
+	}
+
+	// "1.5" is a decimal, a dotted date without a year is written as "1.05"
+	if dotted && len(parts) == 2 && len(parts[1]) != 2 {
+		return time.Time{}, false
+	}
+
+	numbers := make([]int, 0, len(parts))
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return time.Time{}, false
+		}
+		numbers = append(numbers, n)
+	}
+
+	day, month := numbers[0], numbers[1]
+	if monthFirst {
+		day, month = month, day
+	}
+
+	if month < 1 || month > 12 {
+		return time.Time{}, false
+	}
+
+	if len(numbers) == 3 {
+		year := numbers[2]
+		if year < 100 {
+			year += 2000
+		}
+
+		date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		if date.Day() != day {
+			return time.Time{}, false
+		}
+		return date, true
+	}
+
+	return p.calendarDate(time.Month(month), day)
+}
+
+// matchTime returns the time or time range at the beginning of words
+func (p *parser) matchTime(words []string) (start, end *clock, n int, ok bool) {
+	if len(words) == 0 {
+		return nil, nil, 0, false
+	}
+
+	word := normalize(words[0])
+
+	if p.loc.fillers[word] {
+		start, end, n, ok = p.matchTime(words[1:])
+		if !ok {
+			return nil, nil, 0, false
+		}
+		return start, end, n + 1, true
+	}
+
+	// "3pm-4pm" in a single word
+	if from, to, found := strings.Cut(word, "-"); found {
+		start, end, ok = parseClockRange(from, to)
+		if !ok {
+			return nil, nil, 0, false
+		}
+		return start, end, 1, true
+	}
+
+	// "3pm - 4pm" or "3pm to 4pm"
+	if len(words) > 2 && p.loc.rangeWords[normalize(words[1])] {
+		if start, end, ok = parseClockRange(word, normalize(words[2])); ok {
+			return start, end, 3, true
+		}
+	}
+
+	start, ok = parseClock(word, "")
+	if !ok {
+		return nil, nil, 0, false
+	}
+
+	return start, nil, 1, true
+}
+
+func parseClockRange(from, to string) (start, end *clock, ok bool) {
+	end, ok = parseClock(to, "")
+	if !ok {
+		return nil, nil, false
+	}
+
+	// "3-4pm" takes the meridiem from the end of the range
+	meridiem := ""
+	if strings.HasSuffix(to, "am") || strings.HasSuffix(to, "pm") {
+		meridiem = to[len(to)-2:]
+	}
+
+	start, ok = parseClock(from, meridiem)
+	if !ok {
+		return nil, nil, false
+	}
+
+	if meridiem == "pm" && !hasMeridiem(from) && start.hour > end.hour {
+		// "11-1pm" starts in the morning
+		start.hour -= 12
+	}
+
+	return start, end, true
+}
+
+// parseClock parses 3pm, 3:30pm and 15:30. Bare numbers are accepted only
+// when the meridiem is inherited from the end of a range.
+func parseClock(word, inheritedMeridiem string) (*clock, bool) {
+	meridiem := ""
+
+	switch {
+	case strings.HasSuffix(word, "am"), strings.HasSuffix(word, "pm"):
+		meridiem = word[len(word)-2:]
+		word = word[:len(word)-2]
+	case inheritedMeridiem != "" && !strings.Contains(word, ":"):
+		meridiem = inheritedMeridiem
+	case !strings.Contains(word, ":"):
+		return nil, false
+	}
+
+	hourPart, minutePart, hasMinutes := strings.Cut(word, ":")
+
+	hour, err := strconv.Atoi(hourPart)
+	if err != nil {
+		return nil, false
+	}
+
+	minute := 0
+	if hasMinutes {
+		if len(minutePart) != 2 {
+			return nil, false
+		}
+
+		minute, err = strconv.Atoi(minutePart)
+		if err != nil || minute > 59 {
+			return nil, false
+		}
+	}
+
+	switch meridiem {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return nil, false
+		}
+		if hour == 12 {
+			hour = 0
+		}
+		if meridiem == "pm" {
+			hour += 12
+		}
+	default:
+		if hour < 0 || hour > 23 {
+			return nil, false
+		}
+	}
+
+	return &clock{hour: hour, minute: minute}, true
+}
+
+func hasMeridiem(word string) bool {
+	return strings.HasSuffix(word, "am") || strings.HasSuffix(word, "pm")
+}
+
+// today returns the current date in the user's time zone
+func (p *parser) today() time.Time {
+	year, month, day := p.now.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// upcomingWeekday returns the nearest weekday after today
+func (p *parser) upcomingWeekday(weekday time.Weekday) time.Time {
+	today := p.today()
+
+	days := (int(weekday) - int(today.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+
+	return today.AddDate(0, 0, days)
+}
+
+// nextWeekStart returns Monday of the next week
+func (p *parser) nextWeekStart() time.Time {
+	today := p.today()
+	return today.AddDate(0, 0, 8-isoWeekday(today.Weekday()))
+}
+
+// calendarDate returns the nearest date with the given month and day that is not in the past
+func (p *parser) calendarDate(month time.Month, day int) (time.Time, bool) {
+	today := p.today()
+
+	date := time.Date(today.Year(), month, day, 0, 0, 0, 0, time.UTC)
+	if date.Month() != month {
+		return time.Time{}, false
+	}
+
+	if date.Before(today) {
+		date = date.AddDate(1, 0, 0)
+	}
+
+	return date, true
+}
+
+func isoWeekday(weekday time.Weekday) int {
+	if weekday == time.Sunday {
+		return 7
+	}
+	return int(weekday)
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func normalize(word string) string {
+	return strings.TrimRight(strings.ToLower(word), ",;!?")
+}
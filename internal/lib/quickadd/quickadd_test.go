@@ -0,0 +1,298 @@
+package quickadd_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/lib/quickadd"
+)
+
+var (
+	// Wednesday, 15 May 2024
+	location = time.FixedZone("UTC+3", 3*60*60)
+	now      = time.Date(2024, time.May, 15, 10, 0, 0, 0, location)
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func at(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, location)
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		locale   string
+		expected quickadd.Result
+	}{
+		{
+			name:  "Full example",
+			input: "Call Anna tomorrow 3pm-4pm #work !deadline friday /Project X",
+			expected: quickadd.Result{
+				Title:     "Call Anna",
+				StartDate: date(2024, time.May, 16),
+				Deadline:  date(2024, time.May, 17),
+				StartTime: at(2024, time.May, 16, 15, 0),
+				EndTime:   at(2024, time.May, 16, 16, 0),
+				Tags:      []string{"work"},
+				List:      "Project X",
+			},
+		},
+		{
+			name:     "Title only",
+			input:    "Buy milk",
+			expected: quickadd.Result{Title: "Buy milk"},
+		},
+		{
+			name:  "List with heading",
+			input: "Write report /Work/Q2 reports #docs",
+			expected: quickadd.Result{
+				Title:   "Write report",
+				Tags:    []string{"docs"},
+				List:    "Work",
+				Heading: "Q2 reports",
+			},
+		},
+		{
+			name:  "Duplicate tags and punctuation",
+			input: "Review PR #work, #work #code",
+			expected: quickadd.Result{
+				Title: "Review PR",
+				Tags:  []string{"work", "code"},
+			},
+		},
+		{
+			name:  "Deadline shorthand",
+			input: "Pay rent !monday",
+			expected: quickadd.Result{
+				Title:    "Pay rent",
+				Deadline: date(2024, time.May, 20),
+			},
+		},
+		{
+			name:  "Weekday is never today",
+			input: "Standup on wednesday",
+			expected: quickadd.Result{
+				Title:     "Standup",
+				StartDate: date(2024, time.May, 22),
+			},
+		},
+		{
+			name:  "Next weekday",
+			input: "Plan sprint next tuesday",
+			expected: quickadd.Result{
+				Title:     "Plan sprint",
+				StartDate: date(2024, time.May, 21),
+			},
+		},
+		{
+			name:  "Next week",
+			input: "Clean garage next week",
+			expected: quickadd.Result{
+				Title:     "Clean garage",
+				StartDate: date(2024, time.May, 20),
+			},
+		},
+		{
+			name:  "In days",
+			input: "Water plants in 3 days",
+			expected: quickadd.Result{
+				Title:     "Water plants",
+				StartDate: date(2024, time.May, 18),
+			},
+		},
+		{
+			name:  "Month and day in the past moves to next year",
+			input: "Renew passport on jan 10",
+			expected: quickadd.Result{
+				Title:     "Renew passport",
+				StartDate: date(2025, time.January, 10),
+			},
+		},
+		{
+			name:  "ISO date",
+			input: "Flight 2024-06-01",
+			expected: quickadd.Result{
+				Title:     "Flight",
+				StartDate: date(2024, time.June, 1),
+			},
+		},
+		{
+			name:   "Day first numeric date",
+			input:  "Dentist 5/6",
+			locale: "en-GB",
+			expected: quickadd.Result{
+				Title:     "Dentist",
+				StartDate: date(2024, time.June, 5),
+			},
+		},
+		{
+			name:  "Month first numeric date",
+			input: "Dentist 6/5",
+			expected: quickadd.Result{
+				Title:     "Dentist",
+				StartDate: date(2024, time.June, 5),
+			},
+		},
+		{
+			name:  "Dotted date",
+			input: "Dentist 5.06",
+			expected: quickadd.Result{
+				Title:     "Dentist",
+				StartDate: date(2024, time.June, 5),
+			},
+		},
+		{
+			name:  "Decimal stays in title",
+			input: "Buy 1.5 liters of milk",
+			expected: quickadd.Result{
+				Title: "Buy 1.5 liters of milk",
+			},
+		},
+		{
+			name:  "Version stays in title",
+			input: "Release 1.5.2",
+			expected: quickadd.Result{
+				Title: "Release 1.5.2",
+			},
+		},
+		{
+			name:  "Weekday without marker stays in title",
+			input: "Review Friday notes",
+			expected: quickadd.Result{
+				Title: "Review Friday notes",
+			},
+		},
+		{
+			name:  "Time without date is today",
+			input: "Lunch at 12:30",
+			expected: quickadd.Result{
+				Title:     "Lunch",
+				StartDate: date(2024, time.May, 15),
+				StartTime: at(2024, time.May, 15, 12, 30),
+				EndTime:   at(2024, time.May, 15, 13, 30),
+			},
+		},
+		{
+			name:  "Range inherits meridiem",
+			input: "Workshop on friday 3-5pm",
+			expected: quickadd.Result{
+				Title:     "Workshop",
+				StartDate: date(2024, time.May, 17),
+				StartTime: at(2024, time.May, 17, 15, 0),
+				EndTime:   at(2024, time.May, 17, 17, 0),
+			},
+		},
+		{
+			name:  "Range across words goes past midnight",
+			input: "Party on saturday 10pm to 1am",
+			expected: quickadd.Result{
+				Title:     "Party",
+				StartDate: date(2024, time.May, 18),
+				StartTime: at(2024, time.May, 18, 22, 0),
+				EndTime:   at(2024, time.May, 19, 1, 0),
+			},
+		},
+		{
+			name:  "Bare numbers stay in title",
+			input: "Read 20 pages",
+			expected: quickadd.Result{
+				Title: "Read 20 pages",
+			},
+		},
+		{
+			name:   "Russian",
+			input:  "Позвонить Анне завтра в 15:00-16:00 #работа !дедлайн пятница /Проект",
+			locale: "ru",
+			expected: quickadd.Result{
+				Title:     "Позвонить Анне",
+				StartDate: date(2024, time.May, 16),
+				Deadline:  date(2024, time.May, 17),
+				StartTime: at(2024, time.May, 16, 15, 0),
+				EndTime:   at(2024, time.May, 16, 16, 0),
+				Tags:      []string{"работа"},
+				List:      "Проект",
+			},
+		},
+		{
+			name:   "Russian day and month",
+			input:  "Отпуск 1 июня",
+			locale: "ru_RU",
+			expected: quickadd.Result{
+				Title:     "Отпуск",
+				StartDate: date(2024, time.June, 1),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := quickadd.Parse(tc.input, quickadd.Options{
+				Now:      now,
+				Location: location,
+				Locale:   tc.locale,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestParse_TimeZone(t *testing.T) {
+	// 23:30 UTC is already the next day in UTC+3
+	utcNow := time.Date(2024, time.May, 15, 23, 30, 0, 0, time.UTC)
+
+	result, err := quickadd.Parse("Gym today", quickadd.Options{Now: utcNow, Location: location})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if expected := date(2024, time.May, 16); !result.StartDate.Equal(expected) {
+		t.Errorf("Expected start date %v, got %v", expected, result.StartDate)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		locale   string
+		expected error
+	}{
+		{
+			name:     "Empty input",
+			input:    "   ",
+			expected: quickadd.ErrEmptyInput,
+		},
+		{
+			name:     "Empty title",
+			input:    "tomorrow 3pm #work",
+			expected: quickadd.ErrEmptyTitle,
+		},
+		{
+			name:     "Unsupported locale",
+			input:    "Buy milk",
+			locale:   "xx",
+			expected: quickadd.ErrUnsupportedLocale,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := quickadd.Parse(tc.input, quickadd.Options{Now: now, Locale: tc.locale})
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("Expected error %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Package schedule places tasks into the free time of a working day.
+//
+// Tasks are placed one by one into the earliest free slot that is long enough
+// for their estimate, tasks are never split. The order of placement is:
+//   - tasks due by the end of the working day (including overdue ones),
+//     earliest deadline first;
+//   - then by priority, a lower rank goes first;
+//   - then by deadline, tasks without a deadline go last;
+//   - then in the order they were given.
+package schedule
+
+import (
+	"sort"
+	"time"
+)
+
+// Reasons why a task wasn't placed
+const (
+	ReasonNoFreeSlot   = "no free slot long enough for the task"
+	ReasonMissDeadline = "no free slot before the deadline"
+)
+
+// NoRank is the rank of the tasks without priority, they go after the ranked ones
+const NoRank = int(^uint(0) >> 1)
+
+// Task is a task that has to be placed
+type Task struct {
+	ID       string
+	Estimate time.Duration
+	// Deadline is the instant by which the task must be finished, zero if not set
+	Deadline time.Time
+	// Rank is the priority of the task, a lower rank is placed first
+	Rank int
+}
+
+// Interval is the time that is already taken
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Slot is the time proposed for a task
+type Slot struct {
+	TaskID string
+	Start  time.Time
+	End    time.Time
+}
+
+// Unplaced is a task that didn't fit into the day
+type Unplaced struct {
+	TaskID string
+	Reason string
+}
+
+type Result struct {
+	Slots    []Slot
+	Unplaced []Unplaced
+}
+
+// Plan places the tasks into the time between from and to that isn't taken by the busy intervals
+func Plan(from, to time.Time, busy []Interval, tasks []Task) Result {
+	free := freeIntervals(from, to, busy)
+
+	ordered := make([]Task, len(tasks))
+	copy(ordered, tasks)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return less(ordered[i], ordered[j], to)
+	})
+
+	var result Result
+
+	for _, task := range ordered {
+		index, reason := findSlot(free, task, from)
+		if reason != "" {
+			result.Unplaced = append(result.Unplaced, Unplaced{
+				TaskID: task.ID,
+				Reason: reason,
+			})
+			continue
+		}
+
+		start := free[index].Start
+		end := start.Add(task.Estimate)
+
+		result.Slots = append(result.Slots, Slot{
+			TaskID: task.ID,
+			Start:  start,
+			End:    end,
+		})
+
+		free[index].Start = end
+	}
+
+	return result
+}
+
+// less reports whether task a should be placed before task b
+func less(a, b Task, dayEnd time.Time) bool {
+	aDue := dueBy(a, dayEnd)
+	bDue := dueBy(b, dayEnd)
+
+	switch {
+	case aDue != bDue:
+		return aDue
+	case aDue && !a.Deadline.Equal(b.Deadline):
+		return a.Deadline.Before(b.Deadline)
+	case a.Rank != b.Rank:
+		return a.Rank < b.Rank
+	case a.Deadline.IsZero() != b.Deadline.IsZero():
+		return !a.Deadline.IsZero()
+	default:
+		return a.Deadline.Before(b.Deadline)
+	}
+}
+
+func dueBy(task Task, instant time.Time) bool {
+	return !task.Deadline.IsZero() && !task.Deadline.After(instant)
+}
+
+// findSlot returns the index of the first free interval that fits the task.
+// An overdue task is placed as early as possible, its deadline can't be met anyway
+func findSlot(free []Interval, task Task, from time.Time) (int, string) {
+	checkDeadline := !task.Deadline.IsZero() && task.Deadline.After(from)
+	reason := ReasonNoFreeSlot
+
+	for i, interval := range free {
+		if interval.End.Sub(interval.Start) < task.Estimate {
+			continue
+		}
+
+		if checkDeadline && interval.Start.Add(task.Estimate).After(task.Deadline) {
+			reason = ReasonMissDeadline
+			break
+		}
+
+		return i, ""
+	}
+
+	return 0, reason
+}
+
+// freeIntervals returns the parts of [from, to) that don't overlap with the busy intervals
+func freeIntervals(from, to time.Time, busy []Interval) []Interval {
+	sorted := make([]Interval, len(busy))
+	copy(sorted, busy)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	var free []Interval
+
+	cursor := from
+	for _, interval := range sorted {
+		if !interval.End.After(cursor) {
+			continue
+		}
+		if !interval.Start.Before(to) {
+			break
+		}
+		if interval.Start.After(cursor) {
+			free = append(free, Interval{Start: cursor, End: interval.Start})
+		}
+		cursor = interval.End
+	}
+
+	if cursor.Before(to) {
+		free = append(free, Interval{Start: cursor, End: to})
+	}
+
+	return free
+}
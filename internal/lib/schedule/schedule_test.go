@@ -0,0 +1,155 @@
+package schedule_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/lib/schedule"
+)
+
+var day = time.Date(2024, time.May, 15, 0, 0, 0, 0, time.UTC)
+
+func at(hour, minute int) time.Time {
+	return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+}
+
+func TestPlan(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from     time.Time
+		to       time.Time
+		busy     []schedule.Interval
+		tasks    []schedule.Task
+		expected schedule.Result
+	}{
+		{
+			name: "Empty day",
+			from: at(9, 0),
+			to:   at(18, 0),
+			tasks: []schedule.Task{
+				{ID: "a", Estimate: time.Hour, Rank: schedule.NoRank},
+				{ID: "b", Estimate: 30 * time.Minute, Rank: schedule.NoRank},
+			},
+			expected: schedule.Result{
+				Slots: []schedule.Slot{
+					{TaskID: "a", Start: at(9, 0), End: at(10, 0)},
+					{TaskID: "b", Start: at(10, 0), End: at(10, 30)},
+				},
+			},
+		},
+		{
+			name: "Around planned tasks",
+			from: at(9, 0),
+			to:   at(18, 0),
+			busy: []schedule.Interval{
+				{Start: at(9, 30), End: at(11, 0)},
+				{Start: at(8, 0), End: at(9, 15)},
+			},
+			tasks: []schedule.Task{
+				{ID: "a", Estimate: time.Hour, Rank: schedule.NoRank},
+				{ID: "b", Estimate: 15 * time.Minute, Rank: schedule.NoRank},
+			},
+			expected: schedule.Result{
+				Slots: []schedule.Slot{
+					{TaskID: "a", Start: at(11, 0), End: at(12, 0)},
+					{TaskID: "b", Start: at(9, 15), End: at(9, 30)},
+				},
+			},
+		},
+		{
+			name: "Priority",
+			from: at(9, 0),
+			to:   at(18, 0),
+			tasks: []schedule.Task{
+				{ID: "a", Estimate: time.Hour, Rank: schedule.NoRank},
+				{ID: "b", Estimate: time.Hour, Rank: 1},
+				{ID: "c", Estimate: time.Hour, Rank: 0},
+			},
+			expected: schedule.Result{
+				Slots: []schedule.Slot{
+					{TaskID: "c", Start: at(9, 0), End: at(10, 0)},
+					{TaskID: "b", Start: at(10, 0), End: at(11, 0)},
+					{TaskID: "a", Start: at(11, 0), End: at(12, 0)},
+				},
+			},
+		},
+		{
+			name: "Due today goes before priority",
+			from: at(9, 0),
+			to:   at(18, 0),
+			tasks: []schedule.Task{
+				{ID: "a", Estimate: time.Hour, Rank: 0},
+				{ID: "b", Estimate: time.Hour, Rank: schedule.NoRank, Deadline: at(24, 0)},
+				{ID: "c", Estimate: time.Hour, Rank: schedule.NoRank, Deadline: at(12, 0)},
+			},
+			expected: schedule.Result{
+				Slots: []schedule.Slot{
+					{TaskID: "c", Start: at(9, 0), End: at(10, 0)},
+					{TaskID: "a", Start: at(10, 0), End: at(11, 0)},
+					{TaskID: "b", Start: at(11, 0), End: at(12, 0)},
+				},
+			},
+		},
+		{
+			name: "Doesn't fit",
+			from: at(9, 0),
+			to:   at(12, 0),
+			busy: []schedule.Interval{
+				{Start: at(10, 0), End: at(11, 0)},
+			},
+			tasks: []schedule.Task{
+				{ID: "a", Estimate: 90 * time.Minute, Rank: schedule.NoRank},
+				{ID: "b", Estimate: time.Hour, Rank: schedule.NoRank},
+			},
+			expected: schedule.Result{
+				Slots: []schedule.Slot{
+					{TaskID: "b", Start: at(9, 0), End: at(10, 0)},
+				},
+				Unplaced: []schedule.Unplaced{
+					{TaskID: "a", Reason: schedule.ReasonNoFreeSlot},
+				},
+			},
+		},
+		{
+			name: "Misses deadline",
+			from: at(9, 0),
+			to:   at(18, 0),
+			busy: []schedule.Interval{
+				{Start: at(9, 0), End: at(11, 0)},
+			},
+			tasks: []schedule.Task{
+				{ID: "a", Estimate: time.Hour, Rank: schedule.NoRank, Deadline: at(11, 30)},
+			},
+			expected: schedule.Result{
+				Unplaced: []schedule.Unplaced{
+					{TaskID: "a", Reason: schedule.ReasonMissDeadline},
+				},
+			},
+		},
+		{
+			name: "Overdue task goes first",
+			from: at(9, 0),
+			to:   at(18, 0),
+			tasks: []schedule.Task{
+				{ID: "a", Estimate: time.Hour, Rank: 0},
+				{ID: "b", Estimate: time.Hour, Rank: schedule.NoRank, Deadline: day.AddDate(0, 0, -1)},
+			},
+			expected: schedule.Result{
+				Slots: []schedule.Slot{
+					{TaskID: "b", Start: at(9, 0), End: at(10, 0)},
+					{TaskID: "a", Start: at(10, 0), End: at(11, 0)},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := schedule.Plan(tc.from, tc.to, tc.busy, tc.tasks)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("got %+v, expected %+v", result, tc.expected)
+			}
+		})
+	}
+}
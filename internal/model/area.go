@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+)
+
+// Area DB model
+type (
+	Area struct {
+		ID            string    `db:"id"`
+		Title         string    `db:"title"`
+		DefaultListID string    `db:"default_list_id"`
+		UserID        string    `db:"user_id"`
+		CreatedAt     time.Time `db:"created_at"`
+		UpdatedAt     time.Time `db:"updated_at"`
+		DeletedAt     time.Time `db:"deleted_at"`
+	}
+
+	AreaRequestData struct {
+		ID     string `json:"area_id"`
+		Title  string `json:"title" validate:"required"`
+		UserID string `json:"user_id"`
+	}
+
+	AreaMoveRequestData struct {
+		ID       string `json:"area_id"`
+		Position int    `json:"position" validate:"min=0"`
+		UserID   string `json:"user_id"`
+	}
+
+	// AreaDeleteRequestData deletes the area with its lists archived,
+	// or with its lists moved to the top level
+	AreaDeleteRequestData struct {
+		ID     string
+		Lists  AreaListsAction
+		UserID string
+	}
+
+	// AreaResponseData keeps the tasks added directly to the area in the default list
+	AreaResponseData struct {
+		ID            string             `json:"area_id,omitempty"`
+		Title         string             `json:"title,omitempty"`
+		DefaultListID string             `json:"default_list_id,omitempty"`
+		Lists         []ListResponseData `json:"lists,omitempty"`
+		UpdatedAt     time.Time          `json:"updated_at,omitempty"`
+	}
+)
+
+type AreaListsAction string
+
+const (
+	// AreaListsMove moves the lists of the deleted area to the top level,
+	// the tasks added directly to the area are kept in a list titled by the area
+	AreaListsMove AreaListsAction = "move"
+
+	// AreaListsArchive deletes the lists of the deleted area and archives their tasks
+	AreaListsArchive AreaListsAction = "archive"
+)
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+type (
+	// DigestSettings DB model, the send time is the local time of the user in the HH:MM format
+	DigestSettings struct {
+		UserID           string       `db:"user_id"`
+		Email            string       `db:"email"`
+		Daily            bool         `db:"daily"`
+		Weekly           bool         `db:"weekly"`
+		SendTime         string       `db:"send_time"`
+		WeeklyDay        time.Weekday `db:"weekly_day"`
+		UnsubscribeToken string       `db:"unsubscribe_token"`
+		DailySentAt      time.Time    `db:"daily_sent_at"`
+		WeeklySentAt     time.Time    `db:"weekly_sent_at"`
+		CreatedAt        time.Time    `db:"created_at"`
+		UpdatedAt        time.Time    `db:"updated_at"`
+	}
+
+	// DigestSettingsRequestData changes only the fields that are set,
+	// the email is taken from the access token of the user
+	DigestSettingsRequestData struct {
+		Daily     *bool  `json:"daily"`
+		Weekly    *bool  `json:"weekly"`
+		SendTime  string `json:"send_time" validate:"omitempty,datetime=15:04"`
+		WeeklyDay *int   `json:"weekly_day" validate:"omitempty,min=0,max=6"`
+		Email     string `json:"-"`
+		UserID    string `json:"user_id"`
+	}
+
+	DigestSettingsResponseData struct {
+		Email     string    `json:"email,omitempty"`
+		Daily     bool      `json:"daily"`
+		Weekly    bool      `json:"weekly"`
+		SendTime  string    `json:"send_time"`
+		WeeklyDay int       `json:"weekly_day"`
+		UpdatedAt time.Time `json:"updated_at,omitempty"`
+	}
+)
+
+// Defaults of the digest settings, the weekly review is sent on Mondays at 8 AM
+const (
+	DefaultDigestSendTime  = "08:00"
+	DefaultDigestWeeklyDay = time.Monday
+	DigestSendTimeLayout   = "15:04"
+)
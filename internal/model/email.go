@@ -0,0 +1,11 @@
+package model
+
+// Email is sent to a single recipient by the mailer, the HTML body is optional.
+// Headers are added to the message as they are, e.g. List-Unsubscribe
+type Email struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+	Headers map[string]string
+}
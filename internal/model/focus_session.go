@@ -0,0 +1,91 @@
+package model
+
+import (
+	"time"
+)
+
+// FocusSession DB model
+type (
+	FocusSession struct {
+		ID              string             `db:"id"`
+		TaskID          string             `db:"task_id"`
+		UserID          string             `db:"user_id"`
+		DurationMinutes int                `db:"duration_minutes"`
+		Status          FocusSessionStatus `db:"status"`
+		StartedAt       time.Time          `db:"started_at"`
+		EndsAt          time.Time          `db:"ends_at"`
+		PausedAt        time.Time          `db:"paused_at"`
+		FinishedAt      time.Time          `db:"finished_at"`
+		CreatedAt       time.Time          `db:"created_at"`
+		UpdatedAt       time.Time          `db:"updated_at"`
+	}
+
+	FocusSessionEvent struct {
+		SessionID string                `db:"session_id"`
+		UserID    string                `db:"user_id"`
+		Event     FocusSessionEventName `db:"event"`
+		CreatedAt time.Time             `db:"created_at"`
+	}
+
+	FocusSessionRequestData struct {
+		ID              string `json:"focus_session_id"`
+		TaskID          string `json:"task_id"`
+		DurationMinutes int    `json:"duration_minutes" validate:"required,min=1,max=240"`
+		UserID          string `json:"user_id"`
+	}
+
+	FocusSessionResponseData struct {
+		ID               string                      `json:"focus_session_id,omitempty"`
+		TaskID           string                      `json:"task_id,omitempty"`
+		UserID           string                      `json:"user_id,omitempty"`
+		DurationMinutes  int                         `json:"duration_minutes,omitempty"`
+		Status           FocusSessionStatus          `json:"status,omitempty"`
+		RemainingSeconds int                         `json:"remaining_seconds"`
+		StartedAt        time.Time                   `json:"started_at,omitempty"`
+		EndsAt           time.Time                   `json:"ends_at,omitempty"`
+		PausedAt         time.Time                   `json:"paused_at,omitempty"`
+		FinishedAt       time.Time                   `json:"finished_at,omitempty"`
+		Events           []FocusSessionEventResponse `json:"events,omitempty"`
+		UpdatedAt        time.Time                   `json:"updated_at,omitempty"`
+	}
+
+	FocusSessionEventResponse struct {
+		Event     FocusSessionEventName `json:"event"`
+		CreatedAt time.Time             `json:"created_at"`
+	}
+
+	// FocusSessionsSummary counts the sessions started on a day
+	FocusSessionsSummary struct {
+		Completed      int                       `json:"completed"`
+		Abandoned      int                       `json:"abandoned"`
+		FocusedMinutes int                       `json:"focused_minutes"`
+		Active         *FocusSessionResponseData `json:"active,omitempty"`
+	}
+)
+
+type FocusSessionStatus string
+
+const (
+	FocusSessionRunning   FocusSessionStatus = "running"
+	FocusSessionPaused    FocusSessionStatus = "paused"
+	FocusSessionCompleted FocusSessionStatus = "completed"
+	FocusSessionAbandoned FocusSessionStatus = "abandoned"
+)
+
+func (s FocusSessionStatus) String() string {
+	return string(s)
+}
+
+type FocusSessionEventName string
+
+const (
+	FocusEventStarted   FocusSessionEventName = "started"
+	FocusEventPaused    FocusSessionEventName = "paused"
+	FocusEventResumed   FocusSessionEventName = "resumed"
+	FocusEventCompleted FocusSessionEventName = "completed"
+	FocusEventAbandoned FocusSessionEventName = "abandoned"
+)
+
+func (e FocusSessionEventName) String() string {
+	return string(e)
+}
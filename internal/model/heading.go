@@ -7,14 +7,15 @@ import (
 // Heading DB model
 type (
 	Heading struct {
-		ID        string    `db:"id"`
-		Title     string    `db:"title"`
-		ListID    string    `db:"list_id"`
-		UserID    string    `db:"user_id"`
-		IsDefault bool      `db:"is_default"`
-		CreatedAt time.Time `db:"created_at"`
-		UpdatedAt time.Time `db:"updated_at"`
-		DeletedAt time.Time `db:"deleted_at"`
+		ID          string    `db:"id"`
+		Title       string    `db:"title"`
+		ListID      string    `db:"list_id"`
+		UserID      string    `db:"user_id"`
+		IsDefault   bool      `db:"is_default"`
+		CompletedAt time.Time `db:"completed_at"`
+		CreatedAt   time.Time `db:"created_at"`
+		UpdatedAt   time.Time `db:"updated_at"`
+		DeletedAt   time.Time `db:"deleted_at"`
 	}
 
 	HeadingRequestData struct {
@@ -24,16 +25,35 @@ type (
 		UserID string `json:"user_id"`
 	}
 
+	// HeadingCompleteRequestData completes the heading with its open tasks completed or cancelled
+	HeadingCompleteRequestData struct {
+		ID     string
+		ListID string
+		Tasks  HeadingTasksAction
+		UserID string
+	}
+
 	HeadingResponseData struct {
-		ID        string    `json:"heading_id,omitempty"`
-		Title     string    `json:"title,omitempty"`
-		ListID    string    `json:"list_id,omitempty"`
-		UserID    string    `json:"user_id,omitempty"`
-		CreatedAt time.Time `json:"created_at,omitempty"`
-		UpdatedAt time.Time `json:"updated_at,omitempty"`
+		ID          string    `json:"heading_id,omitempty"`
+		Title       string    `json:"title,omitempty"`
+		ListID      string    `json:"list_id,omitempty"`
+		UserID      string    `json:"user_id,omitempty"`
+		CompletedAt time.Time `json:"completed_at,omitempty"`
+		CreatedAt   time.Time `json:"created_at,omitempty"`
+		UpdatedAt   time.Time `json:"updated_at,omitempty"`
 	}
 )
 
+type HeadingTasksAction string
+
+const (
+	// HeadingTasksComplete marks the open tasks of the completed heading as completed
+	HeadingTasksComplete HeadingTasksAction = "complete"
+
+	// HeadingTasksCancel archives the open tasks of the completed heading
+	HeadingTasksCancel HeadingTasksAction = "cancel"
+)
+
 type headingTitle string
 
 func (t headingTitle) String() string {
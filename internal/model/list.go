@@ -7,27 +7,82 @@ import (
 // List DB model
 type (
 	List struct {
-		ID        string    `db:"id"`
-		Title     string    `db:"title"`
-		UserID    string    `db:"user_id"`
-		IsDefault bool      `db:"is_default"`
-		CreatedAt time.Time `db:"created_at"`
-		UpdatedAt time.Time `db:"updated_at"`
-		DeletedAt time.Time `db:"deleted_at"`
+		ID          string    `db:"id"`
+		Title       string    `db:"title"`
+		Description string    `db:"description"`
+		Deadline    time.Time `db:"deadline"`
+		Color       string    `db:"color"`
+		Icon        string    `db:"icon"`
+		UserID      string    `db:"user_id"`
+		IsDefault   bool      `db:"is_default"`
+		AreaID      string    `db:"area_id"`
+		CreatedAt   time.Time `db:"created_at"`
+		UpdatedAt   time.Time `db:"updated_at"`
+		DeletedAt   time.Time `db:"deleted_at"`
+
+		TotalTasks     int
+		CompletedTasks int
 	}
 
 	ListRequestData struct {
-		ID     string `json:"list_id"`
-		Title  string `json:"title" validate:"required"`
-		UserID string `json:"user_id"`
+		ID          string `json:"list_id"`
+		Title       string `json:"title" validate:"required"`
+		Description string `json:"description"`
+		Deadline    string `json:"deadline"`
+		Color       string `json:"color" validate:"omitempty,hexcolor"`
+		Icon        string `json:"icon"`
+		AreaID      string `json:"area_id"`
+		UserID      string `json:"user_id"`
+
+		// Clear lists the optional fields the update removes from the list
+		Clear []ListField `json:"clear" validate:"dive,oneof=description deadline color icon"`
+
+		DeadlineParsed time.Time
+	}
+
+	// ListDemoteRequestData turns the list into a heading of the target list
+	ListDemoteRequestData struct {
+		ID           string `json:"list_id"`
+		TargetListID string `json:"target_list_id"`
+		UserID       string `json:"user_id"`
+	}
+
+	// ListMoveRequestData moves the list into the area at the position,
+	// an empty AreaID moves it to the top level
+	ListMoveRequestData struct {
+		ID       string `json:"list_id"`
+		AreaID   string `json:"area_id"`
+		Position int    `json:"position" validate:"min=0"`
+		UserID   string `json:"user_id"`
 	}
 
 	ListResponseData struct {
-		ID        string    `json:"list_id,omitempty"`
-		Title     string    `json:"title,omitempty"`
-		UserID    string    `json:"user_id,omitempty"`
-		CreatedAt time.Time `json:"created_at,omitempty"`
-		UpdatedAt time.Time `json:"updated_at,omitempty"`
+		ID          string        `json:"list_id,omitempty"`
+		Title       string        `json:"title,omitempty"`
+		Description string        `json:"description,omitempty"`
+		Deadline    time.Time     `json:"deadline,omitempty"`
+		Color       string        `json:"color,omitempty"`
+		Icon        string        `json:"icon,omitempty"`
+		AreaID      string        `json:"area_id,omitempty"`
+		IsDefault   bool          `json:"is_default,omitempty"`
+		Progress    *ListProgress `json:"progress,omitempty"`
+		UserID      string        `json:"user_id,omitempty"`
+		CreatedAt   time.Time     `json:"created_at,omitempty"`
+		UpdatedAt   time.Time     `json:"updated_at,omitempty"`
+	}
+
+	// ListProgress counts the completed tasks of the list out of all its tasks,
+	// the archived tasks are not counted
+	ListProgress struct {
+		CompletedTasks int `json:"completed_tasks"`
+		TotalTasks     int `json:"total_tasks"`
+		Percent        int `json:"percent"`
+	}
+
+	// ListsGroupByArea holds the areas with their lists and the lists of the top level
+	ListsGroupByArea struct {
+		Areas []AreaResponseData `json:"areas"`
+		Lists []ListResponseData `json:"lists"`
 	}
 )
 
@@ -40,3 +95,16 @@ func (t listTitle) String() string {
 const (
 	DefaultInboxList listTitle = "Inbox"
 )
+
+// GroupByArea nests the lists of the user under their areas
+const GroupByArea = "area"
+
+// ListField is an optional field of a list that an update can clear
+type ListField string
+
+const (
+	ListFieldDescription ListField = "description"
+	ListFieldDeadline    ListField = "deadline"
+	ListFieldColor       ListField = "color"
+	ListFieldIcon        ListField = "icon"
+)
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+)
+
+// AuthUser DB model of the users of the local auth provider, the password is stored as a bcrypt hash
+type (
+	AuthUser struct {
+		ID           string    `db:"id"`
+		Email        string    `db:"email"`
+		PasswordHash string    `db:"password_hash"`
+		Verified     bool      `db:"verified"`
+		CreatedAt    time.Time `db:"created_at"`
+		UpdatedAt    time.Time `db:"updated_at"`
+	}
+
+	// AuthSession is found by the hash of the refresh token, the token itself is not stored.
+	// The ID is kept when the refresh token is rotated, so the user can revoke the session by it
+	AuthSession struct {
+		ID         string    `db:"id"`
+		TokenHash  string    `db:"token_hash"`
+		UserID     string    `db:"user_id"`
+		UserAgent  string    `db:"user_agent"`
+		IP         string    `db:"ip"`
+		ExpiresAt  time.Time `db:"expires_at"`
+		LastSeenAt time.Time `db:"last_seen_at"`
+		CreatedAt  time.Time `db:"created_at"`
+	}
+
+	// AuthToken is sent by email to verify the email or to reset the password of the user
+	AuthToken struct {
+		TokenHash string        `db:"token_hash"`
+		UserID    string        `db:"user_id"`
+		Type      AuthTokenType `db:"type"`
+		ExpiresAt time.Time     `db:"expires_at"`
+		CreatedAt time.Time     `db:"created_at"`
+	}
+)
+
+type AuthTokenType string
+
+const (
+	AuthTokenTypeVerifyEmail   AuthTokenType = "verify_email"
+	AuthTokenTypeResetPassword AuthTokenType = "reset_password"
+)
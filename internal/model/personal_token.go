@@ -0,0 +1,49 @@
+package model
+
+import (
+	"time"
+)
+
+// PersonalToken DB model, only the bcrypt hash of the secret part of the token is stored
+type (
+	PersonalToken struct {
+		ID         string    `db:"id"`
+		Name       string    `db:"name"`
+		TokenHash  string    `db:"token_hash"`
+		Scopes     []string  `db:"scopes"`
+		UserID     string    `db:"user_id"`
+		ExpiresAt  time.Time `db:"expires_at"`
+		LastUsedAt time.Time `db:"last_used_at"`
+		CreatedAt  time.Time `db:"created_at"`
+		RevokedAt  time.Time `db:"revoked_at"`
+	}
+
+	PersonalTokenRequestData struct {
+		ID            string   `json:"token_id"`
+		Name          string   `json:"name" validate:"required"`
+		ExpiresInDays int      `json:"expires_in_days" validate:"required,min=1,max=365"`
+		Scopes        []string `json:"scopes" validate:"required,min=1,dive,oneof=read tasks:write lists:write tags:write statuses:write settings:write"`
+		UserID        string   `json:"user_id"`
+	}
+
+	// PersonalTokenResponseData has the Token set only when the token is created
+	PersonalTokenResponseData struct {
+		ID         string    `json:"token_id,omitempty"`
+		Name       string    `json:"name,omitempty"`
+		Token      string    `json:"token,omitempty"`
+		Scopes     []string  `json:"scopes,omitempty"`
+		ExpiresAt  time.Time `json:"expires_at,omitempty"`
+		LastUsedAt time.Time `json:"last_used_at,omitempty"`
+		CreatedAt  time.Time `json:"created_at,omitempty"`
+	}
+)
+
+// Scopes of the personal access tokens, the read scope allows all GET requests
+const (
+	ScopeRead          = "read"
+	ScopeTasksWrite    = "tasks:write"
+	ScopeListsWrite    = "lists:write"
+	ScopeTagsWrite     = "tags:write"
+	ScopeStatusesWrite = "statuses:write"
+	ScopeSettingsWrite = "settings:write"
+)
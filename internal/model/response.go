@@ -5,4 +5,5 @@ type Response struct {
 	StatusText  string `json:"status_text"`
 	Description string `json:"description"`
 	Data        any    `json:"data"`
+	Meta        any    `json:"meta,omitempty"`
 }
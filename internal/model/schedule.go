@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+type (
+	// ScheduleRequestData describes the day to plan. TaskIDs lists the tasks
+	// in priority order, the tasks that aren't listed go after them
+	ScheduleRequestData struct {
+		Date      string
+		WorkStart string
+		WorkEnd   string
+		TaskIDs   []string
+		UserID    string
+	}
+
+	// SchedulePeriod is the free part of the working day. Date is the calendar
+	// date at midnight UTC, WorkStart and WorkEnd are instants in the user's time zone
+	SchedulePeriod struct {
+		Date      time.Time
+		WorkStart time.Time
+		WorkEnd   time.Time
+	}
+
+	ScheduleProposal struct {
+		Date        time.Time         `json:"date"`
+		WorkStart   time.Time         `json:"work_start"`
+		WorkEnd     time.Time         `json:"work_end"`
+		Slots       []ScheduleSlot    `json:"slots"`
+		Unscheduled []UnscheduledTask `json:"unscheduled,omitempty"`
+	}
+
+	ScheduleSlot struct {
+		TaskID    string    `json:"task_id"`
+		Title     string    `json:"title"`
+		StartTime time.Time `json:"start_time"`
+		EndTime   time.Time `json:"end_time"`
+	}
+
+	UnscheduledTask struct {
+		TaskID string `json:"task_id"`
+		Title  string `json:"title"`
+		Reason string `json:"reason"`
+	}
+
+	// ScheduleAcceptRequestData contains the time slots to apply at once
+	ScheduleAcceptRequestData struct {
+		Slots  []TaskRequestTimeData `json:"slots" validate:"required,min=1"`
+		UserID string                `json:"user_id"`
+	}
+)
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+)
+
+type (
+	// Session is a device the user is logged in from, it stays open while the refresh token is used
+	Session struct {
+		ID         string
+		UserAgent  string
+		IP         string
+		LastSeenAt time.Time
+		CreatedAt  time.Time
+	}
+
+	// SessionResponseData has Current set for the session the access token of the request was issued for
+	SessionResponseData struct {
+		ID         string    `json:"session_id"`
+		UserAgent  string    `json:"user_agent"`
+		IP         string    `json:"ip"`
+		LastSeenAt time.Time `json:"last_seen_at"`
+		CreatedAt  time.Time `json:"created_at"`
+		Current    bool      `json:"current"`
+	}
+)
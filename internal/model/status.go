@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 type StatusName string
 
 const (
@@ -14,13 +16,59 @@ func (s StatusName) String() string {
 }
 
 type (
+	// Status is either a built-in status or a custom status of the user.
+	// A built-in status is the category of itself, a custom status is mapped onto
+	// the built-in category, so the views that work with built-in statuses keep working
 	Status struct {
-		ID    int32  `db:"id"`
-		Title string `db:"title"`
+		ID         int32     `db:"id"`
+		Title      string    `db:"title"`
+		CategoryID int32     `db:"category_id"`
+		ListID     string    `db:"list_id"`
+		UserID     string    `db:"user_id"`
+		CreatedAt  time.Time `db:"created_at"`
+		UpdatedAt  time.Time `db:"updated_at"`
+		DeletedAt  time.Time `db:"deleted_at"`
+	}
+
+	// StatusTransition allows to move a task from one status to another.
+	// Transitions without ListID are applied to all lists of the user
+	StatusTransition struct {
+		FromStatusID int32  `db:"from_status_id"`
+		ToStatusID   int32  `db:"to_status_id"`
+		ListID       string `db:"list_id"`
+	}
+
+	// StatusRequestData creates a custom status, the status is available only
+	// in the list if ListID is set. Category and list can't be changed on update
+	StatusRequestData struct {
+		ID         int    `json:"status_id"`
+		Title      string `json:"title" validate:"required"`
+		CategoryID int    `json:"category_id"`
+		ListID     string `json:"list_id"`
+		UserID     string `json:"user_id"`
+	}
+
+	// StatusTransitionsRequestData replaces the transitions of the user for the list,
+	// or the transitions for all lists if ListID is empty
+	StatusTransitionsRequestData struct {
+		ListID      string                        `json:"list_id"`
+		Transitions []StatusTransitionRequestData `json:"transitions" validate:"dive"`
+		UserID      string                        `json:"user_id"`
+	}
+
+	StatusTransitionRequestData struct {
+		FromStatusID int   `json:"from_status_id" validate:"required"`
+		ToStatusIDs  []int `json:"to_status_ids" validate:"required,min=1"`
 	}
 
+	// StatusResponseData lists the statuses the task can be moved to in NextStatusIDs,
+	// the task can be moved to any status if no transitions are configured for the status
 	StatusResponseData struct {
-		ID    int    `json:"status_id"`
-		Title string `json:"title"`
+		ID            int    `json:"status_id"`
+		Title         string `json:"title"`
+		CategoryID    int    `json:"category_id,omitempty"`
+		ListID        string `json:"list_id,omitempty"`
+		Custom        bool   `json:"custom"`
+		NextStatusIDs []int  `json:"next_status_ids,omitempty"`
 	}
 )
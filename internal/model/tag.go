@@ -7,23 +7,52 @@ import (
 // Tag DB model
 type (
 	Tag struct {
-		ID        string    `db:"id"`
-		Title     string    `db:"title"`
-		UserID    string    `db:"user_id"`
-		CreatedAt time.Time `db:"created_at"`
-		UpdatedAt time.Time `db:"updated_at"`
-		DeletedAt time.Time `db:"deleted_at"`
+		ID         string `db:"id"`
+		Title      string `db:"title"`
+		ParentID   string `db:"parent_id"`
+		Color      string `db:"color"`
+		UserID     string `db:"user_id"`
+		TasksCount int
+		CreatedAt  time.Time `db:"created_at"`
+		UpdatedAt  time.Time `db:"updated_at"`
+		DeletedAt  time.Time `db:"deleted_at"`
 	}
 
+	// TagRequestData is titled by the path of the tag, e.g. work/clients/acme.
+	// If ParentID is set on create, the title is the path under the parent tag
 	TagRequestData struct {
-		Title  string `json:"title" validate:"required"`
-		UserID string `json:"user_id"`
+		ID       string `json:"tag_id"`
+		Title    string `json:"title" validate:"required"`
+		ParentID string `json:"parent_tag_id"`
+		Color    string `json:"color" validate:"omitempty,hexcolor"`
+		UserID   string `json:"user_id"`
 	}
 
+	// TagMoveRequestData moves the tag with its child tags under the parent tag,
+	// an empty ParentID moves it to the top level
+	TagMoveRequestData struct {
+		ID       string `json:"tag_id"`
+		ParentID string `json:"parent_tag_id"`
+		UserID   string `json:"user_id"`
+	}
+
+	// TagMergeRequestData moves the tasks of the tag to the target tag and deletes the tag
+	TagMergeRequestData struct {
+		ID       string `json:"tag_id"`
+		TargetID string `json:"target_tag_id" validate:"required"`
+		UserID   string `json:"user_id"`
+	}
+
+	// TagResponseData counts the tasks of the tag together with the tasks of its child tags
 	TagResponseData struct {
-		ID        string    `json:"tag_id,omitempty"`
-		Title     string    `json:"title,omitempty"`
-		CreatedAt time.Time `json:"created_at,omitempty"`
-		UpdatedAt time.Time `json:"updated_at,omitempty"`
+		ID         string            `json:"tag_id,omitempty"`
+		Title      string            `json:"title,omitempty"`
+		Name       string            `json:"name,omitempty"`
+		ParentID   string            `json:"parent_tag_id,omitempty"`
+		Color      string            `json:"color,omitempty"`
+		TasksCount int               `json:"tasks_count"`
+		Children   []TagResponseData `json:"children,omitempty"`
+		CreatedAt  time.Time         `json:"created_at,omitempty"`
+		UpdatedAt  time.Time         `json:"updated_at,omitempty"`
 	}
 )
@@ -4,6 +4,9 @@ import (
 	"time"
 )
 
+// GroupByTag groups the tasks of the today, upcoming and someday views by tag
+const GroupByTag = "tag"
+
 // Task DB model
 type (
 	Task struct {
@@ -20,8 +23,16 @@ type (
 		UserID      string    `db:"user_id"`
 		Tags        []string
 		Overdue     bool
+
+		// CustomStatusID refines StatusID, which is always the built-in category of the custom status
+		CustomStatusID int `db:"custom_status_id"`
+
+		EstimateMinutes int `db:"estimate_minutes"`
+		TrackedMinutes  int
+
 		CreatedAt   time.Time `db:"created_at"`
 		UpdatedAt   time.Time `db:"updated_at"`
+		CompletedAt time.Time `db:"completed_at"`
 		DeletedAt   time.Time `db:"deleted_at"`
 	}
 
@@ -39,6 +50,8 @@ type (
 		StartTimeParsed time.Time
 		EndTimeParsed   time.Time
 
+		EstimateMinutes int `json:"estimate_minutes" validate:"omitempty,min=0"`
+
 		StatusID  int      `json:"status_id"`
 		ListID    string   `json:"list_id"`
 		HeadingID string   `json:"heading_id"`
@@ -60,8 +73,14 @@ type (
 		UserID      string    `json:"user_id,omitempty"`
 		Tags        []string  `json:"tags,omitempty"`
 		Overdue     bool      `json:"overdue,omitempty"`
+
+		CustomStatusID  int `json:"custom_status_id,omitempty"`
+		EstimateMinutes int `json:"estimate_minutes,omitempty"`
+		TrackedMinutes  int `json:"tracked_minutes,omitempty"`
+
 		CreatedAt   time.Time `json:"created_at,omitempty"`
 		UpdatedAt   time.Time `json:"updated_at,omitempty"`
+		CompletedAt time.Time `json:"completed_at,omitempty"`
 	}
 
 	TaskRequestTimeData struct {
@@ -83,11 +102,28 @@ type (
 		UpdatedAt time.Time `json:"updated_at,omitempty"`
 	}
 
+	// TaskStatusRequestData moves the task to a built-in or a custom status
+	TaskStatusRequestData struct {
+		ID       string `json:"task_id"`
+		StatusID int    `json:"status_id" validate:"required"`
+		UserID   string `json:"user_id"`
+	}
+
+	TaskQuickAddRequestData struct {
+		Text     string `json:"text" validate:"required"`
+		Locale   string `json:"locale"`
+		TimeZone string `json:"time_zone"`
+		UserID   string `json:"user_id"`
+	}
+
 	TaskGroupRaw struct {
 		StartDate time.Time `json:"start_date,omitempty"`
 		Month     time.Time `json:"month,omitempty"`
 		ListID    string    `json:"list_id,omitempty"`
 		HeadingID string    `json:"heading_id,omitempty"`
+		TagID     string    `json:"tag_id,omitempty"`
+		TagTitle  string    `json:"tag_title,omitempty"`
+		StatusID  int       `json:"status_id,omitempty"`
 		Tasks     []byte    `json:"tasks,omitempty"`
 	}
 
@@ -96,13 +132,20 @@ type (
 		Tasks  []TaskResponseData `json:"tasks,omitempty"`
 	}
 
+	// TodaySummary is the information shown alongside the tasks for today
+	TodaySummary struct {
+		FocusSessions FocusSessionsSummary `json:"focus_sessions"`
+	}
+
 	UpcomingTaskGroup struct {
 		StartDate time.Time          `json:"start_date,omitempty"`
 		Tasks     []TaskResponseData `json:"tasks,omitempty"`
 	}
 
+	// OverdueTaskGroup has the List set if the deadline of the list itself has passed
 	OverdueTaskGroup struct {
 		ListID string             `json:"list_id,omitempty"`
+		List   *ListResponseData  `json:"list,omitempty"`
 		Tasks  []TaskResponseData `json:"tasks,omitempty"`
 	}
 
@@ -111,14 +154,67 @@ type (
 		Tasks  []TaskResponseData `json:"tasks,omitempty"`
 	}
 
+	// TaskGroupForTag holds the tasks of the tag and its child tags from one list
+	TaskGroupForTag struct {
+		ListID string             `json:"list_id,omitempty"`
+		Tasks  []TaskResponseData `json:"tasks,omitempty"`
+	}
+
+	// TaskGroupByTag holds the tasks of a view with the tag, a task with several tags
+	// is shown in the group of each of them
+	TaskGroupByTag struct {
+		TagID string             `json:"tag_id,omitempty"`
+		Title string             `json:"title,omitempty"`
+		Tasks []TaskResponseData `json:"tasks,omitempty"`
+	}
+
 	TaskGroupWithHeading struct {
 		HeadingID string             `json:"heading_id,omitempty"`
 		Tasks     []TaskResponseData `json:"tasks,omitempty"`
 	}
 
+	// BoardColumn holds the tasks of the list in the status, ordered by their position on the board
+	BoardColumn struct {
+		StatusID   int                `json:"status_id"`
+		Title      string             `json:"title"`
+		CategoryID int                `json:"category_id"`
+		TasksCount int                `json:"tasks_count"`
+		Tasks      []TaskResponseData `json:"tasks"`
+	}
+
+	// TaskBoardMoveRequestData moves the task to the column of the status,
+	// Position is the zero-based index of the task in the column
+	TaskBoardMoveRequestData struct {
+		ID       string `json:"task_id" validate:"required"`
+		StatusID int    `json:"status_id" validate:"required"`
+		Position int    `json:"position" validate:"min=0"`
+		ListID   string `json:"list_id"`
+		UserID   string `json:"user_id"`
+	}
+
+	// CompletedTasksGroup keeps the tasks of the completed headings together,
+	// they are not listed among the tasks of the month
 	CompletedTasksGroup struct {
-		Month time.Time          `json:"month,omitempty"`
-		Tasks []TaskResponseData `json:"tasks,omitempty"`
+		Month    time.Time                      `json:"month,omitempty"`
+		Tasks    []TaskResponseData             `json:"tasks,omitempty"`
+		Headings []CompletedHeadingResponseData `json:"headings,omitempty"`
+	}
+
+	CompletedHeadingRaw struct {
+		ID          string
+		Title       string
+		ListID      string
+		CompletedAt time.Time
+		Month       time.Time
+		Tasks       []byte
+	}
+
+	CompletedHeadingResponseData struct {
+		ID          string             `json:"heading_id,omitempty"`
+		Title       string             `json:"title,omitempty"`
+		ListID      string             `json:"list_id,omitempty"`
+		CompletedAt time.Time          `json:"completed_at,omitempty"`
+		Tasks       []TaskResponseData `json:"tasks,omitempty"`
 	}
 
 	ArchivedTasksGroup struct {
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"time"
+)
+
+// Template DB model, the dates of its tasks and the deadline of the list
+// are kept as the number of days from the anchor date
+type (
+	Template struct {
+		ID             string       `db:"id"`
+		Title          string       `db:"title"`
+		Type           TemplateType `db:"type"`
+		Description    string       `db:"description"`
+		Color          string       `db:"color"`
+		Icon           string       `db:"icon"`
+		DeadlineOffset *int         `db:"deadline_offset"`
+		UserID         string       `db:"user_id"`
+		Headings       []TemplateHeading
+		Tasks          []TemplateTask
+		CreatedAt      time.Time `db:"created_at"`
+		UpdatedAt      time.Time `db:"updated_at"`
+		DeletedAt      time.Time `db:"deleted_at"`
+	}
+
+	TemplateHeading struct {
+		ID    string `db:"id"`
+		Title string `db:"title"`
+	}
+
+	// TemplateTask without HeadingID goes to the default heading of the new list
+	TemplateTask struct {
+		ID              string   `db:"id"`
+		HeadingID       string   `db:"heading_id"`
+		Title           string   `db:"title"`
+		Description     string   `db:"description"`
+		StartDateOffset *int     `db:"start_date_offset"`
+		DeadlineOffset  *int     `db:"deadline_offset"`
+		EstimateMinutes int      `db:"estimate_minutes"`
+		Tags            []string `db:"tags"`
+	}
+
+	// TemplateRequestData saves either the list with its headings and tasks or a single task as a template.
+	// The dates are counted from the AnchorDate, by default from the earliest date of the tasks
+	TemplateRequestData struct {
+		ID         string `json:"template_id"`
+		Title      string `json:"title"`
+		ListID     string `json:"list_id" validate:"required_without=TaskID,excluded_with=TaskID"`
+		TaskID     string `json:"task_id"`
+		AnchorDate string `json:"anchor_date"`
+		UserID     string `json:"user_id"`
+
+		AnchorDateParsed time.Time
+	}
+
+	// TemplateInstantiateRequestData creates a new list in the area from the list template,
+	// or a new task in the list and heading from the task template.
+	// The dates are counted from the AnchorDate, by default from the current date of the user
+	TemplateInstantiateRequestData struct {
+		ID         string `json:"template_id"`
+		Title      string `json:"title"`
+		AnchorDate string `json:"anchor_date"`
+		AreaID     string `json:"area_id"`
+		ListID     string `json:"list_id"`
+		HeadingID  string `json:"heading_id"`
+		UserID     string `json:"user_id"`
+
+		AnchorDateParsed time.Time
+	}
+
+	TemplateResponseData struct {
+		ID             string                        `json:"template_id,omitempty"`
+		Title          string                        `json:"title,omitempty"`
+		Type           TemplateType                  `json:"type,omitempty"`
+		Description    string                        `json:"description,omitempty"`
+		Color          string                        `json:"color,omitempty"`
+		Icon           string                        `json:"icon,omitempty"`
+		DeadlineOffset *int                          `json:"deadline_offset,omitempty"`
+		Headings       []TemplateHeadingResponseData `json:"headings,omitempty"`
+		Tasks          []TemplateTaskResponseData    `json:"tasks,omitempty"`
+		UpdatedAt      time.Time                     `json:"updated_at,omitempty"`
+	}
+
+	TemplateHeadingResponseData struct {
+		ID    string                     `json:"heading_id,omitempty"`
+		Title string                     `json:"title,omitempty"`
+		Tasks []TemplateTaskResponseData `json:"tasks,omitempty"`
+	}
+
+	TemplateTaskResponseData struct {
+		ID              string   `json:"task_id,omitempty"`
+		Title           string   `json:"title,omitempty"`
+		Description     string   `json:"description,omitempty"`
+		StartDateOffset *int     `json:"start_date_offset,omitempty"`
+		DeadlineOffset  *int     `json:"deadline_offset,omitempty"`
+		EstimateMinutes int      `json:"estimate_minutes,omitempty"`
+		Tags            []string `json:"tags,omitempty"`
+	}
+
+	// TemplateInstanceResponseData holds the list created from the list template,
+	// or the task created from the task template
+	TemplateInstanceResponseData struct {
+		List *ListResponseData `json:"list,omitempty"`
+		Task *TaskResponseData `json:"task,omitempty"`
+	}
+)
+
+type TemplateType string
+
+const (
+	TemplateTypeList TemplateType = "list"
+	TemplateTypeTask TemplateType = "task"
+)
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"time"
+)
+
+// TimeEntry DB model
+type (
+	TimeEntry struct {
+		ID        string    `db:"id"`
+		TaskID    string    `db:"task_id"`
+		UserID    string    `db:"user_id"`
+		StartTime time.Time `db:"start_time"`
+		EndTime   time.Time `db:"end_time"`
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+		DeletedAt time.Time `db:"deleted_at"`
+	}
+
+	TimeEntryRequestData struct {
+		ID        string `json:"time_entry_id"`
+		TaskID    string `json:"task_id"`
+		StartTime string `json:"start_time"`
+		EndTime   string `json:"end_time"`
+
+		StartTimeParsed time.Time
+		EndTimeParsed   time.Time
+
+		UserID string `json:"user_id"`
+	}
+
+	TimeEntryResponseData struct {
+		ID        string    `json:"time_entry_id,omitempty"`
+		TaskID    string    `json:"task_id,omitempty"`
+		StartTime time.Time `json:"start_time,omitempty"`
+		EndTime   time.Time `json:"end_time,omitempty"`
+		Minutes   int       `json:"minutes,omitempty"`
+		Running   bool      `json:"running,omitempty"`
+		UserID    string    `json:"user_id,omitempty"`
+		CreatedAt time.Time `json:"created_at,omitempty"`
+		UpdatedAt time.Time `json:"updated_at,omitempty"`
+	}
+
+	TimeReportRequestData struct {
+		From   string
+		To     string
+		UserID string
+	}
+
+	// TimeReportPeriod holds the bounds of a report in both forms the data is stored in:
+	// dates as midnight UTC and time entries as instants in the user's time zone
+	TimeReportPeriod struct {
+		FromDate time.Time
+		ToDate   time.Time
+		FromTime time.Time
+		ToTime   time.Time
+		TimeZone string
+	}
+
+	TimeReportResponseData struct {
+		From   time.Time       `json:"from"`
+		To     time.Time       `json:"to"`
+		ByList []ListTimeTotal `json:"by_list"`
+		ByTag  []TagTimeTotal  `json:"by_tag"`
+		ByDay  []DayTimeTotal  `json:"by_day"`
+	}
+
+	// TimeTotal is the time tracked against the time estimated for a group of tasks
+	TimeTotal struct {
+		TrackedMinutes   int `json:"tracked_minutes"`
+		EstimatedMinutes int `json:"estimated_minutes"`
+	}
+
+	ListTimeTotal struct {
+		ListID string `json:"list_id"`
+		TimeTotal
+	}
+
+	TagTimeTotal struct {
+		Tag string `json:"tag"`
+		TimeTotal
+	}
+
+	DayTimeTotal struct {
+		Date time.Time `json:"date"`
+		TimeTotal
+	}
+)
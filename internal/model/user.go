@@ -27,4 +27,22 @@ type (
 	ChangePasswordRequestData struct {
 		Password string `json:"password" validate:"required,min=8"`
 	}
+
+	// UserSettings DB model
+	UserSettings struct {
+		UserID    string    `db:"user_id"`
+		TimeZone  string    `db:"time_zone"`
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	UserSettingsRequestData struct {
+		TimeZone string `json:"time_zone" validate:"required"`
+		UserID   string `json:"user_id"`
+	}
+
+	UserSettingsResponseData struct {
+		TimeZone  string    `json:"time_zone,omitempty"`
+		UpdatedAt time.Time `json:"updated_at,omitempty"`
+	}
 )
@@ -0,0 +1,39 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	AreaUsecase interface {
+		CreateArea(ctx context.Context, data *model.AreaRequestData) (model.AreaResponseData, error)
+		GetAreaByID(ctx context.Context, data model.AreaRequestData) (model.AreaResponseData, error)
+		GetAreasByUserID(ctx context.Context, userID string) ([]model.AreaResponseData, error)
+		UpdateArea(ctx context.Context, data *model.AreaRequestData) (model.AreaResponseData, error)
+		MoveArea(ctx context.Context, data *model.AreaMoveRequestData) ([]model.AreaResponseData, error)
+		DeleteArea(ctx context.Context, data model.AreaDeleteRequestData) error
+	}
+
+	AreaStorage interface {
+		Transaction(ctx context.Context, fn func(storage AreaStorage) error) error
+		CreateArea(ctx context.Context, area model.Area) error
+		GetAreaByID(ctx context.Context, areaID, userID string) (model.Area, error)
+		GetAreasByUserID(ctx context.Context, userID string) ([]model.Area, error)
+		UpdateArea(ctx context.Context, area model.Area) error
+		UpdateAreasPosition(ctx context.Context, userID string, areaIDs []string) error
+		DeleteArea(ctx context.Context, area model.Area) error
+
+		// The lists of the area are written together with the area in one transaction
+		CreateList(ctx context.Context, list model.List) error
+		CreateHeading(ctx context.Context, heading model.Heading) error
+		GetListIDsByAreaID(ctx context.Context, areaID, userID string) ([]string, error)
+		UpdateList(ctx context.Context, list model.List) error
+		MoveListsToTopLevel(ctx context.Context, list model.List) error
+		DeleteList(ctx context.Context, list model.List) error
+		DeleteHeadingsByListID(ctx context.Context, deletedHeadings model.Heading) error
+		GetTaskStatusID(ctx context.Context, status model.StatusName) (int, error)
+		MarkTasksAsArchivedByListID(ctx context.Context, archivedTasks model.Task) error
+	}
+)
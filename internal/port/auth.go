@@ -5,6 +5,7 @@ import (
 
 	"github.com/rshelekhov/reframed/internal/model"
 	ssov1 "github.com/rshelekhov/sso-protos/gen/go/sso"
+	"google.golang.org/grpc"
 )
 
 type AuthUsecase interface {
@@ -19,3 +20,19 @@ type AuthUsecase interface {
 	UpdateUser(ctx context.Context, data *model.UpdateUserRequestData) error
 	DeleteUser(ctx context.Context, userID string) error
 }
+
+// AuthProvider is the part of the SSO API used by the AuthUsecase. It is implemented by the SSO client
+// and by the local auth provider, which keeps the users in the storage of the service
+type AuthProvider interface {
+	RegisterUser(ctx context.Context, in *ssov1.RegisterUserRequest, opts ...grpc.CallOption) (*ssov1.RegisterUserResponse, error)
+	VerifyEmail(ctx context.Context, in *ssov1.VerifyEmailRequest, opts ...grpc.CallOption) (*ssov1.Empty, error)
+	Login(ctx context.Context, in *ssov1.LoginRequest, opts ...grpc.CallOption) (*ssov1.LoginResponse, error)
+	ResetPassword(ctx context.Context, in *ssov1.ResetPasswordRequest, opts ...grpc.CallOption) (*ssov1.Empty, error)
+	ChangePassword(ctx context.Context, in *ssov1.ChangePasswordRequest, opts ...grpc.CallOption) (*ssov1.Empty, error)
+	Logout(ctx context.Context, in *ssov1.LogoutRequest, opts ...grpc.CallOption) (*ssov1.Empty, error)
+	Refresh(ctx context.Context, in *ssov1.RefreshRequest, opts ...grpc.CallOption) (*ssov1.RefreshResponse, error)
+	GetJWKS(ctx context.Context, in *ssov1.GetJWKSRequest, opts ...grpc.CallOption) (*ssov1.GetJWKSResponse, error)
+	GetUser(ctx context.Context, in *ssov1.GetUserRequest, opts ...grpc.CallOption) (*ssov1.GetUserResponse, error)
+	UpdateUser(ctx context.Context, in *ssov1.UpdateUserRequest, opts ...grpc.CallOption) (*ssov1.Empty, error)
+	DeleteUser(ctx context.Context, in *ssov1.DeleteUserRequest, opts ...grpc.CallOption) (*ssov1.Empty, error)
+}
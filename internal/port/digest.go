@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	DigestUsecase interface {
+		GetDigestSettings(ctx context.Context, userID string) (model.DigestSettingsResponseData, error)
+		UpdateDigestSettings(ctx context.Context, data *model.DigestSettingsRequestData) (model.DigestSettingsResponseData, error)
+		UnsubscribeFromDigests(ctx context.Context, unsubscribeToken string) error
+		SendDueDigests(ctx context.Context, now time.Time) error
+	}
+
+	DigestStorage interface {
+		GetDigestSettings(ctx context.Context, userID string) (model.DigestSettings, error)
+		UpsertDigestSettings(ctx context.Context, settings model.DigestSettings) error
+		UnsubscribeFromDigests(ctx context.Context, unsubscribeToken string, updatedAt time.Time) error
+		GetEnabledDigestSettings(ctx context.Context) ([]model.DigestSettings, error)
+		ClaimDailyDigest(ctx context.Context, userID string, sentAt, dueAt time.Time) (bool, error)
+		ClaimWeeklyDigest(ctx context.Context, userID string, sentAt, dueAt time.Time) (bool, error)
+	}
+)
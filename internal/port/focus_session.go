@@ -0,0 +1,32 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	FocusSessionUsecase interface {
+		StartFocusSession(ctx context.Context, data *model.FocusSessionRequestData) (model.FocusSessionResponseData, error)
+		GetActiveFocusSession(ctx context.Context, userID string) (model.FocusSessionResponseData, error)
+		GetFocusSessionByID(ctx context.Context, data model.FocusSessionRequestData) (model.FocusSessionResponseData, error)
+		PauseFocusSession(ctx context.Context, data model.FocusSessionRequestData) (model.FocusSessionResponseData, error)
+		ResumeFocusSession(ctx context.Context, data model.FocusSessionRequestData) (model.FocusSessionResponseData, error)
+		AbandonFocusSession(ctx context.Context, data model.FocusSessionRequestData) (model.FocusSessionResponseData, error)
+		GetFocusSessionsSummaryForToday(ctx context.Context, userID string) (model.FocusSessionsSummary, error)
+	}
+
+	FocusSessionStorage interface {
+		Transaction(ctx context.Context, fn func(storage FocusSessionStorage) error) error
+		CreateFocusSession(ctx context.Context, session model.FocusSession) error
+		CreateFocusSessionEvent(ctx context.Context, event model.FocusSessionEvent) error
+		GetFocusSessionByID(ctx context.Context, sessionID, userID string) (model.FocusSession, error)
+		GetActiveFocusSession(ctx context.Context, userID string) (model.FocusSession, error)
+		GetFocusSessionEvents(ctx context.Context, sessionID, userID string) ([]model.FocusSessionEvent, error)
+		UpdateFocusSession(ctx context.Context, session model.FocusSession, expectedStatus model.FocusSessionStatus) error
+		CompleteDueFocusSessions(ctx context.Context, userID string, now time.Time) ([]model.FocusSession, error)
+		GetFocusSessionsSummary(ctx context.Context, userID string, from, to, now time.Time) (model.FocusSessionsSummary, error)
+	}
+)
@@ -12,21 +12,41 @@ type (
 		CreateDefaultHeading(ctx context.Context, heading model.Heading) error
 		GetHeadingByID(ctx context.Context, data model.HeadingRequestData) (model.HeadingResponseData, error)
 		GetDefaultHeadingID(ctx context.Context, data model.HeadingRequestData) (string, error)
+		GetHeadingIDByTitle(ctx context.Context, data model.HeadingRequestData) (string, error)
 		GetHeadingsByListID(ctx context.Context, data model.HeadingRequestData) ([]model.HeadingResponseData, error)
 		UpdateHeading(ctx context.Context, data *model.HeadingRequestData) (model.HeadingResponseData, error)
 		MoveHeadingToAnotherList(ctx context.Context, data *model.HeadingRequestData) (model.HeadingResponseData, error)
+		PromoteHeadingToList(ctx context.Context, data *model.HeadingRequestData) (model.ListResponseData, error)
+		DemoteListToHeading(ctx context.Context, data *model.ListDemoteRequestData) (model.HeadingResponseData, error)
+		CompleteHeading(ctx context.Context, data model.HeadingCompleteRequestData) (model.HeadingResponseData, error)
+		RestoreHeading(ctx context.Context, data model.HeadingRequestData) (model.HeadingResponseData, error)
 		DeleteHeading(ctx context.Context, data *model.HeadingRequestData) error
 		DeleteHeadingsByListID(ctx context.Context, data model.HeadingRequestData) error
 	}
 
 	HeadingStorage interface {
+		Transaction(ctx context.Context, fn func(storage HeadingStorage) error) error
 		CreateHeading(ctx context.Context, heading model.Heading) error
 		GetDefaultHeadingID(ctx context.Context, listID, userID string) (string, error)
 		GetHeadingByID(ctx context.Context, headingID, userID string) (model.Heading, error)
+		GetHeadingIDByTitle(ctx context.Context, title, listID, userID string) (string, error)
 		GetHeadingsByListID(ctx context.Context, listID, userID string) ([]model.Heading, error)
 		UpdateHeading(ctx context.Context, heading model.Heading) error
 		MoveHeadingToAnotherList(ctx context.Context, heading model.Heading, task model.Task) error
+		MoveTasksToHeading(ctx context.Context, fromHeadingID string, task model.Task) error
+		CompleteHeading(ctx context.Context, heading model.Heading) error
+		RestoreHeading(ctx context.Context, heading model.Heading) error
 		DeleteHeading(ctx context.Context, heading model.Heading) error
 		DeleteHeadingsByListID(ctx context.Context, deletedHeadings model.Heading) error
+
+		// The lists are created and deleted together with the headings when they are converted into each other
+		CreateList(ctx context.Context, list model.List) error
+		DeleteList(ctx context.Context, list model.List) error
+		GetTaskStatusID(ctx context.Context, status model.StatusName) (int, error)
+		MarkTasksAsArchivedByListID(ctx context.Context, archivedTasks model.Task) error
+
+		// The tasks are closed and reopened together with their heading
+		MarkTasksAsClosedByHeadingID(ctx context.Context, closedTasks model.Task, completedStatusID int) error
+		MarkTasksAsReopenedByHeadingID(ctx context.Context, reopenedTasks model.Task) error
 	}
 )
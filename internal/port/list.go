@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/rshelekhov/reframed/internal/model"
 )
@@ -12,17 +13,29 @@ type (
 		CreateDefaultList(ctx context.Context, userID string) error
 		GetListByID(ctx context.Context, data model.ListRequestData) (model.ListResponseData, error)
 		GetListsByUserID(ctx context.Context, userID string) ([]model.ListResponseData, error)
+		GetListsGroupedByArea(ctx context.Context, userID string) (model.ListsGroupByArea, error)
+		GetOverdueLists(ctx context.Context, userID string, pgn model.Pagination, today time.Time) ([]model.ListResponseData, error)
+		GetListsByAreaID(ctx context.Context, data model.ListRequestData) ([]model.ListResponseData, error)
 		GetDefaultListID(ctx context.Context, userID string) (string, error)
+		GetListIDByTitle(ctx context.Context, data model.ListRequestData) (string, error)
 		UpdateList(ctx context.Context, data *model.ListRequestData) (model.ListResponseData, error)
+		MoveList(ctx context.Context, data *model.ListMoveRequestData) (model.ListResponseData, error)
 		DeleteList(ctx context.Context, data model.ListRequestData) error
 	}
 
 	ListStorage interface {
+		Transaction(ctx context.Context, fn func(storage ListStorage) error) error
 		CreateList(ctx context.Context, list model.List) error
 		GetListByID(ctx context.Context, listID, userID string) (model.List, error)
 		GetListsByUserID(ctx context.Context, userID string) ([]model.List, error)
+		GetOverdueLists(ctx context.Context, userID string, pgn model.Pagination, today time.Time) ([]model.List, error)
 		GetDefaultListID(ctx context.Context, userID string) (string, error)
-		UpdateList(ctx context.Context, list model.List) error
+		GetListIDByTitle(ctx context.Context, title, userID string) (string, error)
+		GetListIDsByAreaID(ctx context.Context, areaID, userID string) ([]string, error)
+		UpdateList(ctx context.Context, list model.List, clear []model.ListField) error
+		UpdateListArea(ctx context.Context, list model.List) error
+		UpdateListsPosition(ctx context.Context, userID string, listIDs []string) error
+		MoveListsToTopLevel(ctx context.Context, list model.List) error
 		DeleteList(ctx context.Context, list model.List) error
 	}
 )
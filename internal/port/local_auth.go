@@ -0,0 +1,27 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type LocalAuthStorage interface {
+	CreateUser(ctx context.Context, user model.AuthUser) error
+	GetUserByEmail(ctx context.Context, email string) (model.AuthUser, error)
+	GetUserByID(ctx context.Context, userID string) (model.AuthUser, error)
+	UpdateUser(ctx context.Context, user model.AuthUser) error
+	DeleteUser(ctx context.Context, userID string) error
+	CreateSession(ctx context.Context, session model.AuthSession) error
+	GetSession(ctx context.Context, tokenHash string) (model.AuthSession, error)
+	GetSessionsByUserID(ctx context.Context, userID string) ([]model.AuthSession, error)
+	RotateSession(ctx context.Context, tokenHash string, session model.AuthSession) error
+	DeleteSession(ctx context.Context, tokenHash string) error
+	DeleteSessionByID(ctx context.Context, session model.AuthSession) error
+	DeleteSessionsByDevice(ctx context.Context, session model.AuthSession) error
+	DeleteSessionsByUserID(ctx context.Context, userID string) error
+	DeleteOtherSessions(ctx context.Context, session model.AuthSession) error
+	CreateToken(ctx context.Context, token model.AuthToken) error
+	GetToken(ctx context.Context, tokenHash string, tokenType model.AuthTokenType) (model.AuthToken, error)
+	DeleteTokensByUserID(ctx context.Context, userID string, tokenType model.AuthTokenType) error
+}
@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type Mailer interface {
+	SendEmail(ctx context.Context, email model.Email) error
+}
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	PersonalTokenUsecase interface {
+		CreatePersonalToken(ctx context.Context, data *model.PersonalTokenRequestData) (model.PersonalTokenResponseData, error)
+		GetPersonalTokensByUserID(ctx context.Context, userID string) ([]model.PersonalTokenResponseData, error)
+		VerifyPersonalToken(ctx context.Context, token string) (jwtoken.PersonalToken, error)
+		RevokePersonalToken(ctx context.Context, data model.PersonalTokenRequestData) error
+	}
+
+	PersonalTokenStorage interface {
+		CreatePersonalToken(ctx context.Context, token model.PersonalToken) error
+		GetPersonalTokenByID(ctx context.Context, tokenID string) (model.PersonalToken, error)
+		GetPersonalTokensByUserID(ctx context.Context, userID string) ([]model.PersonalToken, error)
+		UpdatePersonalTokenLastUsedAt(ctx context.Context, token model.PersonalToken) error
+		RevokePersonalToken(ctx context.Context, token model.PersonalToken) error
+	}
+)
@@ -0,0 +1,23 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	SessionUsecase interface {
+		GetSessions(ctx context.Context, currentSessionID string) ([]model.SessionResponseData, error)
+		RevokeSession(ctx context.Context, sessionID string) error
+		RevokeOtherSessions(ctx context.Context, currentSessionID string) error
+	}
+
+	// SessionProvider manages the sessions of the owner of the access token in the outgoing metadata,
+	// like the AuthProvider it returns the errors as gRPC statuses
+	SessionProvider interface {
+		GetSessions(ctx context.Context) ([]model.Session, error)
+		RevokeSession(ctx context.Context, sessionID string) error
+		RevokeOtherSessions(ctx context.Context, currentSessionID string) error
+	}
+)
@@ -8,12 +8,26 @@ import (
 
 type (
 	StatusUsecase interface {
-		GetStatuses(ctx context.Context) ([]model.StatusResponseData, error)
-		GetStatusByID(ctx context.Context, statusID int) (model.StatusResponseData, error)
+		CreateStatus(ctx context.Context, data *model.StatusRequestData) (model.StatusResponseData, error)
+		GetStatuses(ctx context.Context, userID, listID string) ([]model.StatusResponseData, error)
+		GetStatusByID(ctx context.Context, data model.StatusRequestData) (model.StatusResponseData, error)
+		UpdateStatus(ctx context.Context, data *model.StatusRequestData) (model.StatusResponseData, error)
+		UpdateStatusTransitions(ctx context.Context, data *model.StatusTransitionsRequestData) ([]model.StatusResponseData, error)
+		CheckStatusTransition(ctx context.Context, userID, listID string, fromStatusID, toStatusID int) error
+		DeleteStatus(ctx context.Context, data model.StatusRequestData) error
 	}
 
 	StatusStorage interface {
-		GetStatuses(ctx context.Context) ([]model.Status, error)
-		GetStatusByID(ctx context.Context, statusID int32) (string, error)
+		Transaction(ctx context.Context, fn func(storage StatusStorage) error) error
+		CreateStatus(ctx context.Context, status model.Status) (int32, error)
+		GetStatuses(ctx context.Context, userID, listID string) ([]model.Status, error)
+		GetStatusByID(ctx context.Context, statusID int32, userID string) (model.Status, error)
+		UpdateStatus(ctx context.Context, status model.Status) error
+		DeleteStatus(ctx context.Context, status model.Status) error
+		ResetTasksCustomStatus(ctx context.Context, status model.Status) error
+		GetStatusTransitions(ctx context.Context, userID, listID string) ([]model.StatusTransition, error)
+		CreateStatusTransitions(ctx context.Context, userID, listID string, transitions []model.StatusTransition) error
+		DeleteStatusTransitions(ctx context.Context, userID, listID string) error
+		DeleteStatusTransitionsByStatusID(ctx context.Context, statusID int32, userID string) error
 	}
 )
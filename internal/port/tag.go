@@ -2,25 +2,42 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/rshelekhov/reframed/internal/model"
 )
 
 type (
 	TagUsecase interface {
+		CreateTag(ctx context.Context, data *model.TagRequestData) (model.TagResponseData, error)
 		CreateTagIfNotExists(ctx context.Context, data model.TagRequestData) error
 		LinkTagsToTask(ctx context.Context, userID, taskID string, tags []string) error
 		UnlinkTagsFromTask(ctx context.Context, userID, taskID string, tags []string) error
+		GetTagByID(ctx context.Context, data model.TagRequestData) (model.TagResponseData, error)
 		GetTagsByUserID(ctx context.Context, userID string) ([]model.TagResponseData, error)
 		GetTagsByTaskID(ctx context.Context, taskID string) ([]model.TagResponseData, error)
+		UpdateTag(ctx context.Context, data *model.TagRequestData) (model.TagResponseData, error)
+		MoveTag(ctx context.Context, data *model.TagMoveRequestData) (model.TagResponseData, error)
+		MergeTags(ctx context.Context, data *model.TagMergeRequestData) (model.TagResponseData, error)
+		DeleteTag(ctx context.Context, data model.TagRequestData) error
 	}
 
 	TagStorage interface {
+		Transaction(ctx context.Context, fn func(storage TagStorage) error) error
 		CreateTag(ctx context.Context, tag model.Tag) error
 		LinkTagsToTask(ctx context.Context, userID, taskID string, tags []string) error
 		UnlinkTagsFromTask(ctx context.Context, userID, taskID string, tags []string) error
+		UnlinkTagFromAllTasks(ctx context.Context, tagID string) error
+		CopyTaskLinksToTag(ctx context.Context, sourceTagID, targetTagID string) error
 		GetTagIDByTitle(ctx context.Context, title, userID string) (string, error)
+		GetTagByID(ctx context.Context, tagID, userID string) (model.Tag, error)
 		GetTagsByUserID(ctx context.Context, userID string) ([]model.Tag, error)
 		GetTagsByTaskID(ctx context.Context, taskID string) ([]model.Tag, error)
+		GetTagSubtreeIDs(ctx context.Context, tagID, userID string) ([]string, error)
+		GetTagsByParentID(ctx context.Context, parentID, userID string) ([]model.Tag, error)
+		UpdateTag(ctx context.Context, tag model.Tag) error
+		UpdateTagParent(ctx context.Context, tag model.Tag) error
+		RenameTagSubtree(ctx context.Context, userID string, tagIDs []string, oldTitle, newTitle string, updatedAt time.Time) error
+		DeleteTag(ctx context.Context, tag model.Tag) error
 	}
 )
@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/rshelekhov/reframed/internal/model"
 )
@@ -9,20 +10,31 @@ import (
 type (
 	TaskUsecase interface {
 		CreateTask(ctx context.Context, data *model.TaskRequestData) (model.TaskResponseData, error)
+		QuickAddTask(ctx context.Context, data *model.TaskQuickAddRequestData) (model.TaskResponseData, error)
 		GetTaskByID(ctx context.Context, data model.TaskRequestData) (model.TaskResponseData, error)
 		GetTasksByUserID(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskResponseData, error)
 		GetTasksByListID(ctx context.Context, data model.TaskRequestData) ([]model.TaskResponseData, error)
+		GetTasksByTagID(ctx context.Context, data model.TagRequestData, pgn model.Pagination) ([]model.TaskGroupForTag, error)
 		GetTasksGroupedByHeading(ctx context.Context, data model.TaskRequestData) ([]model.TaskGroupWithHeading, error)
+		GetBoard(ctx context.Context, data model.TaskRequestData) ([]model.BoardColumn, error)
 		GetTasksForToday(ctx context.Context, userID string) ([]model.TodayTaskGroup, error)
+		GetTasksForTodayGroupedByTag(ctx context.Context, userID string) ([]model.TaskGroupByTag, error)
+		GetTodaySummary(ctx context.Context, userID string) (model.TodaySummary, error)
 		GetUpcomingTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.UpcomingTaskGroup, error)
+		GetUpcomingTasksGroupedByTag(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroupByTag, error)
 		GetOverdueTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.OverdueTaskGroup, error)
 		GetTasksForSomeday(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroupForSomeday, error)
+		GetTasksForSomedayGroupedByTag(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroupByTag, error)
 		GetCompletedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.CompletedTasksGroup, error)
 		GetArchivedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.ArchivedTasksGroup, error)
 		UpdateTask(ctx context.Context, data *model.TaskRequestData) (model.TaskResponseData, error)
 		UpdateTaskTime(ctx context.Context, data *model.TaskRequestTimeData) (model.TaskResponseTimeData, error)
+		ProposeSchedule(ctx context.Context, data model.ScheduleRequestData) (model.ScheduleProposal, error)
+		AcceptSchedule(ctx context.Context, data *model.ScheduleAcceptRequestData) ([]model.TaskResponseTimeData, error)
 		MoveTaskToAnotherList(ctx context.Context, data model.TaskRequestData) (model.TaskResponseData, error)
 		MoveTaskToAnotherHeading(ctx context.Context, data model.TaskRequestData) (model.TaskResponseData, error)
+		MoveTaskOnBoard(ctx context.Context, data *model.TaskBoardMoveRequestData) ([]model.BoardColumn, error)
+		ChangeTaskStatus(ctx context.Context, data *model.TaskStatusRequestData) (model.TaskResponseData, error)
 		CompleteTask(ctx context.Context, data model.TaskRequestData) (model.TaskResponseData, error)
 		ArchiveTask(ctx context.Context, data model.TaskRequestData) (model.TaskResponseData, error)
 		ArchiveTasksByHeadingID(ctx context.Context, data model.TaskRequestData) error
@@ -33,23 +45,37 @@ type (
 		Transaction(ctx context.Context, fn func(storage TaskStorage) error) error
 		CreateTask(ctx context.Context, task model.Task) error
 		GetTaskStatusID(ctx context.Context, status model.StatusName) (int, error)
-		GetTaskByID(ctx context.Context, taskID, userID string) (model.Task, error)
-		GetTasksByUserID(ctx context.Context, userID string, pgn model.Pagination) ([]model.Task, error)
-		GetTasksByListID(ctx context.Context, listID, userID string) ([]model.Task, error)
-		GetTasksGroupedByHeadings(ctx context.Context, listID, userID string) ([]model.TaskGroupRaw, error)
-		GetTasksForToday(ctx context.Context, userID string) ([]model.TaskGroupRaw, error)
-		GetUpcomingTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroupRaw, error)
-		GetOverdueTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroupRaw, error)
+		GetTaskByID(ctx context.Context, taskID, userID string, today time.Time) (model.Task, error)
+		GetTasksByUserID(ctx context.Context, userID string, pgn model.Pagination, today time.Time) ([]model.Task, error)
+		GetTasksByListID(ctx context.Context, listID, userID string, today time.Time) ([]model.Task, error)
+		GetTasksByTagID(ctx context.Context, tagID, userID string, pgn model.Pagination, today time.Time) ([]model.TaskGroupRaw, error)
+		GetTasksGroupedByHeadings(ctx context.Context, listID, userID string, today time.Time) ([]model.TaskGroupRaw, error)
+		GetTasksGroupedByStatus(ctx context.Context, listID, userID string, today time.Time) ([]model.TaskGroupRaw, error)
+		GetTaskIDsByBoardColumn(ctx context.Context, listID, userID string, statusID int) ([]string, error)
+		UpdateTasksPosition(ctx context.Context, userID string, taskIDs []string) error
+		GetTasksForToday(ctx context.Context, userID string, today time.Time) ([]model.TaskGroupRaw, error)
+		GetTasksForTodayGroupedByTag(ctx context.Context, userID string, today time.Time) ([]model.TaskGroupRaw, error)
+		GetUpcomingTasks(ctx context.Context, userID string, pgn model.Pagination, today time.Time) ([]model.TaskGroupRaw, error)
+		GetUpcomingTasksGroupedByTag(ctx context.Context, userID string, pgn model.Pagination, today time.Time) ([]model.TaskGroupRaw, error)
+		GetOverdueTasks(ctx context.Context, userID string, pgn model.Pagination, today time.Time) ([]model.TaskGroupRaw, error)
 		GetTasksForSomeday(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroupRaw, error)
-		GetCompletedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroupRaw, error)
+		GetTasksForSomedayGroupedByTag(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroupRaw, error)
+		GetCompletedTasks(ctx context.Context, userID string, pgn model.Pagination, timeZone string) ([]model.TaskGroupRaw, error)
+		GetCompletedHeadings(ctx context.Context, userID string, pgn model.Pagination, timeZone string) ([]model.CompletedHeadingRaw, error)
 		GetArchivedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroupRaw, error)
 		UpdateTask(ctx context.Context, task model.Task) error
 		UpdateTaskTime(ctx context.Context, task model.Task) error
+		GetTasksForSchedule(ctx context.Context, userID string, completedStatusID int, period model.SchedulePeriod) ([]model.Task, error)
+		UpdateTasksTime(ctx context.Context, tasks []model.Task) error
 		MoveTaskToAnotherList(ctx context.Context, task model.Task) error
 		MoveTaskToAnotherHeading(ctx context.Context, task model.Task) error
+		GetTaskStatus(ctx context.Context, taskID, userID string) (model.Task, error)
+		UpdateTaskStatus(ctx context.Context, task model.Task) error
 		MarkAsCompleted(ctx context.Context, task model.Task) error
 		MarkAsArchived(ctx context.Context, task model.Task) error
 		MarkTasksAsArchivedByHeadingID(ctx context.Context, archivedTasks model.Task) error
 		MarkTasksAsArchivedByListID(ctx context.Context, archivedTasks model.Task) error
+		MarkTasksAsClosedByHeadingID(ctx context.Context, closedTasks model.Task, completedStatusID int) error
+		MarkTasksAsReopenedByHeadingID(ctx context.Context, reopenedTasks model.Task) error
 	}
 )
@@ -0,0 +1,32 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	TemplateUsecase interface {
+		CreateTemplate(ctx context.Context, data *model.TemplateRequestData) (model.TemplateResponseData, error)
+		GetTemplateByID(ctx context.Context, data model.TemplateRequestData) (model.TemplateResponseData, error)
+		GetTemplatesByUserID(ctx context.Context, userID string) ([]model.TemplateResponseData, error)
+		InstantiateTemplate(ctx context.Context, data *model.TemplateInstantiateRequestData) (model.TemplateInstanceResponseData, error)
+		DeleteTemplate(ctx context.Context, data model.TemplateRequestData) error
+	}
+
+	TemplateStorage interface {
+		Transaction(ctx context.Context, fn func(storage TemplateStorage) error) error
+		CreateTemplate(ctx context.Context, template model.Template) error
+		GetTemplateByID(ctx context.Context, templateID, userID string) (model.Template, error)
+		GetTemplatesByUserID(ctx context.Context, userID string) ([]model.Template, error)
+		DeleteTemplate(ctx context.Context, template model.Template) error
+
+		// The list instantiated from the template is created with its headings and tasks in one transaction
+		CreateList(ctx context.Context, list model.List) error
+		CreateHeading(ctx context.Context, heading model.Heading) error
+		GetTaskStatusID(ctx context.Context, status model.StatusName) (int, error)
+		CreateTask(ctx context.Context, task model.Task) error
+		LinkTagsToTask(ctx context.Context, userID, taskID string, tags []string) error
+	}
+)
@@ -0,0 +1,33 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	TimeEntryUsecase interface {
+		StartTimer(ctx context.Context, data model.TimeEntryRequestData) (model.TimeEntryResponseData, error)
+		StopTimer(ctx context.Context, data model.TimeEntryRequestData) (model.TimeEntryResponseData, error)
+		CreateTimeEntry(ctx context.Context, data *model.TimeEntryRequestData) (model.TimeEntryResponseData, error)
+		GetTimeEntriesByTaskID(ctx context.Context, data model.TimeEntryRequestData) ([]model.TimeEntryResponseData, error)
+		UpdateTimeEntry(ctx context.Context, data *model.TimeEntryRequestData) (model.TimeEntryResponseData, error)
+		DeleteTimeEntry(ctx context.Context, data model.TimeEntryRequestData) error
+		GetTimeReport(ctx context.Context, data model.TimeReportRequestData) (model.TimeReportResponseData, error)
+	}
+
+	TimeEntryStorage interface {
+		Transaction(ctx context.Context, fn func(storage TimeEntryStorage) error) error
+		CreateTimeEntry(ctx context.Context, entry model.TimeEntry) error
+		GetTimeEntryByID(ctx context.Context, entryID, userID string) (model.TimeEntry, error)
+		GetTimeEntriesByTaskID(ctx context.Context, taskID, userID string) ([]model.TimeEntry, error)
+		GetRunningTimeEntry(ctx context.Context, userID string) (model.TimeEntry, error)
+		StopTimeEntry(ctx context.Context, entry model.TimeEntry) error
+		UpdateTimeEntry(ctx context.Context, entry model.TimeEntry) error
+		DeleteTimeEntry(ctx context.Context, entry model.TimeEntry) error
+		GetTimeReportByList(ctx context.Context, userID string, period model.TimeReportPeriod) ([]model.ListTimeTotal, error)
+		GetTimeReportByTag(ctx context.Context, userID string, period model.TimeReportPeriod) ([]model.TagTimeTotal, error)
+		GetTimeReportByDay(ctx context.Context, userID string, period model.TimeReportPeriod) ([]model.DayTimeTotal, error)
+	}
+)
@@ -1,13 +1,23 @@
 package port
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
 
 type (
 	UserUsecase interface {
 		DeleteUserRelatedData(ctx context.Context, userID string) error
+		GetUserSettings(ctx context.Context, userID string) (model.UserSettingsResponseData, error)
+		UpdateUserSettings(ctx context.Context, data *model.UserSettingsRequestData) (model.UserSettingsResponseData, error)
+		GetUserLocation(ctx context.Context, userID string) (*time.Location, error)
 	}
 
 	UserStorage interface {
 		DeleteUserData(ctx context.Context, userID string) error
+		GetUserSettings(ctx context.Context, userID string) (model.UserSettings, error)
+		UpdateUserSettings(ctx context.Context, settings model.UserSettings) error
 	}
 )
@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type AreaStorage struct {
+	db dbtx
+	*sqlc.Queries
+}
+
+func NewAreaStorage(pool *pgxpool.Pool) *AreaStorage {
+	return &AreaStorage{
+		db:      pool,
+		Queries: sqlc.New(pool),
+	}
+}
+
+func (s *AreaStorage) Transaction(ctx context.Context, fn func(storage port.AreaStorage) error) error {
+	return transaction(ctx, s.db, func(tx pgx.Tx) error {
+		return fn(&AreaStorage{
+			db:      tx,
+			Queries: sqlc.New(tx),
+		})
+	})
+}
+
+// CreateArea places the new area after the other areas of the user
+func (s *AreaStorage) CreateArea(ctx context.Context, area model.Area) error {
+	const op = "area.storage.CreateArea"
+
+	if err := s.Queries.CreateArea(ctx, sqlc.CreateAreaParams{
+		ID:        area.ID,
+		Title:     area.Title,
+		UserID:    area.UserID,
+		CreatedAt: area.CreatedAt,
+		UpdatedAt: area.UpdatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create new area: %w", op, err)
+	}
+	return nil
+}
+
+func (s *AreaStorage) GetAreaByID(ctx context.Context, areaID, userID string) (model.Area, error) {
+	const op = "area.storage.GetAreaByID"
+
+	area, err := s.Queries.GetAreaByID(ctx, sqlc.GetAreaByIDParams{
+		ID:     areaID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Area{}, le.ErrAreaNotFound
+	}
+	if err != nil {
+		return model.Area{}, fmt.Errorf("%s: failed to get area: %w", op, err)
+	}
+
+	return model.Area{
+		ID:            area.ID,
+		Title:         area.Title,
+		DefaultListID: area.DefaultListID.String,
+		UserID:        area.UserID,
+		UpdatedAt:     area.UpdatedAt,
+	}, nil
+}
+
+func (s *AreaStorage) GetAreasByUserID(ctx context.Context, userID string) ([]model.Area, error) {
+	const op = "area.storage.GetAreasByUserID"
+
+	items, err := s.Queries.GetAreasByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get areas: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoAreasFound
+	}
+
+	var areas []model.Area
+
+	for _, item := range items {
+		areas = append(areas, model.Area{
+			ID:            item.ID,
+			Title:         item.Title,
+			DefaultListID: item.DefaultListID.String,
+			UpdatedAt:     item.UpdatedAt,
+		})
+	}
+	return areas, nil
+}
+
+func (s *AreaStorage) UpdateArea(ctx context.Context, area model.Area) error {
+	const op = "area.storage.UpdateArea"
+
+	_, err := s.Queries.UpdateArea(ctx, sqlc.UpdateAreaParams{
+		Title:     area.Title,
+		UpdatedAt: area.UpdatedAt,
+		ID:        area.ID,
+		UserID:    area.UserID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrAreaNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to update area: %w", op, err)
+	}
+	return nil
+}
+
+// UpdateAreasPosition sets the position of each area to its index in areaIDs
+func (s *AreaStorage) UpdateAreasPosition(ctx context.Context, userID string, areaIDs []string) error {
+	const op = "area.storage.UpdateAreasPosition"
+
+	if err := s.Queries.UpdateAreasPosition(ctx, sqlc.UpdateAreasPositionParams{
+		AreaIds: areaIDs,
+		UserID:  userID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to update position of areas: %w", op, err)
+	}
+	return nil
+}
+
+func (s *AreaStorage) DeleteArea(ctx context.Context, area model.Area) error {
+	const op = "area.storage.DeleteArea"
+
+	_, err := s.Queries.DeleteArea(ctx, sqlc.DeleteAreaParams{
+		ID:     area.ID,
+		UserID: area.UserID,
+		DeletedAt: pgtype.Timestamptz{
+			Time:  area.DeletedAt,
+			Valid: true,
+		},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrAreaNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete area: %w", op, err)
+	}
+	return nil
+}
+
+// The lists, headings and tasks of the area are written by their own storages,
+// which run on the same pool or transaction as the area storage
+
+func (s *AreaStorage) lists() *ListStorage {
+	return &ListStorage{db: s.db, Queries: s.Queries}
+}
+
+func (s *AreaStorage) headings() *HeadingStorage {
+	return &HeadingStorage{db: s.db, Queries: s.Queries}
+}
+
+func (s *AreaStorage) tasks() *TaskStorage {
+	return &TaskStorage{db: s.db, Queries: s.Queries}
+}
+
+func (s *AreaStorage) CreateList(ctx context.Context, list model.List) error {
+	return s.lists().CreateList(ctx, list)
+}
+
+func (s *AreaStorage) CreateHeading(ctx context.Context, heading model.Heading) error {
+	return s.headings().CreateHeading(ctx, heading)
+}
+
+func (s *AreaStorage) GetListIDsByAreaID(ctx context.Context, areaID, userID string) ([]string, error) {
+	return s.lists().GetListIDsByAreaID(ctx, areaID, userID)
+}
+
+func (s *AreaStorage) UpdateList(ctx context.Context, list model.List) error {
+	return s.lists().UpdateList(ctx, list, nil)
+}
+
+func (s *AreaStorage) MoveListsToTopLevel(ctx context.Context, list model.List) error {
+	return s.lists().MoveListsToTopLevel(ctx, list)
+}
+
+func (s *AreaStorage) DeleteList(ctx context.Context, list model.List) error {
+	return s.lists().DeleteList(ctx, list)
+}
+
+func (s *AreaStorage) DeleteHeadingsByListID(ctx context.Context, deletedHeadings model.Heading) error {
+	return s.headings().DeleteHeadingsByListID(ctx, deletedHeadings)
+}
+
+func (s *AreaStorage) GetTaskStatusID(ctx context.Context, status model.StatusName) (int, error) {
+	return s.tasks().GetTaskStatusID(ctx, status)
+}
+
+func (s *AreaStorage) MarkTasksAsArchivedByListID(ctx context.Context, archivedTasks model.Task) error {
+	return s.tasks().MarkTasksAsArchivedByListID(ctx, archivedTasks)
+}
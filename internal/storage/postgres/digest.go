@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type DigestStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewDigestStorage(pool *pgxpool.Pool) *DigestStorage {
+	return &DigestStorage{
+		Pool:    pool,
+		Queries: sqlc.New(pool),
+	}
+}
+
+func (s *DigestStorage) GetDigestSettings(ctx context.Context, userID string) (model.DigestSettings, error) {
+	const op = "digest.storage.GetDigestSettings"
+
+	settings, err := s.Queries.GetDigestSettings(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.DigestSettings{}, le.ErrDigestSettingsNotFound
+	}
+	if err != nil {
+		return model.DigestSettings{}, fmt.Errorf("%s: failed to get digest settings: %w", op, err)
+	}
+
+	return model.DigestSettings{
+		UserID:           settings.UserID,
+		Email:            settings.Email,
+		Daily:            settings.Daily,
+		Weekly:           settings.Weekly,
+		SendTime:         settings.SendTime,
+		WeeklyDay:        time.Weekday(settings.WeeklyDay),
+		UnsubscribeToken: settings.UnsubscribeToken,
+		UpdatedAt:        settings.UpdatedAt,
+	}, nil
+}
+
+// UpsertDigestSettings keeps the unsubscribe token of the existing settings
+func (s *DigestStorage) UpsertDigestSettings(ctx context.Context, settings model.DigestSettings) error {
+	const op = "digest.storage.UpsertDigestSettings"
+
+	if err := s.Queries.UpsertDigestSettings(ctx, sqlc.UpsertDigestSettingsParams{
+		UserID:           settings.UserID,
+		Email:            settings.Email,
+		Daily:            settings.Daily,
+		Weekly:           settings.Weekly,
+		SendTime:         settings.SendTime,
+		WeeklyDay:        int32(settings.WeeklyDay),
+		UnsubscribeToken: settings.UnsubscribeToken,
+		CreatedAt:        settings.CreatedAt,
+		UpdatedAt:        settings.UpdatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to update digest settings: %w", op, err)
+	}
+	return nil
+}
+
+func (s *DigestStorage) UnsubscribeFromDigests(ctx context.Context, unsubscribeToken string, updatedAt time.Time) error {
+	const op = "digest.storage.UnsubscribeFromDigests"
+
+	_, err := s.Queries.UnsubscribeFromDigests(ctx, sqlc.UnsubscribeFromDigestsParams{
+		UpdatedAt:        updatedAt,
+		UnsubscribeToken: unsubscribeToken,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrDigestUnsubscribeTokenNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to unsubscribe from digests: %w", op, err)
+	}
+	return nil
+}
+
+func (s *DigestStorage) GetEnabledDigestSettings(ctx context.Context) ([]model.DigestSettings, error) {
+	const op = "digest.storage.GetEnabledDigestSettings"
+
+	items, err := s.Queries.GetEnabledDigestSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get digest settings: %w", op, err)
+	}
+
+	var settings []model.DigestSettings
+
+	for _, item := range items {
+		settings = append(settings, model.DigestSettings{
+			UserID:       item.UserID,
+			Email:        item.Email,
+			Daily:        item.Daily,
+			Weekly:       item.Weekly,
+			SendTime:     item.SendTime,
+			WeeklyDay:    time.Weekday(item.WeeklyDay),
+			DailySentAt:  item.DailySentAt.Time,
+			WeeklySentAt: item.WeeklySentAt.Time,
+		})
+	}
+	return settings, nil
+}
+
+// ClaimDailyDigest marks the daily digest as sent, it returns false if the digest was already sent after dueAt,
+// e.g. by another instance of the service
+func (s *DigestStorage) ClaimDailyDigest(ctx context.Context, userID string, sentAt, dueAt time.Time) (bool, error) {
+	const op = "digest.storage.ClaimDailyDigest"
+
+	_, err := s.Queries.ClaimDailyDigest(ctx, sqlc.ClaimDailyDigestParams{
+		SentAt: pgtype.Timestamptz{
+			Time:  sentAt,
+			Valid: true,
+		},
+		UserID: userID,
+		DueAt: pgtype.Timestamptz{
+			Time:  dueAt,
+			Valid: true,
+		},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to claim daily digest: %w", op, err)
+	}
+	return true, nil
+}
+
+// ClaimWeeklyDigest works the same way as ClaimDailyDigest for the weekly digest
+func (s *DigestStorage) ClaimWeeklyDigest(ctx context.Context, userID string, sentAt, dueAt time.Time) (bool, error) {
+	const op = "digest.storage.ClaimWeeklyDigest"
+
+	_, err := s.Queries.ClaimWeeklyDigest(ctx, sqlc.ClaimWeeklyDigestParams{
+		SentAt: pgtype.Timestamptz{
+			Time:  sentAt,
+			Valid: true,
+		},
+		UserID: userID,
+		DueAt: pgtype.Timestamptz{
+			Time:  dueAt,
+			Valid: true,
+		},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to claim weekly digest: %w", op, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,235 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type FocusSessionStorage struct {
+	db dbtx
+	*sqlc.Queries
+}
+
+func NewFocusSessionStorage(pool *pgxpool.Pool) port.FocusSessionStorage {
+	return &FocusSessionStorage{
+		db:      pool,
+		Queries: sqlc.New(pool),
+	}
+}
+
+func (s *FocusSessionStorage) Transaction(ctx context.Context, fn func(storage port.FocusSessionStorage) error) error {
+	return transaction(ctx, s.db, func(tx pgx.Tx) error {
+		return fn(&FocusSessionStorage{
+			db:      tx,
+			Queries: sqlc.New(tx),
+		})
+	})
+}
+
+func (s *FocusSessionStorage) CreateFocusSession(ctx context.Context, session model.FocusSession) error {
+	const op = "focus_session.storage.CreateFocusSession"
+
+	if err := s.Queries.CreateFocusSession(ctx, sqlc.CreateFocusSessionParams{
+		ID:              session.ID,
+		TaskID:          session.TaskID,
+		UserID:          session.UserID,
+		DurationMinutes: int32(session.DurationMinutes),
+		Status:          session.Status.String(),
+		StartedAt:       session.StartedAt,
+		EndsAt:          session.EndsAt,
+		CreatedAt:       session.CreatedAt,
+		UpdatedAt:       session.UpdatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to insert new focus session: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *FocusSessionStorage) CreateFocusSessionEvent(ctx context.Context, event model.FocusSessionEvent) error {
+	const op = "focus_session.storage.CreateFocusSessionEvent"
+
+	if err := s.Queries.CreateFocusSessionEvent(ctx, sqlc.CreateFocusSessionEventParams{
+		SessionID: event.SessionID,
+		UserID:    event.UserID,
+		Event:     event.Event.String(),
+		CreatedAt: event.CreatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to insert focus session event: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *FocusSessionStorage) GetFocusSessionByID(ctx context.Context, sessionID, userID string) (model.FocusSession, error) {
+	const op = "focus_session.storage.GetFocusSessionByID"
+
+	session, err := s.Queries.GetFocusSessionByID(ctx, sqlc.GetFocusSessionByIDParams{
+		ID:     sessionID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.FocusSession{}, le.ErrFocusSessionNotFound
+	}
+	if err != nil {
+		return model.FocusSession{}, fmt.Errorf("%s: failed to get focus session: %w", op, err)
+	}
+
+	return model.FocusSession{
+		ID:              session.ID,
+		TaskID:          session.TaskID,
+		UserID:          session.UserID,
+		DurationMinutes: int(session.DurationMinutes),
+		Status:          model.FocusSessionStatus(session.Status),
+		StartedAt:       session.StartedAt,
+		EndsAt:          session.EndsAt,
+		PausedAt:        session.PausedAt.Time,
+		FinishedAt:      session.FinishedAt.Time,
+		UpdatedAt:       session.UpdatedAt,
+	}, nil
+}
+
+func (s *FocusSessionStorage) GetActiveFocusSession(ctx context.Context, userID string) (model.FocusSession, error) {
+	const op = "focus_session.storage.GetActiveFocusSession"
+
+	session, err := s.Queries.GetActiveFocusSession(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.FocusSession{}, le.ErrNoActiveFocusSession
+	}
+	if err != nil {
+		return model.FocusSession{}, fmt.Errorf("%s: failed to get active focus session: %w", op, err)
+	}
+
+	return model.FocusSession{
+		ID:              session.ID,
+		TaskID:          session.TaskID,
+		UserID:          session.UserID,
+		DurationMinutes: int(session.DurationMinutes),
+		Status:          model.FocusSessionStatus(session.Status),
+		StartedAt:       session.StartedAt,
+		EndsAt:          session.EndsAt,
+		PausedAt:        session.PausedAt.Time,
+		FinishedAt:      session.FinishedAt.Time,
+		UpdatedAt:       session.UpdatedAt,
+	}, nil
+}
+
+func (s *FocusSessionStorage) GetFocusSessionEvents(ctx context.Context, sessionID, userID string) ([]model.FocusSessionEvent, error) {
+	const op = "focus_session.storage.GetFocusSessionEvents"
+
+	items, err := s.Queries.GetFocusSessionEvents(ctx, sqlc.GetFocusSessionEventsParams{
+		SessionID: sessionID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get focus session events: %w", op, err)
+	}
+
+	var events []model.FocusSessionEvent
+
+	for _, item := range items {
+		events = append(events, model.FocusSessionEvent{
+			SessionID: sessionID,
+			UserID:    userID,
+			Event:     model.FocusSessionEventName(item.Event),
+			CreatedAt: item.CreatedAt,
+		})
+	}
+
+	return events, nil
+}
+
+// UpdateFocusSession updates the session only if it still has the expected status,
+// so of two concurrent changes of the same session only the first one is applied
+func (s *FocusSessionStorage) UpdateFocusSession(ctx context.Context, session model.FocusSession, expectedStatus model.FocusSessionStatus) error {
+	const op = "focus_session.storage.UpdateFocusSession"
+
+	sessionParams := sqlc.UpdateFocusSessionParams{
+		Status:         session.Status.String(),
+		EndsAt:         session.EndsAt,
+		UpdatedAt:      session.UpdatedAt,
+		ID:             session.ID,
+		UserID:         session.UserID,
+		ExpectedStatus: expectedStatus.String(),
+	}
+	if !session.PausedAt.IsZero() {
+		sessionParams.PausedAt = pgtype.Timestamptz{
+			Time:  session.PausedAt,
+			Valid: true,
+		}
+	}
+	if !session.FinishedAt.IsZero() {
+		sessionParams.FinishedAt = pgtype.Timestamptz{
+			Time:  session.FinishedAt,
+			Valid: true,
+		}
+	}
+
+	_, err := s.Queries.UpdateFocusSession(ctx, sessionParams)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrInvalidFocusSessionTransition
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to update focus session: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *FocusSessionStorage) CompleteDueFocusSessions(ctx context.Context, userID string, now time.Time) ([]model.FocusSession, error) {
+	const op = "focus_session.storage.CompleteDueFocusSessions"
+
+	items, err := s.Queries.CompleteDueFocusSessions(ctx, sqlc.CompleteDueFocusSessionsParams{
+		UpdatedAt: now,
+		UserID:    userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to complete focus sessions: %w", op, err)
+	}
+
+	var sessions []model.FocusSession
+
+	for _, item := range items {
+		sessions = append(sessions, model.FocusSession{
+			ID:         item.ID,
+			UserID:     userID,
+			Status:     model.FocusSessionCompleted,
+			EndsAt:     item.EndsAt,
+			FinishedAt: item.EndsAt,
+			UpdatedAt:  now,
+		})
+	}
+
+	return sessions, nil
+}
+
+// GetFocusSessionsSummary counts the running sessions whose time is up at now as completed
+func (s *FocusSessionStorage) GetFocusSessionsSummary(ctx context.Context, userID string, from, to, now time.Time) (model.FocusSessionsSummary, error) {
+	const op = "focus_session.storage.GetFocusSessionsSummary"
+
+	summary, err := s.Queries.GetFocusSessionsSummary(ctx, sqlc.GetFocusSessionsSummaryParams{
+		NowTime:  pgtype.Timestamptz{Time: now, Valid: true},
+		UserID:   userID,
+		FromTime: pgtype.Timestamptz{Time: from, Valid: true},
+		ToTime:   pgtype.Timestamptz{Time: to, Valid: true},
+	})
+	if err != nil {
+		return model.FocusSessionsSummary{}, fmt.Errorf("%s: failed to get focus sessions summary: %w", op, err)
+	}
+
+	return model.FocusSessionsSummary{
+		Completed:      int(summary.Completed),
+		Abandoned:      int(summary.Abandoned),
+		FocusedMinutes: int(summary.FocusedMinutes),
+	}, nil
+}
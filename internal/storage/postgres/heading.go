@@ -12,21 +12,31 @@ import (
 
 	"github.com/rshelekhov/reframed/internal/lib/constant/le"
 	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
 	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
 )
 
 type HeadingStorage struct {
-	*pgxpool.Pool
+	db dbtx
 	*sqlc.Queries
 }
 
 func NewHeadingStorage(pool *pgxpool.Pool) *HeadingStorage {
 	return &HeadingStorage{
-		Pool:    pool,
+		db:      pool,
 		Queries: sqlc.New(pool),
 	}
 }
 
+func (s *HeadingStorage) Transaction(ctx context.Context, fn func(storage port.HeadingStorage) error) error {
+	return transaction(ctx, s.db, func(tx pgx.Tx) error {
+		return fn(&HeadingStorage{
+			db:      tx,
+			Queries: sqlc.New(tx),
+		})
+	})
+}
+
 func (s *HeadingStorage) CreateHeading(ctx context.Context, heading model.Heading) error {
 	const op = "heading.storage.CreateHeading"
 
@@ -61,6 +71,24 @@ func (s *HeadingStorage) GetDefaultHeadingID(ctx context.Context, listID, userID
 	return headingID, nil
 }
 
+func (s *HeadingStorage) GetHeadingIDByTitle(ctx context.Context, title, listID, userID string) (string, error) {
+	const op = "heading.storage.GetHeadingIDByTitle"
+
+	headingID, err := s.Queries.GetHeadingIDByTitle(ctx, sqlc.GetHeadingIDByTitleParams{
+		Title:  title,
+		ListID: listID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", le.ErrHeadingNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to get heading by title: %w", op, err)
+	}
+
+	return headingID, nil
+}
+
 func (s *HeadingStorage) GetHeadingByID(ctx context.Context, headingID, userID string) (model.Heading, error) {
 	const op = "heading.storage.GetHeadingByID"
 
@@ -77,11 +105,13 @@ func (s *HeadingStorage) GetHeadingByID(ctx context.Context, headingID, userID s
 	}
 
 	return model.Heading{
-		ID:        heading.ID,
-		Title:     heading.Title,
-		ListID:    heading.ListID,
-		UserID:    heading.UserID,
-		UpdatedAt: heading.UpdatedAt,
+		ID:          heading.ID,
+		Title:       heading.Title,
+		ListID:      heading.ListID,
+		UserID:      heading.UserID,
+		IsDefault:   heading.IsDefault,
+		CompletedAt: heading.CompletedAt.Time,
+		UpdatedAt:   heading.UpdatedAt,
 	}, nil
 }
 
@@ -161,6 +191,64 @@ func (s *HeadingStorage) MoveHeadingToAnotherList(ctx context.Context, heading m
 	return nil
 }
 
+// MoveTasksToHeading moves all tasks of the heading to another heading,
+// the custom statuses of another list are reset
+func (s *HeadingStorage) MoveTasksToHeading(ctx context.Context, fromHeadingID string, task model.Task) error {
+	const op = "heading.storage.MoveTasksToHeading"
+
+	if err := s.Queries.MoveTasksToHeading(ctx, sqlc.MoveTasksToHeadingParams{
+		HeadingID:     task.HeadingID,
+		ListID:        task.ListID,
+		UpdatedAt:     task.UpdatedAt,
+		FromHeadingID: fromHeadingID,
+		UserID:        task.UserID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to move tasks: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *HeadingStorage) CompleteHeading(ctx context.Context, heading model.Heading) error {
+	const op = "heading.storage.CompleteHeading"
+
+	_, err := s.Queries.CompleteHeading(ctx, sqlc.CompleteHeadingParams{
+		CompletedAt: pgtype.Timestamptz{
+			Time:  heading.CompletedAt,
+			Valid: true,
+		},
+		UpdatedAt: heading.UpdatedAt,
+		ID:        heading.ID,
+		UserID:    heading.UserID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrHeadingNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to complete heading: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *HeadingStorage) RestoreHeading(ctx context.Context, heading model.Heading) error {
+	const op = "heading.storage.RestoreHeading"
+
+	_, err := s.Queries.RestoreHeading(ctx, sqlc.RestoreHeadingParams{
+		UpdatedAt: heading.UpdatedAt,
+		ID:        heading.ID,
+		UserID:    heading.UserID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrHeadingNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to restore heading: %w", op, err)
+	}
+
+	return nil
+}
+
 func (s *HeadingStorage) DeleteHeading(ctx context.Context, heading model.Heading) error {
 	const op = "heading.storage.DeleteHeading"
 
@@ -199,3 +287,38 @@ func (s *HeadingStorage) DeleteHeadingsByListID(ctx context.Context, deletedHead
 
 	return nil
 }
+
+// The lists and tasks of the converted headings are written by their own storages,
+// which run on the same pool or transaction as the heading storage
+
+func (s *HeadingStorage) lists() *ListStorage {
+	return &ListStorage{db: s.db, Queries: s.Queries}
+}
+
+func (s *HeadingStorage) tasks() *TaskStorage {
+	return &TaskStorage{db: s.db, Queries: s.Queries}
+}
+
+func (s *HeadingStorage) CreateList(ctx context.Context, list model.List) error {
+	return s.lists().CreateList(ctx, list)
+}
+
+func (s *HeadingStorage) DeleteList(ctx context.Context, list model.List) error {
+	return s.lists().DeleteList(ctx, list)
+}
+
+func (s *HeadingStorage) GetTaskStatusID(ctx context.Context, status model.StatusName) (int, error) {
+	return s.tasks().GetTaskStatusID(ctx, status)
+}
+
+func (s *HeadingStorage) MarkTasksAsArchivedByListID(ctx context.Context, archivedTasks model.Task) error {
+	return s.tasks().MarkTasksAsArchivedByListID(ctx, archivedTasks)
+}
+
+func (s *HeadingStorage) MarkTasksAsClosedByHeadingID(ctx context.Context, closedTasks model.Task, completedStatusID int) error {
+	return s.tasks().MarkTasksAsClosedByHeadingID(ctx, closedTasks, completedStatusID)
+}
+
+func (s *HeadingStorage) MarkTasksAsReopenedByHeadingID(ctx context.Context, reopenedTasks model.Task) error {
+	return s.tasks().MarkTasksAsReopenedByHeadingID(ctx, reopenedTasks)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 
@@ -12,29 +14,59 @@ import (
 
 	"github.com/rshelekhov/reframed/internal/lib/constant/le"
 	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
 	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
 )
 
 type ListStorage struct {
-	*pgxpool.Pool
+	db dbtx
 	*sqlc.Queries
 }
 
 func NewListStorage(pool *pgxpool.Pool) *ListStorage {
 	return &ListStorage{
-		Pool:    pool,
+		db:      pool,
 		Queries: sqlc.New(pool),
 	}
 }
 
+func (s *ListStorage) Transaction(ctx context.Context, fn func(storage port.ListStorage) error) error {
+	return transaction(ctx, s.db, func(tx pgx.Tx) error {
+		return fn(&ListStorage{
+			db:      tx,
+			Queries: sqlc.New(tx),
+		})
+	})
+}
+
 func (s *ListStorage) CreateList(ctx context.Context, list model.List) error {
 	const op = "list.storage.CreateList"
 
 	if err := s.Queries.CreateList(ctx, sqlc.CreateListParams{
-		ID:        list.ID,
-		Title:     list.Title,
+		ID:    list.ID,
+		Title: list.Title,
+		Description: pgtype.Text{
+			String: list.Description,
+			Valid:  list.Description != "",
+		},
+		Deadline: pgtype.Timestamptz{
+			Time:  list.Deadline,
+			Valid: !list.Deadline.IsZero(),
+		},
+		Color: pgtype.Text{
+			String: list.Color,
+			Valid:  list.Color != "",
+		},
+		Icon: pgtype.Text{
+			String: list.Icon,
+			Valid:  list.Icon != "",
+		},
 		IsDefault: list.IsDefault,
 		UserID:    list.UserID,
+		AreaID: pgtype.Text{
+			String: list.AreaID,
+			Valid:  list.AreaID != "",
+		},
 		CreatedAt: list.CreatedAt,
 		UpdatedAt: list.UpdatedAt,
 	}); err != nil {
@@ -47,8 +79,9 @@ func (s *ListStorage) GetListByID(ctx context.Context, listID, userID string) (m
 	const op = "list.storage.GetListByID"
 
 	list, err := s.Queries.GetListByID(ctx, sqlc.GetListByIDParams{
-		ID:     listID,
-		UserID: userID,
+		StatusTitle: model.StatusCompleted.String(),
+		ID:          listID,
+		UserID:      userID,
 	})
 	if errors.Is(err, pgx.ErrNoRows) {
 		return model.List{}, le.ErrListNotFound
@@ -58,18 +91,28 @@ func (s *ListStorage) GetListByID(ctx context.Context, listID, userID string) (m
 	}
 
 	return model.List{
-		ID:        list.ID,
-		Title:     list.Title,
-		UserID:    list.UserID,
-		IsDefault: list.IsDefault,
-		UpdatedAt: list.UpdatedAt,
+		ID:             list.ID,
+		Title:          list.Title,
+		Description:    list.Description.String,
+		Deadline:       list.Deadline.Time,
+		Color:          list.Color.String,
+		Icon:           list.Icon.String,
+		UserID:         list.UserID,
+		IsDefault:      list.IsDefault,
+		AreaID:         list.AreaID.String,
+		UpdatedAt:      list.UpdatedAt,
+		TotalTasks:     int(list.TotalTasks),
+		CompletedTasks: int(list.CompletedTasks),
 	}, nil
 }
 
 func (s *ListStorage) GetListsByUserID(ctx context.Context, userID string) ([]model.List, error) {
 	const op = "list.storage.GetListsByUserID"
 
-	items, err := s.Queries.GetListsByUserID(ctx, userID)
+	items, err := s.Queries.GetListsByUserID(ctx, sqlc.GetListsByUserIDParams{
+		StatusTitle: model.StatusCompleted.String(),
+		UserID:      userID,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to get lists: %w", op, err)
 	}
@@ -81,9 +124,47 @@ func (s *ListStorage) GetListsByUserID(ctx context.Context, userID string) ([]mo
 
 	for _, item := range items {
 		lists = append(lists, model.List{
-			ID:        item.ID,
-			Title:     item.Title,
-			UpdatedAt: item.UpdatedAt,
+			ID:             item.ID,
+			Title:          item.Title,
+			Description:    item.Description.String,
+			Deadline:       item.Deadline.Time,
+			Color:          item.Color.String,
+			Icon:           item.Icon.String,
+			AreaID:         item.AreaID.String,
+			IsDefault:      item.IsDefault,
+			UpdatedAt:      item.UpdatedAt,
+			TotalTasks:     int(item.TotalTasks),
+			CompletedTasks: int(item.CompletedTasks),
+		})
+	}
+	return lists, nil
+}
+
+// GetOverdueLists returns the lists with the deadline before today which still have open tasks
+func (s *ListStorage) GetOverdueLists(ctx context.Context, userID string, pgn model.Pagination, today time.Time) ([]model.List, error) {
+	const op = "list.storage.GetOverdueLists"
+
+	items, err := s.Queries.GetOverdueLists(ctx, sqlc.GetOverdueListsParams{
+		UserID: userID,
+		Cursor: pgn.Cursor,
+		Today: pgtype.Timestamptz{
+			Valid: true,
+			Time:  today,
+		},
+		StatusTitle: model.StatusCompleted.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get overdue lists: %w", op, err)
+	}
+
+	var lists []model.List
+
+	for _, item := range items {
+		lists = append(lists, model.List{
+			ID:       item.ID,
+			Title:    item.Title,
+			Deadline: item.Deadline.Time,
+			UserID:   userID,
 		})
 	}
 	return lists, nil
@@ -102,11 +183,84 @@ func (s *ListStorage) GetDefaultListID(ctx context.Context, userID string) (stri
 	return listID, nil
 }
 
-func (s *ListStorage) UpdateList(ctx context.Context, list model.List) error {
+func (s *ListStorage) GetListIDByTitle(ctx context.Context, title, userID string) (string, error) {
+	const op = "list.storage.GetListIDByTitle"
+
+	listID, err := s.Queries.GetListIDByTitle(ctx, sqlc.GetListIDByTitleParams{
+		Title:  title,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", le.ErrListNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to get list by title: %w", op, err)
+	}
+	return listID, nil
+}
+
+// GetListIDsByAreaID returns the ordered lists of the area, an empty areaID returns the lists
+// of the top level. The default lists are not ordered, so they are not returned
+func (s *ListStorage) GetListIDsByAreaID(ctx context.Context, areaID, userID string) ([]string, error) {
+	const op = "list.storage.GetListIDsByAreaID"
+
+	listIDs, err := s.Queries.GetListIDsByAreaID(ctx, sqlc.GetListIDsByAreaIDParams{
+		UserID: userID,
+		AreaID: areaID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get lists of area: %w", op, err)
+	}
+	return listIDs, nil
+}
+
+// UpdateList keeps the optional fields that are empty in the list, unless they are cleared
+func (s *ListStorage) UpdateList(ctx context.Context, list model.List, clear []model.ListField) error {
 	const op = "list.storage.UpdateList"
 
 	_, err := s.Queries.UpdateList(ctx, sqlc.UpdateListParams{
-		Title:     list.Title,
+		Title:          list.Title,
+		UpdatedAt:      list.UpdatedAt,
+		ID:             list.ID,
+		UserID:         list.UserID,
+		DescriptionSet: list.Description != "" || slices.Contains(clear, model.ListFieldDescription),
+		Description: pgtype.Text{
+			String: list.Description,
+			Valid:  list.Description != "",
+		},
+		DeadlineSet: !list.Deadline.IsZero() || slices.Contains(clear, model.ListFieldDeadline),
+		Deadline: pgtype.Timestamptz{
+			Time:  list.Deadline,
+			Valid: !list.Deadline.IsZero(),
+		},
+		ColorSet: list.Color != "" || slices.Contains(clear, model.ListFieldColor),
+		Color: pgtype.Text{
+			String: list.Color,
+			Valid:  list.Color != "",
+		},
+		IconSet: list.Icon != "" || slices.Contains(clear, model.ListFieldIcon),
+		Icon: pgtype.Text{
+			String: list.Icon,
+			Valid:  list.Icon != "",
+		},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrListNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to update list: %w", op, err)
+	}
+	return nil
+}
+
+func (s *ListStorage) UpdateListArea(ctx context.Context, list model.List) error {
+	const op = "list.storage.UpdateListArea"
+
+	_, err := s.Queries.UpdateListArea(ctx, sqlc.UpdateListAreaParams{
+		AreaID: pgtype.Text{
+			String: list.AreaID,
+			Valid:  list.AreaID != "",
+		},
 		UpdatedAt: list.UpdatedAt,
 		ID:        list.ID,
 		UserID:    list.UserID,
@@ -115,7 +269,35 @@ func (s *ListStorage) UpdateList(ctx context.Context, list model.List) error {
 		return le.ErrListNotFound
 	}
 	if err != nil {
-		return fmt.Errorf("%s: failed to update list: %w", op, err)
+		return fmt.Errorf("%s: failed to update area of list: %w", op, err)
+	}
+	return nil
+}
+
+// UpdateListsPosition sets the position of each list to its index in listIDs
+func (s *ListStorage) UpdateListsPosition(ctx context.Context, userID string, listIDs []string) error {
+	const op = "list.storage.UpdateListsPosition"
+
+	if err := s.Queries.UpdateListsPosition(ctx, sqlc.UpdateListsPositionParams{
+		ListIds: listIDs,
+		UserID:  userID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to update position of lists: %w", op, err)
+	}
+	return nil
+}
+
+// MoveListsToTopLevel moves the lists of the area after the lists of the top level,
+// the default list of the area becomes a regular list
+func (s *ListStorage) MoveListsToTopLevel(ctx context.Context, list model.List) error {
+	const op = "list.storage.MoveListsToTopLevel"
+
+	if err := s.Queries.MoveListsToTopLevel(ctx, sqlc.MoveListsToTopLevelParams{
+		UserID:    list.UserID,
+		UpdatedAt: list.UpdatedAt,
+		AreaID:    list.AreaID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to move lists to top level: %w", op, err)
 	}
 	return nil
 }
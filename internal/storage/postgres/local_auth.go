@@ -0,0 +1,316 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+// uniqueViolationCode is the Postgres error code returned when a unique constraint is violated
+const uniqueViolationCode = "23505"
+
+type LocalAuthStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewLocalAuthStorage(pool *pgxpool.Pool) *LocalAuthStorage {
+	return &LocalAuthStorage{
+		Pool:    pool,
+		Queries: sqlc.New(pool),
+	}
+}
+
+func (s *LocalAuthStorage) CreateUser(ctx context.Context, user model.AuthUser) error {
+	const op = "local_auth.storage.CreateUser"
+
+	err := s.Queries.CreateAuthUser(ctx, sqlc.CreateAuthUserParams{
+		ID:           user.ID,
+		Email:        user.Email,
+		PasswordHash: user.PasswordHash,
+		CreatedAt:    user.CreatedAt,
+		UpdatedAt:    user.UpdatedAt,
+	})
+	if isUniqueViolation(err) {
+		return le.ErrUserAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to create user: %w", op, err)
+	}
+	return nil
+}
+
+func (s *LocalAuthStorage) GetUserByEmail(ctx context.Context, email string) (model.AuthUser, error) {
+	const op = "local_auth.storage.GetUserByEmail"
+
+	user, err := s.Queries.GetAuthUserByEmail(ctx, email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.AuthUser{}, le.ErrUserNotFound
+	}
+	if err != nil {
+		return model.AuthUser{}, fmt.Errorf("%s: failed to get user: %w", op, err)
+	}
+
+	return model.AuthUser{
+		ID:           user.ID,
+		Email:        user.Email,
+		PasswordHash: user.PasswordHash,
+		Verified:     user.Verified,
+		UpdatedAt:    user.UpdatedAt,
+	}, nil
+}
+
+func (s *LocalAuthStorage) GetUserByID(ctx context.Context, userID string) (model.AuthUser, error) {
+	const op = "local_auth.storage.GetUserByID"
+
+	user, err := s.Queries.GetAuthUserByID(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.AuthUser{}, le.ErrUserNotFound
+	}
+	if err != nil {
+		return model.AuthUser{}, fmt.Errorf("%s: failed to get user: %w", op, err)
+	}
+
+	return model.AuthUser{
+		ID:           user.ID,
+		Email:        user.Email,
+		PasswordHash: user.PasswordHash,
+		Verified:     user.Verified,
+		UpdatedAt:    user.UpdatedAt,
+	}, nil
+}
+
+func (s *LocalAuthStorage) UpdateUser(ctx context.Context, user model.AuthUser) error {
+	const op = "local_auth.storage.UpdateUser"
+
+	err := s.Queries.UpdateAuthUser(ctx, sqlc.UpdateAuthUserParams{
+		Email:        user.Email,
+		PasswordHash: user.PasswordHash,
+		Verified:     user.Verified,
+		UpdatedAt:    user.UpdatedAt,
+		ID:           user.ID,
+	})
+	if isUniqueViolation(err) {
+		return le.ErrEmailAlreadyTaken
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to update user: %w", op, err)
+	}
+	return nil
+}
+
+// DeleteUser deletes the user together with the sessions and the tokens of the user
+func (s *LocalAuthStorage) DeleteUser(ctx context.Context, userID string) error {
+	const op = "local_auth.storage.DeleteUser"
+
+	if err := s.Queries.DeleteAuthUser(ctx, userID); err != nil {
+		return fmt.Errorf("%s: failed to delete user: %w", op, err)
+	}
+	return nil
+}
+
+func (s *LocalAuthStorage) CreateSession(ctx context.Context, session model.AuthSession) error {
+	const op = "local_auth.storage.CreateSession"
+
+	if err := s.Queries.CreateAuthSession(ctx, sqlc.CreateAuthSessionParams{
+		ID:         session.ID,
+		TokenHash:  session.TokenHash,
+		UserID:     session.UserID,
+		UserAgent:  session.UserAgent,
+		Ip:         session.IP,
+		ExpiresAt:  session.ExpiresAt,
+		LastSeenAt: session.LastSeenAt,
+		CreatedAt:  session.CreatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create session: %w", op, err)
+	}
+	return nil
+}
+
+func (s *LocalAuthStorage) GetSession(ctx context.Context, tokenHash string) (model.AuthSession, error) {
+	const op = "local_auth.storage.GetSession"
+
+	session, err := s.Queries.GetAuthSession(ctx, tokenHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.AuthSession{}, le.ErrSessionNotFound
+	}
+	if err != nil {
+		return model.AuthSession{}, fmt.Errorf("%s: failed to get session: %w", op, err)
+	}
+
+	return model.AuthSession{
+		ID:        session.ID,
+		TokenHash: session.TokenHash,
+		UserID:    session.UserID,
+		UserAgent: session.UserAgent,
+		IP:        session.Ip,
+		ExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+func (s *LocalAuthStorage) GetSessionsByUserID(ctx context.Context, userID string) ([]model.AuthSession, error) {
+	const op = "local_auth.storage.GetSessionsByUserID"
+
+	items, err := s.Queries.GetAuthSessionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get sessions: %w", op, err)
+	}
+
+	sessions := make([]model.AuthSession, 0, len(items))
+
+	for _, item := range items {
+		sessions = append(sessions, model.AuthSession{
+			ID:         item.ID,
+			UserID:     userID,
+			UserAgent:  item.UserAgent,
+			IP:         item.Ip,
+			LastSeenAt: item.LastSeenAt,
+			CreatedAt:  item.CreatedAt,
+		})
+	}
+
+	return sessions, nil
+}
+
+// RotateSession replaces the refresh token of the session, it fails with le.ErrSessionNotFound
+// when the token has already been used
+func (s *LocalAuthStorage) RotateSession(ctx context.Context, tokenHash string, session model.AuthSession) error {
+	const op = "local_auth.storage.RotateSession"
+
+	_, err := s.Queries.RotateAuthSession(ctx, sqlc.RotateAuthSessionParams{
+		NewTokenHash: session.TokenHash,
+		UserAgent:    session.UserAgent,
+		Ip:           session.IP,
+		ExpiresAt:    session.ExpiresAt,
+		LastSeenAt:   session.LastSeenAt,
+		TokenHash:    tokenHash,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrSessionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to rotate session: %w", op, err)
+	}
+	return nil
+}
+
+func (s *LocalAuthStorage) DeleteSession(ctx context.Context, tokenHash string) error {
+	const op = "local_auth.storage.DeleteSession"
+
+	if err := s.Queries.DeleteAuthSession(ctx, tokenHash); err != nil {
+		return fmt.Errorf("%s: failed to delete session: %w", op, err)
+	}
+	return nil
+}
+
+func (s *LocalAuthStorage) DeleteSessionByID(ctx context.Context, session model.AuthSession) error {
+	const op = "local_auth.storage.DeleteSessionByID"
+
+	_, err := s.Queries.DeleteAuthSessionByID(ctx, sqlc.DeleteAuthSessionByIDParams{
+		ID:     session.ID,
+		UserID: session.UserID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrSessionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete session: %w", op, err)
+	}
+	return nil
+}
+
+func (s *LocalAuthStorage) DeleteSessionsByDevice(ctx context.Context, session model.AuthSession) error {
+	const op = "local_auth.storage.DeleteSessionsByDevice"
+
+	if err := s.Queries.DeleteAuthSessionsByDevice(ctx, sqlc.DeleteAuthSessionsByDeviceParams{
+		UserID:    session.UserID,
+		UserAgent: session.UserAgent,
+		Ip:        session.IP,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to delete sessions: %w", op, err)
+	}
+	return nil
+}
+
+func (s *LocalAuthStorage) DeleteSessionsByUserID(ctx context.Context, userID string) error {
+	const op = "local_auth.storage.DeleteSessionsByUserID"
+
+	if err := s.Queries.DeleteAuthSessionsByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("%s: failed to delete sessions: %w", op, err)
+	}
+	return nil
+}
+
+// DeleteOtherSessions deletes all the sessions of the user except the given one
+func (s *LocalAuthStorage) DeleteOtherSessions(ctx context.Context, session model.AuthSession) error {
+	const op = "local_auth.storage.DeleteOtherSessions"
+
+	if err := s.Queries.DeleteOtherAuthSessions(ctx, sqlc.DeleteOtherAuthSessionsParams{
+		UserID: session.UserID,
+		ID:     session.ID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to delete sessions: %w", op, err)
+	}
+	return nil
+}
+
+func (s *LocalAuthStorage) CreateToken(ctx context.Context, token model.AuthToken) error {
+	const op = "local_auth.storage.CreateToken"
+
+	if err := s.Queries.CreateAuthToken(ctx, sqlc.CreateAuthTokenParams{
+		TokenHash: token.TokenHash,
+		UserID:    token.UserID,
+		Type:      string(token.Type),
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create token: %w", op, err)
+	}
+	return nil
+}
+
+func (s *LocalAuthStorage) GetToken(ctx context.Context, tokenHash string, tokenType model.AuthTokenType) (model.AuthToken, error) {
+	const op = "local_auth.storage.GetToken"
+
+	token, err := s.Queries.GetAuthToken(ctx, sqlc.GetAuthTokenParams{
+		TokenHash: tokenHash,
+		Type:      string(tokenType),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.AuthToken{}, le.ErrAuthTokenNotFound
+	}
+	if err != nil {
+		return model.AuthToken{}, fmt.Errorf("%s: failed to get token: %w", op, err)
+	}
+
+	return model.AuthToken{
+		TokenHash: token.TokenHash,
+		UserID:    token.UserID,
+		Type:      model.AuthTokenType(token.Type),
+		ExpiresAt: token.ExpiresAt,
+	}, nil
+}
+
+func (s *LocalAuthStorage) DeleteTokensByUserID(ctx context.Context, userID string, tokenType model.AuthTokenType) error {
+	const op = "local_auth.storage.DeleteTokensByUserID"
+
+	if err := s.Queries.DeleteAuthTokensByUserID(ctx, sqlc.DeleteAuthTokensByUserIDParams{
+		UserID: userID,
+		Type:   string(tokenType),
+	}); err != nil {
+		return fmt.Errorf("%s: failed to delete tokens: %w", op, err)
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
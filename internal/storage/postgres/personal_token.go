@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type PersonalTokenStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewPersonalTokenStorage(pool *pgxpool.Pool) *PersonalTokenStorage {
+	return &PersonalTokenStorage{
+		Pool:    pool,
+		Queries: sqlc.New(pool),
+	}
+}
+
+func (s *PersonalTokenStorage) CreatePersonalToken(ctx context.Context, token model.PersonalToken) error {
+	const op = "personal_token.storage.CreatePersonalToken"
+
+	if err := s.Queries.CreatePersonalToken(ctx, sqlc.CreatePersonalTokenParams{
+		ID:        token.ID,
+		Name:      token.Name,
+		TokenHash: token.TokenHash,
+		Scopes:    token.Scopes,
+		UserID:    token.UserID,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create new personal token: %w", op, err)
+	}
+	return nil
+}
+
+// GetPersonalTokenByID returns the token if it is not revoked, the expired tokens are returned as well
+func (s *PersonalTokenStorage) GetPersonalTokenByID(ctx context.Context, tokenID string) (model.PersonalToken, error) {
+	const op = "personal_token.storage.GetPersonalTokenByID"
+
+	token, err := s.Queries.GetPersonalTokenByID(ctx, tokenID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.PersonalToken{}, le.ErrPersonalTokenNotFound
+	}
+	if err != nil {
+		return model.PersonalToken{}, fmt.Errorf("%s: failed to get personal token: %w", op, err)
+	}
+
+	return model.PersonalToken{
+		ID:        token.ID,
+		TokenHash: token.TokenHash,
+		Scopes:    token.Scopes,
+		UserID:    token.UserID,
+		ExpiresAt: token.ExpiresAt,
+	}, nil
+}
+
+func (s *PersonalTokenStorage) GetPersonalTokensByUserID(ctx context.Context, userID string) ([]model.PersonalToken, error) {
+	const op = "personal_token.storage.GetPersonalTokensByUserID"
+
+	items, err := s.Queries.GetPersonalTokensByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get personal tokens: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoPersonalTokensFound
+	}
+
+	var tokens []model.PersonalToken
+
+	for _, item := range items {
+		tokens = append(tokens, model.PersonalToken{
+			ID:         item.ID,
+			Name:       item.Name,
+			Scopes:     item.Scopes,
+			ExpiresAt:  item.ExpiresAt,
+			LastUsedAt: item.LastUsedAt.Time,
+			CreatedAt:  item.CreatedAt,
+		})
+	}
+	return tokens, nil
+}
+
+func (s *PersonalTokenStorage) UpdatePersonalTokenLastUsedAt(ctx context.Context, token model.PersonalToken) error {
+	const op = "personal_token.storage.UpdatePersonalTokenLastUsedAt"
+
+	if err := s.Queries.UpdatePersonalTokenLastUsedAt(ctx, sqlc.UpdatePersonalTokenLastUsedAtParams{
+		ID: token.ID,
+		LastUsedAt: pgtype.Timestamptz{
+			Time:  token.LastUsedAt,
+			Valid: true,
+		},
+	}); err != nil {
+		return fmt.Errorf("%s: failed to update last usage of personal token: %w", op, err)
+	}
+	return nil
+}
+
+func (s *PersonalTokenStorage) RevokePersonalToken(ctx context.Context, token model.PersonalToken) error {
+	const op = "personal_token.storage.RevokePersonalToken"
+
+	_, err := s.Queries.RevokePersonalToken(ctx, sqlc.RevokePersonalTokenParams{
+		ID:     token.ID,
+		UserID: token.UserID,
+		RevokedAt: pgtype.Timestamptz{
+			Time:  token.RevokedAt,
+			Valid: true,
+		},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrPersonalTokenNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to revoke personal token: %w", op, err)
+	}
+	return nil
+}
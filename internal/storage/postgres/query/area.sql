@@ -0,0 +1,54 @@
+-- name: CreateArea :exec
+INSERT INTO areas (id, title, position, user_id, created_at, updated_at)
+VALUES ($1, $2, (
+    SELECT COALESCE(MAX(position), 0) + 1
+    FROM areas
+    WHERE user_id = $3
+      AND deleted_at IS NULL
+), $3, $4, $5);
+
+-- name: GetAreaByID :one
+SELECT a.id, a.title, l.id AS default_list_id, a.user_id, a.updated_at
+FROM areas a
+    LEFT JOIN lists l
+        ON l.area_id = a.id
+        AND l.is_default = TRUE
+        AND l.deleted_at IS NULL
+WHERE a.id = $1
+  AND a.user_id = $2
+  AND a.deleted_at IS NULL;
+
+-- name: GetAreasByUserID :many
+SELECT a.id, a.title, l.id AS default_list_id, a.updated_at
+FROM areas a
+    LEFT JOIN lists l
+        ON l.area_id = a.id
+        AND l.is_default = TRUE
+        AND l.deleted_at IS NULL
+WHERE a.user_id = $1
+  AND a.deleted_at IS NULL
+ORDER BY a.position, a.id;
+
+-- name: UpdateArea :one
+UPDATE areas
+SET title = $1, updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL
+RETURNING id;
+
+-- name: UpdateAreasPosition :exec
+UPDATE areas
+SET position = s.position
+FROM UNNEST(@area_ids::varchar[]) WITH ORDINALITY AS s(area_id, position)
+WHERE areas.id = s.area_id
+  AND areas.user_id = @user_id
+  AND areas.deleted_at IS NULL;
+
+-- name: DeleteArea :one
+UPDATE areas
+SET deleted_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND deleted_at IS NULL
+RETURNING id;
\ No newline at end of file
@@ -0,0 +1,91 @@
+-- name: CreateAuthUser :exec
+INSERT INTO auth_users (id, email, password_hash, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetAuthUserByEmail :one
+SELECT id, email, password_hash, verified, updated_at
+FROM auth_users
+WHERE email = $1;
+
+-- name: GetAuthUserByID :one
+SELECT id, email, password_hash, verified, updated_at
+FROM auth_users
+WHERE id = $1;
+
+-- name: UpdateAuthUser :exec
+UPDATE auth_users
+SET email         = $1,
+    password_hash = $2,
+    verified      = $3,
+    updated_at    = $4
+WHERE id = $5;
+
+-- name: DeleteAuthUser :exec
+DELETE FROM auth_users
+WHERE id = $1;
+
+-- name: CreateAuthSession :exec
+INSERT INTO auth_sessions (id, token_hash, user_id, user_agent, ip, expires_at, last_seen_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetAuthSession :one
+SELECT id, token_hash, user_id, user_agent, ip, expires_at
+FROM auth_sessions
+WHERE token_hash = $1;
+
+-- name: GetAuthSessionsByUserID :many
+SELECT id, user_agent, ip, last_seen_at, created_at
+FROM auth_sessions
+WHERE user_id = $1
+  AND expires_at > now()
+ORDER BY last_seen_at DESC, id;
+
+-- name: RotateAuthSession :one
+UPDATE auth_sessions
+SET token_hash   = @new_token_hash,
+    user_agent   = @user_agent,
+    ip           = @ip,
+    expires_at   = @expires_at,
+    last_seen_at = @last_seen_at
+WHERE token_hash = @token_hash
+RETURNING id;
+
+-- name: DeleteAuthSession :exec
+DELETE FROM auth_sessions
+WHERE token_hash = $1;
+
+-- name: DeleteAuthSessionsByDevice :exec
+DELETE FROM auth_sessions
+WHERE user_id = $1
+  AND user_agent = $2
+  AND ip = $3;
+
+-- name: DeleteAuthSessionByID :one
+DELETE FROM auth_sessions
+WHERE id = $1
+  AND user_id = $2
+RETURNING id;
+
+-- name: DeleteAuthSessionsByUserID :exec
+DELETE FROM auth_sessions
+WHERE user_id = $1;
+
+-- name: DeleteOtherAuthSessions :exec
+DELETE FROM auth_sessions
+WHERE user_id = $1
+  AND id <> $2;
+
+-- name: CreateAuthToken :exec
+INSERT INTO auth_tokens (token_hash, user_id, type, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetAuthToken :one
+SELECT token_hash, user_id, type, expires_at
+FROM auth_tokens
+WHERE token_hash = $1
+  AND type = $2;
+
+-- name: DeleteAuthTokensByUserID :exec
+DELETE FROM auth_tokens
+WHERE user_id = $1
+  AND type = $2;
\ No newline at end of file
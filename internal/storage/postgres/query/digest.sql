@@ -0,0 +1,45 @@
+-- name: GetDigestSettings :one
+SELECT user_id, email, daily, weekly, send_time, weekly_day, unsubscribe_token, updated_at
+FROM digest_settings
+WHERE user_id = $1;
+
+-- name: UpsertDigestSettings :exec
+INSERT INTO digest_settings (user_id, email, daily, weekly, send_time, weekly_day, unsubscribe_token, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (user_id) DO UPDATE
+SET email      = EXCLUDED.email,
+    daily      = EXCLUDED.daily,
+    weekly     = EXCLUDED.weekly,
+    send_time  = EXCLUDED.send_time,
+    weekly_day = EXCLUDED.weekly_day,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: UnsubscribeFromDigests :one
+UPDATE digest_settings
+SET daily      = false,
+    weekly     = false,
+    updated_at = $1
+WHERE unsubscribe_token = $2
+RETURNING user_id;
+
+-- name: GetEnabledDigestSettings :many
+SELECT user_id, email, daily, weekly, send_time, weekly_day, daily_sent_at, weekly_sent_at
+FROM digest_settings
+WHERE daily = true
+   OR weekly = true;
+
+-- name: ClaimDailyDigest :one
+UPDATE digest_settings
+SET daily_sent_at = @sent_at::timestamptz
+WHERE user_id = @user_id::varchar
+  AND daily = true
+  AND (daily_sent_at IS NULL OR daily_sent_at < @due_at::timestamptz)
+RETURNING user_id;
+
+-- name: ClaimWeeklyDigest :one
+UPDATE digest_settings
+SET weekly_sent_at = @sent_at::timestamptz
+WHERE user_id = @user_id::varchar
+  AND weekly = true
+  AND (weekly_sent_at IS NULL OR weekly_sent_at < @due_at::timestamptz)
+RETURNING user_id;
\ No newline at end of file
@@ -0,0 +1,95 @@
+-- name: CreateFocusSession :exec
+INSERT INTO focus_sessions (
+    id,
+    task_id,
+    user_id,
+    duration_minutes,
+    status,
+    started_at,
+    ends_at,
+    created_at,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+);
+
+-- name: CreateFocusSessionEvent :exec
+INSERT INTO focus_session_events (
+    session_id,
+    user_id,
+    event,
+    created_at
+) VALUES (
+    $1, $2, $3, $4
+);
+
+-- name: GetFocusSessionByID :one
+SELECT
+    id,
+    task_id,
+    user_id,
+    duration_minutes,
+    status,
+    started_at,
+    ends_at,
+    paused_at,
+    finished_at,
+    updated_at
+FROM focus_sessions
+WHERE id = $1
+  AND user_id = $2;
+
+-- name: GetActiveFocusSession :one
+SELECT
+    id,
+    task_id,
+    user_id,
+    duration_minutes,
+    status,
+    started_at,
+    ends_at,
+    paused_at,
+    finished_at,
+    updated_at
+FROM focus_sessions
+WHERE user_id = $1
+  AND status IN ('running', 'paused');
+
+-- name: UpdateFocusSession :one
+UPDATE focus_sessions
+SET status = @status,
+    ends_at = @ends_at,
+    paused_at = @paused_at,
+    finished_at = @finished_at,
+    updated_at = @updated_at
+WHERE id = @id
+  AND user_id = @user_id
+  AND status = @expected_status
+RETURNING id;
+
+-- name: CompleteDueFocusSessions :many
+UPDATE focus_sessions
+SET status = 'completed',
+    finished_at = ends_at,
+    updated_at = $1
+WHERE user_id = $2
+  AND status = 'running'
+  AND ends_at <= $1
+RETURNING id, ends_at;
+
+-- name: GetFocusSessionEvents :many
+SELECT event, created_at
+FROM focus_session_events
+WHERE session_id = $1
+  AND user_id = $2
+ORDER BY created_at, id;
+
+-- name: GetFocusSessionsSummary :one
+SELECT
+    COUNT(*) FILTER (WHERE status = 'completed' OR (status = 'running' AND ends_at <= @now_time::timestamptz))::int AS completed,
+    COUNT(*) FILTER (WHERE status = 'abandoned')::int AS abandoned,
+    COALESCE(SUM(duration_minutes) FILTER (WHERE status = 'completed' OR (status = 'running' AND ends_at <= @now_time::timestamptz)), 0)::int AS focused_minutes
+FROM focus_sessions
+WHERE user_id = @user_id
+  AND started_at >= @from_time::timestamptz
+  AND started_at < @to_time::timestamptz;
\ No newline at end of file
@@ -11,17 +11,28 @@ WHERE list_id = $1
   AND deleted_at IS NULL;
 
 -- name: GetHeadingByID :one
-SELECT id, title, list_id, user_id, updated_at
+SELECT id, title, list_id, user_id, is_default, completed_at, updated_at
 FROM headings
 WHERE id = $1
   AND user_id = $2
   AND deleted_at IS NULL;
 
+-- name: GetHeadingIDByTitle :one
+SELECT id
+FROM headings
+WHERE LOWER(title) = LOWER(@title::varchar)
+  AND list_id = @list_id
+  AND user_id = @user_id
+  AND deleted_at IS NULL
+ORDER BY created_at
+LIMIT 1;
+
 -- name: GetHeadingsByListID :many
 SELECT id, title, list_id, user_id, updated_at
 FROM headings
 WHERE list_id = $1
   AND user_id = $2
+  AND completed_at IS NULL
   AND deleted_at IS NULL;
 
 -- name: UpdateHeading :one
@@ -46,6 +57,35 @@ SET list_id = $1, updated_at = $2
 WHERE heading_id = $3
   AND user_id = $4;
 
+-- name: MoveTasksToHeading :exec
+UPDATE tasks
+SET heading_id = @heading_id,
+    list_id = @list_id,
+    custom_status_id = CASE
+        WHEN custom_status_id IN (SELECT id FROM statuses WHERE list_id != @list_id) THEN NULL
+        ELSE custom_status_id END,
+    updated_at = @updated_at
+WHERE heading_id = @from_heading_id
+  AND user_id = @user_id;
+
+-- name: CompleteHeading :one
+UPDATE headings
+SET completed_at = $1, updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND completed_at IS NULL
+  AND deleted_at IS NULL
+RETURNING id;
+
+-- name: RestoreHeading :one
+UPDATE headings
+SET completed_at = NULL, updated_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND completed_at IS NOT NULL
+  AND deleted_at IS NULL
+RETURNING id;
+
 -- name: DeleteHeading :one
 UPDATE headings
 SET deleted_at = $1
@@ -1,36 +1,164 @@
 -- name: CreateList :exec
-INSERT INTO lists (id, title, user_id, is_default, created_at,updated_at)
-VALUES ($1, $2, $3, $4, $5, $6);
+INSERT INTO lists (id, title, description, deadline, color, icon, user_id, is_default, area_id, position, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, (
+    SELECT COALESCE(MAX(position), 0) + 1
+    FROM lists
+    WHERE user_id = $7
+      AND COALESCE(area_id, '') = COALESCE($9, '')
+      AND deleted_at IS NULL
+), $10, $11);
 
 -- name: GetListByID :one
-SELECT id, title, user_id, is_default, updated_at
+SELECT
+    l.id,
+    l.title,
+    l.description,
+    l.deadline,
+    l.color,
+    l.icon,
+    l.user_id,
+    l.is_default,
+    l.area_id,
+    l.updated_at,
+    COUNT(t.id)::int AS total_tasks,
+    COUNT(t.id) FILTER (
+        WHERE t.status_id = (
+            SELECT id
+            FROM statuses
+            WHERE statuses.title = @status_title::varchar
+              AND statuses.user_id IS NULL
+        )
+    )::int AS completed_tasks
+FROM lists l
+    LEFT JOIN tasks t
+        ON t.list_id = l.id
+       AND t.deleted_at IS NULL
+WHERE l.id = @id
+  AND l.user_id = @user_id
+  AND l.deleted_at IS NULL
+GROUP BY l.id;
+
+-- name: GetListIDByTitle :one
+SELECT id
 FROM lists
-WHERE id = $1
-  AND user_id = $2
-  AND deleted_at IS NULL;
+WHERE LOWER(title) = LOWER(@title::varchar)
+  AND user_id = @user_id
+  AND deleted_at IS NULL
+ORDER BY created_at
+LIMIT 1;
 
 -- name: GetListsByUserID :many
-SELECT id, title, updated_at
+SELECT
+    l.id,
+    l.title,
+    l.description,
+    l.deadline,
+    l.color,
+    l.icon,
+    l.area_id,
+    l.is_default,
+    l.updated_at,
+    COUNT(t.id)::int AS total_tasks,
+    COUNT(t.id) FILTER (
+        WHERE t.status_id = (
+            SELECT id
+            FROM statuses
+            WHERE statuses.title = @status_title::varchar
+              AND statuses.user_id IS NULL
+        )
+    )::int AS completed_tasks
+FROM lists l
+    LEFT JOIN tasks t
+        ON t.list_id = l.id
+       AND t.deleted_at IS NULL
+WHERE l.user_id = @user_id
+  AND l.deleted_at IS NULL
+GROUP BY l.id
+ORDER BY l.position, l.id;
+
+-- name: GetOverdueLists :many
+SELECT l.id, l.title, l.deadline
+FROM lists l
+WHERE l.user_id = @user_id
+  AND l.id > @cursor::varchar
+  AND l.deadline <= @today::timestamptz
+  AND l.deleted_at IS NULL
+  AND EXISTS (
+      SELECT 1
+      FROM tasks t
+      WHERE t.list_id = l.id
+        AND t.status_id != (
+            SELECT id
+            FROM statuses
+            WHERE statuses.title = @status_title::varchar
+              AND statuses.user_id IS NULL
+        )
+        AND t.deleted_at IS NULL
+  )
+ORDER BY l.id;
+
+-- name: GetListIDsByAreaID :many
+SELECT id
 FROM lists
-WHERE user_id = $1
+WHERE user_id = @user_id
+  AND COALESCE(area_id, '') = @area_id::varchar
+  AND is_default = FALSE
   AND deleted_at IS NULL
-ORDER BY id;
+ORDER BY position, id;
 
 -- name: GetDefaultListID :one
 SELECT id
 FROM lists
 WHERE user_id = $1
   AND is_default = TRUE
+  AND area_id IS NULL
   AND deleted_at IS NULL;
 
 -- name: UpdateList :one
 UPDATE lists
-SET title = $1,	updated_at = $2
+SET title = $1,
+    description = CASE WHEN @description_set::boolean THEN sqlc.narg('description')::varchar ELSE description END,
+    deadline = CASE WHEN @deadline_set::boolean THEN sqlc.narg('deadline')::timestamptz ELSE deadline END,
+    color = CASE WHEN @color_set::boolean THEN sqlc.narg('color')::varchar ELSE color END,
+    icon = CASE WHEN @icon_set::boolean THEN sqlc.narg('icon')::varchar ELSE icon END,
+    updated_at = $2
 WHERE id = $3
   AND user_id = $4
   AND deleted_at IS NULL
 RETURNING id;
 
+-- name: UpdateListArea :one
+UPDATE lists
+SET area_id = $1, updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL
+RETURNING id;
+
+-- name: UpdateListsPosition :exec
+UPDATE lists
+SET position = s.position
+FROM UNNEST(@list_ids::varchar[]) WITH ORDINALITY AS s(list_id, position)
+WHERE lists.id = s.list_id
+  AND lists.user_id = @user_id
+  AND lists.deleted_at IS NULL;
+
+-- name: MoveListsToTopLevel :exec
+UPDATE lists
+SET area_id = NULL,
+    is_default = FALSE,
+    position = lists.position + (
+        SELECT COALESCE(MAX(top.position), 0) + 1
+        FROM lists top
+        WHERE top.user_id = @user_id
+          AND top.area_id IS NULL
+          AND top.deleted_at IS NULL
+    ),
+    updated_at = @updated_at
+WHERE area_id = @area_id::varchar
+  AND user_id = @user_id
+  AND deleted_at IS NULL;
+
 -- name: DeleteList :one
 UPDATE lists
 SET deleted_at = $1
@@ -0,0 +1,29 @@
+-- name: CreatePersonalToken :exec
+INSERT INTO personal_tokens (id, name, token_hash, scopes, user_id, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetPersonalTokenByID :one
+SELECT id, token_hash, scopes, user_id, expires_at
+FROM personal_tokens
+WHERE id = $1
+  AND revoked_at IS NULL;
+
+-- name: GetPersonalTokensByUserID :many
+SELECT id, name, scopes, expires_at, last_used_at, created_at
+FROM personal_tokens
+WHERE user_id = $1
+  AND revoked_at IS NULL
+ORDER BY created_at DESC, id;
+
+-- name: UpdatePersonalTokenLastUsedAt :exec
+UPDATE personal_tokens
+SET last_used_at = $1
+WHERE id = $2;
+
+-- name: RevokePersonalToken :one
+UPDATE personal_tokens
+SET revoked_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND revoked_at IS NULL
+RETURNING id;
\ No newline at end of file
@@ -1,8 +1,63 @@
 -- name: GetStatuses :many
-SELECT id, title
-FROM statuses;
+SELECT id, title, category_id, list_id
+FROM statuses
+WHERE deleted_at IS NULL
+  AND (user_id IS NULL
+    OR (user_id = $1 AND (list_id IS NULL OR list_id = @list_id::varchar)))
+ORDER BY category_id, id;
 
 -- name: GetStatusByID :one
-SELECT title
+SELECT id, title, category_id, list_id
 FROM statuses
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1
+  AND (user_id IS NULL OR user_id = $2)
+  AND deleted_at IS NULL;
+
+-- name: CreateStatus :one
+INSERT INTO statuses (title, category_id, user_id, list_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id;
+
+-- name: UpdateStatus :one
+UPDATE statuses
+SET title = $1,
+    updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL
+RETURNING id;
+
+-- name: DeleteStatus :one
+UPDATE statuses
+SET deleted_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND deleted_at IS NULL
+RETURNING id;
+
+-- name: ResetTasksCustomStatus :exec
+UPDATE tasks
+SET custom_status_id = NULL,
+    updated_at = $1
+WHERE custom_status_id = $2
+  AND user_id = $3;
+
+-- name: GetStatusTransitions :many
+SELECT from_status_id, to_status_id, list_id
+FROM status_transitions
+WHERE user_id = $1
+  AND (list_id IS NULL OR list_id = @list_id::varchar);
+
+-- name: CreateStatusTransitions :exec
+INSERT INTO status_transitions (user_id, list_id, from_status_id, to_status_id)
+SELECT @user_id::varchar, NULLIF(@list_id::varchar, ''), UNNEST(@from_status_ids::int[]), UNNEST(@to_status_ids::int[]);
+
+-- name: DeleteStatusTransitions :exec
+DELETE FROM status_transitions
+WHERE user_id = $1
+  AND COALESCE(list_id, '') = @list_id::varchar;
+
+-- name: DeleteStatusTransitionsByStatusID :exec
+DELETE FROM status_transitions
+WHERE user_id = $1
+  AND (from_status_id = @status_id::int OR to_status_id = @status_id::int);
\ No newline at end of file
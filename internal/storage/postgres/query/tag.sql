@@ -1,13 +1,14 @@
 -- name: CreateTag :exec
-INSERT INTO tags (id, title, user_id, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5);
+INSERT INTO tags (id, title, parent_id, color, user_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
 
 -- name: LinkTagToTask :exec
 INSERT INTO tasks_tags (task_id, tag_id)
 VALUES ($1, (SELECT id
              FROM tags
              WHERE title = $2
-             AND user_id = $3)
+             AND user_id = $3
+             AND deleted_at IS NULL)
 );
 
 -- name: UnlinkTagFromTask :exec
@@ -17,8 +18,21 @@ WHERE task_id = $1
                 FROM tags
                 WHERE title = $2
                 AND user_id = $3
+                AND deleted_at IS NULL
 );
 
+-- name: UnlinkTagFromAllTasks :exec
+DELETE FROM tasks_tags
+WHERE tag_id = $1;
+
+-- name: CopyTaskLinksToTag :exec
+INSERT INTO tasks_tags (task_id, tag_id)
+SELECT tasks_tags.task_id, tags.id
+FROM tasks_tags, tags
+WHERE tasks_tags.tag_id = @source_tag_id
+  AND tags.id = @target_tag_id
+ON CONFLICT DO NOTHING;
+
 -- name: GetTagIDByTitle :one
 SELECT id
 FROM tags
@@ -26,11 +40,92 @@ WHERE title = $1
   AND user_id = $2
   AND deleted_at IS NULL;
 
+-- name: GetTagByID :one
+WITH RECURSIVE subtree AS (
+    SELECT tags.id AS root_id, tags.id
+    FROM tags
+    WHERE tags.id = $1
+      AND tags.user_id = $2
+      AND tags.deleted_at IS NULL
+    UNION ALL
+    SELECT subtree.root_id, tags.id
+    FROM tags
+        JOIN subtree
+            ON tags.parent_id = subtree.id
+    WHERE tags.deleted_at IS NULL
+)
+SELECT
+    tags.id,
+    tags.title,
+    tags.parent_id,
+    tags.color,
+    tags.updated_at,
+    COUNT(DISTINCT tasks.id)::int AS tasks_count
+FROM tags
+    JOIN subtree
+        ON tags.id = subtree.root_id
+    LEFT JOIN tasks_tags
+        ON subtree.id = tasks_tags.tag_id
+    LEFT JOIN tasks
+        ON tasks_tags.task_id = tasks.id
+        AND tasks.deleted_at IS NULL
+GROUP BY tags.id;
+
 -- name: GetTagsByUserID :many
-SELECT id, title, updated_at
+WITH RECURSIVE subtree AS (
+    SELECT tags.id AS root_id, tags.id
+    FROM tags
+    WHERE tags.user_id = $1
+      AND tags.deleted_at IS NULL
+    UNION ALL
+    SELECT subtree.root_id, tags.id
+    FROM tags
+        JOIN subtree
+            ON tags.parent_id = subtree.id
+    WHERE tags.deleted_at IS NULL
+)
+SELECT
+    tags.id,
+    tags.title,
+    tags.parent_id,
+    tags.color,
+    tags.updated_at,
+    COUNT(DISTINCT tasks.id)::int AS tasks_count
 FROM tags
-WHERE user_id = $1
-  AND deleted_at IS NULL;
+    JOIN subtree
+        ON tags.id = subtree.root_id
+    LEFT JOIN tasks_tags
+        ON subtree.id = tasks_tags.tag_id
+    LEFT JOIN tasks
+        ON tasks_tags.task_id = tasks.id
+        AND tasks.deleted_at IS NULL
+GROUP BY tags.id
+ORDER BY tags.title;
+
+-- name: GetTagSubtreeIDs :many
+WITH RECURSIVE subtree AS (
+    SELECT tags.id
+    FROM tags
+    WHERE tags.id = $1
+      AND tags.user_id = $2
+      AND tags.deleted_at IS NULL
+    UNION ALL
+    SELECT tags.id
+    FROM tags
+        JOIN subtree
+            ON tags.parent_id = subtree.id
+    WHERE tags.deleted_at IS NULL
+)
+SELECT id
+FROM subtree;
+
+-- name: GetTagsByParentID :many
+SELECT id, title, parent_id, updated_at
+FROM tags
+WHERE parent_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+ORDER BY title;
 
 -- name: GetTagsByTaskID :many
 SELECT tags.id, tags.title, tags.updated_at
@@ -40,3 +135,36 @@ FROM tags
 WHERE tasks_tags.task_id = $1
   AND tags.deleted_at IS NULL;
 
+-- name: UpdateTag :one
+UPDATE tags
+SET title = $1,
+    color = COALESCE(@color::varchar, color),
+    updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL
+RETURNING id;
+
+-- name: UpdateTagParent :exec
+UPDATE tags
+SET parent_id = $1,
+    updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL;
+
+-- name: RenameTagSubtree :exec
+UPDATE tags
+SET title = @new_title::varchar || substr(title, length(@old_title::varchar) + 1),
+    updated_at = $1
+WHERE id = ANY(@tag_ids::varchar[])
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: DeleteTag :one
+UPDATE tags
+SET deleted_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND deleted_at IS NULL
+RETURNING id;
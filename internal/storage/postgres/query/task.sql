@@ -12,15 +12,24 @@ INSERT INTO tasks (
     heading_id,
     user_id,
     created_at,
-    updated_at
+    updated_at,
+    estimate_minutes
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 );
 
 -- name: GetTaskStatusID :one
 SELECT id
 FROM statuses
-WHERE title = $1;
+WHERE title = $1
+  AND user_id IS NULL;
+
+-- name: GetTaskStatus :one
+SELECT status_id, custom_status_id, list_id
+FROM tasks
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
 
 -- name: GetTaskByID :one
 SELECT
@@ -32,17 +41,22 @@ SELECT
     t.start_time,
     t.end_time,
     t.status_id,
+    t.custom_status_id,
     t.list_id,
     t.heading_id,
     t.updated_at,
+    t.estimate_minutes,
+    COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
     ttv.tags as tags,
     CASE
-        WHEN t.deadline <= CURRENT_DATE THEN TRUE
+        WHEN t.deadline <= @today::timestamptz THEN TRUE
         ELSE FALSE END
         AS overdue
 FROM tasks t
     LEFT JOIN task_tags_view ttv
         ON t.id = ttv.task_id
+    LEFT JOIN task_time_view tmv
+        ON t.id = tmv.task_id
 WHERE t.id = $1
   AND t.user_id = $2
   AND t.deleted_at IS NULL;
@@ -60,14 +74,18 @@ SELECT
     t.list_id,
     t.heading_id,
     t.updated_at,
+    t.estimate_minutes,
+    COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
     ttv.tags as tags,
     CASE
-        WHEN t.deadline <= CURRENT_DATE THEN TRUE
+        WHEN t.deadline <= @today::timestamptz THEN TRUE
         ELSE FALSE END
       AS overdue
 FROM tasks t
     LEFT JOIN task_tags_view ttv
         ON t.id = ttv.task_id
+    LEFT JOIN task_time_view tmv
+        ON t.id = tmv.task_id
 WHERE t.user_id = $1
   AND t.deleted_at IS NULL
   AND t.id > @cursor::varchar
@@ -82,6 +100,8 @@ GROUP BY
     t.status_id,
     t.list_id,
     t.heading_id,
+    t.estimate_minutes,
+    tmv.tracked_minutes,
     ttv.tags,
     t.created_at,
     t.updated_at
@@ -102,14 +122,18 @@ SELECT
     t.heading_id,
     t.user_id,
     t.updated_at,
+    t.estimate_minutes,
+    COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
     ttv.tags as tags,
     CASE
-        WHEN t.deadline <= CURRENT_DATE THEN TRUE
+        WHEN t.deadline <= @today::timestamptz THEN TRUE
         ELSE FALSE END
         AS overdue
 FROM tasks t
     LEFT JOIN task_tags_view ttv
         ON t.id = ttv.task_id
+    LEFT JOIN task_time_view tmv
+        ON t.id = tmv.task_id
 WHERE t.list_id = $1
   AND t.user_id = $2
   AND t.deleted_at IS NULL
@@ -125,6 +149,8 @@ GROUP BY
     t.heading_id,
     overdue,
     t.updated_at,
+    t.estimate_minutes,
+    tmv.tracked_minutes,
     ttv.tags
 ORDER BY t.id;
 
@@ -143,6 +169,8 @@ SELECT
                             'end_time', t.end_time,
                             'heading_id', t.heading_id,
                             'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
                             'tags', tags,
                             'overdue', overdue,
                             'updated_at', t.updated_at
@@ -161,15 +189,19 @@ JOIN (
         t.end_time,
         t.heading_id,
         t.user_id,
+        t.estimate_minutes,
+        COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
         ttv.tags as tags,
         CASE
-            WHEN t.deadline <= CURRENT_DATE THEN TRUE
+            WHEN t.deadline <= @today::timestamptz THEN TRUE
             ELSE FALSE END
                  AS overdue,
         t.updated_at
     FROM tasks t
              LEFT JOIN task_tags_view ttv
                        ON t.id = ttv.task_id
+             LEFT JOIN task_time_view tmv
+                       ON t.id = tmv.task_id
     WHERE t.list_id = $1
       AND t.user_id = $2
       AND t.deleted_at IS NULL
@@ -184,6 +216,8 @@ JOIN (
         t.heading_id,
         t.user_id,
         t.updated_at,
+        t.estimate_minutes,
+        tmv.tracked_minutes,
         ttv.tags
 ) t
               ON h.id = t.heading_id
@@ -192,6 +226,105 @@ WHERE h.list_id = $1
 GROUP BY h.id
 ORDER BY h.id;
 
+-- name: GetTasksGroupedByStatus :many
+SELECT
+    s.id AS status_id,
+    ARRAY_TO_JSON(
+            ARRAY_AGG(
+                    JSON_BUILD_OBJECT(
+                            'task_id', t.id,
+                            'title', t.title,
+                            'description', t.description,
+                            'start_date', t.start_date,
+                            'deadline', t.deadline,
+                            'start_time', t.start_time,
+                            'end_time', t.end_time,
+                            'status_id', t.status_id,
+                            'custom_status_id', t.custom_status_id,
+                            'heading_id', t.heading_id,
+                            'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
+                            'tags', tags,
+                            'overdue', overdue,
+                            'updated_at', t.updated_at
+                    )
+                    ORDER BY t.position, t.created_at
+            )
+    ) AS tasks
+FROM statuses s
+JOIN (
+    SELECT
+        t.id,
+        t.title,
+        t.description,
+        t.start_date,
+        t.deadline,
+        t.start_time,
+        t.end_time,
+        t.status_id,
+        t.custom_status_id,
+        COALESCE(t.custom_status_id, t.status_id) AS board_status_id,
+        t.heading_id,
+        t.user_id,
+        t.estimate_minutes,
+        COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
+        ttv.tags as tags,
+        CASE
+            WHEN t.deadline <= @today::timestamptz THEN TRUE
+            ELSE FALSE END
+                 AS overdue,
+        t.position,
+        t.created_at,
+        t.updated_at
+    FROM tasks t
+             LEFT JOIN task_tags_view ttv
+                       ON t.id = ttv.task_id
+             LEFT JOIN task_time_view tmv
+                       ON t.id = tmv.task_id
+    WHERE t.list_id = $1
+      AND t.user_id = $2
+      AND t.deleted_at IS NULL
+    GROUP BY
+        t.id,
+        t.title,
+        t.description,
+        t.start_date,
+        t.deadline,
+        t.start_time,
+        t.end_time,
+        t.status_id,
+        t.custom_status_id,
+        t.heading_id,
+        t.user_id,
+        t.position,
+        t.created_at,
+        t.updated_at,
+        t.estimate_minutes,
+        tmv.tracked_minutes,
+        ttv.tags
+) t
+              ON s.id = t.board_status_id
+GROUP BY s.id
+ORDER BY s.id;
+
+-- name: GetTaskIDsByBoardColumn :many
+SELECT id
+FROM tasks
+WHERE list_id = $1
+  AND user_id = $2
+  AND COALESCE(custom_status_id, status_id) = @status_id::int
+  AND deleted_at IS NULL
+ORDER BY position, created_at;
+
+-- name: UpdateTasksPosition :exec
+UPDATE tasks
+SET position = s.position
+FROM UNNEST(@task_ids::varchar[]) WITH ORDINALITY AS s(task_id, position)
+WHERE tasks.id = s.task_id
+  AND tasks.user_id = @user_id
+  AND tasks.deleted_at IS NULL;
+
 -- name: GetTasksForToday :many
 SELECT
     l.id AS list_id,
@@ -207,6 +340,8 @@ SELECT
                             'end_time', t.end_time,
                             'list_id', t.list_id,
                             'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
                             'tags', tags,
                             'overdue', overdue,
                             'updated_at', t.updated_at
@@ -225,16 +360,21 @@ FROM lists l
             t.end_time,
             t.list_id,
             t.user_id,
+            t.estimate_minutes,
+            COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
             ttv.tags as tags,
             CASE
-                WHEN t.deadline <= CURRENT_DATE THEN TRUE
+                WHEN t.deadline <= @today::timestamptz THEN TRUE
                 ELSE FALSE END AS overdue,
             t.updated_at
         FROM tasks t
             LEFT JOIN task_tags_view ttv
                 ON t.id = ttv.task_id
+            LEFT JOIN task_time_view tmv
+                ON t.id = tmv.task_id
         WHERE t.user_id = $1
-          AND t.start_date::date = CURRENT_DATE
+          AND t.start_date >= @today::timestamptz
+          AND t.start_date < @today::timestamptz + interval '1 day'
           AND t.deleted_at IS NULL
         GROUP BY
             t.id,
@@ -246,6 +386,8 @@ FROM lists l
             t.end_time,
             t.list_id,
             t.user_id,
+            t.estimate_minutes,
+            tmv.tracked_minutes,
             ttv.tags,
             t.updated_at
         ) t
@@ -269,6 +411,8 @@ SELECT
                             'end_time', t.end_time,
                             'list_id', t.list_id,
                             'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
                             'tags', tags,
                             'updated_at', t.updated_at
                     )
@@ -285,14 +429,18 @@ FROM (
         t.end_time,
         t.list_id,
         t.user_id,
+        t.estimate_minutes,
+        COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
         ttv.tags as tags,
         t.updated_at
     FROM tasks t
         LEFT JOIN task_tags_view ttv
              ON t.id = ttv.task_id
+        LEFT JOIN task_time_view tmv
+             ON t.id = tmv.task_id
     WHERE t.user_id = $1
         AND (
-             (t.start_date >= COALESCE(@after_date::timestamptz, CURRENT_DATE + interval '1 day'))
+             (t.start_date >= COALESCE(@after_date::timestamptz, @today::timestamptz + interval '1 day'))
              AND (t.deleted_at IS NULL)
         )
    GROUP BY
@@ -305,6 +453,8 @@ FROM (
         t.end_time,
         t.list_id,
         t.user_id,
+        t.estimate_minutes,
+        tmv.tracked_minutes,
         ttv.tags,
         t.updated_at
 ) t
@@ -327,6 +477,8 @@ SELECT
                             'end_time', t.end_time,
                             'list_id', t.list_id,
                             'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
                             'tags', tags,
                             'overdue', overdue,
                             'updated_at', t.updated_at
@@ -345,17 +497,21 @@ FROM lists l
             t.end_time,
             t.list_id,
             t.user_id,
+            t.estimate_minutes,
+            COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
             ttv.tags as tags,
             CASE
-                WHEN t.deadline <= CURRENT_DATE THEN TRUE
+                WHEN t.deadline <= @today::timestamptz THEN TRUE
                 ELSE FALSE END
                 AS overdue,
             t.updated_at
         FROM tasks t
             LEFT JOIN task_tags_view ttv
                 ON t.id = ttv.task_id
+            LEFT JOIN task_time_view tmv
+                ON t.id = tmv.task_id
         WHERE t.user_id = $1
-          AND t.deadline <= CURRENT_DATE
+          AND t.deadline <= @today::timestamptz
           AND t.deleted_at IS NULL
         GROUP BY
             t.id,
@@ -367,6 +523,8 @@ FROM lists l
             t.end_time,
             t.list_id,
             t.user_id,
+            t.estimate_minutes,
+            tmv.tracked_minutes,
             ttv.tags,
             t.updated_at
         ) t ON l.id = t.list_id
@@ -390,6 +548,8 @@ SELECT
                             'end_time', t.end_time,
                             'list_id', t.list_id,
                             'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
                             'tags', tags,
                             'updated_at', t.updated_at
                     )
@@ -408,10 +568,13 @@ JOIN (
         t.list_id,
         t.heading_id,
         t.user_id,
+        t.estimate_minutes,
+        COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
         ttv.tags as tags,
         t.updated_at
     FROM tasks t
              LEFT JOIN task_tags_view ttv ON t.id = ttv.task_id
+             LEFT JOIN task_time_view tmv ON t.id = tmv.task_id
     WHERE t.user_id = $1
       AND t.start_date IS NULL
       AND t.deadline IS NULL
@@ -427,6 +590,8 @@ JOIN (
         t.list_id,
         t.heading_id,
         t.user_id,
+        t.estimate_minutes,
+        tmv.tracked_minutes,
         ttv.tags,
         t.updated_at
 ) t ON l.id = t.list_id
@@ -436,9 +601,22 @@ GROUP BY l.id
 ORDER BY l.id
 LIMIT $2;
 
--- name: GetCompletedTasks :many
+-- name: GetTasksByTagID :many
+WITH RECURSIVE subtree AS (
+    SELECT tags.id
+    FROM tags
+    WHERE tags.id = $1
+      AND tags.user_id = $2
+      AND tags.deleted_at IS NULL
+    UNION ALL
+    SELECT tags.id
+    FROM tags
+        JOIN subtree
+            ON tags.parent_id = subtree.id
+    WHERE tags.deleted_at IS NULL
+)
 SELECT
-    DATE_TRUNC('month', t.updated_at)::timestamptz AS month,
+    l.id AS list_id,
     ARRAY_TO_JSON(
             ARRAY_AGG(
                     JSON_BUILD_OBJECT(
@@ -451,11 +629,310 @@ SELECT
                             'end_time', t.end_time,
                             'list_id', t.list_id,
                             'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
                             'tags', tags,
+                            'overdue', overdue,
+                            'updated_at', t.updated_at
+                    )
+            )
+    ) AS tasks
+FROM lists l
+    JOIN (
+        SELECT
+            t.id,
+            t.title,
+            t.description,
+            t.start_date,
+            t.deadline,
+            t.start_time,
+            t.end_time,
+            t.list_id,
+            t.user_id,
+            t.estimate_minutes,
+            COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
+            ttv.tags as tags,
+            CASE
+                WHEN t.deadline <= @today::timestamptz THEN TRUE
+                ELSE FALSE END AS overdue,
+            t.updated_at
+        FROM tasks t
+            LEFT JOIN task_tags_view ttv
+                ON t.id = ttv.task_id
+            LEFT JOIN task_time_view tmv
+                ON t.id = tmv.task_id
+        WHERE t.user_id = $2
+          AND t.id IN (SELECT tasks_tags.task_id
+                       FROM tasks_tags
+                           JOIN subtree
+                               ON tasks_tags.tag_id = subtree.id)
+          AND t.deleted_at IS NULL
+        GROUP BY
+            t.id,
+            t.title,
+            t.description,
+            t.start_date,
+            t.deadline,
+            t.start_time,
+            t.end_time,
+            t.list_id,
+            t.user_id,
+            t.estimate_minutes,
+            tmv.tracked_minutes,
+            ttv.tags,
+            t.updated_at
+        ) t
+        ON l.id = t.list_id
+WHERE l.user_id = $2
+  AND l.id > @cursor::varchar
+GROUP BY l.id
+ORDER BY l.id
+LIMIT $3;
+
+-- name: GetTasksForTodayGroupedByTag :many
+SELECT
+    tags.id AS tag_id,
+    tags.title AS tag_title,
+    ARRAY_TO_JSON(
+            ARRAY_AGG(
+                    JSON_BUILD_OBJECT(
+                            'id', t.id,
+                            'title', t.title,
+                            'description', t.description,
+                            'start_date', t.start_date,
+                            'deadline', t.deadline,
+                            'start_time', t.start_time,
+                            'end_time', t.end_time,
+                            'list_id', t.list_id,
+                            'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
+                            'tags', t.tags,
+                            'overdue', overdue,
                             'updated_at', t.updated_at
                     )
             )
     ) AS tasks
+FROM tags
+    JOIN tasks_tags
+        ON tags.id = tasks_tags.tag_id
+    JOIN (
+        SELECT
+            t.id,
+            t.title,
+            t.description,
+            t.start_date,
+            t.deadline,
+            t.start_time,
+            t.end_time,
+            t.list_id,
+            t.user_id,
+            t.estimate_minutes,
+            COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
+            ttv.tags as tags,
+            CASE
+                WHEN t.deadline <= @today::timestamptz THEN TRUE
+                ELSE FALSE END AS overdue,
+            t.updated_at
+        FROM tasks t
+            LEFT JOIN task_tags_view ttv
+                ON t.id = ttv.task_id
+            LEFT JOIN task_time_view tmv
+                ON t.id = tmv.task_id
+        WHERE t.user_id = $1
+          AND t.start_date >= @today::timestamptz
+          AND t.start_date < @today::timestamptz + interval '1 day'
+          AND t.deleted_at IS NULL
+        GROUP BY
+            t.id,
+            t.title,
+            t.description,
+            t.start_date,
+            t.deadline,
+            t.start_time,
+            t.end_time,
+            t.list_id,
+            t.user_id,
+            t.estimate_minutes,
+            tmv.tracked_minutes,
+            ttv.tags,
+            t.updated_at
+        ) t
+        ON tasks_tags.task_id = t.id
+WHERE tags.user_id = $1
+  AND tags.deleted_at IS NULL
+GROUP BY tags.id
+ORDER BY tags.title;
+
+-- name: GetUpcomingTasksGroupedByTag :many
+SELECT
+    tags.id AS tag_id,
+    tags.title AS tag_title,
+    ARRAY_TO_JSON(
+            ARRAY_AGG(
+                    JSON_BUILD_OBJECT(
+                            'id', t.id,
+                            'title', t.title,
+                            'description', t.description,
+                            'start_date', t.start_date,
+                            'deadline', t.deadline,
+                            'start_time', t.start_time,
+                            'end_time', t.end_time,
+                            'list_id', t.list_id,
+                            'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
+                            'tags', t.tags,
+                            'updated_at', t.updated_at
+                    )
+            )
+    ) AS tasks
+FROM tags
+    JOIN tasks_tags
+        ON tags.id = tasks_tags.tag_id
+    JOIN (
+        SELECT
+            t.id,
+            t.title,
+            t.description,
+            t.start_date,
+            t.deadline,
+            t.start_time,
+            t.end_time,
+            t.list_id,
+            t.user_id,
+            t.estimate_minutes,
+            COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
+            ttv.tags as tags,
+            t.updated_at
+        FROM tasks t
+            LEFT JOIN task_tags_view ttv
+                ON t.id = ttv.task_id
+            LEFT JOIN task_time_view tmv
+                ON t.id = tmv.task_id
+        WHERE t.user_id = $1
+          AND t.start_date >= @today::timestamptz + interval '1 day'
+          AND t.deleted_at IS NULL
+        GROUP BY
+            t.id,
+            t.title,
+            t.description,
+            t.start_date,
+            t.deadline,
+            t.start_time,
+            t.end_time,
+            t.list_id,
+            t.user_id,
+            t.estimate_minutes,
+            tmv.tracked_minutes,
+            ttv.tags,
+            t.updated_at
+        ) t
+        ON tasks_tags.task_id = t.id
+WHERE tags.user_id = $1
+  AND tags.id > @cursor::varchar
+  AND tags.deleted_at IS NULL
+GROUP BY tags.id
+ORDER BY tags.id
+LIMIT $2;
+
+-- name: GetTasksForSomedayGroupedByTag :many
+SELECT
+    tags.id AS tag_id,
+    tags.title AS tag_title,
+    ARRAY_TO_JSON(
+            ARRAY_AGG(
+                    JSON_BUILD_OBJECT(
+                            'id', t.id,
+                            'title', t.title,
+                            'description', t.description,
+                            'deadline', t.deadline,
+                            'start_time', t.start_time,
+                            'end_time', t.end_time,
+                            'list_id', t.list_id,
+                            'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
+                            'tags', t.tags,
+                            'updated_at', t.updated_at
+                    )
+            )
+    ) AS tasks
+FROM tags
+    JOIN tasks_tags
+        ON tags.id = tasks_tags.tag_id
+    JOIN (
+        SELECT
+            t.id,
+            t.title,
+            t.description,
+            t.deadline,
+            t.start_time,
+            t.end_time,
+            t.status_id,
+            t.list_id,
+            t.heading_id,
+            t.user_id,
+            t.estimate_minutes,
+            COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
+            ttv.tags as tags,
+            t.updated_at
+        FROM tasks t
+            LEFT JOIN task_tags_view ttv
+                ON t.id = ttv.task_id
+            LEFT JOIN task_time_view tmv
+                ON t.id = tmv.task_id
+        WHERE t.user_id = $1
+          AND t.start_date IS NULL
+          AND t.deadline IS NULL
+          AND t.deleted_at IS NULL
+        GROUP BY
+            t.id,
+            t.title,
+            t.description,
+            t.deadline,
+            t.start_time,
+            t.end_time,
+            t.status_id,
+            t.list_id,
+            t.heading_id,
+            t.user_id,
+            t.estimate_minutes,
+            tmv.tracked_minutes,
+            ttv.tags,
+            t.updated_at
+        ) t
+        ON tasks_tags.task_id = t.id
+WHERE tags.user_id = $1
+  AND tags.id > @cursor::varchar
+  AND tags.deleted_at IS NULL
+GROUP BY tags.id
+ORDER BY tags.id
+LIMIT $2;
+
+-- name: GetCompletedTasks :many
+SELECT
+    t.month,
+    ARRAY_TO_JSON(
+            ARRAY_AGG(
+                    JSON_BUILD_OBJECT(
+                            'id', t.id,
+                            'title', t.title,
+                            'description', t.description,
+                            'start_date', t.start_date,
+                            'deadline', t.deadline,
+                            'start_time', t.start_time,
+                            'end_time', t.end_time,
+                            'list_id', t.list_id,
+                            'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
+                            'tags', tags,
+                            'updated_at', t.updated_at,
+                            'completed_at', t.completed_at
+                    )
+            )
+    ) AS tasks
 FROM (
     SELECT
         t.id,
@@ -467,20 +944,33 @@ FROM (
         t.end_time,
         t.list_id,
         t.user_id,
+        t.estimate_minutes,
+        COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
         ttv.tags as tags,
-        t.updated_at
+        t.updated_at,
+        t.completed_at,
+        DATE_TRUNC('month', t.completed_at AT TIME ZONE @time_zone::varchar)::date AS month
     FROM tasks t
         LEFT JOIN task_tags_view ttv
             ON t.id = ttv.task_id
+        LEFT JOIN task_time_view tmv
+            ON t.id = tmv.task_id
     WHERE t.user_id = $1
       AND t.status_id = (
           SELECT id
           FROM statuses
           WHERE statuses.title = @status_title::varchar
       )
-      AND (t.deleted_at IS NULL
-               OR (DATE_TRUNC('month', t.updated_at) > @cursor_date::timestamptz AND t.deleted_at IS NULL)
+      AND t.deleted_at IS NULL
+      AND (@cursor_date::date IS NULL
+               OR DATE_TRUNC('month', t.completed_at AT TIME ZONE @time_zone::varchar)::date < @cursor_date::date
           )
+      AND NOT EXISTS (
+          SELECT 1
+          FROM headings h
+          WHERE h.id = t.heading_id
+            AND h.completed_at IS NOT NULL
+      )
     GROUP BY
         t.id,
         t.title,
@@ -491,13 +981,75 @@ FROM (
         t.end_time,
         t.list_id,
         t.user_id,
+        t.estimate_minutes,
+        tmv.tracked_minutes,
         tags,
-        t.updated_at
+        t.updated_at,
+        t.completed_at
     ) t
-GROUP BY month
-ORDER BY month DESC
+GROUP BY t.month
+ORDER BY t.month DESC
 LIMIT $2;
 
+-- name: GetCompletedHeadings :many
+WITH completed_headings AS (
+    SELECT
+        id,
+        title,
+        list_id,
+        user_id,
+        completed_at,
+        DATE_TRUNC('month', completed_at AT TIME ZONE @time_zone::varchar)::date AS month
+    FROM headings
+    WHERE user_id = $1
+      AND completed_at IS NOT NULL
+      AND deleted_at IS NULL
+),
+ranked_headings AS (
+    SELECT
+        *,
+        DENSE_RANK() OVER (ORDER BY month DESC) AS month_rank
+    FROM completed_headings
+    WHERE @cursor_date::date IS NULL
+       OR month < @cursor_date::date
+)
+SELECT
+    h.id,
+    h.title,
+    h.list_id,
+    h.completed_at::timestamptz AS completed_at,
+    h.month,
+    COALESCE(
+        (
+            SELECT ARRAY_TO_JSON(
+                    ARRAY_AGG(
+                            JSON_BUILD_OBJECT(
+                                    'id', t.id,
+                                    'title', t.title,
+                                    'description', t.description,
+                                    'start_date', t.start_date,
+                                    'deadline', t.deadline,
+                                    'status_id', t.status_id,
+                                    'list_id', t.list_id,
+                                    'heading_id', t.heading_id,
+                                    'user_id', t.user_id,
+                                    'tags', ttv.tags,
+                                    'updated_at', t.updated_at
+                            ) ORDER BY t.position
+                    )
+            )
+            FROM tasks t
+                LEFT JOIN task_tags_view ttv
+                    ON t.id = ttv.task_id
+            WHERE t.heading_id = h.id
+              AND t.user_id = h.user_id
+        ),
+        '[]'
+    )::json AS tasks
+FROM ranked_headings h
+WHERE h.month_rank <= @month_limit::int
+ORDER BY h.completed_at DESC;
+
 -- name: GetArchivedTasks :many
 SELECT
     DATE_TRUNC('month', t.updated_at)::timestamptz AS month,
@@ -513,6 +1065,8 @@ SELECT
                             'end_time', t.end_time,
                             'list_id', t.list_id,
                             'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
                             'tags', tags,
                             'updated_at', t.updated_at,
                             'deleted_at', t.deleted_at
@@ -530,12 +1084,16 @@ FROM (
         t.end_time,
         t.list_id,
         t.user_id,
+        t.estimate_minutes,
+        COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
         ttv.tags as tags,
         t.updated_at,
         t.deleted_at
     FROM tasks t
         LEFT JOIN task_tags_view ttv
             ON t.id = ttv.task_id
+        LEFT JOIN task_time_view tmv
+            ON t.id = tmv.task_id
     WHERE t.user_id = $1
       AND t.status_id = (
         SELECT id
@@ -555,6 +1113,8 @@ FROM (
         t.end_time,
         t.list_id,
         t.user_id,
+        t.estimate_minutes,
+        tmv.tracked_minutes,
         tags,
         t.updated_at,
         t.deleted_at
@@ -563,10 +1123,52 @@ GROUP BY month
 ORDER BY month DESC
 LIMIT $2;
 
+-- name: GetTasksForSchedule :many
+SELECT
+    id,
+    title,
+    start_date,
+    deadline,
+    start_time,
+    end_time,
+    estimate_minutes
+FROM tasks
+WHERE user_id = $1
+  AND status_id != $2
+  AND deleted_at IS NULL
+  AND (
+      (start_date >= @day::timestamptz AND start_date < @day::timestamptz + interval '1 day')
+      OR (end_time > @day_start::timestamptz AND start_time < @day_end::timestamptz)
+  )
+ORDER BY created_at;
+
+-- name: UpdateTasksTime :many
+UPDATE tasks
+SET start_time = s.start_time,
+    end_time = s.end_time,
+    status_id = CASE WHEN tasks.custom_status_id IS NULL THEN @status_id::int ELSE tasks.status_id END,
+    completed_at = CASE WHEN tasks.custom_status_id IS NULL THEN NULL ELSE tasks.completed_at END,
+    updated_at = @updated_at
+FROM UNNEST(@task_ids::varchar[], @start_times::timestamptz[], @end_times::timestamptz[]) AS s(task_id, start_time, end_time)
+WHERE tasks.id = s.task_id
+  AND tasks.user_id = @user_id
+  AND tasks.deleted_at IS NULL
+  AND (
+      SELECT COUNT(*)
+      FROM tasks
+      WHERE id = ANY(@task_ids::varchar[])
+        AND user_id = @user_id
+        AND deleted_at IS NULL
+  ) = CARDINALITY(@task_ids::varchar[])
+RETURNING tasks.id;
+
 -- name: MoveTaskToAnotherList :one
 UPDATE tasks
 SET	list_id = $1,
     heading_id = $2,
+    custom_status_id = CASE
+        WHEN custom_status_id IN (SELECT id FROM statuses WHERE list_id != $1) THEN NULL
+        ELSE custom_status_id END,
     updated_at = $3
 WHERE id = $4
   AND user_id = $5
@@ -582,10 +1184,23 @@ WHERE id = $3
     AND deleted_at IS NULL
 RETURNING id;
 
+-- name: UpdateTaskStatus :one
+UPDATE tasks
+SET status_id = $1,
+    custom_status_id = $2,
+    updated_at = $3,
+    completed_at = $6
+WHERE id = $4
+  AND user_id = $5
+  AND deleted_at IS NULL
+RETURNING id;
+
 -- name: MarkTaskAsCompleted :one
 UPDATE tasks
 SET	status_id = $1,
-    updated_at = $2
+    custom_status_id = NULL,
+    updated_at = $2,
+    completed_at = $2
 WHERE id = $3
   AND user_id = $4
   AND deleted_at IS NULL
@@ -593,7 +1208,7 @@ RETURNING id;
 
 -- name: MarkTaskAsArchived :one
 UPDATE tasks
-SET status_id = $1, deleted_at = $2
+SET status_id = $1, custom_status_id = NULL, deleted_at = $2
 WHERE id = $3
   AND user_id = $4
   AND deleted_at IS NULL
@@ -601,14 +1216,41 @@ RETURNING id;
 
 -- name: ArchiveTasksByHeadingID :exec
 UPDATE tasks
-SET status_id = $1, deleted_at = $2
+SET status_id = $1, custom_status_id = NULL, deleted_at = $2
 WHERE heading_id = $3
   AND user_id = $4
   AND deleted_at IS NULL;
 
 -- name: ArchiveTasksByListID :exec
 UPDATE tasks
-SET status_id = $1, deleted_at = $2
+SET status_id = $1, custom_status_id = NULL, deleted_at = $2
 WHERE list_id = $3
   AND user_id = $4
-  AND deleted_at IS NULL;
\ No newline at end of file
+  AND deleted_at IS NULL;
+
+-- name: CloseTasksByHeadingID :exec
+UPDATE tasks
+SET previous_status_id = status_id,
+    previous_custom_status_id = custom_status_id,
+    status_id = @status_id,
+    custom_status_id = NULL,
+    updated_at = @updated_at,
+    deleted_at = @deleted_at,
+    completed_at = CASE WHEN @status_id = @completed_status_id THEN @updated_at END
+WHERE heading_id = @heading_id
+  AND user_id = @user_id
+  AND status_id != @completed_status_id
+  AND deleted_at IS NULL;
+
+-- name: ReopenTasksByHeadingID :exec
+UPDATE tasks
+SET status_id = previous_status_id,
+    custom_status_id = previous_custom_status_id,
+    previous_status_id = NULL,
+    previous_custom_status_id = NULL,
+    updated_at = $1,
+    deleted_at = NULL,
+    completed_at = NULL
+WHERE heading_id = $2
+  AND user_id = $3
+  AND previous_status_id IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,67 @@
+-- name: CreateTemplate :exec
+INSERT INTO templates (id, title, type, description, color, icon, deadline_offset, user_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: CreateTemplateHeading :exec
+INSERT INTO template_headings (id, template_id, title, position, user_id)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: CreateTemplateTask :exec
+INSERT INTO template_tasks (
+    id,
+    template_id,
+    heading_id,
+    title,
+    description,
+    start_date_offset,
+    deadline_offset,
+    estimate_minutes,
+    tags,
+    position,
+    user_id
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+
+-- name: GetTemplateByID :one
+SELECT id, title, type, description, color, icon, deadline_offset, user_id, updated_at
+FROM templates
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: GetTemplatesByUserID :many
+SELECT id, title, type, updated_at
+FROM templates
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY title, id;
+
+-- name: GetTemplateHeadings :many
+SELECT id, title
+FROM template_headings
+WHERE template_id = $1
+  AND user_id = $2
+ORDER BY position;
+
+-- name: GetTemplateTasks :many
+SELECT
+    id,
+    heading_id,
+    title,
+    description,
+    start_date_offset,
+    deadline_offset,
+    estimate_minutes,
+    tags
+FROM template_tasks
+WHERE template_id = $1
+  AND user_id = $2
+ORDER BY position;
+
+-- name: DeleteTemplate :one
+UPDATE templates
+SET deleted_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND deleted_at IS NULL
+RETURNING id;
\ No newline at end of file
@@ -0,0 +1,170 @@
+-- name: CreateTimeEntry :exec
+INSERT INTO time_entries (
+    id,
+    task_id,
+    user_id,
+    start_time,
+    end_time,
+    created_at,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: GetTimeEntryByID :one
+SELECT
+    id,
+    task_id,
+    user_id,
+    start_time,
+    end_time,
+    updated_at
+FROM time_entries
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: GetTimeEntriesByTaskID :many
+SELECT
+    id,
+    task_id,
+    user_id,
+    start_time,
+    end_time,
+    updated_at
+FROM time_entries
+WHERE task_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+ORDER BY start_time;
+
+-- name: GetRunningTimeEntry :one
+SELECT
+    id,
+    task_id,
+    user_id,
+    start_time,
+    end_time,
+    updated_at
+FROM time_entries
+WHERE user_id = $1
+  AND end_time IS NULL
+  AND deleted_at IS NULL;
+
+-- name: StopTimeEntry :one
+UPDATE time_entries
+SET end_time = $1,
+    updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND end_time IS NULL
+  AND deleted_at IS NULL
+RETURNING id;
+
+-- name: UpdateTimeEntry :one
+UPDATE time_entries
+SET start_time = $1,
+    end_time = $2,
+    updated_at = $3
+WHERE id = $4
+  AND user_id = $5
+  AND deleted_at IS NULL
+RETURNING id;
+
+-- name: DeleteTimeEntry :one
+UPDATE time_entries
+SET deleted_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND deleted_at IS NULL
+RETURNING id;
+
+-- name: GetTimeReportByList :many
+WITH tracked AS (
+    SELECT
+        task_id,
+        (EXTRACT(EPOCH FROM SUM(COALESCE(end_time, now()) - start_time)) / 60)::int AS tracked_minutes
+    FROM time_entries
+    WHERE user_id = $1
+      AND start_time >= @from_time::timestamptz
+      AND start_time < @to_time::timestamptz
+      AND deleted_at IS NULL
+    GROUP BY task_id
+)
+SELECT
+    t.list_id,
+    COALESCE(SUM(tr.tracked_minutes), 0)::int AS tracked_minutes,
+    COALESCE(SUM(t.estimate_minutes), 0)::int AS estimated_minutes
+FROM tasks t
+    LEFT JOIN tracked tr
+        ON t.id = tr.task_id
+WHERE t.user_id = $1
+  AND t.deleted_at IS NULL
+  AND (tr.task_id IS NOT NULL
+           OR (t.start_date >= @from_date::timestamptz AND t.start_date < @to_date::timestamptz)
+      )
+GROUP BY t.list_id
+ORDER BY t.list_id;
+
+-- name: GetTimeReportByTag :many
+WITH tracked AS (
+    SELECT
+        task_id,
+        (EXTRACT(EPOCH FROM SUM(COALESCE(end_time, now()) - start_time)) / 60)::int AS tracked_minutes
+    FROM time_entries
+    WHERE user_id = $1
+      AND start_time >= @from_time::timestamptz
+      AND start_time < @to_time::timestamptz
+      AND deleted_at IS NULL
+    GROUP BY task_id
+)
+SELECT
+    tg.title AS tag,
+    COALESCE(SUM(tr.tracked_minutes), 0)::int AS tracked_minutes,
+    COALESCE(SUM(t.estimate_minutes), 0)::int AS estimated_minutes
+FROM tasks t
+    JOIN tasks_tags tt
+        ON t.id = tt.task_id
+    JOIN tags tg
+        ON tt.tag_id = tg.id
+    LEFT JOIN tracked tr
+        ON t.id = tr.task_id
+WHERE t.user_id = $1
+  AND t.deleted_at IS NULL
+  AND (tr.task_id IS NOT NULL
+           OR (t.start_date >= @from_date::timestamptz AND t.start_date < @to_date::timestamptz)
+      )
+GROUP BY tg.title
+ORDER BY tg.title;
+
+-- name: GetTimeReportByDay :many
+WITH tracked AS (
+    SELECT
+        (start_time AT TIME ZONE @time_zone::varchar)::date AS day,
+        (EXTRACT(EPOCH FROM SUM(COALESCE(end_time, now()) - start_time)) / 60)::int AS tracked_minutes
+    FROM time_entries
+    WHERE user_id = $1
+      AND start_time >= @from_time::timestamptz
+      AND start_time < @to_time::timestamptz
+      AND deleted_at IS NULL
+    GROUP BY day
+),
+estimated AS (
+    SELECT
+        (start_date AT TIME ZONE 'UTC')::date AS day,
+        SUM(estimate_minutes)::int AS estimated_minutes
+    FROM tasks
+    WHERE user_id = $1
+      AND start_date >= @from_date::timestamptz
+      AND start_date < @to_date::timestamptz
+      AND deleted_at IS NULL
+    GROUP BY day
+)
+SELECT
+    COALESCE(tr.day, es.day)::date AS day,
+    COALESCE(tr.tracked_minutes, 0)::int AS tracked_minutes,
+    COALESCE(es.estimated_minutes, 0)::int AS estimated_minutes
+FROM tracked tr
+    FULL JOIN estimated es
+        ON tr.day = es.day
+ORDER BY day;
\ No newline at end of file
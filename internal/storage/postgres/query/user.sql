@@ -1,2 +1,14 @@
 -- name: DeleteUserRelatedData :exec
-SELECT delete_user_related_data($1);
\ No newline at end of file
+SELECT delete_user_related_data($1);
+
+-- name: GetUserSettings :one
+SELECT user_id, time_zone, updated_at
+FROM user_settings
+WHERE user_id = $1;
+
+-- name: UpsertUserSettings :exec
+INSERT INTO user_settings (user_id, time_zone, created_at, updated_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id) DO UPDATE
+SET time_zone = EXCLUDED.time_zone,
+    updated_at = EXCLUDED.updated_at;
\ No newline at end of file
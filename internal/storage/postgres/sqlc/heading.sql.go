@@ -12,6 +12,35 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const completeHeading = `-- name: CompleteHeading :one
+UPDATE headings
+SET completed_at = $1, updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND completed_at IS NULL
+  AND deleted_at IS NULL
+RETURNING id
+`
+
+type CompleteHeadingParams struct {
+	CompletedAt pgtype.Timestamptz `db:"completed_at"`
+	UpdatedAt   time.Time          `db:"updated_at"`
+	ID          string             `db:"id"`
+	UserID      string             `db:"user_id"`
+}
+
+func (q *Queries) CompleteHeading(ctx context.Context, arg CompleteHeadingParams) (string, error) {
+	row := q.db.QueryRow(ctx, completeHeading,
+		arg.CompletedAt,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.UserID,
+	)
+	var id string
+	err := row.Scan(&id)
+	return id, err
+}
+
 const createHeading = `-- name: CreateHeading :exec
 INSERT INTO headings (id, title, list_id, user_id, is_default, created_at,updated_at)
 VALUES($1, $2, $3, $4, $5, $6, $7)
@@ -103,7 +132,7 @@ func (q *Queries) GetDefaultHeadingID(ctx context.Context, arg GetDefaultHeading
 }
 
 const getHeadingByID = `-- name: GetHeadingByID :one
-SELECT id, title, list_id, user_id, updated_at
+SELECT id, title, list_id, user_id, is_default, completed_at, updated_at
 FROM headings
 WHERE id = $1
   AND user_id = $2
@@ -116,11 +145,13 @@ type GetHeadingByIDParams struct {
 }
 
 type GetHeadingByIDRow struct {
-	ID        string    `db:"id"`
-	Title     string    `db:"title"`
-	ListID    string    `db:"list_id"`
-	UserID    string    `db:"user_id"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID          string             `db:"id"`
+	Title       string             `db:"title"`
+	ListID      string             `db:"list_id"`
+	UserID      string             `db:"user_id"`
+	IsDefault   bool               `db:"is_default"`
+	CompletedAt pgtype.Timestamptz `db:"completed_at"`
+	UpdatedAt   time.Time          `db:"updated_at"`
 }
 
 func (q *Queries) GetHeadingByID(ctx context.Context, arg GetHeadingByIDParams) (GetHeadingByIDRow, error) {
@@ -131,16 +162,43 @@ func (q *Queries) GetHeadingByID(ctx context.Context, arg GetHeadingByIDParams)
 		&i.Title,
 		&i.ListID,
 		&i.UserID,
+		&i.IsDefault,
+		&i.CompletedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const getHeadingIDByTitle = `-- name: GetHeadingIDByTitle :one
+SELECT id
+FROM headings
+WHERE LOWER(title) = LOWER($1::varchar)
+  AND list_id = $2
+  AND user_id = $3
+  AND deleted_at IS NULL
+ORDER BY created_at
+LIMIT 1
+`
+
+type GetHeadingIDByTitleParams struct {
+	Title  string `db:"title"`
+	ListID string `db:"list_id"`
+	UserID string `db:"user_id"`
+}
+
+func (q *Queries) GetHeadingIDByTitle(ctx context.Context, arg GetHeadingIDByTitleParams) (string, error) {
+	row := q.db.QueryRow(ctx, getHeadingIDByTitle, arg.Title, arg.ListID, arg.UserID)
+	var id string
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getHeadingsByListID = `-- name: GetHeadingsByListID :many
 SELECT id, title, list_id, user_id, updated_at
 FROM headings
 WHERE list_id = $1
   AND user_id = $2
+  AND completed_at IS NULL
   AND deleted_at IS NULL
 `
 
@@ -211,6 +269,60 @@ func (q *Queries) MoveHeadingToAnotherList(ctx context.Context, arg MoveHeadingT
 	return id, err
 }
 
+const moveTasksToHeading = `-- name: MoveTasksToHeading :exec
+UPDATE tasks
+SET heading_id = $1,
+    list_id = $2,
+    custom_status_id = CASE
+        WHEN custom_status_id IN (SELECT id FROM statuses WHERE list_id != $2) THEN NULL
+        ELSE custom_status_id END,
+    updated_at = $3
+WHERE heading_id = $4
+  AND user_id = $5
+`
+
+type MoveTasksToHeadingParams struct {
+	HeadingID     string    `db:"heading_id"`
+	ListID        string    `db:"list_id"`
+	UpdatedAt     time.Time `db:"updated_at"`
+	FromHeadingID string    `db:"from_heading_id"`
+	UserID        string    `db:"user_id"`
+}
+
+func (q *Queries) MoveTasksToHeading(ctx context.Context, arg MoveTasksToHeadingParams) error {
+	_, err := q.db.Exec(ctx, moveTasksToHeading,
+		arg.HeadingID,
+		arg.ListID,
+		arg.UpdatedAt,
+		arg.FromHeadingID,
+		arg.UserID,
+	)
+	return err
+}
+
+const restoreHeading = `-- name: RestoreHeading :one
+UPDATE headings
+SET completed_at = NULL, updated_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND completed_at IS NOT NULL
+  AND deleted_at IS NULL
+RETURNING id
+`
+
+type RestoreHeadingParams struct {
+	UpdatedAt time.Time `db:"updated_at"`
+	ID        string    `db:"id"`
+	UserID    string    `db:"user_id"`
+}
+
+func (q *Queries) RestoreHeading(ctx context.Context, arg RestoreHeadingParams) (string, error) {
+	row := q.db.QueryRow(ctx, restoreHeading, arg.UpdatedAt, arg.ID, arg.UserID)
+	var id string
+	err := row.Scan(&id)
+	return id, err
+}
+
 const updateHeading = `-- name: UpdateHeading :one
 UPDATE headings
 SET title = $1, updated_at = $2
@@ -13,25 +13,41 @@ import (
 )
 
 const createList = `-- name: CreateList :exec
-INSERT INTO lists (id, title, user_id, is_default, created_at,updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO lists (id, title, description, deadline, color, icon, user_id, is_default, area_id, position, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, (
+    SELECT COALESCE(MAX(position), 0) + 1
+    FROM lists
+    WHERE user_id = $7
+      AND COALESCE(area_id, '') = COALESCE($9, '')
+      AND deleted_at IS NULL
+), $10, $11)
 `
 
 type CreateListParams struct {
-	ID        string    `db:"id"`
-	Title     string    `db:"title"`
-	UserID    string    `db:"user_id"`
-	IsDefault bool      `db:"is_default"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID          string             `db:"id"`
+	Title       string             `db:"title"`
+	Description pgtype.Text        `db:"description"`
+	Deadline    pgtype.Timestamptz `db:"deadline"`
+	Color       pgtype.Text        `db:"color"`
+	Icon        pgtype.Text        `db:"icon"`
+	UserID      string             `db:"user_id"`
+	IsDefault   bool               `db:"is_default"`
+	AreaID      pgtype.Text        `db:"area_id"`
+	CreatedAt   time.Time          `db:"created_at"`
+	UpdatedAt   time.Time          `db:"updated_at"`
 }
 
 func (q *Queries) CreateList(ctx context.Context, arg CreateListParams) error {
 	_, err := q.db.Exec(ctx, createList,
 		arg.ID,
 		arg.Title,
+		arg.Description,
+		arg.Deadline,
+		arg.Color,
+		arg.Icon,
 		arg.UserID,
 		arg.IsDefault,
+		arg.AreaID,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
@@ -65,6 +81,7 @@ SELECT id
 FROM lists
 WHERE user_id = $1
   AND is_default = TRUE
+  AND area_id IS NULL
   AND deleted_at IS NULL
 `
 
@@ -76,55 +93,185 @@ func (q *Queries) GetDefaultListID(ctx context.Context, userID string) (string,
 }
 
 const getListByID = `-- name: GetListByID :one
-SELECT id, title, user_id, is_default, updated_at
-FROM lists
-WHERE id = $1
-  AND user_id = $2
-  AND deleted_at IS NULL
+SELECT
+    l.id,
+    l.title,
+    l.description,
+    l.deadline,
+    l.color,
+    l.icon,
+    l.user_id,
+    l.is_default,
+    l.area_id,
+    l.updated_at,
+    COUNT(t.id)::int AS total_tasks,
+    COUNT(t.id) FILTER (
+        WHERE t.status_id = (
+            SELECT id
+            FROM statuses
+            WHERE statuses.title = $1::varchar
+              AND statuses.user_id IS NULL
+        )
+    )::int AS completed_tasks
+FROM lists l
+    LEFT JOIN tasks t
+        ON t.list_id = l.id
+       AND t.deleted_at IS NULL
+WHERE l.id = $2
+  AND l.user_id = $3
+  AND l.deleted_at IS NULL
+GROUP BY l.id
 `
 
 type GetListByIDParams struct {
-	ID     string `db:"id"`
-	UserID string `db:"user_id"`
+	StatusTitle string `db:"status_title"`
+	ID          string `db:"id"`
+	UserID      string `db:"user_id"`
 }
 
 type GetListByIDRow struct {
-	ID        string    `db:"id"`
-	Title     string    `db:"title"`
-	UserID    string    `db:"user_id"`
-	IsDefault bool      `db:"is_default"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID             string             `db:"id"`
+	Title          string             `db:"title"`
+	Description    pgtype.Text        `db:"description"`
+	Deadline       pgtype.Timestamptz `db:"deadline"`
+	Color          pgtype.Text        `db:"color"`
+	Icon           pgtype.Text        `db:"icon"`
+	UserID         string             `db:"user_id"`
+	IsDefault      bool               `db:"is_default"`
+	AreaID         pgtype.Text        `db:"area_id"`
+	UpdatedAt      time.Time          `db:"updated_at"`
+	TotalTasks     int32              `db:"total_tasks"`
+	CompletedTasks int32              `db:"completed_tasks"`
 }
 
 func (q *Queries) GetListByID(ctx context.Context, arg GetListByIDParams) (GetListByIDRow, error) {
-	row := q.db.QueryRow(ctx, getListByID, arg.ID, arg.UserID)
+	row := q.db.QueryRow(ctx, getListByID, arg.StatusTitle, arg.ID, arg.UserID)
 	var i GetListByIDRow
 	err := row.Scan(
 		&i.ID,
 		&i.Title,
+		&i.Description,
+		&i.Deadline,
+		&i.Color,
+		&i.Icon,
 		&i.UserID,
 		&i.IsDefault,
+		&i.AreaID,
 		&i.UpdatedAt,
+		&i.TotalTasks,
+		&i.CompletedTasks,
 	)
 	return i, err
 }
 
-const getListsByUserID = `-- name: GetListsByUserID :many
-SELECT id, title, updated_at
+const getListIDByTitle = `-- name: GetListIDByTitle :one
+SELECT id
+FROM lists
+WHERE LOWER(title) = LOWER($1::varchar)
+  AND user_id = $2
+  AND deleted_at IS NULL
+ORDER BY created_at
+LIMIT 1
+`
+
+type GetListIDByTitleParams struct {
+	Title  string `db:"title"`
+	UserID string `db:"user_id"`
+}
+
+func (q *Queries) GetListIDByTitle(ctx context.Context, arg GetListIDByTitleParams) (string, error) {
+	row := q.db.QueryRow(ctx, getListIDByTitle, arg.Title, arg.UserID)
+	var id string
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getListIDsByAreaID = `-- name: GetListIDsByAreaID :many
+SELECT id
 FROM lists
 WHERE user_id = $1
+  AND COALESCE(area_id, '') = $2::varchar
+  AND is_default = FALSE
   AND deleted_at IS NULL
-ORDER BY id
+ORDER BY position, id
 `
 
+type GetListIDsByAreaIDParams struct {
+	UserID string `db:"user_id"`
+	AreaID string `db:"area_id"`
+}
+
+func (q *Queries) GetListIDsByAreaID(ctx context.Context, arg GetListIDsByAreaIDParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, getListIDsByAreaID, arg.UserID, arg.AreaID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getListsByUserID = `-- name: GetListsByUserID :many
+SELECT
+    l.id,
+    l.title,
+    l.description,
+    l.deadline,
+    l.color,
+    l.icon,
+    l.area_id,
+    l.is_default,
+    l.updated_at,
+    COUNT(t.id)::int AS total_tasks,
+    COUNT(t.id) FILTER (
+        WHERE t.status_id = (
+            SELECT id
+            FROM statuses
+            WHERE statuses.title = $1::varchar
+              AND statuses.user_id IS NULL
+        )
+    )::int AS completed_tasks
+FROM lists l
+    LEFT JOIN tasks t
+        ON t.list_id = l.id
+       AND t.deleted_at IS NULL
+WHERE l.user_id = $2
+  AND l.deleted_at IS NULL
+GROUP BY l.id
+ORDER BY l.position, l.id
+`
+
+type GetListsByUserIDParams struct {
+	StatusTitle string `db:"status_title"`
+	UserID      string `db:"user_id"`
+}
+
 type GetListsByUserIDRow struct {
-	ID        string    `db:"id"`
-	Title     string    `db:"title"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID             string             `db:"id"`
+	Title          string             `db:"title"`
+	Description    pgtype.Text        `db:"description"`
+	Deadline       pgtype.Timestamptz `db:"deadline"`
+	Color          pgtype.Text        `db:"color"`
+	Icon           pgtype.Text        `db:"icon"`
+	AreaID         pgtype.Text        `db:"area_id"`
+	IsDefault      bool               `db:"is_default"`
+	UpdatedAt      time.Time          `db:"updated_at"`
+	TotalTasks     int32              `db:"total_tasks"`
+	CompletedTasks int32              `db:"completed_tasks"`
 }
 
-func (q *Queries) GetListsByUserID(ctx context.Context, userID string) ([]GetListsByUserIDRow, error) {
-	rows, err := q.db.Query(ctx, getListsByUserID, userID)
+func (q *Queries) GetListsByUserID(ctx context.Context, arg GetListsByUserIDParams) ([]GetListsByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, getListsByUserID, arg.StatusTitle, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +279,19 @@ func (q *Queries) GetListsByUserID(ctx context.Context, userID string) ([]GetLis
 	items := []GetListsByUserIDRow{}
 	for rows.Next() {
 		var i GetListsByUserIDRow
-		if err := rows.Scan(&i.ID, &i.Title, &i.UpdatedAt); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Deadline,
+			&i.Color,
+			&i.Icon,
+			&i.AreaID,
+			&i.IsDefault,
+			&i.UpdatedAt,
+			&i.TotalTasks,
+			&i.CompletedTasks,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -143,9 +302,102 @@ func (q *Queries) GetListsByUserID(ctx context.Context, userID string) ([]GetLis
 	return items, nil
 }
 
+const getOverdueLists = `-- name: GetOverdueLists :many
+SELECT l.id, l.title, l.deadline
+FROM lists l
+WHERE l.user_id = $1
+  AND l.id > $2::varchar
+  AND l.deadline <= $3::timestamptz
+  AND l.deleted_at IS NULL
+  AND EXISTS (
+      SELECT 1
+      FROM tasks t
+      WHERE t.list_id = l.id
+        AND t.status_id != (
+            SELECT id
+            FROM statuses
+            WHERE statuses.title = $4::varchar
+              AND statuses.user_id IS NULL
+        )
+        AND t.deleted_at IS NULL
+  )
+ORDER BY l.id
+`
+
+type GetOverdueListsParams struct {
+	UserID      string             `db:"user_id"`
+	Cursor      string             `db:"cursor"`
+	Today       pgtype.Timestamptz `db:"today"`
+	StatusTitle string             `db:"status_title"`
+}
+
+type GetOverdueListsRow struct {
+	ID       string             `db:"id"`
+	Title    string             `db:"title"`
+	Deadline pgtype.Timestamptz `db:"deadline"`
+}
+
+func (q *Queries) GetOverdueLists(ctx context.Context, arg GetOverdueListsParams) ([]GetOverdueListsRow, error) {
+	rows, err := q.db.Query(ctx, getOverdueLists,
+		arg.UserID,
+		arg.Cursor,
+		arg.Today,
+		arg.StatusTitle,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetOverdueListsRow{}
+	for rows.Next() {
+		var i GetOverdueListsRow
+		if err := rows.Scan(&i.ID, &i.Title, &i.Deadline); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moveListsToTopLevel = `-- name: MoveListsToTopLevel :exec
+UPDATE lists
+SET area_id = NULL,
+    is_default = FALSE,
+    position = lists.position + (
+        SELECT COALESCE(MAX(top.position), 0) + 1
+        FROM lists top
+        WHERE top.user_id = $1
+          AND top.area_id IS NULL
+          AND top.deleted_at IS NULL
+    ),
+    updated_at = $2
+WHERE area_id = $3::varchar
+  AND user_id = $1
+  AND deleted_at IS NULL
+`
+
+type MoveListsToTopLevelParams struct {
+	UserID    string    `db:"user_id"`
+	UpdatedAt time.Time `db:"updated_at"`
+	AreaID    string    `db:"area_id"`
+}
+
+func (q *Queries) MoveListsToTopLevel(ctx context.Context, arg MoveListsToTopLevelParams) error {
+	_, err := q.db.Exec(ctx, moveListsToTopLevel, arg.UserID, arg.UpdatedAt, arg.AreaID)
+	return err
+}
+
 const updateList = `-- name: UpdateList :one
 UPDATE lists
-SET title = $1,	updated_at = $2
+SET title = $1,
+    description = CASE WHEN $5::boolean THEN $6::varchar ELSE description END,
+    deadline = CASE WHEN $7::boolean THEN $8::timestamptz ELSE deadline END,
+    color = CASE WHEN $9::boolean THEN $10::varchar ELSE color END,
+    icon = CASE WHEN $11::boolean THEN $12::varchar ELSE icon END,
+    updated_at = $2
 WHERE id = $3
   AND user_id = $4
   AND deleted_at IS NULL
@@ -153,10 +405,18 @@ RETURNING id
 `
 
 type UpdateListParams struct {
-	Title     string    `db:"title"`
-	UpdatedAt time.Time `db:"updated_at"`
-	ID        string    `db:"id"`
-	UserID    string    `db:"user_id"`
+	Title          string             `db:"title"`
+	UpdatedAt      time.Time          `db:"updated_at"`
+	ID             string             `db:"id"`
+	UserID         string             `db:"user_id"`
+	DescriptionSet bool               `db:"description_set"`
+	Description    pgtype.Text        `db:"description"`
+	DeadlineSet    bool               `db:"deadline_set"`
+	Deadline       pgtype.Timestamptz `db:"deadline"`
+	ColorSet       bool               `db:"color_set"`
+	Color          pgtype.Text        `db:"color"`
+	IconSet        bool               `db:"icon_set"`
+	Icon           pgtype.Text        `db:"icon"`
 }
 
 func (q *Queries) UpdateList(ctx context.Context, arg UpdateListParams) (string, error) {
@@ -165,8 +425,63 @@ func (q *Queries) UpdateList(ctx context.Context, arg UpdateListParams) (string,
 		arg.UpdatedAt,
 		arg.ID,
 		arg.UserID,
+		arg.DescriptionSet,
+		arg.Description,
+		arg.DeadlineSet,
+		arg.Deadline,
+		arg.ColorSet,
+		arg.Color,
+		arg.IconSet,
+		arg.Icon,
 	)
 	var id string
 	err := row.Scan(&id)
 	return id, err
 }
+
+const updateListArea = `-- name: UpdateListArea :one
+UPDATE lists
+SET area_id = $1, updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL
+RETURNING id
+`
+
+type UpdateListAreaParams struct {
+	AreaID    pgtype.Text `db:"area_id"`
+	UpdatedAt time.Time   `db:"updated_at"`
+	ID        string      `db:"id"`
+	UserID    string      `db:"user_id"`
+}
+
+func (q *Queries) UpdateListArea(ctx context.Context, arg UpdateListAreaParams) (string, error) {
+	row := q.db.QueryRow(ctx, updateListArea,
+		arg.AreaID,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.UserID,
+	)
+	var id string
+	err := row.Scan(&id)
+	return id, err
+}
+
+const updateListsPosition = `-- name: UpdateListsPosition :exec
+UPDATE lists
+SET position = s.position
+FROM UNNEST($1::varchar[]) WITH ORDINALITY AS s(list_id, position)
+WHERE lists.id = s.list_id
+  AND lists.user_id = $2
+  AND lists.deleted_at IS NULL
+`
+
+type UpdateListsPositionParams struct {
+	ListIds []string `db:"list_ids"`
+	UserID  string   `db:"user_id"`
+}
+
+func (q *Queries) UpdateListsPosition(ctx context.Context, arg UpdateListsPositionParams) error {
+	_, err := q.db.Exec(ctx, updateListsPosition, arg.ListIds, arg.UserID)
+	return err
+}
@@ -10,25 +10,118 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type Heading struct {
+type Area struct {
 	ID        string             `db:"id"`
 	Title     string             `db:"title"`
-	ListID    string             `db:"list_id"`
+	Position  int32              `db:"position"`
 	UserID    string             `db:"user_id"`
-	IsDefault bool               `db:"is_default"`
 	CreatedAt time.Time          `db:"created_at"`
 	UpdatedAt time.Time          `db:"updated_at"`
 	DeletedAt pgtype.Timestamptz `db:"deleted_at"`
 }
 
+type AuthSession struct {
+	TokenHash  string    `db:"token_hash"`
+	UserID     string    `db:"user_id"`
+	UserAgent  string    `db:"user_agent"`
+	Ip         string    `db:"ip"`
+	ExpiresAt  time.Time `db:"expires_at"`
+	CreatedAt  time.Time `db:"created_at"`
+	ID         string    `db:"id"`
+	LastSeenAt time.Time `db:"last_seen_at"`
+}
+
+type AuthToken struct {
+	TokenHash string    `db:"token_hash"`
+	UserID    string    `db:"user_id"`
+	Type      string    `db:"type"`
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type AuthUser struct {
+	ID           string    `db:"id"`
+	Email        string    `db:"email"`
+	PasswordHash string    `db:"password_hash"`
+	Verified     bool      `db:"verified"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+type DigestSetting struct {
+	UserID           string             `db:"user_id"`
+	Email            string             `db:"email"`
+	Daily            bool               `db:"daily"`
+	Weekly           bool               `db:"weekly"`
+	SendTime         string             `db:"send_time"`
+	WeeklyDay        int32              `db:"weekly_day"`
+	UnsubscribeToken string             `db:"unsubscribe_token"`
+	DailySentAt      pgtype.Timestamptz `db:"daily_sent_at"`
+	WeeklySentAt     pgtype.Timestamptz `db:"weekly_sent_at"`
+	CreatedAt        time.Time          `db:"created_at"`
+	UpdatedAt        time.Time          `db:"updated_at"`
+}
+
+type FocusSession struct {
+	ID              string             `db:"id"`
+	TaskID          string             `db:"task_id"`
+	UserID          string             `db:"user_id"`
+	DurationMinutes int32              `db:"duration_minutes"`
+	Status          string             `db:"status"`
+	StartedAt       time.Time          `db:"started_at"`
+	EndsAt          time.Time          `db:"ends_at"`
+	PausedAt        pgtype.Timestamptz `db:"paused_at"`
+	FinishedAt      pgtype.Timestamptz `db:"finished_at"`
+	CreatedAt       time.Time          `db:"created_at"`
+	UpdatedAt       time.Time          `db:"updated_at"`
+}
+
+type FocusSessionEvent struct {
+	ID        int32     `db:"id"`
+	SessionID string    `db:"session_id"`
+	UserID    string    `db:"user_id"`
+	Event     string    `db:"event"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type Heading struct {
+	ID          string             `db:"id"`
+	Title       string             `db:"title"`
+	ListID      string             `db:"list_id"`
+	UserID      string             `db:"user_id"`
+	IsDefault   bool               `db:"is_default"`
+	CreatedAt   time.Time          `db:"created_at"`
+	UpdatedAt   time.Time          `db:"updated_at"`
+	DeletedAt   pgtype.Timestamptz `db:"deleted_at"`
+	CompletedAt pgtype.Timestamptz `db:"completed_at"`
+}
+
 type List struct {
-	ID        string             `db:"id"`
-	Title     string             `db:"title"`
-	UserID    string             `db:"user_id"`
-	IsDefault bool               `db:"is_default"`
-	CreatedAt time.Time          `db:"created_at"`
-	UpdatedAt time.Time          `db:"updated_at"`
-	DeletedAt pgtype.Timestamptz `db:"deleted_at"`
+	ID          string             `db:"id"`
+	Title       string             `db:"title"`
+	UserID      string             `db:"user_id"`
+	IsDefault   bool               `db:"is_default"`
+	CreatedAt   time.Time          `db:"created_at"`
+	UpdatedAt   time.Time          `db:"updated_at"`
+	DeletedAt   pgtype.Timestamptz `db:"deleted_at"`
+	AreaID      pgtype.Text        `db:"area_id"`
+	Position    int32              `db:"position"`
+	Description pgtype.Text        `db:"description"`
+	Deadline    pgtype.Timestamptz `db:"deadline"`
+	Color       pgtype.Text        `db:"color"`
+	Icon        pgtype.Text        `db:"icon"`
+}
+
+type PersonalToken struct {
+	ID         string             `db:"id"`
+	Name       string             `db:"name"`
+	TokenHash  string             `db:"token_hash"`
+	Scopes     []string           `db:"scopes"`
+	UserID     string             `db:"user_id"`
+	ExpiresAt  time.Time          `db:"expires_at"`
+	LastUsedAt pgtype.Timestamptz `db:"last_used_at"`
+	CreatedAt  time.Time          `db:"created_at"`
+	RevokedAt  pgtype.Timestamptz `db:"revoked_at"`
 }
 
 type Reminder struct {
@@ -48,8 +141,21 @@ type ReminderSetting struct {
 }
 
 type Status struct {
-	ID    int32  `db:"id"`
-	Title string `db:"title"`
+	ID         int32              `db:"id"`
+	Title      string             `db:"title"`
+	CategoryID int32              `db:"category_id"`
+	UserID     pgtype.Text        `db:"user_id"`
+	ListID     pgtype.Text        `db:"list_id"`
+	CreatedAt  time.Time          `db:"created_at"`
+	UpdatedAt  time.Time          `db:"updated_at"`
+	DeletedAt  pgtype.Timestamptz `db:"deleted_at"`
+}
+
+type StatusTransition struct {
+	UserID       string      `db:"user_id"`
+	ListID       pgtype.Text `db:"list_id"`
+	FromStatusID int32       `db:"from_status_id"`
+	ToStatusID   int32       `db:"to_status_id"`
 }
 
 type Tag struct {
@@ -59,23 +165,31 @@ type Tag struct {
 	CreatedAt time.Time          `db:"created_at"`
 	UpdatedAt time.Time          `db:"updated_at"`
 	DeletedAt pgtype.Timestamptz `db:"deleted_at"`
+	Color     pgtype.Text        `db:"color"`
+	ParentID  pgtype.Text        `db:"parent_id"`
 }
 
 type Task struct {
-	ID          string             `db:"id"`
-	Title       string             `db:"title"`
-	Description pgtype.Text        `db:"description"`
-	StartDate   pgtype.Timestamptz `db:"start_date"`
-	Deadline    pgtype.Timestamptz `db:"deadline"`
-	StartTime   pgtype.Timestamptz `db:"start_time"`
-	EndTime     pgtype.Timestamptz `db:"end_time"`
-	StatusID    int32              `db:"status_id"`
-	ListID      string             `db:"list_id"`
-	HeadingID   string             `db:"heading_id"`
-	UserID      string             `db:"user_id"`
-	CreatedAt   time.Time          `db:"created_at"`
-	UpdatedAt   time.Time          `db:"updated_at"`
-	DeletedAt   pgtype.Timestamptz `db:"deleted_at"`
+	ID                     string             `db:"id"`
+	Title                  string             `db:"title"`
+	Description            pgtype.Text        `db:"description"`
+	StartDate              pgtype.Timestamptz `db:"start_date"`
+	Deadline               pgtype.Timestamptz `db:"deadline"`
+	StartTime              pgtype.Timestamptz `db:"start_time"`
+	EndTime                pgtype.Timestamptz `db:"end_time"`
+	StatusID               int32              `db:"status_id"`
+	ListID                 string             `db:"list_id"`
+	HeadingID              string             `db:"heading_id"`
+	UserID                 string             `db:"user_id"`
+	CreatedAt              time.Time          `db:"created_at"`
+	UpdatedAt              time.Time          `db:"updated_at"`
+	DeletedAt              pgtype.Timestamptz `db:"deleted_at"`
+	EstimateMinutes        pgtype.Int4        `db:"estimate_minutes"`
+	CustomStatusID         pgtype.Int4        `db:"custom_status_id"`
+	Position               int32              `db:"position"`
+	PreviousStatusID       pgtype.Int4        `db:"previous_status_id"`
+	PreviousCustomStatusID pgtype.Int4        `db:"previous_custom_status_id"`
+	CompletedAt            pgtype.Timestamptz `db:"completed_at"`
 }
 
 type TaskTagsView struct {
@@ -83,7 +197,66 @@ type TaskTagsView struct {
 	Tags   interface{} `db:"tags"`
 }
 
+type TaskTimeView struct {
+	TaskID         string `db:"task_id"`
+	TrackedMinutes int32  `db:"tracked_minutes"`
+}
+
 type TasksTag struct {
 	TaskID string `db:"task_id"`
 	TagID  string `db:"tag_id"`
 }
+
+type Template struct {
+	ID             string             `db:"id"`
+	Title          string             `db:"title"`
+	Type           string             `db:"type"`
+	Description    pgtype.Text        `db:"description"`
+	Color          pgtype.Text        `db:"color"`
+	Icon           pgtype.Text        `db:"icon"`
+	DeadlineOffset pgtype.Int4        `db:"deadline_offset"`
+	UserID         string             `db:"user_id"`
+	CreatedAt      time.Time          `db:"created_at"`
+	UpdatedAt      time.Time          `db:"updated_at"`
+	DeletedAt      pgtype.Timestamptz `db:"deleted_at"`
+}
+
+type TemplateHeading struct {
+	ID         string `db:"id"`
+	TemplateID string `db:"template_id"`
+	Title      string `db:"title"`
+	Position   int32  `db:"position"`
+	UserID     string `db:"user_id"`
+}
+
+type TemplateTask struct {
+	ID              string      `db:"id"`
+	TemplateID      string      `db:"template_id"`
+	HeadingID       pgtype.Text `db:"heading_id"`
+	Title           string      `db:"title"`
+	Description     pgtype.Text `db:"description"`
+	StartDateOffset pgtype.Int4 `db:"start_date_offset"`
+	DeadlineOffset  pgtype.Int4 `db:"deadline_offset"`
+	EstimateMinutes pgtype.Int4 `db:"estimate_minutes"`
+	Tags            []string    `db:"tags"`
+	Position        int32       `db:"position"`
+	UserID          string      `db:"user_id"`
+}
+
+type TimeEntry struct {
+	ID        string             `db:"id"`
+	TaskID    string             `db:"task_id"`
+	UserID    string             `db:"user_id"`
+	StartTime time.Time          `db:"start_time"`
+	EndTime   pgtype.Timestamptz `db:"end_time"`
+	CreatedAt time.Time          `db:"created_at"`
+	UpdatedAt time.Time          `db:"updated_at"`
+	DeletedAt pgtype.Timestamptz `db:"deleted_at"`
+}
+
+type UserSetting struct {
+	UserID    string    `db:"user_id"`
+	TimeZone  string    `db:"time_zone"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
@@ -11,46 +11,149 @@ import (
 type Querier interface {
 	ArchiveTasksByHeadingID(ctx context.Context, arg ArchiveTasksByHeadingIDParams) error
 	ArchiveTasksByListID(ctx context.Context, arg ArchiveTasksByListIDParams) error
+	ClaimDailyDigest(ctx context.Context, arg ClaimDailyDigestParams) (string, error)
+	ClaimWeeklyDigest(ctx context.Context, arg ClaimWeeklyDigestParams) (string, error)
+	CloseTasksByHeadingID(ctx context.Context, arg CloseTasksByHeadingIDParams) error
+	CompleteDueFocusSessions(ctx context.Context, arg CompleteDueFocusSessionsParams) ([]CompleteDueFocusSessionsRow, error)
+	CompleteHeading(ctx context.Context, arg CompleteHeadingParams) (string, error)
+	CopyTaskLinksToTag(ctx context.Context, arg CopyTaskLinksToTagParams) error
+	CreateArea(ctx context.Context, arg CreateAreaParams) error
+	CreateAuthSession(ctx context.Context, arg CreateAuthSessionParams) error
+	CreateAuthToken(ctx context.Context, arg CreateAuthTokenParams) error
+	CreateAuthUser(ctx context.Context, arg CreateAuthUserParams) error
+	CreateFocusSession(ctx context.Context, arg CreateFocusSessionParams) error
+	CreateFocusSessionEvent(ctx context.Context, arg CreateFocusSessionEventParams) error
 	CreateHeading(ctx context.Context, arg CreateHeadingParams) error
 	CreateList(ctx context.Context, arg CreateListParams) error
+	CreatePersonalToken(ctx context.Context, arg CreatePersonalTokenParams) error
+	CreateStatus(ctx context.Context, arg CreateStatusParams) (int32, error)
+	CreateStatusTransitions(ctx context.Context, arg CreateStatusTransitionsParams) error
 	CreateTag(ctx context.Context, arg CreateTagParams) error
 	CreateTask(ctx context.Context, arg CreateTaskParams) error
+	CreateTemplate(ctx context.Context, arg CreateTemplateParams) error
+	CreateTemplateHeading(ctx context.Context, arg CreateTemplateHeadingParams) error
+	CreateTemplateTask(ctx context.Context, arg CreateTemplateTaskParams) error
+	CreateTimeEntry(ctx context.Context, arg CreateTimeEntryParams) error
+	DeleteArea(ctx context.Context, arg DeleteAreaParams) (string, error)
+	DeleteAuthSession(ctx context.Context, tokenHash string) error
+	DeleteAuthSessionByID(ctx context.Context, arg DeleteAuthSessionByIDParams) (string, error)
+	DeleteAuthSessionsByDevice(ctx context.Context, arg DeleteAuthSessionsByDeviceParams) error
+	DeleteAuthSessionsByUserID(ctx context.Context, userID string) error
+	DeleteAuthTokensByUserID(ctx context.Context, arg DeleteAuthTokensByUserIDParams) error
+	DeleteAuthUser(ctx context.Context, id string) error
+	DeleteOtherAuthSessions(ctx context.Context, arg DeleteOtherAuthSessionsParams) error
 	DeleteHeading(ctx context.Context, arg DeleteHeadingParams) (string, error)
 	DeleteHeadingsByListID(ctx context.Context, arg DeleteHeadingsByListIDParams) error
 	DeleteList(ctx context.Context, arg DeleteListParams) (string, error)
+	DeleteStatus(ctx context.Context, arg DeleteStatusParams) (int32, error)
+	DeleteStatusTransitions(ctx context.Context, arg DeleteStatusTransitionsParams) error
+	DeleteStatusTransitionsByStatusID(ctx context.Context, arg DeleteStatusTransitionsByStatusIDParams) error
+	DeleteTag(ctx context.Context, arg DeleteTagParams) (string, error)
+	DeleteTemplate(ctx context.Context, arg DeleteTemplateParams) (string, error)
+	DeleteTimeEntry(ctx context.Context, arg DeleteTimeEntryParams) (string, error)
 	DeleteUserRelatedData(ctx context.Context, deletingUserID string) error
+	GetActiveFocusSession(ctx context.Context, userID string) (GetActiveFocusSessionRow, error)
 	GetArchivedTasks(ctx context.Context, arg GetArchivedTasksParams) ([]GetArchivedTasksRow, error)
+	GetAreaByID(ctx context.Context, arg GetAreaByIDParams) (GetAreaByIDRow, error)
+	GetAreasByUserID(ctx context.Context, userID string) ([]GetAreasByUserIDRow, error)
+	GetAuthSession(ctx context.Context, tokenHash string) (GetAuthSessionRow, error)
+	GetAuthSessionsByUserID(ctx context.Context, userID string) ([]GetAuthSessionsByUserIDRow, error)
+	GetAuthToken(ctx context.Context, arg GetAuthTokenParams) (GetAuthTokenRow, error)
+	GetAuthUserByEmail(ctx context.Context, email string) (GetAuthUserByEmailRow, error)
+	GetAuthUserByID(ctx context.Context, id string) (GetAuthUserByIDRow, error)
+	GetCompletedHeadings(ctx context.Context, arg GetCompletedHeadingsParams) ([]GetCompletedHeadingsRow, error)
 	GetCompletedTasks(ctx context.Context, arg GetCompletedTasksParams) ([]GetCompletedTasksRow, error)
 	GetDefaultHeadingID(ctx context.Context, arg GetDefaultHeadingIDParams) (string, error)
 	GetDefaultListID(ctx context.Context, userID string) (string, error)
+	GetDigestSettings(ctx context.Context, userID string) (GetDigestSettingsRow, error)
+	GetEnabledDigestSettings(ctx context.Context) ([]GetEnabledDigestSettingsRow, error)
+	GetFocusSessionByID(ctx context.Context, arg GetFocusSessionByIDParams) (GetFocusSessionByIDRow, error)
+	GetFocusSessionEvents(ctx context.Context, arg GetFocusSessionEventsParams) ([]GetFocusSessionEventsRow, error)
+	GetFocusSessionsSummary(ctx context.Context, arg GetFocusSessionsSummaryParams) (GetFocusSessionsSummaryRow, error)
 	GetHeadingByID(ctx context.Context, arg GetHeadingByIDParams) (GetHeadingByIDRow, error)
+	GetHeadingIDByTitle(ctx context.Context, arg GetHeadingIDByTitleParams) (string, error)
 	GetHeadingsByListID(ctx context.Context, arg GetHeadingsByListIDParams) ([]GetHeadingsByListIDRow, error)
 	GetListByID(ctx context.Context, arg GetListByIDParams) (GetListByIDRow, error)
-	GetListsByUserID(ctx context.Context, userID string) ([]GetListsByUserIDRow, error)
+	GetListIDByTitle(ctx context.Context, arg GetListIDByTitleParams) (string, error)
+	GetListIDsByAreaID(ctx context.Context, arg GetListIDsByAreaIDParams) ([]string, error)
+	GetListsByUserID(ctx context.Context, arg GetListsByUserIDParams) ([]GetListsByUserIDRow, error)
+	GetOverdueLists(ctx context.Context, arg GetOverdueListsParams) ([]GetOverdueListsRow, error)
 	GetOverdueTasks(ctx context.Context, arg GetOverdueTasksParams) ([]GetOverdueTasksRow, error)
-	GetStatusByID(ctx context.Context, id int32) (string, error)
-	GetStatuses(ctx context.Context) ([]Status, error)
+	GetPersonalTokenByID(ctx context.Context, id string) (GetPersonalTokenByIDRow, error)
+	GetPersonalTokensByUserID(ctx context.Context, userID string) ([]GetPersonalTokensByUserIDRow, error)
+	GetRunningTimeEntry(ctx context.Context, userID string) (GetRunningTimeEntryRow, error)
+	GetStatusByID(ctx context.Context, arg GetStatusByIDParams) (GetStatusByIDRow, error)
+	GetStatusTransitions(ctx context.Context, arg GetStatusTransitionsParams) ([]GetStatusTransitionsRow, error)
+	GetStatuses(ctx context.Context, arg GetStatusesParams) ([]GetStatusesRow, error)
+	GetTagByID(ctx context.Context, arg GetTagByIDParams) (GetTagByIDRow, error)
 	GetTagIDByTitle(ctx context.Context, arg GetTagIDByTitleParams) (string, error)
+	GetTagSubtreeIDs(ctx context.Context, arg GetTagSubtreeIDsParams) ([]string, error)
+	GetTagsByParentID(ctx context.Context, arg GetTagsByParentIDParams) ([]GetTagsByParentIDRow, error)
 	GetTagsByTaskID(ctx context.Context, taskID string) ([]GetTagsByTaskIDRow, error)
 	GetTagsByUserID(ctx context.Context, userID string) ([]GetTagsByUserIDRow, error)
 	GetTaskByID(ctx context.Context, arg GetTaskByIDParams) (GetTaskByIDRow, error)
+	GetTaskIDsByBoardColumn(ctx context.Context, arg GetTaskIDsByBoardColumnParams) ([]string, error)
+	GetTaskStatus(ctx context.Context, arg GetTaskStatusParams) (GetTaskStatusRow, error)
 	GetTaskStatusID(ctx context.Context, title string) (int32, error)
 	GetTasksByListID(ctx context.Context, arg GetTasksByListIDParams) ([]GetTasksByListIDRow, error)
+	GetTasksByTagID(ctx context.Context, arg GetTasksByTagIDParams) ([]GetTasksByTagIDRow, error)
 	GetTasksByUserID(ctx context.Context, arg GetTasksByUserIDParams) ([]GetTasksByUserIDRow, error)
+	GetTasksForSchedule(ctx context.Context, arg GetTasksForScheduleParams) ([]GetTasksForScheduleRow, error)
 	GetTasksForSomeday(ctx context.Context, arg GetTasksForSomedayParams) ([]GetTasksForSomedayRow, error)
-	GetTasksForToday(ctx context.Context, userID string) ([]GetTasksForTodayRow, error)
+	GetTasksForSomedayGroupedByTag(ctx context.Context, arg GetTasksForSomedayGroupedByTagParams) ([]GetTasksForSomedayGroupedByTagRow, error)
+	GetTasksForToday(ctx context.Context, arg GetTasksForTodayParams) ([]GetTasksForTodayRow, error)
+	GetTasksForTodayGroupedByTag(ctx context.Context, arg GetTasksForTodayGroupedByTagParams) ([]GetTasksForTodayGroupedByTagRow, error)
 	GetTasksGroupedByHeading(ctx context.Context, arg GetTasksGroupedByHeadingParams) ([]GetTasksGroupedByHeadingRow, error)
+	GetTasksGroupedByStatus(ctx context.Context, arg GetTasksGroupedByStatusParams) ([]GetTasksGroupedByStatusRow, error)
+	GetTemplateByID(ctx context.Context, arg GetTemplateByIDParams) (GetTemplateByIDRow, error)
+	GetTemplateHeadings(ctx context.Context, arg GetTemplateHeadingsParams) ([]GetTemplateHeadingsRow, error)
+	GetTemplateTasks(ctx context.Context, arg GetTemplateTasksParams) ([]GetTemplateTasksRow, error)
+	GetTemplatesByUserID(ctx context.Context, userID string) ([]GetTemplatesByUserIDRow, error)
+	GetTimeEntriesByTaskID(ctx context.Context, arg GetTimeEntriesByTaskIDParams) ([]GetTimeEntriesByTaskIDRow, error)
+	GetTimeEntryByID(ctx context.Context, arg GetTimeEntryByIDParams) (GetTimeEntryByIDRow, error)
+	GetTimeReportByDay(ctx context.Context, arg GetTimeReportByDayParams) ([]GetTimeReportByDayRow, error)
+	GetTimeReportByList(ctx context.Context, arg GetTimeReportByListParams) ([]GetTimeReportByListRow, error)
+	GetTimeReportByTag(ctx context.Context, arg GetTimeReportByTagParams) ([]GetTimeReportByTagRow, error)
 	GetUpcomingTasks(ctx context.Context, arg GetUpcomingTasksParams) ([]GetUpcomingTasksRow, error)
+	GetUpcomingTasksGroupedByTag(ctx context.Context, arg GetUpcomingTasksGroupedByTagParams) ([]GetUpcomingTasksGroupedByTagRow, error)
+	GetUserSettings(ctx context.Context, userID string) (GetUserSettingsRow, error)
 	LinkTagToTask(ctx context.Context, arg LinkTagToTaskParams) error
 	MarkTaskAsArchived(ctx context.Context, arg MarkTaskAsArchivedParams) (string, error)
 	MarkTaskAsCompleted(ctx context.Context, arg MarkTaskAsCompletedParams) (string, error)
 	MoveHeadingToAnotherList(ctx context.Context, arg MoveHeadingToAnotherListParams) (string, error)
+	MoveListsToTopLevel(ctx context.Context, arg MoveListsToTopLevelParams) error
 	MoveTaskToAnotherHeading(ctx context.Context, arg MoveTaskToAnotherHeadingParams) (string, error)
 	MoveTaskToAnotherList(ctx context.Context, arg MoveTaskToAnotherListParams) (string, error)
+	MoveTasksToHeading(ctx context.Context, arg MoveTasksToHeadingParams) error
+	RenameTagSubtree(ctx context.Context, arg RenameTagSubtreeParams) error
+	ReopenTasksByHeadingID(ctx context.Context, arg ReopenTasksByHeadingIDParams) error
+	ResetTasksCustomStatus(ctx context.Context, arg ResetTasksCustomStatusParams) error
+	RestoreHeading(ctx context.Context, arg RestoreHeadingParams) (string, error)
+	RevokePersonalToken(ctx context.Context, arg RevokePersonalTokenParams) (string, error)
+	RotateAuthSession(ctx context.Context, arg RotateAuthSessionParams) (string, error)
+	StopTimeEntry(ctx context.Context, arg StopTimeEntryParams) (string, error)
+	UnlinkTagFromAllTasks(ctx context.Context, tagID string) error
 	UnlinkTagFromTask(ctx context.Context, arg UnlinkTagFromTaskParams) error
+	UnsubscribeFromDigests(ctx context.Context, arg UnsubscribeFromDigestsParams) (string, error)
+	UpdateArea(ctx context.Context, arg UpdateAreaParams) (string, error)
+	UpdateAreasPosition(ctx context.Context, arg UpdateAreasPositionParams) error
+	UpdateAuthUser(ctx context.Context, arg UpdateAuthUserParams) error
+	UpdateFocusSession(ctx context.Context, arg UpdateFocusSessionParams) (string, error)
 	UpdateHeading(ctx context.Context, arg UpdateHeadingParams) (string, error)
 	UpdateList(ctx context.Context, arg UpdateListParams) (string, error)
+	UpdateListArea(ctx context.Context, arg UpdateListAreaParams) (string, error)
+	UpdateListsPosition(ctx context.Context, arg UpdateListsPositionParams) error
+	UpdatePersonalTokenLastUsedAt(ctx context.Context, arg UpdatePersonalTokenLastUsedAtParams) error
+	UpdateStatus(ctx context.Context, arg UpdateStatusParams) (int32, error)
+	UpdateTag(ctx context.Context, arg UpdateTagParams) (string, error)
+	UpdateTagParent(ctx context.Context, arg UpdateTagParentParams) error
+	UpdateTaskStatus(ctx context.Context, arg UpdateTaskStatusParams) (string, error)
 	UpdateTasksListID(ctx context.Context, arg UpdateTasksListIDParams) error
+	UpdateTasksPosition(ctx context.Context, arg UpdateTasksPositionParams) error
+	UpdateTasksTime(ctx context.Context, arg UpdateTasksTimeParams) ([]string, error)
+	UpdateTimeEntry(ctx context.Context, arg UpdateTimeEntryParams) (string, error)
+	UpsertDigestSettings(ctx context.Context, arg UpsertDigestSettingsParams) error
+	UpsertUserSettings(ctx context.Context, arg UpsertUserSettingsParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -7,36 +7,222 @@ package sqlc
 
 import (
 	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const createStatus = `-- name: CreateStatus :one
+INSERT INTO statuses (title, category_id, user_id, list_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id
+`
+
+type CreateStatusParams struct {
+	Title      string      `db:"title"`
+	CategoryID int32       `db:"category_id"`
+	UserID     pgtype.Text `db:"user_id"`
+	ListID     pgtype.Text `db:"list_id"`
+	CreatedAt  time.Time   `db:"created_at"`
+	UpdatedAt  time.Time   `db:"updated_at"`
+}
+
+func (q *Queries) CreateStatus(ctx context.Context, arg CreateStatusParams) (int32, error) {
+	row := q.db.QueryRow(ctx, createStatus,
+		arg.Title,
+		arg.CategoryID,
+		arg.UserID,
+		arg.ListID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createStatusTransitions = `-- name: CreateStatusTransitions :exec
+INSERT INTO status_transitions (user_id, list_id, from_status_id, to_status_id)
+SELECT $1::varchar, NULLIF($2::varchar, ''), UNNEST($3::int[]), UNNEST($4::int[])
+`
+
+type CreateStatusTransitionsParams struct {
+	UserID        string  `db:"user_id"`
+	ListID        string  `db:"list_id"`
+	FromStatusIds []int32 `db:"from_status_ids"`
+	ToStatusIds   []int32 `db:"to_status_ids"`
+}
+
+func (q *Queries) CreateStatusTransitions(ctx context.Context, arg CreateStatusTransitionsParams) error {
+	_, err := q.db.Exec(ctx, createStatusTransitions,
+		arg.UserID,
+		arg.ListID,
+		arg.FromStatusIds,
+		arg.ToStatusIds,
+	)
+	return err
+}
+
+const deleteStatus = `-- name: DeleteStatus :one
+UPDATE statuses
+SET deleted_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND deleted_at IS NULL
+RETURNING id
+`
+
+type DeleteStatusParams struct {
+	DeletedAt pgtype.Timestamptz `db:"deleted_at"`
+	ID        int32              `db:"id"`
+	UserID    pgtype.Text        `db:"user_id"`
+}
+
+func (q *Queries) DeleteStatus(ctx context.Context, arg DeleteStatusParams) (int32, error) {
+	row := q.db.QueryRow(ctx, deleteStatus, arg.DeletedAt, arg.ID, arg.UserID)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteStatusTransitions = `-- name: DeleteStatusTransitions :exec
+DELETE FROM status_transitions
+WHERE user_id = $1
+  AND COALESCE(list_id, '') = $2::varchar
+`
+
+type DeleteStatusTransitionsParams struct {
+	UserID string `db:"user_id"`
+	ListID string `db:"list_id"`
+}
+
+func (q *Queries) DeleteStatusTransitions(ctx context.Context, arg DeleteStatusTransitionsParams) error {
+	_, err := q.db.Exec(ctx, deleteStatusTransitions, arg.UserID, arg.ListID)
+	return err
+}
+
+const deleteStatusTransitionsByStatusID = `-- name: DeleteStatusTransitionsByStatusID :exec
+DELETE FROM status_transitions
+WHERE user_id = $1
+  AND (from_status_id = $2::int OR to_status_id = $2::int)
+`
+
+type DeleteStatusTransitionsByStatusIDParams struct {
+	UserID   string `db:"user_id"`
+	StatusID int32  `db:"status_id"`
+}
+
+func (q *Queries) DeleteStatusTransitionsByStatusID(ctx context.Context, arg DeleteStatusTransitionsByStatusIDParams) error {
+	_, err := q.db.Exec(ctx, deleteStatusTransitionsByStatusID, arg.UserID, arg.StatusID)
+	return err
+}
+
 const getStatusByID = `-- name: GetStatusByID :one
-SELECT title
+SELECT id, title, category_id, list_id
 FROM statuses
 WHERE id = $1
+  AND (user_id IS NULL OR user_id = $2)
+  AND deleted_at IS NULL
+`
+
+type GetStatusByIDParams struct {
+	ID     int32       `db:"id"`
+	UserID pgtype.Text `db:"user_id"`
+}
+
+type GetStatusByIDRow struct {
+	ID         int32       `db:"id"`
+	Title      string      `db:"title"`
+	CategoryID int32       `db:"category_id"`
+	ListID     pgtype.Text `db:"list_id"`
+}
+
+func (q *Queries) GetStatusByID(ctx context.Context, arg GetStatusByIDParams) (GetStatusByIDRow, error) {
+	row := q.db.QueryRow(ctx, getStatusByID, arg.ID, arg.UserID)
+	var i GetStatusByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.CategoryID,
+		&i.ListID,
+	)
+	return i, err
+}
+
+const getStatusTransitions = `-- name: GetStatusTransitions :many
+SELECT from_status_id, to_status_id, list_id
+FROM status_transitions
+WHERE user_id = $1
+  AND (list_id IS NULL OR list_id = $2::varchar)
 `
 
-func (q *Queries) GetStatusByID(ctx context.Context, id int32) (string, error) {
-	row := q.db.QueryRow(ctx, getStatusByID, id)
-	var title string
-	err := row.Scan(&title)
-	return title, err
+type GetStatusTransitionsParams struct {
+	UserID string `db:"user_id"`
+	ListID string `db:"list_id"`
+}
+
+type GetStatusTransitionsRow struct {
+	FromStatusID int32       `db:"from_status_id"`
+	ToStatusID   int32       `db:"to_status_id"`
+	ListID       pgtype.Text `db:"list_id"`
+}
+
+func (q *Queries) GetStatusTransitions(ctx context.Context, arg GetStatusTransitionsParams) ([]GetStatusTransitionsRow, error) {
+	rows, err := q.db.Query(ctx, getStatusTransitions, arg.UserID, arg.ListID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetStatusTransitionsRow{}
+	for rows.Next() {
+		var i GetStatusTransitionsRow
+		if err := rows.Scan(&i.FromStatusID, &i.ToStatusID, &i.ListID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const getStatuses = `-- name: GetStatuses :many
-SELECT id, title
+SELECT id, title, category_id, list_id
 FROM statuses
+WHERE deleted_at IS NULL
+  AND (user_id IS NULL
+    OR (user_id = $1 AND (list_id IS NULL OR list_id = $2::varchar)))
+ORDER BY category_id, id
 `
 
-func (q *Queries) GetStatuses(ctx context.Context) ([]Status, error) {
-	rows, err := q.db.Query(ctx, getStatuses)
+type GetStatusesParams struct {
+	UserID pgtype.Text `db:"user_id"`
+	ListID string      `db:"list_id"`
+}
+
+type GetStatusesRow struct {
+	ID         int32       `db:"id"`
+	Title      string      `db:"title"`
+	CategoryID int32       `db:"category_id"`
+	ListID     pgtype.Text `db:"list_id"`
+}
+
+func (q *Queries) GetStatuses(ctx context.Context, arg GetStatusesParams) ([]GetStatusesRow, error) {
+	rows, err := q.db.Query(ctx, getStatuses, arg.UserID, arg.ListID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Status{}
+	items := []GetStatusesRow{}
 	for rows.Next() {
-		var i Status
-		if err := rows.Scan(&i.ID, &i.Title); err != nil {
+		var i GetStatusesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.CategoryID,
+			&i.ListID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -46,3 +232,51 @@ func (q *Queries) GetStatuses(ctx context.Context) ([]Status, error) {
 	}
 	return items, nil
 }
+
+const resetTasksCustomStatus = `-- name: ResetTasksCustomStatus :exec
+UPDATE tasks
+SET custom_status_id = NULL,
+    updated_at = $1
+WHERE custom_status_id = $2
+  AND user_id = $3
+`
+
+type ResetTasksCustomStatusParams struct {
+	UpdatedAt      time.Time   `db:"updated_at"`
+	CustomStatusID pgtype.Int4 `db:"custom_status_id"`
+	UserID         string      `db:"user_id"`
+}
+
+func (q *Queries) ResetTasksCustomStatus(ctx context.Context, arg ResetTasksCustomStatusParams) error {
+	_, err := q.db.Exec(ctx, resetTasksCustomStatus, arg.UpdatedAt, arg.CustomStatusID, arg.UserID)
+	return err
+}
+
+const updateStatus = `-- name: UpdateStatus :one
+UPDATE statuses
+SET title = $1,
+    updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL
+RETURNING id
+`
+
+type UpdateStatusParams struct {
+	Title     string      `db:"title"`
+	UpdatedAt time.Time   `db:"updated_at"`
+	ID        int32       `db:"id"`
+	UserID    pgtype.Text `db:"user_id"`
+}
+
+func (q *Queries) UpdateStatus(ctx context.Context, arg UpdateStatusParams) (int32, error) {
+	row := q.db.QueryRow(ctx, updateStatus,
+		arg.Title,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.UserID,
+	)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
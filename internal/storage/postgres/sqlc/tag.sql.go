@@ -8,25 +8,50 @@ package sqlc
 import (
 	"context"
 	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const copyTaskLinksToTag = `-- name: CopyTaskLinksToTag :exec
+INSERT INTO tasks_tags (task_id, tag_id)
+SELECT tasks_tags.task_id, tags.id
+FROM tasks_tags, tags
+WHERE tasks_tags.tag_id = $1
+  AND tags.id = $2
+ON CONFLICT DO NOTHING
+`
+
+type CopyTaskLinksToTagParams struct {
+	SourceTagID string `db:"source_tag_id"`
+	TargetTagID string `db:"target_tag_id"`
+}
+
+func (q *Queries) CopyTaskLinksToTag(ctx context.Context, arg CopyTaskLinksToTagParams) error {
+	_, err := q.db.Exec(ctx, copyTaskLinksToTag, arg.SourceTagID, arg.TargetTagID)
+	return err
+}
+
 const createTag = `-- name: CreateTag :exec
-INSERT INTO tags (id, title, user_id, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO tags (id, title, parent_id, color, user_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 `
 
 type CreateTagParams struct {
-	ID        string    `db:"id"`
-	Title     string    `db:"title"`
-	UserID    string    `db:"user_id"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID        string      `db:"id"`
+	Title     string      `db:"title"`
+	ParentID  pgtype.Text `db:"parent_id"`
+	Color     pgtype.Text `db:"color"`
+	UserID    string      `db:"user_id"`
+	CreatedAt time.Time   `db:"created_at"`
+	UpdatedAt time.Time   `db:"updated_at"`
 }
 
 func (q *Queries) CreateTag(ctx context.Context, arg CreateTagParams) error {
 	_, err := q.db.Exec(ctx, createTag,
 		arg.ID,
 		arg.Title,
+		arg.ParentID,
+		arg.Color,
 		arg.UserID,
 		arg.CreatedAt,
 		arg.UpdatedAt,
@@ -34,6 +59,88 @@ func (q *Queries) CreateTag(ctx context.Context, arg CreateTagParams) error {
 	return err
 }
 
+const deleteTag = `-- name: DeleteTag :one
+UPDATE tags
+SET deleted_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND deleted_at IS NULL
+RETURNING id
+`
+
+type DeleteTagParams struct {
+	DeletedAt pgtype.Timestamptz `db:"deleted_at"`
+	ID        string             `db:"id"`
+	UserID    string             `db:"user_id"`
+}
+
+func (q *Queries) DeleteTag(ctx context.Context, arg DeleteTagParams) (string, error) {
+	row := q.db.QueryRow(ctx, deleteTag, arg.DeletedAt, arg.ID, arg.UserID)
+	var id string
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getTagByID = `-- name: GetTagByID :one
+WITH RECURSIVE subtree AS (
+    SELECT tags.id AS root_id, tags.id
+    FROM tags
+    WHERE tags.id = $1
+      AND tags.user_id = $2
+      AND tags.deleted_at IS NULL
+    UNION ALL
+    SELECT subtree.root_id, tags.id
+    FROM tags
+        JOIN subtree
+            ON tags.parent_id = subtree.id
+    WHERE tags.deleted_at IS NULL
+)
+SELECT
+    tags.id,
+    tags.title,
+    tags.parent_id,
+    tags.color,
+    tags.updated_at,
+    COUNT(DISTINCT tasks.id)::int AS tasks_count
+FROM tags
+    JOIN subtree
+        ON tags.id = subtree.root_id
+    LEFT JOIN tasks_tags
+        ON subtree.id = tasks_tags.tag_id
+    LEFT JOIN tasks
+        ON tasks_tags.task_id = tasks.id
+        AND tasks.deleted_at IS NULL
+GROUP BY tags.id
+`
+
+type GetTagByIDParams struct {
+	ID     string `db:"id"`
+	UserID string `db:"user_id"`
+}
+
+type GetTagByIDRow struct {
+	ID         string      `db:"id"`
+	Title      string      `db:"title"`
+	ParentID   pgtype.Text `db:"parent_id"`
+	Color      pgtype.Text `db:"color"`
+	UpdatedAt  time.Time   `db:"updated_at"`
+	TasksCount int32       `db:"tasks_count"`
+}
+
+func (q *Queries) GetTagByID(ctx context.Context, arg GetTagByIDParams) (GetTagByIDRow, error) {
+	row := q.db.QueryRow(ctx, getTagByID, arg.ID, arg.UserID)
+	var i GetTagByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.ParentID,
+		&i.Color,
+		&i.UpdatedAt,
+		&i.TasksCount,
+	)
+	return i, err
+}
+
 const getTagIDByTitle = `-- name: GetTagIDByTitle :one
 SELECT id
 FROM tags
@@ -54,6 +161,95 @@ func (q *Queries) GetTagIDByTitle(ctx context.Context, arg GetTagIDByTitleParams
 	return id, err
 }
 
+const getTagSubtreeIDs = `-- name: GetTagSubtreeIDs :many
+WITH RECURSIVE subtree AS (
+    SELECT tags.id
+    FROM tags
+    WHERE tags.id = $1
+      AND tags.user_id = $2
+      AND tags.deleted_at IS NULL
+    UNION ALL
+    SELECT tags.id
+    FROM tags
+        JOIN subtree
+            ON tags.parent_id = subtree.id
+    WHERE tags.deleted_at IS NULL
+)
+SELECT id
+FROM subtree
+`
+
+type GetTagSubtreeIDsParams struct {
+	ID     string `db:"id"`
+	UserID string `db:"user_id"`
+}
+
+func (q *Queries) GetTagSubtreeIDs(ctx context.Context, arg GetTagSubtreeIDsParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, getTagSubtreeIDs, arg.ID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTagsByParentID = `-- name: GetTagsByParentID :many
+SELECT id, title, parent_id, updated_at
+FROM tags
+WHERE parent_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+ORDER BY title
+`
+
+type GetTagsByParentIDParams struct {
+	ParentID pgtype.Text `db:"parent_id"`
+	UserID   string      `db:"user_id"`
+}
+
+type GetTagsByParentIDRow struct {
+	ID        string      `db:"id"`
+	Title     string      `db:"title"`
+	ParentID  pgtype.Text `db:"parent_id"`
+	UpdatedAt time.Time   `db:"updated_at"`
+}
+
+func (q *Queries) GetTagsByParentID(ctx context.Context, arg GetTagsByParentIDParams) ([]GetTagsByParentIDRow, error) {
+	rows, err := q.db.Query(ctx, getTagsByParentID, arg.ParentID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTagsByParentIDRow{}
+	for rows.Next() {
+		var i GetTagsByParentIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.ParentID,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTagsByTaskID = `-- name: GetTagsByTaskID :many
 SELECT tags.id, tags.title, tags.updated_at
 FROM tags
@@ -90,16 +286,44 @@ func (q *Queries) GetTagsByTaskID(ctx context.Context, taskID string) ([]GetTags
 }
 
 const getTagsByUserID = `-- name: GetTagsByUserID :many
-SELECT id, title, updated_at
+WITH RECURSIVE subtree AS (
+    SELECT tags.id AS root_id, tags.id
+    FROM tags
+    WHERE tags.user_id = $1
+      AND tags.deleted_at IS NULL
+    UNION ALL
+    SELECT subtree.root_id, tags.id
+    FROM tags
+        JOIN subtree
+            ON tags.parent_id = subtree.id
+    WHERE tags.deleted_at IS NULL
+)
+SELECT
+    tags.id,
+    tags.title,
+    tags.parent_id,
+    tags.color,
+    tags.updated_at,
+    COUNT(DISTINCT tasks.id)::int AS tasks_count
 FROM tags
-WHERE user_id = $1
-  AND deleted_at IS NULL
+    JOIN subtree
+        ON tags.id = subtree.root_id
+    LEFT JOIN tasks_tags
+        ON subtree.id = tasks_tags.tag_id
+    LEFT JOIN tasks
+        ON tasks_tags.task_id = tasks.id
+        AND tasks.deleted_at IS NULL
+GROUP BY tags.id
+ORDER BY tags.title
 `
 
 type GetTagsByUserIDRow struct {
-	ID        string    `db:"id"`
-	Title     string    `db:"title"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID         string      `db:"id"`
+	Title      string      `db:"title"`
+	ParentID   pgtype.Text `db:"parent_id"`
+	Color      pgtype.Text `db:"color"`
+	UpdatedAt  time.Time   `db:"updated_at"`
+	TasksCount int32       `db:"tasks_count"`
 }
 
 func (q *Queries) GetTagsByUserID(ctx context.Context, userID string) ([]GetTagsByUserIDRow, error) {
@@ -111,7 +335,13 @@ func (q *Queries) GetTagsByUserID(ctx context.Context, userID string) ([]GetTags
 	items := []GetTagsByUserIDRow{}
 	for rows.Next() {
 		var i GetTagsByUserIDRow
-		if err := rows.Scan(&i.ID, &i.Title, &i.UpdatedAt); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Color,
+			&i.UpdatedAt,
+			&i.TasksCount,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -127,7 +357,8 @@ INSERT INTO tasks_tags (task_id, tag_id)
 VALUES ($1, (SELECT id
              FROM tags
              WHERE title = $2
-             AND user_id = $3)
+             AND user_id = $3
+             AND deleted_at IS NULL)
 )
 `
 
@@ -142,6 +373,44 @@ func (q *Queries) LinkTagToTask(ctx context.Context, arg LinkTagToTaskParams) er
 	return err
 }
 
+const renameTagSubtree = `-- name: RenameTagSubtree :exec
+UPDATE tags
+SET title = $3::varchar || substr(title, length($4::varchar) + 1),
+    updated_at = $1
+WHERE id = ANY($5::varchar[])
+  AND user_id = $2
+  AND deleted_at IS NULL
+`
+
+type RenameTagSubtreeParams struct {
+	UpdatedAt time.Time `db:"updated_at"`
+	UserID    string    `db:"user_id"`
+	NewTitle  string    `db:"new_title"`
+	OldTitle  string    `db:"old_title"`
+	TagIds    []string  `db:"tag_ids"`
+}
+
+func (q *Queries) RenameTagSubtree(ctx context.Context, arg RenameTagSubtreeParams) error {
+	_, err := q.db.Exec(ctx, renameTagSubtree,
+		arg.UpdatedAt,
+		arg.UserID,
+		arg.NewTitle,
+		arg.OldTitle,
+		arg.TagIds,
+	)
+	return err
+}
+
+const unlinkTagFromAllTasks = `-- name: UnlinkTagFromAllTasks :exec
+DELETE FROM tasks_tags
+WHERE tag_id = $1
+`
+
+func (q *Queries) UnlinkTagFromAllTasks(ctx context.Context, tagID string) error {
+	_, err := q.db.Exec(ctx, unlinkTagFromAllTasks, tagID)
+	return err
+}
+
 const unlinkTagFromTask = `-- name: UnlinkTagFromTask :exec
 DELETE FROM tasks_tags
 WHERE task_id = $1
@@ -149,6 +418,7 @@ WHERE task_id = $1
                 FROM tags
                 WHERE title = $2
                 AND user_id = $3
+                AND deleted_at IS NULL
 )
 `
 
@@ -162,3 +432,61 @@ func (q *Queries) UnlinkTagFromTask(ctx context.Context, arg UnlinkTagFromTaskPa
 	_, err := q.db.Exec(ctx, unlinkTagFromTask, arg.TaskID, arg.Title, arg.UserID)
 	return err
 }
+
+const updateTag = `-- name: UpdateTag :one
+UPDATE tags
+SET title = $1,
+    color = COALESCE($5::varchar, color),
+    updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL
+RETURNING id
+`
+
+type UpdateTagParams struct {
+	Title     string      `db:"title"`
+	UpdatedAt time.Time   `db:"updated_at"`
+	ID        string      `db:"id"`
+	UserID    string      `db:"user_id"`
+	Color     pgtype.Text `db:"color"`
+}
+
+func (q *Queries) UpdateTag(ctx context.Context, arg UpdateTagParams) (string, error) {
+	row := q.db.QueryRow(ctx, updateTag,
+		arg.Title,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.UserID,
+		arg.Color,
+	)
+	var id string
+	err := row.Scan(&id)
+	return id, err
+}
+
+const updateTagParent = `-- name: UpdateTagParent :exec
+UPDATE tags
+SET parent_id = $1,
+    updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL
+`
+
+type UpdateTagParentParams struct {
+	ParentID  pgtype.Text `db:"parent_id"`
+	UpdatedAt time.Time   `db:"updated_at"`
+	ID        string      `db:"id"`
+	UserID    string      `db:"user_id"`
+}
+
+func (q *Queries) UpdateTagParent(ctx context.Context, arg UpdateTagParentParams) error {
+	_, err := q.db.Exec(ctx, updateTagParent,
+		arg.ParentID,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.UserID,
+	)
+	return err
+}
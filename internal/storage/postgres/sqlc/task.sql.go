@@ -14,7 +14,7 @@ import (
 
 const archiveTasksByHeadingID = `-- name: ArchiveTasksByHeadingID :exec
 UPDATE tasks
-SET status_id = $1, deleted_at = $2
+SET status_id = $1, custom_status_id = NULL, deleted_at = $2
 WHERE heading_id = $3
   AND user_id = $4
   AND deleted_at IS NULL
@@ -39,7 +39,7 @@ func (q *Queries) ArchiveTasksByHeadingID(ctx context.Context, arg ArchiveTasksB
 
 const archiveTasksByListID = `-- name: ArchiveTasksByListID :exec
 UPDATE tasks
-SET status_id = $1, deleted_at = $2
+SET status_id = $1, custom_status_id = NULL, deleted_at = $2
 WHERE list_id = $3
   AND user_id = $4
   AND deleted_at IS NULL
@@ -62,6 +62,42 @@ func (q *Queries) ArchiveTasksByListID(ctx context.Context, arg ArchiveTasksByLi
 	return err
 }
 
+const closeTasksByHeadingID = `-- name: CloseTasksByHeadingID :exec
+UPDATE tasks
+SET previous_status_id = status_id,
+    previous_custom_status_id = custom_status_id,
+    status_id = $1,
+    custom_status_id = NULL,
+    updated_at = $2,
+    deleted_at = $3,
+    completed_at = CASE WHEN $1 = $4 THEN $2 END
+WHERE heading_id = $5
+  AND user_id = $6
+  AND status_id != $4
+  AND deleted_at IS NULL
+`
+
+type CloseTasksByHeadingIDParams struct {
+	StatusID          int32              `db:"status_id"`
+	UpdatedAt         time.Time          `db:"updated_at"`
+	DeletedAt         pgtype.Timestamptz `db:"deleted_at"`
+	CompletedStatusID int32              `db:"completed_status_id"`
+	HeadingID         string             `db:"heading_id"`
+	UserID            string             `db:"user_id"`
+}
+
+func (q *Queries) CloseTasksByHeadingID(ctx context.Context, arg CloseTasksByHeadingIDParams) error {
+	_, err := q.db.Exec(ctx, closeTasksByHeadingID,
+		arg.StatusID,
+		arg.UpdatedAt,
+		arg.DeletedAt,
+		arg.CompletedStatusID,
+		arg.HeadingID,
+		arg.UserID,
+	)
+	return err
+}
+
 const createTask = `-- name: CreateTask :exec
 INSERT INTO tasks (
     id,
@@ -76,26 +112,28 @@ INSERT INTO tasks (
     heading_id,
     user_id,
     created_at,
-    updated_at
+    updated_at,
+    estimate_minutes
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 )
 `
 
 type CreateTaskParams struct {
-	ID          string             `db:"id"`
-	Title       string             `db:"title"`
-	Description pgtype.Text        `db:"description"`
-	StartDate   pgtype.Timestamptz `db:"start_date"`
-	Deadline    pgtype.Timestamptz `db:"deadline"`
-	StartTime   pgtype.Timestamptz `db:"start_time"`
-	EndTime     pgtype.Timestamptz `db:"end_time"`
-	StatusID    int32              `db:"status_id"`
-	ListID      string             `db:"list_id"`
-	HeadingID   string             `db:"heading_id"`
-	UserID      string             `db:"user_id"`
-	CreatedAt   time.Time          `db:"created_at"`
-	UpdatedAt   time.Time          `db:"updated_at"`
+	ID              string             `db:"id"`
+	Title           string             `db:"title"`
+	Description     pgtype.Text        `db:"description"`
+	StartDate       pgtype.Timestamptz `db:"start_date"`
+	Deadline        pgtype.Timestamptz `db:"deadline"`
+	StartTime       pgtype.Timestamptz `db:"start_time"`
+	EndTime         pgtype.Timestamptz `db:"end_time"`
+	StatusID        int32              `db:"status_id"`
+	ListID          string             `db:"list_id"`
+	HeadingID       string             `db:"heading_id"`
+	UserID          string             `db:"user_id"`
+	CreatedAt       time.Time          `db:"created_at"`
+	UpdatedAt       time.Time          `db:"updated_at"`
+	EstimateMinutes pgtype.Int4        `db:"estimate_minutes"`
 }
 
 func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) error {
@@ -113,6 +151,7 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) error {
 		arg.UserID,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.EstimateMinutes,
 	)
 	return err
 }
@@ -132,6 +171,8 @@ SELECT
                             'end_time', t.end_time,
                             'list_id', t.list_id,
                             'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
                             'tags', tags,
                             'updated_at', t.updated_at,
                             'deleted_at', t.deleted_at
@@ -149,12 +190,16 @@ FROM (
         t.end_time,
         t.list_id,
         t.user_id,
+        t.estimate_minutes,
+        COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
         ttv.tags as tags,
         t.updated_at,
         t.deleted_at
     FROM tasks t
         LEFT JOIN task_tags_view ttv
             ON t.id = ttv.task_id
+        LEFT JOIN task_time_view tmv
+            ON t.id = tmv.task_id
     WHERE t.user_id = $1
       AND t.status_id = (
         SELECT id
@@ -174,6 +219,8 @@ FROM (
         t.end_time,
         t.list_id,
         t.user_id,
+        t.estimate_minutes,
+        tmv.tracked_minutes,
         tags,
         t.updated_at,
         t.deleted_at
@@ -220,9 +267,117 @@ func (q *Queries) GetArchivedTasks(ctx context.Context, arg GetArchivedTasksPara
 	return items, nil
 }
 
+const getCompletedHeadings = `-- name: GetCompletedHeadings :many
+WITH completed_headings AS (
+    SELECT
+        id,
+        title,
+        list_id,
+        user_id,
+        completed_at,
+        DATE_TRUNC('month', completed_at AT TIME ZONE $2::varchar)::date AS month
+    FROM headings
+    WHERE user_id = $1
+      AND completed_at IS NOT NULL
+      AND deleted_at IS NULL
+),
+ranked_headings AS (
+    SELECT
+        *,
+        DENSE_RANK() OVER (ORDER BY month DESC) AS month_rank
+    FROM completed_headings
+    WHERE $3::date IS NULL
+       OR month < $3::date
+)
+SELECT
+    h.id,
+    h.title,
+    h.list_id,
+    h.completed_at::timestamptz AS completed_at,
+    h.month,
+    COALESCE(
+        (
+            SELECT ARRAY_TO_JSON(
+                    ARRAY_AGG(
+                            JSON_BUILD_OBJECT(
+                                    'id', t.id,
+                                    'title', t.title,
+                                    'description', t.description,
+                                    'start_date', t.start_date,
+                                    'deadline', t.deadline,
+                                    'status_id', t.status_id,
+                                    'list_id', t.list_id,
+                                    'heading_id', t.heading_id,
+                                    'user_id', t.user_id,
+                                    'tags', ttv.tags,
+                                    'updated_at', t.updated_at
+                            ) ORDER BY t.position
+                    )
+            )
+            FROM tasks t
+                LEFT JOIN task_tags_view ttv
+                    ON t.id = ttv.task_id
+            WHERE t.heading_id = h.id
+              AND t.user_id = h.user_id
+        ),
+        '[]'
+    )::json AS tasks
+FROM ranked_headings h
+WHERE h.month_rank <= $4::int
+ORDER BY h.completed_at DESC
+`
+
+type GetCompletedHeadingsParams struct {
+	UserID     string      `db:"user_id"`
+	TimeZone   string      `db:"time_zone"`
+	CursorDate pgtype.Date `db:"cursor_date"`
+	MonthLimit int32       `db:"month_limit"`
+}
+
+type GetCompletedHeadingsRow struct {
+	ID          string             `db:"id"`
+	Title       string             `db:"title"`
+	ListID      string             `db:"list_id"`
+	CompletedAt pgtype.Timestamptz `db:"completed_at"`
+	Month       pgtype.Date        `db:"month"`
+	Tasks       []byte             `db:"tasks"`
+}
+
+func (q *Queries) GetCompletedHeadings(ctx context.Context, arg GetCompletedHeadingsParams) ([]GetCompletedHeadingsRow, error) {
+	rows, err := q.db.Query(ctx, getCompletedHeadings,
+		arg.UserID,
+		arg.TimeZone,
+		arg.CursorDate,
+		arg.MonthLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCompletedHeadingsRow{}
+	for rows.Next() {
+		var i GetCompletedHeadingsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.ListID,
+			&i.CompletedAt,
+			&i.Month,
+			&i.Tasks,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCompletedTasks = `-- name: GetCompletedTasks :many
 SELECT
-    DATE_TRUNC('month', t.updated_at)::timestamptz AS month,
+    t.month,
     ARRAY_TO_JSON(
             ARRAY_AGG(
                     JSON_BUILD_OBJECT(
@@ -235,8 +390,11 @@ SELECT
                             'end_time', t.end_time,
                             'list_id', t.list_id,
                             'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
                             'tags', tags,
-                            'updated_at', t.updated_at
+                            'updated_at', t.updated_at,
+                            'completed_at', t.completed_at
                     )
             )
     ) AS tasks
@@ -251,20 +409,33 @@ FROM (
         t.end_time,
         t.list_id,
         t.user_id,
+        t.estimate_minutes,
+        COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
         ttv.tags as tags,
-        t.updated_at
+        t.updated_at,
+        t.completed_at,
+        DATE_TRUNC('month', t.completed_at AT TIME ZONE $3::varchar)::date AS month
     FROM tasks t
         LEFT JOIN task_tags_view ttv
             ON t.id = ttv.task_id
+        LEFT JOIN task_time_view tmv
+            ON t.id = tmv.task_id
     WHERE t.user_id = $1
       AND t.status_id = (
           SELECT id
           FROM statuses
-          WHERE statuses.title = $3::varchar
+          WHERE statuses.title = $4::varchar
       )
-      AND (t.deleted_at IS NULL
-               OR (DATE_TRUNC('month', t.updated_at) > $4::timestamptz AND t.deleted_at IS NULL)
+      AND t.deleted_at IS NULL
+      AND ($5::date IS NULL
+               OR DATE_TRUNC('month', t.completed_at AT TIME ZONE $3::varchar)::date < $5::date
           )
+      AND NOT EXISTS (
+          SELECT 1
+          FROM headings h
+          WHERE h.id = t.heading_id
+            AND h.completed_at IS NOT NULL
+      )
     GROUP BY
         t.id,
         t.title,
@@ -275,30 +446,35 @@ FROM (
         t.end_time,
         t.list_id,
         t.user_id,
+        t.estimate_minutes,
+        tmv.tracked_minutes,
         tags,
-        t.updated_at
+        t.updated_at,
+        t.completed_at
     ) t
-GROUP BY month
-ORDER BY month DESC
+GROUP BY t.month
+ORDER BY t.month DESC
 LIMIT $2
 `
 
 type GetCompletedTasksParams struct {
-	UserID      string             `db:"user_id"`
-	Limit       int32              `db:"limit"`
-	StatusTitle string             `db:"status_title"`
-	CursorDate  pgtype.Timestamptz `db:"cursor_date"`
+	UserID      string      `db:"user_id"`
+	Limit       int32       `db:"limit"`
+	TimeZone    string      `db:"time_zone"`
+	StatusTitle string      `db:"status_title"`
+	CursorDate  pgtype.Date `db:"cursor_date"`
 }
 
 type GetCompletedTasksRow struct {
-	Month pgtype.Timestamptz `db:"month"`
-	Tasks []byte             `db:"tasks"`
+	Month pgtype.Date `db:"month"`
+	Tasks []byte      `db:"tasks"`
 }
 
 func (q *Queries) GetCompletedTasks(ctx context.Context, arg GetCompletedTasksParams) ([]GetCompletedTasksRow, error) {
 	rows, err := q.db.Query(ctx, getCompletedTasks,
 		arg.UserID,
 		arg.Limit,
+		arg.TimeZone,
 		arg.StatusTitle,
 		arg.CursorDate,
 	)
@@ -335,6 +511,8 @@ SELECT
                             'end_time', t.end_time,
                             'list_id', t.list_id,
                             'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
                             'tags', tags,
                             'overdue', overdue,
                             'updated_at', t.updated_at
@@ -353,17 +531,21 @@ FROM lists l
             t.end_time,
             t.list_id,
             t.user_id,
+            t.estimate_minutes,
+            COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
             ttv.tags as tags,
             CASE
-                WHEN t.deadline <= CURRENT_DATE THEN TRUE
+                WHEN t.deadline <= $3::timestamptz THEN TRUE
                 ELSE FALSE END
                 AS overdue,
             t.updated_at
         FROM tasks t
             LEFT JOIN task_tags_view ttv
                 ON t.id = ttv.task_id
+            LEFT JOIN task_time_view tmv
+                ON t.id = tmv.task_id
         WHERE t.user_id = $1
-          AND t.deadline <= CURRENT_DATE
+          AND t.deadline <= $3::timestamptz
           AND t.deleted_at IS NULL
         GROUP BY
             t.id,
@@ -375,20 +557,23 @@ FROM lists l
             t.end_time,
             t.list_id,
             t.user_id,
+            t.estimate_minutes,
+            tmv.tracked_minutes,
             ttv.tags,
             t.updated_at
         ) t ON l.id = t.list_id
 WHERE l.user_id = $1
-  AND l.id > $3::varchar
+  AND l.id > $4::varchar
 GROUP BY l.id
 ORDER BY l.id
 LIMIT $2
 `
 
 type GetOverdueTasksParams struct {
-	UserID string `db:"user_id"`
-	Limit  int32  `db:"limit"`
-	Cursor string `db:"cursor"`
+	UserID string             `db:"user_id"`
+	Limit  int32              `db:"limit"`
+	Today  pgtype.Timestamptz `db:"today"`
+	Cursor string             `db:"cursor"`
 }
 
 type GetOverdueTasksRow struct {
@@ -397,7 +582,12 @@ type GetOverdueTasksRow struct {
 }
 
 func (q *Queries) GetOverdueTasks(ctx context.Context, arg GetOverdueTasksParams) ([]GetOverdueTasksRow, error) {
-	rows, err := q.db.Query(ctx, getOverdueTasks, arg.UserID, arg.Limit, arg.Cursor)
+	rows, err := q.db.Query(ctx, getOverdueTasks,
+		arg.UserID,
+		arg.Limit,
+		arg.Today,
+		arg.Cursor,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -426,45 +616,54 @@ SELECT
     t.start_time,
     t.end_time,
     t.status_id,
+    t.custom_status_id,
     t.list_id,
     t.heading_id,
     t.updated_at,
+    t.estimate_minutes,
+    COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
     ttv.tags as tags,
     CASE
-        WHEN t.deadline <= CURRENT_DATE THEN TRUE
+        WHEN t.deadline <= $3::timestamptz THEN TRUE
         ELSE FALSE END
         AS overdue
 FROM tasks t
     LEFT JOIN task_tags_view ttv
         ON t.id = ttv.task_id
+    LEFT JOIN task_time_view tmv
+        ON t.id = tmv.task_id
 WHERE t.id = $1
   AND t.user_id = $2
   AND t.deleted_at IS NULL
 `
 
 type GetTaskByIDParams struct {
-	ID     string `db:"id"`
-	UserID string `db:"user_id"`
+	ID     string             `db:"id"`
+	UserID string             `db:"user_id"`
+	Today  pgtype.Timestamptz `db:"today"`
 }
 
 type GetTaskByIDRow struct {
-	ID          string             `db:"id"`
-	Title       string             `db:"title"`
-	Description pgtype.Text        `db:"description"`
-	StartDate   pgtype.Timestamptz `db:"start_date"`
-	Deadline    pgtype.Timestamptz `db:"deadline"`
-	StartTime   pgtype.Timestamptz `db:"start_time"`
-	EndTime     pgtype.Timestamptz `db:"end_time"`
-	StatusID    int32              `db:"status_id"`
-	ListID      string             `db:"list_id"`
-	HeadingID   string             `db:"heading_id"`
-	UpdatedAt   time.Time          `db:"updated_at"`
-	Tags        interface{}        `db:"tags"`
-	Overdue     bool               `db:"overdue"`
+	ID              string             `db:"id"`
+	Title           string             `db:"title"`
+	Description     pgtype.Text        `db:"description"`
+	StartDate       pgtype.Timestamptz `db:"start_date"`
+	Deadline        pgtype.Timestamptz `db:"deadline"`
+	StartTime       pgtype.Timestamptz `db:"start_time"`
+	EndTime         pgtype.Timestamptz `db:"end_time"`
+	StatusID        int32              `db:"status_id"`
+	CustomStatusID  pgtype.Int4        `db:"custom_status_id"`
+	ListID          string             `db:"list_id"`
+	HeadingID       string             `db:"heading_id"`
+	UpdatedAt       time.Time          `db:"updated_at"`
+	EstimateMinutes pgtype.Int4        `db:"estimate_minutes"`
+	TrackedMinutes  int32              `db:"tracked_minutes"`
+	Tags            interface{}        `db:"tags"`
+	Overdue         bool               `db:"overdue"`
 }
 
 func (q *Queries) GetTaskByID(ctx context.Context, arg GetTaskByIDParams) (GetTaskByIDRow, error) {
-	row := q.db.QueryRow(ctx, getTaskByID, arg.ID, arg.UserID)
+	row := q.db.QueryRow(ctx, getTaskByID, arg.ID, arg.UserID, arg.Today)
 	var i GetTaskByIDRow
 	err := row.Scan(
 		&i.ID,
@@ -475,19 +674,85 @@ func (q *Queries) GetTaskByID(ctx context.Context, arg GetTaskByIDParams) (GetTa
 		&i.StartTime,
 		&i.EndTime,
 		&i.StatusID,
+		&i.CustomStatusID,
 		&i.ListID,
 		&i.HeadingID,
 		&i.UpdatedAt,
+		&i.EstimateMinutes,
+		&i.TrackedMinutes,
 		&i.Tags,
 		&i.Overdue,
 	)
 	return i, err
 }
 
+const getTaskIDsByBoardColumn = `-- name: GetTaskIDsByBoardColumn :many
+SELECT id
+FROM tasks
+WHERE list_id = $1
+  AND user_id = $2
+  AND COALESCE(custom_status_id, status_id) = $3::int
+  AND deleted_at IS NULL
+ORDER BY position, created_at
+`
+
+type GetTaskIDsByBoardColumnParams struct {
+	ListID   string `db:"list_id"`
+	UserID   string `db:"user_id"`
+	StatusID int32  `db:"status_id"`
+}
+
+func (q *Queries) GetTaskIDsByBoardColumn(ctx context.Context, arg GetTaskIDsByBoardColumnParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, getTaskIDsByBoardColumn, arg.ListID, arg.UserID, arg.StatusID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTaskStatus = `-- name: GetTaskStatus :one
+SELECT status_id, custom_status_id, list_id
+FROM tasks
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+`
+
+type GetTaskStatusParams struct {
+	ID     string `db:"id"`
+	UserID string `db:"user_id"`
+}
+
+type GetTaskStatusRow struct {
+	StatusID       int32       `db:"status_id"`
+	CustomStatusID pgtype.Int4 `db:"custom_status_id"`
+	ListID         string      `db:"list_id"`
+}
+
+func (q *Queries) GetTaskStatus(ctx context.Context, arg GetTaskStatusParams) (GetTaskStatusRow, error) {
+	row := q.db.QueryRow(ctx, getTaskStatus, arg.ID, arg.UserID)
+	var i GetTaskStatusRow
+	err := row.Scan(&i.StatusID, &i.CustomStatusID, &i.ListID)
+	return i, err
+}
+
 const getTaskStatusID = `-- name: GetTaskStatusID :one
 SELECT id
 FROM statuses
 WHERE title = $1
+  AND user_id IS NULL
 `
 
 func (q *Queries) GetTaskStatusID(ctx context.Context, title string) (int32, error) {
@@ -511,14 +776,18 @@ SELECT
     t.heading_id,
     t.user_id,
     t.updated_at,
+    t.estimate_minutes,
+    COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
     ttv.tags as tags,
     CASE
-        WHEN t.deadline <= CURRENT_DATE THEN TRUE
+        WHEN t.deadline <= $3::timestamptz THEN TRUE
         ELSE FALSE END
         AS overdue
 FROM tasks t
     LEFT JOIN task_tags_view ttv
         ON t.id = ttv.task_id
+    LEFT JOIN task_time_view tmv
+        ON t.id = tmv.task_id
 WHERE t.list_id = $1
   AND t.user_id = $2
   AND t.deleted_at IS NULL
@@ -534,34 +803,39 @@ GROUP BY
     t.heading_id,
     overdue,
     t.updated_at,
+    t.estimate_minutes,
+    tmv.tracked_minutes,
     ttv.tags
 ORDER BY t.id
 `
 
 type GetTasksByListIDParams struct {
-	ListID string `db:"list_id"`
-	UserID string `db:"user_id"`
+	ListID string             `db:"list_id"`
+	UserID string             `db:"user_id"`
+	Today  pgtype.Timestamptz `db:"today"`
 }
 
 type GetTasksByListIDRow struct {
-	ID          string             `db:"id"`
-	Title       string             `db:"title"`
-	Description pgtype.Text        `db:"description"`
-	StartDate   pgtype.Timestamptz `db:"start_date"`
-	Deadline    pgtype.Timestamptz `db:"deadline"`
-	StartTime   pgtype.Timestamptz `db:"start_time"`
-	EndTime     pgtype.Timestamptz `db:"end_time"`
-	StatusID    int32              `db:"status_id"`
-	ListID      string             `db:"list_id"`
-	HeadingID   string             `db:"heading_id"`
-	UserID      string             `db:"user_id"`
-	UpdatedAt   time.Time          `db:"updated_at"`
-	Tags        interface{}        `db:"tags"`
-	Overdue     bool               `db:"overdue"`
+	ID              string             `db:"id"`
+	Title           string             `db:"title"`
+	Description     pgtype.Text        `db:"description"`
+	StartDate       pgtype.Timestamptz `db:"start_date"`
+	Deadline        pgtype.Timestamptz `db:"deadline"`
+	StartTime       pgtype.Timestamptz `db:"start_time"`
+	EndTime         pgtype.Timestamptz `db:"end_time"`
+	StatusID        int32              `db:"status_id"`
+	ListID          string             `db:"list_id"`
+	HeadingID       string             `db:"heading_id"`
+	UserID          string             `db:"user_id"`
+	UpdatedAt       time.Time          `db:"updated_at"`
+	EstimateMinutes pgtype.Int4        `db:"estimate_minutes"`
+	TrackedMinutes  int32              `db:"tracked_minutes"`
+	Tags            interface{}        `db:"tags"`
+	Overdue         bool               `db:"overdue"`
 }
 
 func (q *Queries) GetTasksByListID(ctx context.Context, arg GetTasksByListIDParams) ([]GetTasksByListIDRow, error) {
-	rows, err := q.db.Query(ctx, getTasksByListID, arg.ListID, arg.UserID)
+	rows, err := q.db.Query(ctx, getTasksByListID, arg.ListID, arg.UserID, arg.Today)
 	if err != nil {
 		return nil, err
 	}
@@ -582,6 +856,8 @@ func (q *Queries) GetTasksByListID(ctx context.Context, arg GetTasksByListIDPara
 			&i.HeadingID,
 			&i.UserID,
 			&i.UpdatedAt,
+			&i.EstimateMinutes,
+			&i.TrackedMinutes,
 			&i.Tags,
 			&i.Overdue,
 		); err != nil {
@@ -595,6 +871,134 @@ func (q *Queries) GetTasksByListID(ctx context.Context, arg GetTasksByListIDPara
 	return items, nil
 }
 
+const getTasksByTagID = `-- name: GetTasksByTagID :many
+WITH RECURSIVE subtree AS (
+    SELECT tags.id
+    FROM tags
+    WHERE tags.id = $1
+      AND tags.user_id = $2
+      AND tags.deleted_at IS NULL
+    UNION ALL
+    SELECT tags.id
+    FROM tags
+        JOIN subtree
+            ON tags.parent_id = subtree.id
+    WHERE tags.deleted_at IS NULL
+)
+SELECT
+    l.id AS list_id,
+    ARRAY_TO_JSON(
+            ARRAY_AGG(
+                    JSON_BUILD_OBJECT(
+                            'id', t.id,
+                            'title', t.title,
+                            'description', t.description,
+                            'start_date', t.start_date,
+                            'deadline', t.deadline,
+                            'start_time', t.start_time,
+                            'end_time', t.end_time,
+                            'list_id', t.list_id,
+                            'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
+                            'tags', tags,
+                            'overdue', overdue,
+                            'updated_at', t.updated_at
+                    )
+            )
+    ) AS tasks
+FROM lists l
+    JOIN (
+        SELECT
+            t.id,
+            t.title,
+            t.description,
+            t.start_date,
+            t.deadline,
+            t.start_time,
+            t.end_time,
+            t.list_id,
+            t.user_id,
+            t.estimate_minutes,
+            COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
+            ttv.tags as tags,
+            CASE
+                WHEN t.deadline <= $4::timestamptz THEN TRUE
+                ELSE FALSE END AS overdue,
+            t.updated_at
+        FROM tasks t
+            LEFT JOIN task_tags_view ttv
+                ON t.id = ttv.task_id
+            LEFT JOIN task_time_view tmv
+                ON t.id = tmv.task_id
+        WHERE t.user_id = $2
+          AND t.id IN (SELECT tasks_tags.task_id
+                       FROM tasks_tags
+                           JOIN subtree
+                               ON tasks_tags.tag_id = subtree.id)
+          AND t.deleted_at IS NULL
+        GROUP BY
+            t.id,
+            t.title,
+            t.description,
+            t.start_date,
+            t.deadline,
+            t.start_time,
+            t.end_time,
+            t.list_id,
+            t.user_id,
+            t.estimate_minutes,
+            tmv.tracked_minutes,
+            ttv.tags,
+            t.updated_at
+        ) t
+        ON l.id = t.list_id
+WHERE l.user_id = $2
+  AND l.id > $5::varchar
+GROUP BY l.id
+ORDER BY l.id
+LIMIT $3
+`
+
+type GetTasksByTagIDParams struct {
+	ID     string             `db:"id"`
+	UserID string             `db:"user_id"`
+	Limit  int32              `db:"limit"`
+	Today  pgtype.Timestamptz `db:"today"`
+	Cursor string             `db:"cursor"`
+}
+
+type GetTasksByTagIDRow struct {
+	ListID string `db:"list_id"`
+	Tasks  []byte `db:"tasks"`
+}
+
+func (q *Queries) GetTasksByTagID(ctx context.Context, arg GetTasksByTagIDParams) ([]GetTasksByTagIDRow, error) {
+	rows, err := q.db.Query(ctx, getTasksByTagID,
+		arg.ID,
+		arg.UserID,
+		arg.Limit,
+		arg.Today,
+		arg.Cursor,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTasksByTagIDRow{}
+	for rows.Next() {
+		var i GetTasksByTagIDRow
+		if err := rows.Scan(&i.ListID, &i.Tasks); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTasksByUserID = `-- name: GetTasksByUserID :many
 SELECT
     t.id,
@@ -608,17 +1012,21 @@ SELECT
     t.list_id,
     t.heading_id,
     t.updated_at,
+    t.estimate_minutes,
+    COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
     ttv.tags as tags,
     CASE
-        WHEN t.deadline <= CURRENT_DATE THEN TRUE
+        WHEN t.deadline <= $3::timestamptz THEN TRUE
         ELSE FALSE END
       AS overdue
 FROM tasks t
     LEFT JOIN task_tags_view ttv
         ON t.id = ttv.task_id
+    LEFT JOIN task_time_view tmv
+        ON t.id = tmv.task_id
 WHERE t.user_id = $1
   AND t.deleted_at IS NULL
-  AND t.id > $3::varchar
+  AND t.id > $4::varchar
 GROUP BY
     t.id,
     t.title,
@@ -630,6 +1038,8 @@ GROUP BY
     t.status_id,
     t.list_id,
     t.heading_id,
+    t.estimate_minutes,
+    tmv.tracked_minutes,
     ttv.tags,
     t.created_at,
     t.updated_at
@@ -638,29 +1048,37 @@ LIMIT $2
 `
 
 type GetTasksByUserIDParams struct {
-	UserID string `db:"user_id"`
-	Limit  int32  `db:"limit"`
-	Cursor string `db:"cursor"`
+	UserID string             `db:"user_id"`
+	Limit  int32              `db:"limit"`
+	Today  pgtype.Timestamptz `db:"today"`
+	Cursor string             `db:"cursor"`
 }
 
 type GetTasksByUserIDRow struct {
-	ID          string             `db:"id"`
-	Title       string             `db:"title"`
-	Description pgtype.Text        `db:"description"`
-	StartDate   pgtype.Timestamptz `db:"start_date"`
-	Deadline    pgtype.Timestamptz `db:"deadline"`
-	StartTime   pgtype.Timestamptz `db:"start_time"`
-	EndTime     pgtype.Timestamptz `db:"end_time"`
-	StatusID    int32              `db:"status_id"`
-	ListID      string             `db:"list_id"`
-	HeadingID   string             `db:"heading_id"`
-	UpdatedAt   time.Time          `db:"updated_at"`
-	Tags        interface{}        `db:"tags"`
-	Overdue     bool               `db:"overdue"`
+	ID              string             `db:"id"`
+	Title           string             `db:"title"`
+	Description     pgtype.Text        `db:"description"`
+	StartDate       pgtype.Timestamptz `db:"start_date"`
+	Deadline        pgtype.Timestamptz `db:"deadline"`
+	StartTime       pgtype.Timestamptz `db:"start_time"`
+	EndTime         pgtype.Timestamptz `db:"end_time"`
+	StatusID        int32              `db:"status_id"`
+	ListID          string             `db:"list_id"`
+	HeadingID       string             `db:"heading_id"`
+	UpdatedAt       time.Time          `db:"updated_at"`
+	EstimateMinutes pgtype.Int4        `db:"estimate_minutes"`
+	TrackedMinutes  int32              `db:"tracked_minutes"`
+	Tags            interface{}        `db:"tags"`
+	Overdue         bool               `db:"overdue"`
 }
 
 func (q *Queries) GetTasksByUserID(ctx context.Context, arg GetTasksByUserIDParams) ([]GetTasksByUserIDRow, error) {
-	rows, err := q.db.Query(ctx, getTasksByUserID, arg.UserID, arg.Limit, arg.Cursor)
+	rows, err := q.db.Query(ctx, getTasksByUserID,
+		arg.UserID,
+		arg.Limit,
+		arg.Today,
+		arg.Cursor,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -680,6 +1098,8 @@ func (q *Queries) GetTasksByUserID(ctx context.Context, arg GetTasksByUserIDPara
 			&i.ListID,
 			&i.HeadingID,
 			&i.UpdatedAt,
+			&i.EstimateMinutes,
+			&i.TrackedMinutes,
 			&i.Tags,
 			&i.Overdue,
 		); err != nil {
@@ -693,45 +1113,122 @@ func (q *Queries) GetTasksByUserID(ctx context.Context, arg GetTasksByUserIDPara
 	return items, nil
 }
 
-const getTasksForSomeday = `-- name: GetTasksForSomeday :many
+const getTasksForSchedule = `-- name: GetTasksForSchedule :many
 SELECT
-    l.id AS list_id,
-    ARRAY_TO_JSON(
-            ARRAY_AGG(
-                    JSON_BUILD_OBJECT(
-                            'id', t.id,
-                            'title', t.title,
-                            'description', t.description,
-                            'deadline', t.deadline,
-                            'start_time', t.start_time,
-                            'end_time', t.end_time,
-                            'list_id', t.list_id,
-                            'user_id', t.user_id,
-                            'tags', tags,
-                            'updated_at', t.updated_at
-                    )
-            )
-    ) AS tasks
-FROM lists l
-JOIN (
-    SELECT
-        t.id,
-        t.title,
-        t.description,
-        t.deadline,
-        t.start_time,
-        t.end_time,
-        t.status_id,
-        t.list_id,
-        t.heading_id,
-        t.user_id,
-        ttv.tags as tags,
-        t.updated_at
-    FROM tasks t
-             LEFT JOIN task_tags_view ttv ON t.id = ttv.task_id
-    WHERE t.user_id = $1
-      AND t.start_date IS NULL
-      AND t.deadline IS NULL
+    id,
+    title,
+    start_date,
+    deadline,
+    start_time,
+    end_time,
+    estimate_minutes
+FROM tasks
+WHERE user_id = $1
+  AND status_id != $2
+  AND deleted_at IS NULL
+  AND (
+      (start_date >= $3::timestamptz AND start_date < $3::timestamptz + interval '1 day')
+      OR (end_time > $4::timestamptz AND start_time < $5::timestamptz)
+  )
+ORDER BY created_at
+`
+
+type GetTasksForScheduleParams struct {
+	UserID   string             `db:"user_id"`
+	StatusID int32              `db:"status_id"`
+	Day      pgtype.Timestamptz `db:"day"`
+	DayStart pgtype.Timestamptz `db:"day_start"`
+	DayEnd   pgtype.Timestamptz `db:"day_end"`
+}
+
+type GetTasksForScheduleRow struct {
+	ID              string             `db:"id"`
+	Title           string             `db:"title"`
+	StartDate       pgtype.Timestamptz `db:"start_date"`
+	Deadline        pgtype.Timestamptz `db:"deadline"`
+	StartTime       pgtype.Timestamptz `db:"start_time"`
+	EndTime         pgtype.Timestamptz `db:"end_time"`
+	EstimateMinutes pgtype.Int4        `db:"estimate_minutes"`
+}
+
+func (q *Queries) GetTasksForSchedule(ctx context.Context, arg GetTasksForScheduleParams) ([]GetTasksForScheduleRow, error) {
+	rows, err := q.db.Query(ctx, getTasksForSchedule,
+		arg.UserID,
+		arg.StatusID,
+		arg.Day,
+		arg.DayStart,
+		arg.DayEnd,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTasksForScheduleRow{}
+	for rows.Next() {
+		var i GetTasksForScheduleRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.StartDate,
+			&i.Deadline,
+			&i.StartTime,
+			&i.EndTime,
+			&i.EstimateMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTasksForSomeday = `-- name: GetTasksForSomeday :many
+SELECT
+    l.id AS list_id,
+    ARRAY_TO_JSON(
+            ARRAY_AGG(
+                    JSON_BUILD_OBJECT(
+                            'id', t.id,
+                            'title', t.title,
+                            'description', t.description,
+                            'deadline', t.deadline,
+                            'start_time', t.start_time,
+                            'end_time', t.end_time,
+                            'list_id', t.list_id,
+                            'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
+                            'tags', tags,
+                            'updated_at', t.updated_at
+                    )
+            )
+    ) AS tasks
+FROM lists l
+JOIN (
+    SELECT
+        t.id,
+        t.title,
+        t.description,
+        t.deadline,
+        t.start_time,
+        t.end_time,
+        t.status_id,
+        t.list_id,
+        t.heading_id,
+        t.user_id,
+        t.estimate_minutes,
+        COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
+        ttv.tags as tags,
+        t.updated_at
+    FROM tasks t
+             LEFT JOIN task_tags_view ttv ON t.id = ttv.task_id
+             LEFT JOIN task_time_view tmv ON t.id = tmv.task_id
+    WHERE t.user_id = $1
+      AND t.start_date IS NULL
+      AND t.deadline IS NULL
       AND t.deleted_at IS NULL
     GROUP BY
         t.id,
@@ -744,6 +1241,8 @@ JOIN (
         t.list_id,
         t.heading_id,
         t.user_id,
+        t.estimate_minutes,
+        tmv.tracked_minutes,
         ttv.tags,
         t.updated_at
 ) t ON l.id = t.list_id
@@ -785,6 +1284,113 @@ func (q *Queries) GetTasksForSomeday(ctx context.Context, arg GetTasksForSomeday
 	return items, nil
 }
 
+const getTasksForSomedayGroupedByTag = `-- name: GetTasksForSomedayGroupedByTag :many
+SELECT
+    tags.id AS tag_id,
+    tags.title AS tag_title,
+    ARRAY_TO_JSON(
+            ARRAY_AGG(
+                    JSON_BUILD_OBJECT(
+                            'id', t.id,
+                            'title', t.title,
+                            'description', t.description,
+                            'deadline', t.deadline,
+                            'start_time', t.start_time,
+                            'end_time', t.end_time,
+                            'list_id', t.list_id,
+                            'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
+                            'tags', t.tags,
+                            'updated_at', t.updated_at
+                    )
+            )
+    ) AS tasks
+FROM tags
+    JOIN tasks_tags
+        ON tags.id = tasks_tags.tag_id
+    JOIN (
+        SELECT
+            t.id,
+            t.title,
+            t.description,
+            t.deadline,
+            t.start_time,
+            t.end_time,
+            t.status_id,
+            t.list_id,
+            t.heading_id,
+            t.user_id,
+            t.estimate_minutes,
+            COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
+            ttv.tags as tags,
+            t.updated_at
+        FROM tasks t
+            LEFT JOIN task_tags_view ttv
+                ON t.id = ttv.task_id
+            LEFT JOIN task_time_view tmv
+                ON t.id = tmv.task_id
+        WHERE t.user_id = $1
+          AND t.start_date IS NULL
+          AND t.deadline IS NULL
+          AND t.deleted_at IS NULL
+        GROUP BY
+            t.id,
+            t.title,
+            t.description,
+            t.deadline,
+            t.start_time,
+            t.end_time,
+            t.status_id,
+            t.list_id,
+            t.heading_id,
+            t.user_id,
+            t.estimate_minutes,
+            tmv.tracked_minutes,
+            ttv.tags,
+            t.updated_at
+        ) t
+        ON tasks_tags.task_id = t.id
+WHERE tags.user_id = $1
+  AND tags.id > $3::varchar
+  AND tags.deleted_at IS NULL
+GROUP BY tags.id
+ORDER BY tags.id
+LIMIT $2
+`
+
+type GetTasksForSomedayGroupedByTagParams struct {
+	UserID string `db:"user_id"`
+	Limit  int32  `db:"limit"`
+	Cursor string `db:"cursor"`
+}
+
+type GetTasksForSomedayGroupedByTagRow struct {
+	TagID    string `db:"tag_id"`
+	TagTitle string `db:"tag_title"`
+	Tasks    []byte `db:"tasks"`
+}
+
+func (q *Queries) GetTasksForSomedayGroupedByTag(ctx context.Context, arg GetTasksForSomedayGroupedByTagParams) ([]GetTasksForSomedayGroupedByTagRow, error) {
+	rows, err := q.db.Query(ctx, getTasksForSomedayGroupedByTag, arg.UserID, arg.Limit, arg.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTasksForSomedayGroupedByTagRow{}
+	for rows.Next() {
+		var i GetTasksForSomedayGroupedByTagRow
+		if err := rows.Scan(&i.TagID, &i.TagTitle, &i.Tasks); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTasksForToday = `-- name: GetTasksForToday :many
 SELECT
     l.id AS list_id,
@@ -800,6 +1406,8 @@ SELECT
                             'end_time', t.end_time,
                             'list_id', t.list_id,
                             'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
                             'tags', tags,
                             'overdue', overdue,
                             'updated_at', t.updated_at
@@ -818,16 +1426,21 @@ FROM lists l
             t.end_time,
             t.list_id,
             t.user_id,
+            t.estimate_minutes,
+            COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
             ttv.tags as tags,
             CASE
-                WHEN t.deadline <= CURRENT_DATE THEN TRUE
+                WHEN t.deadline <= $2::timestamptz THEN TRUE
                 ELSE FALSE END AS overdue,
             t.updated_at
         FROM tasks t
             LEFT JOIN task_tags_view ttv
                 ON t.id = ttv.task_id
+            LEFT JOIN task_time_view tmv
+                ON t.id = tmv.task_id
         WHERE t.user_id = $1
-          AND t.start_date::date = CURRENT_DATE
+          AND t.start_date >= $2::timestamptz
+          AND t.start_date < $2::timestamptz + interval '1 day'
           AND t.deleted_at IS NULL
         GROUP BY
             t.id,
@@ -839,6 +1452,8 @@ FROM lists l
             t.end_time,
             t.list_id,
             t.user_id,
+            t.estimate_minutes,
+            tmv.tracked_minutes,
             ttv.tags,
             t.updated_at
         ) t
@@ -848,13 +1463,18 @@ GROUP BY l.id
 ORDER BY l.id
 `
 
+type GetTasksForTodayParams struct {
+	UserID string             `db:"user_id"`
+	Today  pgtype.Timestamptz `db:"today"`
+}
+
 type GetTasksForTodayRow struct {
 	ListID string `db:"list_id"`
 	Tasks  []byte `db:"tasks"`
 }
 
-func (q *Queries) GetTasksForToday(ctx context.Context, userID string) ([]GetTasksForTodayRow, error) {
-	rows, err := q.db.Query(ctx, getTasksForToday, userID)
+func (q *Queries) GetTasksForToday(ctx context.Context, arg GetTasksForTodayParams) ([]GetTasksForTodayRow, error) {
+	rows, err := q.db.Query(ctx, getTasksForToday, arg.UserID, arg.Today)
 	if err != nil {
 		return nil, err
 	}
@@ -873,6 +1493,113 @@ func (q *Queries) GetTasksForToday(ctx context.Context, userID string) ([]GetTas
 	return items, nil
 }
 
+const getTasksForTodayGroupedByTag = `-- name: GetTasksForTodayGroupedByTag :many
+SELECT
+    tags.id AS tag_id,
+    tags.title AS tag_title,
+    ARRAY_TO_JSON(
+            ARRAY_AGG(
+                    JSON_BUILD_OBJECT(
+                            'id', t.id,
+                            'title', t.title,
+                            'description', t.description,
+                            'start_date', t.start_date,
+                            'deadline', t.deadline,
+                            'start_time', t.start_time,
+                            'end_time', t.end_time,
+                            'list_id', t.list_id,
+                            'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
+                            'tags', t.tags,
+                            'overdue', overdue,
+                            'updated_at', t.updated_at
+                    )
+            )
+    ) AS tasks
+FROM tags
+    JOIN tasks_tags
+        ON tags.id = tasks_tags.tag_id
+    JOIN (
+        SELECT
+            t.id,
+            t.title,
+            t.description,
+            t.start_date,
+            t.deadline,
+            t.start_time,
+            t.end_time,
+            t.list_id,
+            t.user_id,
+            t.estimate_minutes,
+            COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
+            ttv.tags as tags,
+            CASE
+                WHEN t.deadline <= $2::timestamptz THEN TRUE
+                ELSE FALSE END AS overdue,
+            t.updated_at
+        FROM tasks t
+            LEFT JOIN task_tags_view ttv
+                ON t.id = ttv.task_id
+            LEFT JOIN task_time_view tmv
+                ON t.id = tmv.task_id
+        WHERE t.user_id = $1
+          AND t.start_date >= $2::timestamptz
+          AND t.start_date < $2::timestamptz + interval '1 day'
+          AND t.deleted_at IS NULL
+        GROUP BY
+            t.id,
+            t.title,
+            t.description,
+            t.start_date,
+            t.deadline,
+            t.start_time,
+            t.end_time,
+            t.list_id,
+            t.user_id,
+            t.estimate_minutes,
+            tmv.tracked_minutes,
+            ttv.tags,
+            t.updated_at
+        ) t
+        ON tasks_tags.task_id = t.id
+WHERE tags.user_id = $1
+  AND tags.deleted_at IS NULL
+GROUP BY tags.id
+ORDER BY tags.title
+`
+
+type GetTasksForTodayGroupedByTagParams struct {
+	UserID string             `db:"user_id"`
+	Today  pgtype.Timestamptz `db:"today"`
+}
+
+type GetTasksForTodayGroupedByTagRow struct {
+	TagID    string `db:"tag_id"`
+	TagTitle string `db:"tag_title"`
+	Tasks    []byte `db:"tasks"`
+}
+
+func (q *Queries) GetTasksForTodayGroupedByTag(ctx context.Context, arg GetTasksForTodayGroupedByTagParams) ([]GetTasksForTodayGroupedByTagRow, error) {
+	rows, err := q.db.Query(ctx, getTasksForTodayGroupedByTag, arg.UserID, arg.Today)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTasksForTodayGroupedByTagRow{}
+	for rows.Next() {
+		var i GetTasksForTodayGroupedByTagRow
+		if err := rows.Scan(&i.TagID, &i.TagTitle, &i.Tasks); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTasksGroupedByHeading = `-- name: GetTasksGroupedByHeading :many
 SELECT
     h.id AS heading_id,
@@ -888,6 +1615,8 @@ SELECT
                             'end_time', t.end_time,
                             'heading_id', t.heading_id,
                             'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
                             'tags', tags,
                             'overdue', overdue,
                             'updated_at', t.updated_at
@@ -906,15 +1635,19 @@ JOIN (
         t.end_time,
         t.heading_id,
         t.user_id,
+        t.estimate_minutes,
+        COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
         ttv.tags as tags,
         CASE
-            WHEN t.deadline <= CURRENT_DATE THEN TRUE
+            WHEN t.deadline <= $3::timestamptz THEN TRUE
             ELSE FALSE END
                  AS overdue,
         t.updated_at
     FROM tasks t
              LEFT JOIN task_tags_view ttv
                        ON t.id = ttv.task_id
+             LEFT JOIN task_time_view tmv
+                       ON t.id = tmv.task_id
     WHERE t.list_id = $1
       AND t.user_id = $2
       AND t.deleted_at IS NULL
@@ -929,6 +1662,8 @@ JOIN (
         t.heading_id,
         t.user_id,
         t.updated_at,
+        t.estimate_minutes,
+        tmv.tracked_minutes,
         ttv.tags
 ) t
               ON h.id = t.heading_id
@@ -939,8 +1674,9 @@ ORDER BY h.id
 `
 
 type GetTasksGroupedByHeadingParams struct {
-	ListID string `db:"list_id"`
-	UserID string `db:"user_id"`
+	ListID string             `db:"list_id"`
+	UserID string             `db:"user_id"`
+	Today  pgtype.Timestamptz `db:"today"`
 }
 
 type GetTasksGroupedByHeadingRow struct {
@@ -949,7 +1685,7 @@ type GetTasksGroupedByHeadingRow struct {
 }
 
 func (q *Queries) GetTasksGroupedByHeading(ctx context.Context, arg GetTasksGroupedByHeadingParams) ([]GetTasksGroupedByHeadingRow, error) {
-	rows, err := q.db.Query(ctx, getTasksGroupedByHeading, arg.ListID, arg.UserID)
+	rows, err := q.db.Query(ctx, getTasksGroupedByHeading, arg.ListID, arg.UserID, arg.Today)
 	if err != nil {
 		return nil, err
 	}
@@ -968,6 +1704,120 @@ func (q *Queries) GetTasksGroupedByHeading(ctx context.Context, arg GetTasksGrou
 	return items, nil
 }
 
+const getTasksGroupedByStatus = `-- name: GetTasksGroupedByStatus :many
+SELECT
+    s.id AS status_id,
+    ARRAY_TO_JSON(
+            ARRAY_AGG(
+                    JSON_BUILD_OBJECT(
+                            'task_id', t.id,
+                            'title', t.title,
+                            'description', t.description,
+                            'start_date', t.start_date,
+                            'deadline', t.deadline,
+                            'start_time', t.start_time,
+                            'end_time', t.end_time,
+                            'status_id', t.status_id,
+                            'custom_status_id', t.custom_status_id,
+                            'heading_id', t.heading_id,
+                            'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
+                            'tags', tags,
+                            'overdue', overdue,
+                            'updated_at', t.updated_at
+                    )
+                    ORDER BY t.position, t.created_at
+            )
+    ) AS tasks
+FROM statuses s
+JOIN (
+    SELECT
+        t.id,
+        t.title,
+        t.description,
+        t.start_date,
+        t.deadline,
+        t.start_time,
+        t.end_time,
+        t.status_id,
+        t.custom_status_id,
+        COALESCE(t.custom_status_id, t.status_id) AS board_status_id,
+        t.heading_id,
+        t.user_id,
+        t.estimate_minutes,
+        COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
+        ttv.tags as tags,
+        CASE
+            WHEN t.deadline <= $3::timestamptz THEN TRUE
+            ELSE FALSE END
+                 AS overdue,
+        t.position,
+        t.created_at,
+        t.updated_at
+    FROM tasks t
+             LEFT JOIN task_tags_view ttv
+                       ON t.id = ttv.task_id
+             LEFT JOIN task_time_view tmv
+                       ON t.id = tmv.task_id
+    WHERE t.list_id = $1
+      AND t.user_id = $2
+      AND t.deleted_at IS NULL
+    GROUP BY
+        t.id,
+        t.title,
+        t.description,
+        t.start_date,
+        t.deadline,
+        t.start_time,
+        t.end_time,
+        t.status_id,
+        t.custom_status_id,
+        t.heading_id,
+        t.user_id,
+        t.position,
+        t.created_at,
+        t.updated_at,
+        t.estimate_minutes,
+        tmv.tracked_minutes,
+        ttv.tags
+) t
+              ON s.id = t.board_status_id
+GROUP BY s.id
+ORDER BY s.id
+`
+
+type GetTasksGroupedByStatusParams struct {
+	ListID string             `db:"list_id"`
+	UserID string             `db:"user_id"`
+	Today  pgtype.Timestamptz `db:"today"`
+}
+
+type GetTasksGroupedByStatusRow struct {
+	StatusID int32  `db:"status_id"`
+	Tasks    []byte `db:"tasks"`
+}
+
+func (q *Queries) GetTasksGroupedByStatus(ctx context.Context, arg GetTasksGroupedByStatusParams) ([]GetTasksGroupedByStatusRow, error) {
+	rows, err := q.db.Query(ctx, getTasksGroupedByStatus, arg.ListID, arg.UserID, arg.Today)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTasksGroupedByStatusRow{}
+	for rows.Next() {
+		var i GetTasksGroupedByStatusRow
+		if err := rows.Scan(&i.StatusID, &i.Tasks); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUpcomingTasks = `-- name: GetUpcomingTasks :many
 SELECT
     t.start_date AS start_date,
@@ -983,6 +1833,8 @@ SELECT
                             'end_time', t.end_time,
                             'list_id', t.list_id,
                             'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
                             'tags', tags,
                             'updated_at', t.updated_at
                     )
@@ -999,14 +1851,18 @@ FROM (
         t.end_time,
         t.list_id,
         t.user_id,
+        t.estimate_minutes,
+        COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
         ttv.tags as tags,
         t.updated_at
     FROM tasks t
         LEFT JOIN task_tags_view ttv
              ON t.id = ttv.task_id
+        LEFT JOIN task_time_view tmv
+             ON t.id = tmv.task_id
     WHERE t.user_id = $1
         AND (
-             (t.start_date >= COALESCE($3::timestamptz, CURRENT_DATE + interval '1 day'))
+             (t.start_date >= COALESCE($3::timestamptz, $4::timestamptz + interval '1 day'))
              AND (t.deleted_at IS NULL)
         )
    GROUP BY
@@ -1019,6 +1875,8 @@ FROM (
         t.end_time,
         t.list_id,
         t.user_id,
+        t.estimate_minutes,
+        tmv.tracked_minutes,
         ttv.tags,
         t.updated_at
 ) t
@@ -1031,6 +1889,7 @@ type GetUpcomingTasksParams struct {
 	UserID    string             `db:"user_id"`
 	Limit     int32              `db:"limit"`
 	AfterDate pgtype.Timestamptz `db:"after_date"`
+	Today     pgtype.Timestamptz `db:"today"`
 }
 
 type GetUpcomingTasksRow struct {
@@ -1039,7 +1898,12 @@ type GetUpcomingTasksRow struct {
 }
 
 func (q *Queries) GetUpcomingTasks(ctx context.Context, arg GetUpcomingTasksParams) ([]GetUpcomingTasksRow, error) {
-	rows, err := q.db.Query(ctx, getUpcomingTasks, arg.UserID, arg.Limit, arg.AfterDate)
+	rows, err := q.db.Query(ctx, getUpcomingTasks,
+		arg.UserID,
+		arg.Limit,
+		arg.AfterDate,
+		arg.Today,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -1058,9 +1922,120 @@ func (q *Queries) GetUpcomingTasks(ctx context.Context, arg GetUpcomingTasksPara
 	return items, nil
 }
 
+const getUpcomingTasksGroupedByTag = `-- name: GetUpcomingTasksGroupedByTag :many
+SELECT
+    tags.id AS tag_id,
+    tags.title AS tag_title,
+    ARRAY_TO_JSON(
+            ARRAY_AGG(
+                    JSON_BUILD_OBJECT(
+                            'id', t.id,
+                            'title', t.title,
+                            'description', t.description,
+                            'start_date', t.start_date,
+                            'deadline', t.deadline,
+                            'start_time', t.start_time,
+                            'end_time', t.end_time,
+                            'list_id', t.list_id,
+                            'user_id', t.user_id,
+                            'estimate_minutes', t.estimate_minutes,
+                            'tracked_minutes', t.tracked_minutes,
+                            'tags', t.tags,
+                            'updated_at', t.updated_at
+                    )
+            )
+    ) AS tasks
+FROM tags
+    JOIN tasks_tags
+        ON tags.id = tasks_tags.tag_id
+    JOIN (
+        SELECT
+            t.id,
+            t.title,
+            t.description,
+            t.start_date,
+            t.deadline,
+            t.start_time,
+            t.end_time,
+            t.list_id,
+            t.user_id,
+            t.estimate_minutes,
+            COALESCE(tmv.tracked_minutes, 0)::int AS tracked_minutes,
+            ttv.tags as tags,
+            t.updated_at
+        FROM tasks t
+            LEFT JOIN task_tags_view ttv
+                ON t.id = ttv.task_id
+            LEFT JOIN task_time_view tmv
+                ON t.id = tmv.task_id
+        WHERE t.user_id = $1
+          AND t.start_date >= $3::timestamptz + interval '1 day'
+          AND t.deleted_at IS NULL
+        GROUP BY
+            t.id,
+            t.title,
+            t.description,
+            t.start_date,
+            t.deadline,
+            t.start_time,
+            t.end_time,
+            t.list_id,
+            t.user_id,
+            t.estimate_minutes,
+            tmv.tracked_minutes,
+            ttv.tags,
+            t.updated_at
+        ) t
+        ON tasks_tags.task_id = t.id
+WHERE tags.user_id = $1
+  AND tags.id > $4::varchar
+  AND tags.deleted_at IS NULL
+GROUP BY tags.id
+ORDER BY tags.id
+LIMIT $2
+`
+
+type GetUpcomingTasksGroupedByTagParams struct {
+	UserID string             `db:"user_id"`
+	Limit  int32              `db:"limit"`
+	Today  pgtype.Timestamptz `db:"today"`
+	Cursor string             `db:"cursor"`
+}
+
+type GetUpcomingTasksGroupedByTagRow struct {
+	TagID    string `db:"tag_id"`
+	TagTitle string `db:"tag_title"`
+	Tasks    []byte `db:"tasks"`
+}
+
+func (q *Queries) GetUpcomingTasksGroupedByTag(ctx context.Context, arg GetUpcomingTasksGroupedByTagParams) ([]GetUpcomingTasksGroupedByTagRow, error) {
+	rows, err := q.db.Query(ctx, getUpcomingTasksGroupedByTag,
+		arg.UserID,
+		arg.Limit,
+		arg.Today,
+		arg.Cursor,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUpcomingTasksGroupedByTagRow{}
+	for rows.Next() {
+		var i GetUpcomingTasksGroupedByTagRow
+		if err := rows.Scan(&i.TagID, &i.TagTitle, &i.Tasks); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const markTaskAsArchived = `-- name: MarkTaskAsArchived :one
 UPDATE tasks
-SET status_id = $1, deleted_at = $2
+SET status_id = $1, custom_status_id = NULL, deleted_at = $2
 WHERE id = $3
   AND user_id = $4
   AND deleted_at IS NULL
@@ -1089,7 +2064,9 @@ func (q *Queries) MarkTaskAsArchived(ctx context.Context, arg MarkTaskAsArchived
 const markTaskAsCompleted = `-- name: MarkTaskAsCompleted :one
 UPDATE tasks
 SET	status_id = $1,
-    updated_at = $2
+    custom_status_id = NULL,
+    updated_at = $2,
+    completed_at = $2
 WHERE id = $3
   AND user_id = $4
   AND deleted_at IS NULL
@@ -1148,6 +2125,9 @@ const moveTaskToAnotherList = `-- name: MoveTaskToAnotherList :one
 UPDATE tasks
 SET	list_id = $1,
     heading_id = $2,
+    custom_status_id = CASE
+        WHEN custom_status_id IN (SELECT id FROM statuses WHERE list_id != $1) THEN NULL
+        ELSE custom_status_id END,
     updated_at = $3
 WHERE id = $4
   AND user_id = $5
@@ -1175,3 +2155,139 @@ func (q *Queries) MoveTaskToAnotherList(ctx context.Context, arg MoveTaskToAnoth
 	err := row.Scan(&id)
 	return id, err
 }
+
+const reopenTasksByHeadingID = `-- name: ReopenTasksByHeadingID :exec
+UPDATE tasks
+SET status_id = previous_status_id,
+    custom_status_id = previous_custom_status_id,
+    previous_status_id = NULL,
+    previous_custom_status_id = NULL,
+    updated_at = $1,
+    deleted_at = NULL,
+    completed_at = NULL
+WHERE heading_id = $2
+  AND user_id = $3
+  AND previous_status_id IS NOT NULL
+`
+
+type ReopenTasksByHeadingIDParams struct {
+	UpdatedAt time.Time `db:"updated_at"`
+	HeadingID string    `db:"heading_id"`
+	UserID    string    `db:"user_id"`
+}
+
+func (q *Queries) ReopenTasksByHeadingID(ctx context.Context, arg ReopenTasksByHeadingIDParams) error {
+	_, err := q.db.Exec(ctx, reopenTasksByHeadingID, arg.UpdatedAt, arg.HeadingID, arg.UserID)
+	return err
+}
+
+const updateTaskStatus = `-- name: UpdateTaskStatus :one
+UPDATE tasks
+SET status_id = $1,
+    custom_status_id = $2,
+    updated_at = $3,
+    completed_at = $6
+WHERE id = $4
+  AND user_id = $5
+  AND deleted_at IS NULL
+RETURNING id
+`
+
+type UpdateTaskStatusParams struct {
+	StatusID       int32              `db:"status_id"`
+	CustomStatusID pgtype.Int4        `db:"custom_status_id"`
+	UpdatedAt      time.Time          `db:"updated_at"`
+	ID             string             `db:"id"`
+	UserID         string             `db:"user_id"`
+	CompletedAt    pgtype.Timestamptz `db:"completed_at"`
+}
+
+func (q *Queries) UpdateTaskStatus(ctx context.Context, arg UpdateTaskStatusParams) (string, error) {
+	row := q.db.QueryRow(ctx, updateTaskStatus,
+		arg.StatusID,
+		arg.CustomStatusID,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.UserID,
+		arg.CompletedAt,
+	)
+	var id string
+	err := row.Scan(&id)
+	return id, err
+}
+
+const updateTasksPosition = `-- name: UpdateTasksPosition :exec
+UPDATE tasks
+SET position = s.position
+FROM UNNEST($1::varchar[]) WITH ORDINALITY AS s(task_id, position)
+WHERE tasks.id = s.task_id
+  AND tasks.user_id = $2
+  AND tasks.deleted_at IS NULL
+`
+
+type UpdateTasksPositionParams struct {
+	TaskIds []string `db:"task_ids"`
+	UserID  string   `db:"user_id"`
+}
+
+func (q *Queries) UpdateTasksPosition(ctx context.Context, arg UpdateTasksPositionParams) error {
+	_, err := q.db.Exec(ctx, updateTasksPosition, arg.TaskIds, arg.UserID)
+	return err
+}
+
+const updateTasksTime = `-- name: UpdateTasksTime :many
+UPDATE tasks
+SET start_time = s.start_time,
+    end_time = s.end_time,
+    status_id = CASE WHEN tasks.custom_status_id IS NULL THEN $1::int ELSE tasks.status_id END,
+    completed_at = CASE WHEN tasks.custom_status_id IS NULL THEN NULL ELSE tasks.completed_at END,
+    updated_at = $2
+FROM UNNEST($3::varchar[], $4::timestamptz[], $5::timestamptz[]) AS s(task_id, start_time, end_time)
+WHERE tasks.id = s.task_id
+  AND tasks.user_id = $6
+  AND tasks.deleted_at IS NULL
+  AND (
+      SELECT COUNT(*)
+      FROM tasks
+      WHERE id = ANY($3::varchar[])
+        AND user_id = $6
+        AND deleted_at IS NULL
+  ) = CARDINALITY($3::varchar[])
+RETURNING tasks.id
+`
+
+type UpdateTasksTimeParams struct {
+	StatusID   int32       `db:"status_id"`
+	UpdatedAt  time.Time   `db:"updated_at"`
+	TaskIds    []string    `db:"task_ids"`
+	StartTimes []time.Time `db:"start_times"`
+	EndTimes   []time.Time `db:"end_times"`
+	UserID     string      `db:"user_id"`
+}
+
+func (q *Queries) UpdateTasksTime(ctx context.Context, arg UpdateTasksTimeParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, updateTasksTime,
+		arg.StatusID,
+		arg.UpdatedAt,
+		arg.TaskIds,
+		arg.StartTimes,
+		arg.EndTimes,
+		arg.UserID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
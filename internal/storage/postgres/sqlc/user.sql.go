@@ -7,6 +7,7 @@ package sqlc
 
 import (
 	"context"
+	"time"
 )
 
 const deleteUserRelatedData = `-- name: DeleteUserRelatedData :exec
@@ -17,3 +18,47 @@ func (q *Queries) DeleteUserRelatedData(ctx context.Context, deletingUserID stri
 	_, err := q.db.Exec(ctx, deleteUserRelatedData, deletingUserID)
 	return err
 }
+
+const getUserSettings = `-- name: GetUserSettings :one
+SELECT user_id, time_zone, updated_at
+FROM user_settings
+WHERE user_id = $1
+`
+
+type GetUserSettingsRow struct {
+	UserID    string    `db:"user_id"`
+	TimeZone  string    `db:"time_zone"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (q *Queries) GetUserSettings(ctx context.Context, userID string) (GetUserSettingsRow, error) {
+	row := q.db.QueryRow(ctx, getUserSettings, userID)
+	var i GetUserSettingsRow
+	err := row.Scan(&i.UserID, &i.TimeZone, &i.UpdatedAt)
+	return i, err
+}
+
+const upsertUserSettings = `-- name: UpsertUserSettings :exec
+INSERT INTO user_settings (user_id, time_zone, created_at, updated_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id) DO UPDATE
+SET time_zone = EXCLUDED.time_zone,
+    updated_at = EXCLUDED.updated_at
+`
+
+type UpsertUserSettingsParams struct {
+	UserID    string    `db:"user_id"`
+	TimeZone  string    `db:"time_zone"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (q *Queries) UpsertUserSettings(ctx context.Context, arg UpsertUserSettingsParams) error {
+	_, err := q.db.Exec(ctx, upsertUserSettings,
+		arg.UserID,
+		arg.TimeZone,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
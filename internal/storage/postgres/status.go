@@ -6,30 +6,72 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rshelekhov/reframed/internal/lib/constant/le"
 	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
 	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
 )
 
 type StatusStorage struct {
-	*pgxpool.Pool
+	db dbtx
 	*sqlc.Queries
 }
 
 func NewStatusStorage(pool *pgxpool.Pool) *StatusStorage {
 	return &StatusStorage{
-		Pool:    pool,
+		db:      pool,
 		Queries: sqlc.New(pool),
 	}
 }
 
-func (s *StatusStorage) GetStatuses(ctx context.Context) ([]model.Status, error) {
+func (s *StatusStorage) Transaction(ctx context.Context, fn func(storage port.StatusStorage) error) error {
+	return transaction(ctx, s.db, func(tx pgx.Tx) error {
+		return fn(&StatusStorage{
+			db:      tx,
+			Queries: sqlc.New(tx),
+		})
+	})
+}
+
+func (s *StatusStorage) CreateStatus(ctx context.Context, status model.Status) (int32, error) {
+	const op = "status.storage.CreateStatus"
+
+	statusID, err := s.Queries.CreateStatus(ctx, sqlc.CreateStatusParams{
+		Title:      status.Title,
+		CategoryID: status.CategoryID,
+		UserID: pgtype.Text{
+			String: status.UserID,
+			Valid:  true,
+		},
+		ListID: pgtype.Text{
+			String: status.ListID,
+			Valid:  status.ListID != "",
+		},
+		CreatedAt: status.CreatedAt,
+		UpdatedAt: status.UpdatedAt,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to insert status: %w", op, err)
+	}
+
+	return statusID, nil
+}
+
+// GetStatuses returns the built-in statuses and the custom statuses of the user available in the list
+func (s *StatusStorage) GetStatuses(ctx context.Context, userID, listID string) ([]model.Status, error) {
 	const op = "status.storage.GetStatuses"
 
-	items, err := s.Queries.GetStatuses(ctx)
+	items, err := s.Queries.GetStatuses(ctx, sqlc.GetStatusesParams{
+		UserID: pgtype.Text{
+			String: userID,
+			Valid:  true,
+		},
+		ListID: listID,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to get tags: %w", op, err)
+		return nil, fmt.Errorf("%s: failed to get statuses: %w", op, err)
 	}
 	if len(items) == 0 {
 		return nil, le.ErrNoStatusesFound
@@ -39,24 +81,175 @@ func (s *StatusStorage) GetStatuses(ctx context.Context) ([]model.Status, error)
 
 	for _, item := range items {
 		statuses = append(statuses, model.Status{
-			ID:    item.ID,
-			Title: item.Title,
+			ID:         item.ID,
+			Title:      item.Title,
+			CategoryID: item.CategoryID,
+			ListID:     item.ListID.String,
 		})
 	}
 
 	return statuses, nil
 }
 
-func (s *StatusStorage) GetStatusByID(ctx context.Context, statusID int32) (string, error) {
-	const op = "status.storage.GetStatusName"
+func (s *StatusStorage) GetStatusByID(ctx context.Context, statusID int32, userID string) (model.Status, error) {
+	const op = "status.storage.GetStatusByID"
+
+	item, err := s.Queries.GetStatusByID(ctx, sqlc.GetStatusByIDParams{
+		ID: statusID,
+		UserID: pgtype.Text{
+			String: userID,
+			Valid:  true,
+		},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Status{}, le.ErrStatusNotFound
+	}
+	if err != nil {
+		return model.Status{}, fmt.Errorf("%s: failed to get status: %w", op, err)
+	}
+
+	return model.Status{
+		ID:         item.ID,
+		Title:      item.Title,
+		CategoryID: item.CategoryID,
+		ListID:     item.ListID.String,
+	}, nil
+}
+
+func (s *StatusStorage) UpdateStatus(ctx context.Context, status model.Status) error {
+	const op = "status.storage.UpdateStatus"
 
-	statusName, err := s.Queries.GetStatusByID(ctx, statusID)
+	_, err := s.Queries.UpdateStatus(ctx, sqlc.UpdateStatusParams{
+		Title:     status.Title,
+		UpdatedAt: status.UpdatedAt,
+		ID:        status.ID,
+		UserID: pgtype.Text{
+			String: status.UserID,
+			Valid:  true,
+		},
+	})
 	if errors.Is(err, pgx.ErrNoRows) {
-		return "", le.ErrStatusNotFound
+		return le.ErrStatusNotFound
 	}
 	if err != nil {
-		return "", fmt.Errorf("%s: failed to get statusName: %w", op, err)
+		return fmt.Errorf("%s: failed to update status: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *StatusStorage) DeleteStatus(ctx context.Context, status model.Status) error {
+	const op = "status.storage.DeleteStatus"
+
+	_, err := s.Queries.DeleteStatus(ctx, sqlc.DeleteStatusParams{
+		DeletedAt: pgtype.Timestamptz{
+			Time:  status.DeletedAt,
+			Valid: true,
+		},
+		ID: status.ID,
+		UserID: pgtype.Text{
+			String: status.UserID,
+			Valid:  true,
+		},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrStatusNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete status: %w", op, err)
+	}
+
+	return nil
+}
+
+// ResetTasksCustomStatus leaves the tasks of the custom status in its category
+func (s *StatusStorage) ResetTasksCustomStatus(ctx context.Context, status model.Status) error {
+	const op = "status.storage.ResetTasksCustomStatus"
+
+	if err := s.Queries.ResetTasksCustomStatus(ctx, sqlc.ResetTasksCustomStatusParams{
+		UpdatedAt: status.DeletedAt,
+		CustomStatusID: pgtype.Int4{
+			Int32: status.ID,
+			Valid: true,
+		},
+		UserID: status.UserID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to reset custom status of tasks: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetStatusTransitions returns the transitions of the user for all lists and for the list
+func (s *StatusStorage) GetStatusTransitions(ctx context.Context, userID, listID string) ([]model.StatusTransition, error) {
+	const op = "status.storage.GetStatusTransitions"
+
+	items, err := s.Queries.GetStatusTransitions(ctx, sqlc.GetStatusTransitionsParams{
+		UserID: userID,
+		ListID: listID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get status transitions: %w", op, err)
+	}
+
+	var transitions []model.StatusTransition
+
+	for _, item := range items {
+		transitions = append(transitions, model.StatusTransition{
+			FromStatusID: item.FromStatusID,
+			ToStatusID:   item.ToStatusID,
+			ListID:       item.ListID.String,
+		})
+	}
+
+	return transitions, nil
+}
+
+func (s *StatusStorage) CreateStatusTransitions(ctx context.Context, userID, listID string, transitions []model.StatusTransition) error {
+	const op = "status.storage.CreateStatusTransitions"
+
+	fromStatusIDs := make([]int32, 0, len(transitions))
+	toStatusIDs := make([]int32, 0, len(transitions))
+
+	for _, transition := range transitions {
+		fromStatusIDs = append(fromStatusIDs, transition.FromStatusID)
+		toStatusIDs = append(toStatusIDs, transition.ToStatusID)
+	}
+
+	if err := s.Queries.CreateStatusTransitions(ctx, sqlc.CreateStatusTransitionsParams{
+		UserID:        userID,
+		ListID:        listID,
+		FromStatusIds: fromStatusIDs,
+		ToStatusIds:   toStatusIDs,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to insert status transitions: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *StatusStorage) DeleteStatusTransitions(ctx context.Context, userID, listID string) error {
+	const op = "status.storage.DeleteStatusTransitions"
+
+	if err := s.Queries.DeleteStatusTransitions(ctx, sqlc.DeleteStatusTransitionsParams{
+		UserID: userID,
+		ListID: listID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to delete status transitions: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *StatusStorage) DeleteStatusTransitionsByStatusID(ctx context.Context, statusID int32, userID string) error {
+	const op = "status.storage.DeleteStatusTransitionsByStatusID"
+
+	if err := s.Queries.DeleteStatusTransitionsByStatusID(ctx, sqlc.DeleteStatusTransitionsByStatusIDParams{
+		UserID:   userID,
+		StatusID: statusID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to delete status transitions: %w", op, err)
 	}
 
-	return statusName, nil
+	return nil
 }
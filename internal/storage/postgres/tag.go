@@ -4,33 +4,53 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/rshelekhov/reframed/internal/lib/constant/le"
 	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
 	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
 )
 
 type TagStorage struct {
-	*pgxpool.Pool
+	db dbtx
 	*sqlc.Queries
 }
 
-func NewTagStorage(pool *pgxpool.Pool) *TagStorage {
+func NewTagStorage(pool *pgxpool.Pool) port.TagStorage {
 	return &TagStorage{
-		Pool:    pool,
+		db:      pool,
 		Queries: sqlc.New(pool),
 	}
 }
 
+func (s *TagStorage) Transaction(ctx context.Context, fn func(storage port.TagStorage) error) error {
+	return transaction(ctx, s.db, func(tx pgx.Tx) error {
+		return fn(&TagStorage{
+			db:      tx,
+			Queries: sqlc.New(tx),
+		})
+	})
+}
+
 func (s *TagStorage) CreateTag(ctx context.Context, tag model.Tag) error {
 	const op = "tag.storage.CreateTag"
 
 	if err := s.Queries.CreateTag(ctx, sqlc.CreateTagParams{
-		ID:        tag.ID,
-		Title:     tag.Title,
+		ID:    tag.ID,
+		Title: tag.Title,
+		ParentID: pgtype.Text{
+			String: tag.ParentID,
+			Valid:  tag.ParentID != "",
+		},
+		Color: pgtype.Text{
+			String: tag.Color,
+			Valid:  tag.Color != "",
+		},
 		UserID:    tag.UserID,
 		CreatedAt: tag.CreatedAt,
 		UpdatedAt: tag.UpdatedAt,
@@ -70,6 +90,29 @@ func (s *TagStorage) UnlinkTagsFromTask(ctx context.Context, userID, taskID stri
 	return nil
 }
 
+// UnlinkTagFromAllTasks removes the tag from all tasks it's linked to
+func (s *TagStorage) UnlinkTagFromAllTasks(ctx context.Context, tagID string) error {
+	const op = "tag.storage.UnlinkTagFromAllTasks"
+
+	if err := s.Queries.UnlinkTagFromAllTasks(ctx, tagID); err != nil {
+		return fmt.Errorf("%s: failed to unlink tag from tasks: %w", op, err)
+	}
+	return nil
+}
+
+// CopyTaskLinksToTag links the target tag to all tasks of the source tag
+func (s *TagStorage) CopyTaskLinksToTag(ctx context.Context, sourceTagID, targetTagID string) error {
+	const op = "tag.storage.CopyTaskLinksToTag"
+
+	if err := s.Queries.CopyTaskLinksToTag(ctx, sqlc.CopyTaskLinksToTagParams{
+		SourceTagID: sourceTagID,
+		TargetTagID: targetTagID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to copy task links to tag: %w", op, err)
+	}
+	return nil
+}
+
 func (s *TagStorage) GetTagIDByTitle(ctx context.Context, title, userID string) (string, error) {
 	const op = "tag.storage.GetTagByTitle"
 
@@ -87,6 +130,30 @@ func (s *TagStorage) GetTagIDByTitle(ctx context.Context, title, userID string)
 	return tagID, nil
 }
 
+func (s *TagStorage) GetTagByID(ctx context.Context, tagID, userID string) (model.Tag, error) {
+	const op = "tag.storage.GetTagByID"
+
+	tag, err := s.Queries.GetTagByID(ctx, sqlc.GetTagByIDParams{
+		ID:     tagID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Tag{}, le.ErrTagNotFound
+	}
+	if err != nil {
+		return model.Tag{}, fmt.Errorf("%s: failed to get tag: %w", op, err)
+	}
+
+	return model.Tag{
+		ID:         tag.ID,
+		Title:      tag.Title,
+		ParentID:   tag.ParentID.String,
+		Color:      tag.Color.String,
+		TasksCount: int(tag.TasksCount),
+		UpdatedAt:  tag.UpdatedAt,
+	}, nil
+}
+
 func (s *TagStorage) GetTagsByUserID(ctx context.Context, userID string) ([]model.Tag, error) {
 	const op = "tag.storage.GetTagsByUserID"
 
@@ -102,9 +169,12 @@ func (s *TagStorage) GetTagsByUserID(ctx context.Context, userID string) ([]mode
 
 	for _, item := range items {
 		tags = append(tags, model.Tag{
-			ID:        item.ID,
-			Title:     item.Title,
-			UpdatedAt: item.UpdatedAt,
+			ID:         item.ID,
+			Title:      item.Title,
+			ParentID:   item.ParentID.String,
+			Color:      item.Color.String,
+			TasksCount: int(item.TasksCount),
+			UpdatedAt:  item.UpdatedAt,
 		})
 	}
 	return tags, nil
@@ -133,3 +203,125 @@ func (s *TagStorage) GetTagsByTaskID(ctx context.Context, taskID string) ([]mode
 	}
 	return tagsTitles, nil
 }
+
+// GetTagSubtreeIDs returns the ids of the tag and all its descendants
+func (s *TagStorage) GetTagSubtreeIDs(ctx context.Context, tagID, userID string) ([]string, error) {
+	const op = "tag.storage.GetTagSubtreeIDs"
+
+	tagIDs, err := s.Queries.GetTagSubtreeIDs(ctx, sqlc.GetTagSubtreeIDsParams{
+		ID:     tagID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get tag subtree: %w", op, err)
+	}
+	if len(tagIDs) == 0 {
+		return nil, le.ErrTagNotFound
+	}
+
+	return tagIDs, nil
+}
+
+func (s *TagStorage) GetTagsByParentID(ctx context.Context, parentID, userID string) ([]model.Tag, error) {
+	const op = "tag.storage.GetTagsByParentID"
+
+	items, err := s.Queries.GetTagsByParentID(ctx, sqlc.GetTagsByParentIDParams{
+		ParentID: pgtype.Text{
+			String: parentID,
+			Valid:  true,
+		},
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get child tags: %w", op, err)
+	}
+
+	var tags []model.Tag
+
+	for _, item := range items {
+		tags = append(tags, model.Tag{
+			ID:        item.ID,
+			Title:     item.Title,
+			ParentID:  item.ParentID.String,
+			UserID:    userID,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+	return tags, nil
+}
+
+func (s *TagStorage) UpdateTag(ctx context.Context, tag model.Tag) error {
+	const op = "tag.storage.UpdateTag"
+
+	_, err := s.Queries.UpdateTag(ctx, sqlc.UpdateTagParams{
+		Title:     tag.Title,
+		UpdatedAt: tag.UpdatedAt,
+		ID:        tag.ID,
+		UserID:    tag.UserID,
+		Color: pgtype.Text{
+			String: tag.Color,
+			Valid:  tag.Color != "",
+		},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrTagNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to update tag: %w", op, err)
+	}
+	return nil
+}
+
+// UpdateTagParent moves the tag under another parent, an empty ParentID makes it a top-level tag
+func (s *TagStorage) UpdateTagParent(ctx context.Context, tag model.Tag) error {
+	const op = "tag.storage.UpdateTagParent"
+
+	if err := s.Queries.UpdateTagParent(ctx, sqlc.UpdateTagParentParams{
+		ParentID: pgtype.Text{
+			String: tag.ParentID,
+			Valid:  tag.ParentID != "",
+		},
+		UpdatedAt: tag.UpdatedAt,
+		ID:        tag.ID,
+		UserID:    tag.UserID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to update tag parent: %w", op, err)
+	}
+	return nil
+}
+
+// RenameTagSubtree replaces the old title at the beginning of the tags titles with the new one
+func (s *TagStorage) RenameTagSubtree(ctx context.Context, userID string, tagIDs []string, oldTitle, newTitle string, updatedAt time.Time) error {
+	const op = "tag.storage.RenameTagSubtree"
+
+	if err := s.Queries.RenameTagSubtree(ctx, sqlc.RenameTagSubtreeParams{
+		UpdatedAt: updatedAt,
+		UserID:    userID,
+		NewTitle:  newTitle,
+		OldTitle:  oldTitle,
+		TagIds:    tagIDs,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to rename tags: %w", op, err)
+	}
+	return nil
+}
+
+func (s *TagStorage) DeleteTag(ctx context.Context, tag model.Tag) error {
+	const op = "tag.storage.DeleteTag"
+
+	_, err := s.Queries.DeleteTag(ctx, sqlc.DeleteTagParams{
+		ID:     tag.ID,
+		UserID: tag.UserID,
+		DeletedAt: pgtype.Timestamptz{
+			Time:  tag.DeletedAt,
+			Valid: true,
+		},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrTagNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete tag: %w", op, err)
+	}
+	return nil
+}
@@ -18,34 +18,24 @@ import (
 )
 
 type TaskStorage struct {
-	*pgxpool.Pool
+	db dbtx
 	*sqlc.Queries
 }
 
 func NewTaskStorage(pool *pgxpool.Pool) port.TaskStorage {
 	return &TaskStorage{
-		Pool:    pool,
+		db:      pool,
 		Queries: sqlc.New(pool),
 	}
 }
 
 func (s *TaskStorage) Transaction(ctx context.Context, fn func(storage port.TaskStorage) error) error {
-	tx, err := s.Pool.Begin(ctx)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			if rbErr := tx.Rollback(ctx); rbErr != nil {
-				err = fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
-			}
-		} else {
-			err = tx.Commit(ctx)
-		}
-	}()
-
-	return fn(s)
+	return transaction(ctx, s.db, func(tx pgx.Tx) error {
+		return fn(&TaskStorage{
+			db:      tx,
+			Queries: sqlc.New(tx),
+		})
+	})
 }
 
 func (s *TaskStorage) CreateTask(ctx context.Context, task model.Task) error {
@@ -92,6 +82,12 @@ func (s *TaskStorage) CreateTask(ctx context.Context, task model.Task) error {
 			Valid: true,
 		}
 	}
+	if task.EstimateMinutes > 0 {
+		taskParams.EstimateMinutes = pgtype.Int4{
+			Int32: int32(task.EstimateMinutes),
+			Valid: true,
+		}
+	}
 
 	if err := s.Queries.CreateTask(ctx, taskParams); err != nil {
 		return fmt.Errorf("%s: failed to insert new task: %w", op, err)
@@ -114,12 +110,16 @@ func (s *TaskStorage) GetTaskStatusID(ctx context.Context, status model.StatusNa
 	}
 }
 
-func (s *TaskStorage) GetTaskByID(ctx context.Context, taskID, userID string) (model.Task, error) {
+func (s *TaskStorage) GetTaskByID(ctx context.Context, taskID, userID string, today time.Time) (model.Task, error) {
 	const op = "task.storage.GetTaskByID"
 
 	task, err := s.Queries.GetTaskByID(ctx, sqlc.GetTaskByIDParams{
 		ID:     taskID,
 		UserID: userID,
+		Today: pgtype.Timestamptz{
+			Valid: true,
+			Time:  today,
+		},
 	})
 
 	switch {
@@ -130,13 +130,17 @@ func (s *TaskStorage) GetTaskByID(ctx context.Context, taskID, userID string) (m
 	}
 
 	taskResp := model.Task{
-		ID:        task.ID,
-		Title:     task.Title,
-		StatusID:  int(task.StatusID),
-		ListID:    task.ListID,
-		HeadingID: task.HeadingID,
-		UpdatedAt: task.UpdatedAt,
-		Overdue:   task.Overdue,
+		ID:             task.ID,
+		Title:          task.Title,
+		StatusID:       int(task.StatusID),
+		ListID:         task.ListID,
+		HeadingID:      task.HeadingID,
+		TrackedMinutes: int(task.TrackedMinutes),
+		UpdatedAt:      task.UpdatedAt,
+		Overdue:        task.Overdue,
+	}
+	if task.CustomStatusID.Valid {
+		taskResp.CustomStatusID = int(task.CustomStatusID.Int32)
 	}
 	if task.Description.Valid {
 		taskResp.Description = task.Description.String
@@ -153,6 +157,9 @@ func (s *TaskStorage) GetTaskByID(ctx context.Context, taskID, userID string) (m
 	if task.EndTime.Valid {
 		taskResp.EndTime = task.EndTime.Time
 	}
+	if task.EstimateMinutes.Valid {
+		taskResp.EstimateMinutes = int(task.EstimateMinutes.Int32)
+	}
 
 	if task.Tags != nil {
 		tagsArray, ok := task.Tags.([]interface{})
@@ -172,13 +179,17 @@ func (s *TaskStorage) GetTaskByID(ctx context.Context, taskID, userID string) (m
 	return taskResp, nil
 }
 
-func (s *TaskStorage) GetTasksByUserID(ctx context.Context, userID string, pgn model.Pagination) ([]model.Task, error) {
+func (s *TaskStorage) GetTasksByUserID(ctx context.Context, userID string, pgn model.Pagination, today time.Time) ([]model.Task, error) {
 	const op = "task.storage.GetTasksByUserID"
 
 	tasksRaw, err := s.Queries.GetTasksByUserID(ctx, sqlc.GetTasksByUserIDParams{
 		UserID: userID,
 		Cursor: pgn.Cursor,
 		Limit:  pgn.Limit,
+		Today: pgtype.Timestamptz{
+			Valid: true,
+			Time:  today,
+		},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to get tasks: %w", op, err)
@@ -201,12 +212,16 @@ func (s *TaskStorage) GetTasksByUserID(ctx context.Context, userID string, pgn m
 	return tasksResp, nil
 }
 
-func (s *TaskStorage) GetTasksByListID(ctx context.Context, listID, userID string) ([]model.Task, error) {
+func (s *TaskStorage) GetTasksByListID(ctx context.Context, listID, userID string, today time.Time) ([]model.Task, error) {
 	const op = "task.storage.GetTasksByListID"
 
 	tasksRaw, err := s.Queries.GetTasksByListID(ctx, sqlc.GetTasksByListIDParams{
 		ListID: listID,
 		UserID: userID,
+		Today: pgtype.Timestamptz{
+			Valid: true,
+			Time:  today,
+		},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to get tasks: %w", op, err)
@@ -257,13 +272,14 @@ func transformTask(task interface{}) (model.Task, error) {
 
 func transformGetTasksByUserIDRow(task sqlc.GetTasksByUserIDRow) (model.Task, error) {
 	t := model.Task{
-		ID:        task.ID,
-		Title:     task.Title,
-		StatusID:  int(task.StatusID),
-		ListID:    task.ListID,
-		HeadingID: task.HeadingID,
-		UpdatedAt: task.UpdatedAt,
-		Overdue:   task.Overdue,
+		ID:             task.ID,
+		Title:          task.Title,
+		StatusID:       int(task.StatusID),
+		ListID:         task.ListID,
+		HeadingID:      task.HeadingID,
+		TrackedMinutes: int(task.TrackedMinutes),
+		UpdatedAt:      task.UpdatedAt,
+		Overdue:        task.Overdue,
 	}
 
 	if task.Description.Valid {
@@ -281,6 +297,9 @@ func transformGetTasksByUserIDRow(task sqlc.GetTasksByUserIDRow) (model.Task, er
 	if task.EndTime.Valid {
 		t.EndTime = task.EndTime.Time
 	}
+	if task.EstimateMinutes.Valid {
+		t.EstimateMinutes = int(task.EstimateMinutes.Int32)
+	}
 
 	if task.Tags != nil {
 		tags, err := transformTags(task.Tags)
@@ -296,13 +315,14 @@ func transformGetTasksByUserIDRow(task sqlc.GetTasksByUserIDRow) (model.Task, er
 
 func transformGetTasksByListIDRow(task sqlc.GetTasksByListIDRow) (model.Task, error) {
 	t := model.Task{
-		ID:        task.ID,
-		Title:     task.Title,
-		StatusID:  int(task.StatusID),
-		ListID:    task.ListID,
-		HeadingID: task.HeadingID,
-		UpdatedAt: task.UpdatedAt,
-		Overdue:   task.Overdue,
+		ID:             task.ID,
+		Title:          task.Title,
+		StatusID:       int(task.StatusID),
+		ListID:         task.ListID,
+		HeadingID:      task.HeadingID,
+		TrackedMinutes: int(task.TrackedMinutes),
+		UpdatedAt:      task.UpdatedAt,
+		Overdue:        task.Overdue,
 	}
 
 	if task.Description.Valid {
@@ -320,6 +340,9 @@ func transformGetTasksByListIDRow(task sqlc.GetTasksByListIDRow) (model.Task, er
 	if task.EndTime.Valid {
 		t.EndTime = task.EndTime.Time
 	}
+	if task.EstimateMinutes.Valid {
+		t.EstimateMinutes = int(task.EstimateMinutes.Int32)
+	}
 
 	if task.Tags != nil {
 		tags, err := transformTags(task.Tags)
@@ -349,12 +372,51 @@ func transformTags(tags interface{}) ([]string, error) {
 	return transformedTags, nil
 }
 
-func (s *TaskStorage) GetTasksGroupedByHeadings(ctx context.Context, listID, userID string) ([]model.TaskGroupRaw, error) {
+// GetTasksByTagID returns the tasks with the tag or any of its child tags, grouped by list
+func (s *TaskStorage) GetTasksByTagID(ctx context.Context, tagID, userID string, pgn model.Pagination, today time.Time) ([]model.TaskGroupRaw, error) {
+	const op = "task.storage.GetTasksByTagID"
+
+	groups, err := s.Queries.GetTasksByTagID(ctx, sqlc.GetTasksByTagIDParams{
+		ID:     tagID,
+		UserID: userID,
+		Limit:  pgn.Limit,
+		Today: pgtype.Timestamptz{
+			Valid: true,
+			Time:  today,
+		},
+		Cursor: pgn.Cursor,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get tasks groups: %w", op, err)
+	}
+	if len(groups) == 0 {
+		return nil, le.ErrNoTasksFound
+	}
+
+	var groupsRaw []model.TaskGroupRaw
+
+	for _, group := range groups {
+		var taskGroup model.TaskGroupRaw
+
+		taskGroup.ListID = group.ListID
+		taskGroup.Tasks = group.Tasks
+
+		groupsRaw = append(groupsRaw, taskGroup)
+	}
+
+	return groupsRaw, nil
+}
+
+func (s *TaskStorage) GetTasksGroupedByHeadings(ctx context.Context, listID, userID string, today time.Time) ([]model.TaskGroupRaw, error) {
 	const op = "task.storage.GetTasksGroupedByHeading"
 
 	groups, err := s.Queries.GetTasksGroupedByHeading(ctx, sqlc.GetTasksGroupedByHeadingParams{
 		ListID: listID,
 		UserID: userID,
+		Today: pgtype.Timestamptz{
+			Valid: true,
+			Time:  today,
+		},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to get tasks groups: %w", op, err)
@@ -377,10 +439,79 @@ func (s *TaskStorage) GetTasksGroupedByHeadings(ctx context.Context, listID, use
 	return groupsRaw, nil
 }
 
-func (s *TaskStorage) GetTasksForToday(ctx context.Context, userID string) ([]model.TaskGroupRaw, error) {
+// GetTasksGroupedByStatus groups the tasks of the list by the columns of the board,
+// a task with a custom status is in the column of the custom status
+func (s *TaskStorage) GetTasksGroupedByStatus(ctx context.Context, listID, userID string, today time.Time) ([]model.TaskGroupRaw, error) {
+	const op = "task.storage.GetTasksGroupedByStatus"
+
+	groups, err := s.Queries.GetTasksGroupedByStatus(ctx, sqlc.GetTasksGroupedByStatusParams{
+		ListID: listID,
+		UserID: userID,
+		Today: pgtype.Timestamptz{
+			Valid: true,
+			Time:  today,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get tasks groups: %w", op, err)
+	}
+	if len(groups) == 0 {
+		return nil, le.ErrNoTasksFound
+	}
+
+	var groupsRaw []model.TaskGroupRaw
+
+	for _, group := range groups {
+		var taskGroup model.TaskGroupRaw
+
+		taskGroup.StatusID = int(group.StatusID)
+		taskGroup.Tasks = group.Tasks
+
+		groupsRaw = append(groupsRaw, taskGroup)
+	}
+
+	return groupsRaw, nil
+}
+
+func (s *TaskStorage) GetTaskIDsByBoardColumn(ctx context.Context, listID, userID string, statusID int) ([]string, error) {
+	const op = "task.storage.GetTaskIDsByBoardColumn"
+
+	taskIDs, err := s.Queries.GetTaskIDsByBoardColumn(ctx, sqlc.GetTaskIDsByBoardColumnParams{
+		ListID:   listID,
+		UserID:   userID,
+		StatusID: int32(statusID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get tasks of board column: %w", op, err)
+	}
+
+	return taskIDs, nil
+}
+
+// UpdateTasksPosition sets the position of the tasks to their order in taskIDs
+func (s *TaskStorage) UpdateTasksPosition(ctx context.Context, userID string, taskIDs []string) error {
+	const op = "task.storage.UpdateTasksPosition"
+
+	if err := s.Queries.UpdateTasksPosition(ctx, sqlc.UpdateTasksPositionParams{
+		TaskIds: taskIDs,
+		UserID:  userID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to update tasks position: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *TaskStorage) GetTasksForToday(ctx context.Context, userID string, today time.Time) ([]model.TaskGroupRaw, error) {
 	const op = "task.storage.GetTasksForToday"
 
-	groups, err := s.Queries.GetTasksForToday(ctx, userID)
+	groups, err := s.Queries.GetTasksForToday(ctx, sqlc.GetTasksForTodayParams{
+		UserID: userID,
+		Today: pgtype.Timestamptz{
+			Valid: true,
+			Time:  today,
+		},
+	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to get tasks groups: %w", op, err)
 	}
@@ -402,15 +533,52 @@ func (s *TaskStorage) GetTasksForToday(ctx context.Context, userID string) ([]mo
 	return groupsRaw, nil
 }
 
-func (s *TaskStorage) GetUpcomingTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroupRaw, error) {
+// GetTasksForTodayGroupedByTag returns the tasks for today with tags, grouped by tag
+func (s *TaskStorage) GetTasksForTodayGroupedByTag(ctx context.Context, userID string, today time.Time) ([]model.TaskGroupRaw, error) {
+	const op = "task.storage.GetTasksForTodayGroupedByTag"
+
+	groups, err := s.Queries.GetTasksForTodayGroupedByTag(ctx, sqlc.GetTasksForTodayGroupedByTagParams{
+		UserID: userID,
+		Today: pgtype.Timestamptz{
+			Valid: true,
+			Time:  today,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get tasks groups: %w", op, err)
+	}
+	if len(groups) == 0 {
+		return nil, le.ErrNoTasksFound
+	}
+
+	var groupsRaw []model.TaskGroupRaw
+
+	for _, group := range groups {
+		var taskGroup model.TaskGroupRaw
+
+		taskGroup.TagID = group.TagID
+		taskGroup.TagTitle = group.TagTitle
+		taskGroup.Tasks = group.Tasks
+
+		groupsRaw = append(groupsRaw, taskGroup)
+	}
+
+	return groupsRaw, nil
+}
+
+func (s *TaskStorage) GetUpcomingTasks(ctx context.Context, userID string, pgn model.Pagination, today time.Time) ([]model.TaskGroupRaw, error) {
 	const op = "task.storage.GetUpcomingTasks"
 
 	groups, err := s.Queries.GetUpcomingTasks(ctx, sqlc.GetUpcomingTasksParams{
 		UserID: userID,
 		AfterDate: pgtype.Timestamptz{
-			Valid: true,
+			Valid: !pgn.CursorDate.IsZero(),
 			Time:  pgn.CursorDate,
 		},
+		Today: pgtype.Timestamptz{
+			Valid: true,
+			Time:  today,
+		},
 		Limit: pgn.Limit,
 	})
 	if err != nil {
@@ -434,12 +602,51 @@ func (s *TaskStorage) GetUpcomingTasks(ctx context.Context, userID string, pgn m
 	return groupsRaw, nil
 }
 
-func (s *TaskStorage) GetOverdueTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroupRaw, error) {
+// GetUpcomingTasksGroupedByTag returns the upcoming tasks with tags, grouped by tag
+func (s *TaskStorage) GetUpcomingTasksGroupedByTag(ctx context.Context, userID string, pgn model.Pagination, today time.Time) ([]model.TaskGroupRaw, error) {
+	const op = "task.storage.GetUpcomingTasksGroupedByTag"
+
+	groups, err := s.Queries.GetUpcomingTasksGroupedByTag(ctx, sqlc.GetUpcomingTasksGroupedByTagParams{
+		UserID: userID,
+		Limit:  pgn.Limit,
+		Today: pgtype.Timestamptz{
+			Valid: true,
+			Time:  today,
+		},
+		Cursor: pgn.Cursor,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get tasks groups: %w", op, err)
+	}
+	if len(groups) == 0 {
+		return nil, le.ErrNoTasksFound
+	}
+
+	var groupsRaw []model.TaskGroupRaw
+
+	for _, group := range groups {
+		var taskGroup model.TaskGroupRaw
+
+		taskGroup.TagID = group.TagID
+		taskGroup.TagTitle = group.TagTitle
+		taskGroup.Tasks = group.Tasks
+
+		groupsRaw = append(groupsRaw, taskGroup)
+	}
+
+	return groupsRaw, nil
+}
+
+func (s *TaskStorage) GetOverdueTasks(ctx context.Context, userID string, pgn model.Pagination, today time.Time) ([]model.TaskGroupRaw, error) {
 	const op = "task.storage.GetOverdueTasks"
 
 	groups, err := s.Queries.GetOverdueTasks(ctx, sqlc.GetOverdueTasksParams{
 		UserID: userID,
 		Limit:  pgn.Limit,
+		Today: pgtype.Timestamptz{
+			Valid: true,
+			Time:  today,
+		},
 		Cursor: pgn.Cursor,
 	})
 	if err != nil {
@@ -492,15 +699,49 @@ func (s *TaskStorage) GetTasksForSomeday(ctx context.Context, userID string, pgn
 	return groupsRaw, nil
 }
 
-func (s *TaskStorage) GetCompletedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroupRaw, error) {
+// GetTasksForSomedayGroupedByTag returns the tasks for someday with tags, grouped by tag
+func (s *TaskStorage) GetTasksForSomedayGroupedByTag(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroupRaw, error) {
+	const op = "task.storage.GetTasksForSomedayGroupedByTag"
+
+	groups, err := s.Queries.GetTasksForSomedayGroupedByTag(ctx, sqlc.GetTasksForSomedayGroupedByTagParams{
+		UserID: userID,
+		Limit:  pgn.Limit,
+		Cursor: pgn.Cursor,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get tasks groups: %w", op, err)
+	}
+	if len(groups) == 0 {
+		return nil, le.ErrNoTasksFound
+	}
+
+	var groupsRaw []model.TaskGroupRaw
+
+	for _, group := range groups {
+		var taskGroup model.TaskGroupRaw
+
+		taskGroup.TagID = group.TagID
+		taskGroup.TagTitle = group.TagTitle
+		taskGroup.Tasks = group.Tasks
+
+		groupsRaw = append(groupsRaw, taskGroup)
+	}
+
+	return groupsRaw, nil
+}
+
+// GetCompletedTasks returns the months before the cursor date, the tasks are grouped by the month
+// they were completed in, the months start in the time zone
+func (s *TaskStorage) GetCompletedTasks(ctx context.Context, userID string, pgn model.Pagination, timeZone string) ([]model.TaskGroupRaw, error) {
 	const op = "task.storage.GetCompletedTasks"
 
 	groups, err := s.Queries.GetCompletedTasks(ctx, sqlc.GetCompletedTasksParams{
 		UserID:      userID,
 		Limit:       pgn.Limit,
+		TimeZone:    timeZone,
 		StatusTitle: model.StatusCompleted.String(),
-		CursorDate: pgtype.Timestamptz{
-			Valid: true,
+		CursorDate: pgtype.Date{
+			Valid: !pgn.CursorDate.IsZero(),
 			Time:  pgn.CursorDate,
 		},
 	})
@@ -528,6 +769,40 @@ func (s *TaskStorage) GetCompletedTasks(ctx context.Context, userID string, pgn
 	return taskGroups, nil
 }
 
+// GetCompletedHeadings returns the headings of the same months as GetCompletedTasks:
+// at most pgn.Limit months before the cursor date
+func (s *TaskStorage) GetCompletedHeadings(ctx context.Context, userID string, pgn model.Pagination, timeZone string) ([]model.CompletedHeadingRaw, error) {
+	const op = "task.storage.GetCompletedHeadings"
+
+	items, err := s.Queries.GetCompletedHeadings(ctx, sqlc.GetCompletedHeadingsParams{
+		UserID:   userID,
+		TimeZone: timeZone,
+		CursorDate: pgtype.Date{
+			Valid: !pgn.CursorDate.IsZero(),
+			Time:  pgn.CursorDate,
+		},
+		MonthLimit: pgn.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get completed headings: %w", op, err)
+	}
+
+	var headings []model.CompletedHeadingRaw
+
+	for _, item := range items {
+		headings = append(headings, model.CompletedHeadingRaw{
+			ID:          item.ID,
+			Title:       item.Title,
+			ListID:      item.ListID,
+			CompletedAt: item.CompletedAt.Time,
+			Month:       item.Month.Time,
+			Tasks:       item.Tasks,
+		})
+	}
+
+	return headings, nil
+}
+
 func (s *TaskStorage) GetArchivedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroupRaw, error) {
 	const op = "task.storage.GetArchivedTasks"
 
@@ -596,6 +871,10 @@ func (s *TaskStorage) UpdateTask(ctx context.Context, task model.Task) error {
 		queryUpdate += ", deadline = $" + strconv.Itoa(len(queryParams)+1)
 		queryParams = append(queryParams, task.Deadline)
 	}
+	if task.EstimateMinutes > 0 {
+		queryUpdate += ", estimate_minutes = $" + strconv.Itoa(len(queryParams)+1)
+		queryParams = append(queryParams, task.EstimateMinutes)
+	}
 
 	// Add condition for the specific user ID
 	queryUpdate += " WHERE id = $" + strconv.Itoa(len(queryParams)+1)
@@ -605,7 +884,7 @@ func (s *TaskStorage) UpdateTask(ctx context.Context, task model.Task) error {
 	queryParams = append(queryParams, task.UserID)
 
 	// Execute the update query
-	result, err := s.Exec(ctx, queryUpdate, queryParams...)
+	result, err := s.db.Exec(ctx, queryUpdate, queryParams...)
 	if err != nil {
 		return fmt.Errorf("%s: failed to update task: %w", op, err)
 	}
@@ -649,7 +928,7 @@ func (s *TaskStorage) UpdateTaskTime(ctx context.Context, task model.Task) error
 	queryParams = append(queryParams, task.UserID)
 
 	// Execute the update query
-	result, err := s.Exec(ctx, queryUpdate, queryParams...)
+	result, err := s.db.Exec(ctx, queryUpdate, queryParams...)
 	if err != nil {
 		return fmt.Errorf("%s: failed to update task time: %w", op, err)
 	}
@@ -661,6 +940,82 @@ func (s *TaskStorage) UpdateTaskTime(ctx context.Context, task model.Task) error
 	return nil
 }
 
+func (s *TaskStorage) GetTasksForSchedule(ctx context.Context, userID string, completedStatusID int, period model.SchedulePeriod) ([]model.Task, error) {
+	const op = "task.storage.GetTasksForSchedule"
+
+	items, err := s.Queries.GetTasksForSchedule(ctx, sqlc.GetTasksForScheduleParams{
+		UserID:   userID,
+		StatusID: int32(completedStatusID),
+		Day:      pgtype.Timestamptz{Time: period.Date, Valid: true},
+		DayStart: pgtype.Timestamptz{Time: period.WorkStart, Valid: true},
+		DayEnd:   pgtype.Timestamptz{Time: period.WorkEnd, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get tasks for schedule: %w", op, err)
+	}
+
+	tasks := make([]model.Task, 0, len(items))
+
+	for _, item := range items {
+		task := model.Task{
+			ID:     item.ID,
+			Title:  item.Title,
+			UserID: userID,
+		}
+		if item.StartDate.Valid {
+			task.StartDate = item.StartDate.Time
+		}
+		if item.Deadline.Valid {
+			task.Deadline = item.Deadline.Time
+		}
+		if item.StartTime.Valid {
+			task.StartTime = item.StartTime.Time
+		}
+		if item.EndTime.Valid {
+			task.EndTime = item.EndTime.Time
+		}
+		if item.EstimateMinutes.Valid {
+			task.EstimateMinutes = int(item.EstimateMinutes.Int32)
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// UpdateTasksTime sets the time of all tasks in one statement, nothing is updated
+// if any of the tasks is not found. StatusID, UserID and UpdatedAt are taken from the first task
+func (s *TaskStorage) UpdateTasksTime(ctx context.Context, tasks []model.Task) error {
+	const op = "task.storage.UpdateTasksTime"
+
+	params := sqlc.UpdateTasksTimeParams{
+		StatusID:   int32(tasks[0].StatusID),
+		UpdatedAt:  tasks[0].UpdatedAt,
+		TaskIds:    make([]string, 0, len(tasks)),
+		StartTimes: make([]time.Time, 0, len(tasks)),
+		EndTimes:   make([]time.Time, 0, len(tasks)),
+		UserID:     tasks[0].UserID,
+	}
+
+	for _, task := range tasks {
+		params.TaskIds = append(params.TaskIds, task.ID)
+		params.StartTimes = append(params.StartTimes, task.StartTime)
+		params.EndTimes = append(params.EndTimes, task.EndTime)
+	}
+
+	updatedIDs, err := s.Queries.UpdateTasksTime(ctx, params)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update tasks time: %w", op, err)
+	}
+
+	if len(updatedIDs) == 0 {
+		return le.ErrTaskNotFound
+	}
+
+	return nil
+}
+
 func (s *TaskStorage) MoveTaskToAnotherList(ctx context.Context, task model.Task) error {
 	const op = "task.storage.MoveTaskToAnotherList"
 
@@ -702,6 +1057,58 @@ func (s *TaskStorage) MoveTaskToAnotherHeading(ctx context.Context, task model.T
 	}
 }
 
+func (s *TaskStorage) GetTaskStatus(ctx context.Context, taskID, userID string) (model.Task, error) {
+	const op = "task.storage.GetTaskStatus"
+
+	status, err := s.Queries.GetTaskStatus(ctx, sqlc.GetTaskStatusParams{
+		ID:     taskID,
+		UserID: userID,
+	})
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return model.Task{}, le.ErrTaskNotFound
+	case err != nil:
+		return model.Task{}, fmt.Errorf("%s: failed to get task status: %w", op, err)
+	}
+
+	return model.Task{
+		ID:             taskID,
+		StatusID:       int(status.StatusID),
+		CustomStatusID: int(status.CustomStatusID.Int32),
+		ListID:         status.ListID,
+		UserID:         userID,
+	}, nil
+}
+
+func (s *TaskStorage) UpdateTaskStatus(ctx context.Context, task model.Task) error {
+	const op = "task.storage.UpdateTaskStatus"
+
+	_, err := s.Queries.UpdateTaskStatus(ctx, sqlc.UpdateTaskStatusParams{
+		StatusID: int32(task.StatusID),
+		CustomStatusID: pgtype.Int4{
+			Int32: int32(task.CustomStatusID),
+			Valid: task.CustomStatusID != 0,
+		},
+		UpdatedAt: task.UpdatedAt,
+		ID:        task.ID,
+		UserID:    task.UserID,
+		CompletedAt: pgtype.Timestamptz{
+			Valid: !task.CompletedAt.IsZero(),
+			Time:  task.CompletedAt,
+		},
+	})
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return le.ErrTaskNotFound
+	case err != nil:
+		return fmt.Errorf("%s: failed to update task status: %w", op, err)
+	default:
+		return nil
+	}
+}
+
 func (s *TaskStorage) MarkAsCompleted(ctx context.Context, task model.Task) error {
 	const op = "task.storage.MarkAsCompleted"
 
@@ -782,3 +1189,46 @@ func (s *TaskStorage) MarkTasksAsArchivedByListID(ctx context.Context, archivedT
 
 	return nil
 }
+
+// MarkTasksAsClosedByHeadingID sets the status of the open tasks of the heading,
+// the previous status is kept to reopen the tasks when the heading is restored
+func (s *TaskStorage) MarkTasksAsClosedByHeadingID(ctx context.Context, closedTasks model.Task, completedStatusID int) error {
+	const op = "task.storage.MarkTasksAsClosedByHeadingID"
+
+	deletedAt := pgtype.Timestamptz{}
+	if !closedTasks.DeletedAt.IsZero() {
+		deletedAt = pgtype.Timestamptz{
+			Valid: true,
+			Time:  closedTasks.DeletedAt,
+		}
+	}
+
+	err := s.Queries.CloseTasksByHeadingID(ctx, sqlc.CloseTasksByHeadingIDParams{
+		StatusID:          int32(closedTasks.StatusID),
+		UpdatedAt:         closedTasks.UpdatedAt,
+		DeletedAt:         deletedAt,
+		HeadingID:         closedTasks.HeadingID,
+		UserID:            closedTasks.UserID,
+		CompletedStatusID: int32(completedStatusID),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to mark tasks as closed by headingID: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *TaskStorage) MarkTasksAsReopenedByHeadingID(ctx context.Context, reopenedTasks model.Task) error {
+	const op = "task.storage.MarkTasksAsReopenedByHeadingID"
+
+	err := s.Queries.ReopenTasksByHeadingID(ctx, sqlc.ReopenTasksByHeadingIDParams{
+		UpdatedAt: reopenedTasks.UpdatedAt,
+		HeadingID: reopenedTasks.HeadingID,
+		UserID:    reopenedTasks.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to mark tasks as reopened by headingID: %w", op, err)
+	}
+
+	return nil
+}
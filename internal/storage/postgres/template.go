@@ -0,0 +1,281 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type TemplateStorage struct {
+	db dbtx
+	*sqlc.Queries
+}
+
+func NewTemplateStorage(pool *pgxpool.Pool) *TemplateStorage {
+	return &TemplateStorage{
+		db:      pool,
+		Queries: sqlc.New(pool),
+	}
+}
+
+func (s *TemplateStorage) Transaction(ctx context.Context, fn func(storage port.TemplateStorage) error) error {
+	return transaction(ctx, s.db, func(tx pgx.Tx) error {
+		return fn(&TemplateStorage{
+			db:      tx,
+			Queries: sqlc.New(tx),
+		})
+	})
+}
+
+// CreateTemplate saves the template with its headings and tasks in the order they are given
+func (s *TemplateStorage) CreateTemplate(ctx context.Context, template model.Template) error {
+	const op = "template.storage.CreateTemplate"
+
+	if err := s.Queries.CreateTemplate(ctx, sqlc.CreateTemplateParams{
+		ID:    template.ID,
+		Title: template.Title,
+		Type:  string(template.Type),
+		Description: pgtype.Text{
+			String: template.Description,
+			Valid:  template.Description != "",
+		},
+		Color: pgtype.Text{
+			String: template.Color,
+			Valid:  template.Color != "",
+		},
+		Icon: pgtype.Text{
+			String: template.Icon,
+			Valid:  template.Icon != "",
+		},
+		DeadlineOffset: offsetToInt4(template.DeadlineOffset),
+		UserID:         template.UserID,
+		CreatedAt:      template.CreatedAt,
+		UpdatedAt:      template.UpdatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create new template: %w", op, err)
+	}
+
+	for i, heading := range template.Headings {
+		if err := s.Queries.CreateTemplateHeading(ctx, sqlc.CreateTemplateHeadingParams{
+			ID:         heading.ID,
+			TemplateID: template.ID,
+			Title:      heading.Title,
+			Position:   int32(i),
+			UserID:     template.UserID,
+		}); err != nil {
+			return fmt.Errorf("%s: failed to create template heading: %w", op, err)
+		}
+	}
+
+	for i, task := range template.Tasks {
+		taskParams := sqlc.CreateTemplateTaskParams{
+			ID:         task.ID,
+			TemplateID: template.ID,
+			HeadingID: pgtype.Text{
+				String: task.HeadingID,
+				Valid:  task.HeadingID != "",
+			},
+			Title: task.Title,
+			Description: pgtype.Text{
+				String: task.Description,
+				Valid:  task.Description != "",
+			},
+			StartDateOffset: offsetToInt4(task.StartDateOffset),
+			DeadlineOffset:  offsetToInt4(task.DeadlineOffset),
+			Tags:            task.Tags,
+			Position:        int32(i),
+			UserID:          template.UserID,
+		}
+
+		if task.EstimateMinutes > 0 {
+			taskParams.EstimateMinutes = pgtype.Int4{
+				Int32: int32(task.EstimateMinutes),
+				Valid: true,
+			}
+		}
+
+		if taskParams.Tags == nil {
+			taskParams.Tags = []string{}
+		}
+
+		if err := s.Queries.CreateTemplateTask(ctx, taskParams); err != nil {
+			return fmt.Errorf("%s: failed to create template task: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *TemplateStorage) GetTemplateByID(ctx context.Context, templateID, userID string) (model.Template, error) {
+	const op = "template.storage.GetTemplateByID"
+
+	template, err := s.Queries.GetTemplateByID(ctx, sqlc.GetTemplateByIDParams{
+		ID:     templateID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Template{}, le.ErrTemplateNotFound
+	}
+	if err != nil {
+		return model.Template{}, fmt.Errorf("%s: failed to get template: %w", op, err)
+	}
+
+	headings, err := s.Queries.GetTemplateHeadings(ctx, sqlc.GetTemplateHeadingsParams{
+		TemplateID: templateID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return model.Template{}, fmt.Errorf("%s: failed to get template headings: %w", op, err)
+	}
+
+	tasks, err := s.Queries.GetTemplateTasks(ctx, sqlc.GetTemplateTasksParams{
+		TemplateID: templateID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return model.Template{}, fmt.Errorf("%s: failed to get template tasks: %w", op, err)
+	}
+
+	templateResp := model.Template{
+		ID:             template.ID,
+		Title:          template.Title,
+		Type:           model.TemplateType(template.Type),
+		Description:    template.Description.String,
+		Color:          template.Color.String,
+		Icon:           template.Icon.String,
+		DeadlineOffset: offsetFromInt4(template.DeadlineOffset),
+		UserID:         template.UserID,
+		UpdatedAt:      template.UpdatedAt,
+	}
+
+	for _, heading := range headings {
+		templateResp.Headings = append(templateResp.Headings, model.TemplateHeading{
+			ID:    heading.ID,
+			Title: heading.Title,
+		})
+	}
+
+	for _, task := range tasks {
+		templateResp.Tasks = append(templateResp.Tasks, model.TemplateTask{
+			ID:              task.ID,
+			HeadingID:       task.HeadingID.String,
+			Title:           task.Title,
+			Description:     task.Description.String,
+			StartDateOffset: offsetFromInt4(task.StartDateOffset),
+			DeadlineOffset:  offsetFromInt4(task.DeadlineOffset),
+			EstimateMinutes: int(task.EstimateMinutes.Int32),
+			Tags:            task.Tags,
+		})
+	}
+
+	return templateResp, nil
+}
+
+func (s *TemplateStorage) GetTemplatesByUserID(ctx context.Context, userID string) ([]model.Template, error) {
+	const op = "template.storage.GetTemplatesByUserID"
+
+	items, err := s.Queries.GetTemplatesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get templates: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoTemplatesFound
+	}
+
+	var templates []model.Template
+
+	for _, item := range items {
+		templates = append(templates, model.Template{
+			ID:        item.ID,
+			Title:     item.Title,
+			Type:      model.TemplateType(item.Type),
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+	return templates, nil
+}
+
+func (s *TemplateStorage) DeleteTemplate(ctx context.Context, template model.Template) error {
+	const op = "template.storage.DeleteTemplate"
+
+	_, err := s.Queries.DeleteTemplate(ctx, sqlc.DeleteTemplateParams{
+		ID:     template.ID,
+		UserID: template.UserID,
+		DeletedAt: pgtype.Timestamptz{
+			Time:  template.DeletedAt,
+			Valid: true,
+		},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrTemplateNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete template: %w", op, err)
+	}
+	return nil
+}
+
+func offsetToInt4(offset *int) pgtype.Int4 {
+	if offset == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{
+		Int32: int32(*offset),
+		Valid: true,
+	}
+}
+
+func offsetFromInt4(offset pgtype.Int4) *int {
+	if !offset.Valid {
+		return nil
+	}
+	days := int(offset.Int32)
+	return &days
+}
+
+// The list instantiated from the template is written by the storages of the lists, headings, tasks and tags,
+// which run on the same pool or transaction as the template storage
+
+func (s *TemplateStorage) lists() *ListStorage {
+	return &ListStorage{db: s.db, Queries: s.Queries}
+}
+
+func (s *TemplateStorage) headings() *HeadingStorage {
+	return &HeadingStorage{db: s.db, Queries: s.Queries}
+}
+
+func (s *TemplateStorage) tasks() *TaskStorage {
+	return &TaskStorage{db: s.db, Queries: s.Queries}
+}
+
+func (s *TemplateStorage) tags() *TagStorage {
+	return &TagStorage{db: s.db, Queries: s.Queries}
+}
+
+func (s *TemplateStorage) CreateList(ctx context.Context, list model.List) error {
+	return s.lists().CreateList(ctx, list)
+}
+
+func (s *TemplateStorage) CreateHeading(ctx context.Context, heading model.Heading) error {
+	return s.headings().CreateHeading(ctx, heading)
+}
+
+func (s *TemplateStorage) GetTaskStatusID(ctx context.Context, status model.StatusName) (int, error) {
+	return s.tasks().GetTaskStatusID(ctx, status)
+}
+
+func (s *TemplateStorage) CreateTask(ctx context.Context, task model.Task) error {
+	return s.tasks().CreateTask(ctx, task)
+}
+
+func (s *TemplateStorage) LinkTagsToTask(ctx context.Context, userID, taskID string, tags []string) error {
+	return s.tags().LinkTagsToTask(ctx, userID, taskID, tags)
+}
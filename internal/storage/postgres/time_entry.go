@@ -0,0 +1,293 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type TimeEntryStorage struct {
+	db dbtx
+	*sqlc.Queries
+}
+
+func NewTimeEntryStorage(pool *pgxpool.Pool) port.TimeEntryStorage {
+	return &TimeEntryStorage{
+		db:      pool,
+		Queries: sqlc.New(pool),
+	}
+}
+
+func (s *TimeEntryStorage) Transaction(ctx context.Context, fn func(storage port.TimeEntryStorage) error) error {
+	return transaction(ctx, s.db, func(tx pgx.Tx) error {
+		return fn(&TimeEntryStorage{
+			db:      tx,
+			Queries: sqlc.New(tx),
+		})
+	})
+}
+
+func (s *TimeEntryStorage) CreateTimeEntry(ctx context.Context, entry model.TimeEntry) error {
+	const op = "time_entry.storage.CreateTimeEntry"
+
+	entryParams := sqlc.CreateTimeEntryParams{
+		ID:        entry.ID,
+		TaskID:    entry.TaskID,
+		UserID:    entry.UserID,
+		StartTime: entry.StartTime,
+		CreatedAt: entry.CreatedAt,
+		UpdatedAt: entry.UpdatedAt,
+	}
+	if !entry.EndTime.IsZero() {
+		entryParams.EndTime = pgtype.Timestamptz{
+			Time:  entry.EndTime,
+			Valid: true,
+		}
+	}
+
+	if err := s.Queries.CreateTimeEntry(ctx, entryParams); err != nil {
+		return fmt.Errorf("%s: failed to insert new time entry: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *TimeEntryStorage) GetTimeEntryByID(ctx context.Context, entryID, userID string) (model.TimeEntry, error) {
+	const op = "time_entry.storage.GetTimeEntryByID"
+
+	entry, err := s.Queries.GetTimeEntryByID(ctx, sqlc.GetTimeEntryByIDParams{
+		ID:     entryID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.TimeEntry{}, le.ErrTimeEntryNotFound
+	}
+	if err != nil {
+		return model.TimeEntry{}, fmt.Errorf("%s: failed to get time entry: %w", op, err)
+	}
+
+	return model.TimeEntry{
+		ID:        entry.ID,
+		TaskID:    entry.TaskID,
+		UserID:    entry.UserID,
+		StartTime: entry.StartTime,
+		EndTime:   entry.EndTime.Time,
+		UpdatedAt: entry.UpdatedAt,
+	}, nil
+}
+
+func (s *TimeEntryStorage) GetTimeEntriesByTaskID(ctx context.Context, taskID, userID string) ([]model.TimeEntry, error) {
+	const op = "time_entry.storage.GetTimeEntriesByTaskID"
+
+	items, err := s.Queries.GetTimeEntriesByTaskID(ctx, sqlc.GetTimeEntriesByTaskIDParams{
+		TaskID: taskID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get time entries: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoTimeEntriesFound
+	}
+
+	var entries []model.TimeEntry
+
+	for _, item := range items {
+		entries = append(entries, model.TimeEntry{
+			ID:        item.ID,
+			TaskID:    item.TaskID,
+			UserID:    item.UserID,
+			StartTime: item.StartTime,
+			EndTime:   item.EndTime.Time,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+
+	return entries, nil
+}
+
+func (s *TimeEntryStorage) GetRunningTimeEntry(ctx context.Context, userID string) (model.TimeEntry, error) {
+	const op = "time_entry.storage.GetRunningTimeEntry"
+
+	entry, err := s.Queries.GetRunningTimeEntry(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.TimeEntry{}, le.ErrTimerNotRunning
+	}
+	if err != nil {
+		return model.TimeEntry{}, fmt.Errorf("%s: failed to get running time entry: %w", op, err)
+	}
+
+	return model.TimeEntry{
+		ID:        entry.ID,
+		TaskID:    entry.TaskID,
+		UserID:    entry.UserID,
+		StartTime: entry.StartTime,
+		UpdatedAt: entry.UpdatedAt,
+	}, nil
+}
+
+func (s *TimeEntryStorage) StopTimeEntry(ctx context.Context, entry model.TimeEntry) error {
+	const op = "time_entry.storage.StopTimeEntry"
+
+	_, err := s.Queries.StopTimeEntry(ctx, sqlc.StopTimeEntryParams{
+		EndTime: pgtype.Timestamptz{
+			Time:  entry.EndTime,
+			Valid: true,
+		},
+		UpdatedAt: entry.UpdatedAt,
+		ID:        entry.ID,
+		UserID:    entry.UserID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrTimerNotRunning
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to stop time entry: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *TimeEntryStorage) UpdateTimeEntry(ctx context.Context, entry model.TimeEntry) error {
+	const op = "time_entry.storage.UpdateTimeEntry"
+
+	entryParams := sqlc.UpdateTimeEntryParams{
+		StartTime: entry.StartTime,
+		UpdatedAt: entry.UpdatedAt,
+		ID:        entry.ID,
+		UserID:    entry.UserID,
+	}
+	if !entry.EndTime.IsZero() {
+		entryParams.EndTime = pgtype.Timestamptz{
+			Time:  entry.EndTime,
+			Valid: true,
+		}
+	}
+
+	_, err := s.Queries.UpdateTimeEntry(ctx, entryParams)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrTimeEntryNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to update time entry: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *TimeEntryStorage) DeleteTimeEntry(ctx context.Context, entry model.TimeEntry) error {
+	const op = "time_entry.storage.DeleteTimeEntry"
+
+	_, err := s.Queries.DeleteTimeEntry(ctx, sqlc.DeleteTimeEntryParams{
+		ID:     entry.ID,
+		UserID: entry.UserID,
+		DeletedAt: pgtype.Timestamptz{
+			Time:  entry.DeletedAt,
+			Valid: true,
+		},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return le.ErrTimeEntryNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete time entry: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *TimeEntryStorage) GetTimeReportByList(ctx context.Context, userID string, period model.TimeReportPeriod) ([]model.ListTimeTotal, error) {
+	const op = "time_entry.storage.GetTimeReportByList"
+
+	items, err := s.Queries.GetTimeReportByList(ctx, sqlc.GetTimeReportByListParams{
+		UserID:   userID,
+		FromTime: pgtype.Timestamptz{Time: period.FromTime, Valid: true},
+		ToTime:   pgtype.Timestamptz{Time: period.ToTime, Valid: true},
+		FromDate: pgtype.Timestamptz{Time: period.FromDate, Valid: true},
+		ToDate:   pgtype.Timestamptz{Time: period.ToDate, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get time report by list: %w", op, err)
+	}
+
+	totals := make([]model.ListTimeTotal, 0, len(items))
+
+	for _, item := range items {
+		totals = append(totals, model.ListTimeTotal{
+			ListID: item.ListID,
+			TimeTotal: model.TimeTotal{
+				TrackedMinutes:   int(item.TrackedMinutes),
+				EstimatedMinutes: int(item.EstimatedMinutes),
+			},
+		})
+	}
+
+	return totals, nil
+}
+
+func (s *TimeEntryStorage) GetTimeReportByTag(ctx context.Context, userID string, period model.TimeReportPeriod) ([]model.TagTimeTotal, error) {
+	const op = "time_entry.storage.GetTimeReportByTag"
+
+	items, err := s.Queries.GetTimeReportByTag(ctx, sqlc.GetTimeReportByTagParams{
+		UserID:   userID,
+		FromTime: pgtype.Timestamptz{Time: period.FromTime, Valid: true},
+		ToTime:   pgtype.Timestamptz{Time: period.ToTime, Valid: true},
+		FromDate: pgtype.Timestamptz{Time: period.FromDate, Valid: true},
+		ToDate:   pgtype.Timestamptz{Time: period.ToDate, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get time report by tag: %w", op, err)
+	}
+
+	totals := make([]model.TagTimeTotal, 0, len(items))
+
+	for _, item := range items {
+		totals = append(totals, model.TagTimeTotal{
+			Tag: item.Tag,
+			TimeTotal: model.TimeTotal{
+				TrackedMinutes:   int(item.TrackedMinutes),
+				EstimatedMinutes: int(item.EstimatedMinutes),
+			},
+		})
+	}
+
+	return totals, nil
+}
+
+func (s *TimeEntryStorage) GetTimeReportByDay(ctx context.Context, userID string, period model.TimeReportPeriod) ([]model.DayTimeTotal, error) {
+	const op = "time_entry.storage.GetTimeReportByDay"
+
+	items, err := s.Queries.GetTimeReportByDay(ctx, sqlc.GetTimeReportByDayParams{
+		UserID:   userID,
+		TimeZone: period.TimeZone,
+		FromTime: pgtype.Timestamptz{Time: period.FromTime, Valid: true},
+		ToTime:   pgtype.Timestamptz{Time: period.ToTime, Valid: true},
+		FromDate: pgtype.Timestamptz{Time: period.FromDate, Valid: true},
+		ToDate:   pgtype.Timestamptz{Time: period.ToDate, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get time report by day: %w", op, err)
+	}
+
+	totals := make([]model.DayTimeTotal, 0, len(items))
+
+	for _, item := range items {
+		totals = append(totals, model.DayTimeTotal{
+			Date: item.Day.Time,
+			TimeTotal: model.TimeTotal{
+				TrackedMinutes:   int(item.TrackedMinutes),
+				EstimatedMinutes: int(item.EstimatedMinutes),
+			},
+		})
+	}
+
+	return totals, nil
+}
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+// dbtx is the pool or the transaction the storage runs its queries on,
+// a storage bound to a transaction begins the nested transactions as savepoints
+type dbtx interface {
+	sqlc.DBTX
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// transaction runs fn in a transaction. The transaction is rolled back when fn returns an error or panics,
+// otherwise it's committed. The storage passed to the callbacks of Transaction must be built on tx
+func transaction(ctx context.Context, db dbtx, fn func(tx pgx.Tx) error) (err error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				err = fmt.Errorf("tx err: %w, rb err: %v", err, rbErr)
+			}
+			return
+		}
+
+		err = tx.Commit(ctx)
+	}()
+
+	return fn(tx)
+}
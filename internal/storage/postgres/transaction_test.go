@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+// fakeTx records how the transaction was finished, the queries are not used
+type fakeTx struct {
+	pgx.Tx
+
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) Commit(_ context.Context) error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(_ context.Context) error {
+	tx.rolledBack = true
+	return nil
+}
+
+type fakeDB struct {
+	sqlc.DBTX
+
+	tx *fakeTx
+}
+
+func (db *fakeDB) Begin(_ context.Context) (pgx.Tx, error) {
+	return db.tx, nil
+}
+
+var errCallback = errors.New("callback failed")
+
+func TestTransaction(t *testing.T) {
+	tests := []struct {
+		name       string
+		fnErr      error
+		committed  bool
+		rolledBack bool
+	}{
+		{name: "commit", committed: true},
+		{name: "rollback on error", fnErr: errCallback, rolledBack: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeDB{tx: &fakeTx{}}
+
+			var passedTx pgx.Tx
+
+			err := transaction(context.Background(), db, func(tx pgx.Tx) error {
+				passedTx = tx
+				return tt.fnErr
+			})
+
+			if !errors.Is(err, tt.fnErr) {
+				t.Errorf("expected error %v, got %v", tt.fnErr, err)
+			}
+			if passedTx != db.tx {
+				t.Error("expected the callback to get the transaction")
+			}
+			if db.tx.committed != tt.committed || db.tx.rolledBack != tt.rolledBack {
+				t.Errorf("expected committed=%v rolled back=%v, got committed=%v rolled back=%v",
+					tt.committed, tt.rolledBack, db.tx.committed, db.tx.rolledBack)
+			}
+		})
+	}
+}
+
+func TestTransaction_RollbackOnPanic(t *testing.T) {
+	db := &fakeDB{tx: &fakeTx{}}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected the panic to be propagated")
+		}
+		if db.tx.committed || !db.tx.rolledBack {
+			t.Error("expected the transaction to be rolled back")
+		}
+	}()
+
+	_ = transaction(context.Background(), db, func(_ pgx.Tx) error {
+		panic("callback panicked")
+	})
+}
@@ -2,9 +2,13 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/model"
 	"github.com/rshelekhov/reframed/internal/port"
 	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
 )
@@ -31,3 +35,37 @@ func (s *UserStorage) DeleteUserData(ctx context.Context, userID string) error {
 
 	return nil
 }
+
+func (s *UserStorage) GetUserSettings(ctx context.Context, userID string) (model.UserSettings, error) {
+	const op = "storage.UserStorage.GetUserSettings"
+
+	settings, err := s.Queries.GetUserSettings(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.UserSettings{}, le.ErrUserSettingsNotFound
+	}
+	if err != nil {
+		return model.UserSettings{}, fmt.Errorf("%s: failed to get user settings: %w", op, err)
+	}
+
+	return model.UserSettings{
+		UserID:    settings.UserID,
+		TimeZone:  settings.TimeZone,
+		UpdatedAt: settings.UpdatedAt,
+	}, nil
+}
+
+func (s *UserStorage) UpdateUserSettings(ctx context.Context, settings model.UserSettings) error {
+	const op = "storage.UserStorage.UpdateUserSettings"
+
+	err := s.Queries.UpsertUserSettings(ctx, sqlc.UpsertUserSettingsParams{
+		UserID:    settings.UserID,
+		TimeZone:  settings.TimeZone,
+		CreatedAt: settings.CreatedAt,
+		UpdatedAt: settings.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update user settings: %w", op, err)
+	}
+
+	return nil
+}
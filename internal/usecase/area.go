@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+// AreaUsecase manages the areas grouping the lists of the user. The tasks added directly
+// to an area are kept in its default list, which is created together with the area
+type AreaUsecase struct {
+	storage     port.AreaStorage
+	ListUsecase port.ListUsecase
+}
+
+func NewAreaUsecase(storage port.AreaStorage) *AreaUsecase {
+	return &AreaUsecase{storage: storage}
+}
+
+func (u *AreaUsecase) CreateArea(ctx context.Context, data *model.AreaRequestData) (model.AreaResponseData, error) {
+	currentTime := time.Now()
+
+	newArea := model.Area{
+		ID:            ksuid.New().String(),
+		Title:         data.Title,
+		DefaultListID: ksuid.New().String(),
+		UserID:        data.UserID,
+		CreatedAt:     currentTime,
+		UpdatedAt:     currentTime,
+	}
+
+	if err := u.storage.Transaction(ctx, func(storage port.AreaStorage) error {
+		if err := storage.CreateArea(ctx, newArea); err != nil {
+			return err
+		}
+
+		return createAreaDefaultList(ctx, storage, newArea)
+	}); err != nil {
+		return model.AreaResponseData{}, err
+	}
+
+	return mapAreaToResponseData(newArea, nil), nil
+}
+
+func (u *AreaUsecase) GetAreaByID(ctx context.Context, data model.AreaRequestData) (model.AreaResponseData, error) {
+	area, err := u.storage.GetAreaByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.AreaResponseData{}, err
+	}
+
+	lists, err := u.ListUsecase.GetListsByAreaID(ctx, model.ListRequestData{
+		AreaID: area.ID,
+		UserID: data.UserID,
+	})
+	if err != nil {
+		return model.AreaResponseData{}, err
+	}
+
+	return mapAreaToResponseData(area, lists), nil
+}
+
+// GetAreasByUserID returns the ordered areas without their lists
+func (u *AreaUsecase) GetAreasByUserID(ctx context.Context, userID string) ([]model.AreaResponseData, error) {
+	areas, err := u.storage.GetAreasByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var areasResp []model.AreaResponseData
+
+	for _, area := range areas {
+		areasResp = append(areasResp, mapAreaToResponseData(area, nil))
+	}
+
+	return areasResp, nil
+}
+
+// UpdateArea renames the area together with its default list
+func (u *AreaUsecase) UpdateArea(ctx context.Context, data *model.AreaRequestData) (model.AreaResponseData, error) {
+	area, err := u.storage.GetAreaByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.AreaResponseData{}, err
+	}
+
+	updatedArea := model.Area{
+		ID:        data.ID,
+		Title:     data.Title,
+		UserID:    data.UserID,
+		UpdatedAt: time.Now(),
+	}
+
+	if err = u.storage.Transaction(ctx, func(storage port.AreaStorage) error {
+		if err = storage.UpdateArea(ctx, updatedArea); err != nil {
+			return err
+		}
+
+		if area.DefaultListID == "" {
+			return nil
+		}
+
+		return storage.UpdateList(ctx, model.List{
+			ID:        area.DefaultListID,
+			Title:     updatedArea.Title,
+			UserID:    updatedArea.UserID,
+			UpdatedAt: updatedArea.UpdatedAt,
+		})
+	}); err != nil {
+		return model.AreaResponseData{}, err
+	}
+
+	return u.GetAreaByID(ctx, model.AreaRequestData{
+		ID:     data.ID,
+		UserID: data.UserID,
+	})
+}
+
+// MoveArea places the area at the position among the areas of the user
+func (u *AreaUsecase) MoveArea(ctx context.Context, data *model.AreaMoveRequestData) ([]model.AreaResponseData, error) {
+	areas, err := u.storage.GetAreasByUserID(ctx, data.UserID)
+	switch {
+	case errors.Is(err, le.ErrNoAreasFound):
+		return nil, le.ErrAreaNotFound
+	case err != nil:
+		return nil, err
+	}
+
+	areaIDs := make([]string, 0, len(areas))
+	for _, area := range areas {
+		if area.ID != data.ID {
+			areaIDs = append(areaIDs, area.ID)
+		}
+	}
+
+	if len(areaIDs) == len(areas) {
+		return nil, le.ErrAreaNotFound
+	}
+
+	areaIDs = slices.Insert(areaIDs, min(data.Position, len(areaIDs)), data.ID)
+
+	if err = u.storage.UpdateAreasPosition(ctx, data.UserID, areaIDs); err != nil {
+		return nil, err
+	}
+
+	return u.GetAreasByUserID(ctx, data.UserID)
+}
+
+// DeleteArea deletes the area and either moves its lists to the top level or deletes them with their tasks archived
+func (u *AreaUsecase) DeleteArea(ctx context.Context, data model.AreaDeleteRequestData) error {
+	if data.Lists != model.AreaListsMove && data.Lists != model.AreaListsArchive {
+		return le.ErrInvalidAreaListsAction
+	}
+
+	area, err := u.storage.GetAreaByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return err
+	}
+
+	currentTime := time.Now()
+
+	deletedArea := model.Area{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		DeletedAt: currentTime,
+	}
+
+	return u.storage.Transaction(ctx, func(storage port.AreaStorage) error {
+		switch data.Lists {
+		case model.AreaListsArchive:
+			err = deleteAreaLists(ctx, storage, area, currentTime)
+		case model.AreaListsMove:
+			err = storage.MoveListsToTopLevel(ctx, model.List{
+				AreaID:    area.ID,
+				UserID:    area.UserID,
+				UpdatedAt: currentTime,
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		return storage.DeleteArea(ctx, deletedArea)
+	})
+}
+
+// createAreaDefaultList creates the list for the tasks added directly to the area
+func createAreaDefaultList(ctx context.Context, storage port.AreaStorage, area model.Area) error {
+	defaultList := model.List{
+		ID:        area.DefaultListID,
+		Title:     area.Title,
+		IsDefault: true,
+		AreaID:    area.ID,
+		UserID:    area.UserID,
+		CreatedAt: area.CreatedAt,
+		UpdatedAt: area.UpdatedAt,
+	}
+
+	if err := storage.CreateList(ctx, defaultList); err != nil {
+		return err
+	}
+
+	return storage.CreateHeading(ctx, model.Heading{
+		ID:        ksuid.New().String(),
+		Title:     model.DefaultHeading.String(),
+		ListID:    defaultList.ID,
+		UserID:    area.UserID,
+		IsDefault: true,
+		CreatedAt: area.CreatedAt,
+		UpdatedAt: area.UpdatedAt,
+	})
+}
+
+// deleteAreaLists deletes all lists of the area, including its default list, with their headings and archives their tasks
+func deleteAreaLists(ctx context.Context, storage port.AreaStorage, area model.Area, deletedAt time.Time) error {
+	statusArchived, err := storage.GetTaskStatusID(ctx, model.StatusArchived)
+	if err != nil {
+		return err
+	}
+
+	listIDs, err := storage.GetListIDsByAreaID(ctx, area.ID, area.UserID)
+	if err != nil {
+		return err
+	}
+
+	if area.DefaultListID != "" {
+		listIDs = append(listIDs, area.DefaultListID)
+	}
+
+	for _, listID := range listIDs {
+		if err = storage.DeleteList(ctx, model.List{
+			ID:        listID,
+			UserID:    area.UserID,
+			DeletedAt: deletedAt,
+		}); err != nil {
+			return err
+		}
+
+		if err = storage.DeleteHeadingsByListID(ctx, model.Heading{
+			ListID:    listID,
+			UserID:    area.UserID,
+			DeletedAt: deletedAt,
+		}); err != nil {
+			return err
+		}
+
+		if err = storage.MarkTasksAsArchivedByListID(ctx, model.Task{
+			StatusID:  statusArchived,
+			ListID:    listID,
+			UserID:    area.UserID,
+			UpdatedAt: deletedAt,
+			DeletedAt: deletedAt,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func mapAreaToResponseData(area model.Area, lists []model.ListResponseData) model.AreaResponseData {
+	return model.AreaResponseData{
+		ID:            area.ID,
+		Title:         area.Title,
+		DefaultListID: area.DefaultListID,
+		Lists:         lists,
+		UpdatedAt:     area.UpdatedAt,
+	}
+}
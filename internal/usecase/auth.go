@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/golang-jwt/jwt/v5"
-	ssogrpc "github.com/rshelekhov/reframed/internal/clients/sso/grpc"
 	"github.com/rshelekhov/reframed/internal/config"
 	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
 	ssov1 "github.com/rshelekhov/sso-protos/gen/go/sso"
@@ -20,7 +19,7 @@ import (
 
 type AuthUsecase struct {
 	cfg            *config.ServerSettings
-	ssoClient      *ssogrpc.Client
+	provider       port.AuthProvider
 	jwt            *jwtoken.TokenService
 	UserUsecase    port.UserUsecase
 	ListUsecase    port.ListUsecase
@@ -29,13 +28,13 @@ type AuthUsecase struct {
 
 func NewAuthUsecase(
 	cfg *config.ServerSettings,
-	ssoClient *ssogrpc.Client,
+	provider port.AuthProvider,
 	jwt *jwtoken.TokenService,
 ) *AuthUsecase {
 	return &AuthUsecase{
-		cfg:       cfg,
-		ssoClient: ssoClient,
-		jwt:       jwt,
+		cfg:      cfg,
+		provider: provider,
+		jwt:      jwt,
 	}
 }
 
@@ -48,13 +47,11 @@ func (u *AuthUsecase) RegisterNewUser(
 	userID string,
 	err error,
 ) {
-	verificationURL := u.cfg.AppData.BaseURL + "/verify-email/?token="
-
-	resp, err := u.ssoClient.Api.RegisterUser(ctx, &ssov1.RegisterUserRequest{
+	resp, err := u.provider.RegisterUser(ctx, &ssov1.RegisterUserRequest{
 		Email:           userData.Email,
 		Password:        userData.Password,
 		AppID:           u.jwt.AppID,
-		VerificationURL: verificationURL,
+		VerificationURL: u.cfg.AppData.VerificationURL(),
 		UserDeviceData: &ssov1.UserDeviceData{
 			UserAgent: userDevice.UserAgent,
 			Ip:        userDevice.IP,
@@ -101,7 +98,7 @@ func (u *AuthUsecase) RegisterNewUser(
 }
 
 func (u *AuthUsecase) VerifyEmail(ctx context.Context, verificationToken string) error {
-	_, err := u.ssoClient.Api.VerifyEmail(ctx, &ssov1.VerifyEmailRequest{
+	_, err := u.provider.VerifyEmail(ctx, &ssov1.VerifyEmailRequest{
 		VerificationToken: verificationToken,
 	})
 	if err != nil {
@@ -131,7 +128,7 @@ func (u *AuthUsecase) LoginUser(
 	userID string,
 	err error,
 ) {
-	resp, err := u.ssoClient.Api.Login(ctx, &ssov1.LoginRequest{
+	resp, err := u.provider.Login(ctx, &ssov1.LoginRequest{
 		Email:    userData.Email,
 		Password: userData.Password,
 		AppID:    u.jwt.AppID,
@@ -174,13 +171,12 @@ func (u *AuthUsecase) LoginUser(
 	return tokenData, userID, nil
 }
 
+// RequestResetPassword succeeds for the unknown emails too, so the registered emails can't be found out with it
 func (u *AuthUsecase) RequestResetPassword(ctx context.Context, email string) error {
-	confirmChangePasswordURL := u.cfg.AppData.BaseURL + "/password/change?token="
-
-	_, err := u.ssoClient.Api.ResetPassword(ctx, &ssov1.ResetPasswordRequest{
+	_, err := u.provider.ResetPassword(ctx, &ssov1.ResetPasswordRequest{
 		Email:                    email,
 		AppID:                    u.jwt.AppID,
-		ConfirmChangePasswordURL: confirmChangePasswordURL,
+		ConfirmChangePasswordURL: u.cfg.AppData.ConfirmChangePasswordURL(),
 	})
 	if err != nil {
 		st, ok := status.FromError(err)
@@ -192,7 +188,7 @@ func (u *AuthUsecase) RequestResetPassword(ctx context.Context, email string) er
 		case codes.Unauthenticated:
 			return le.ErrAppIDDoesNotExists
 		case codes.NotFound:
-			return le.ErrUserNotFound
+			return nil
 		default:
 			return err
 		}
@@ -201,7 +197,7 @@ func (u *AuthUsecase) RequestResetPassword(ctx context.Context, email string) er
 }
 
 func (u *AuthUsecase) ChangePassword(ctx context.Context, password, resetPasswordToken string) error {
-	_, err := u.ssoClient.Api.ChangePassword(ctx, &ssov1.ChangePasswordRequest{
+	_, err := u.provider.ChangePassword(ctx, &ssov1.ChangePasswordRequest{
 		ResetPasswordToken: resetPasswordToken,
 		AppID:              u.jwt.AppID,
 		UpdatedPassword:    password,
@@ -233,7 +229,7 @@ func (u *AuthUsecase) RefreshTokens(
 	userID string,
 	err error,
 ) {
-	resp, err := u.ssoClient.Api.Refresh(ctx, &ssov1.RefreshRequest{
+	resp, err := u.provider.Refresh(ctx, &ssov1.RefreshRequest{
 		RefreshToken: refreshToken,
 		AppID:        u.jwt.AppID,
 		UserDeviceData: &ssov1.UserDeviceData{
@@ -271,7 +267,7 @@ func (u *AuthUsecase) LogoutUser(ctx context.Context, data model.UserDeviceReque
 		return err
 	}
 
-	_, err = u.ssoClient.Api.Logout(ctx, &ssov1.LogoutRequest{
+	_, err = u.provider.Logout(ctx, &ssov1.LogoutRequest{
 		AppID: u.jwt.AppID,
 		UserDeviceData: &ssov1.UserDeviceData{
 			UserAgent: data.UserAgent,
@@ -291,7 +287,7 @@ func (u *AuthUsecase) GetUserByID(ctx context.Context) (model.UserResponseData,
 		return model.UserResponseData{}, err
 	}
 
-	user, err := u.ssoClient.Api.GetUser(ctx, &ssov1.GetUserRequest{
+	user, err := u.provider.GetUser(ctx, &ssov1.GetUserRequest{
 		AppID: u.jwt.AppID,
 	})
 	if err != nil {
@@ -319,7 +315,7 @@ func (u *AuthUsecase) UpdateUser(ctx context.Context, data *model.UpdateUserRequ
 		return err
 	}
 
-	_, err = u.ssoClient.Api.UpdateUser(ctx, &ssov1.UpdateUserRequest{
+	_, err = u.provider.UpdateUser(ctx, &ssov1.UpdateUserRequest{
 		Email:           data.Email,
 		CurrentPassword: data.Password,
 		UpdatedPassword: data.UpdatedPassword,
@@ -352,7 +348,7 @@ func (u *AuthUsecase) DeleteUser(ctx context.Context, userID string) error {
 		return err
 	}
 
-	_, err = u.ssoClient.Api.DeleteUser(ctx, &ssov1.DeleteUserRequest{
+	_, err = u.provider.DeleteUser(ctx, &ssov1.DeleteUserRequest{
 		AppID: u.jwt.AppID,
 	})
 	if err != nil {
@@ -0,0 +1,344 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/config"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/mailer"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type DigestUsecase struct {
+	cfg         *config.ServerSettings
+	log         *slog.Logger
+	storage     port.DigestStorage
+	mailer      port.Mailer
+	TaskUsecase port.TaskUsecase
+	UserUsecase port.UserUsecase
+}
+
+func NewDigestUsecase(
+	cfg *config.ServerSettings,
+	log *slog.Logger,
+	storage port.DigestStorage,
+	mailer port.Mailer,
+) *DigestUsecase {
+	return &DigestUsecase{
+		cfg:     cfg,
+		log:     log,
+		storage: storage,
+		mailer:  mailer,
+	}
+}
+
+const (
+	defaultDigestCheckInterval = 5 * time.Minute
+
+	// digestUnsubscribeTokenSize is the number of random bytes in the token of the unsubscribe link
+	digestUnsubscribeTokenSize = 24
+
+	// digestUpcomingDays is how far ahead the weekly review looks
+	digestUpcomingDays = 7
+
+	// digestCompletedMonths is the number of the groups of completed tasks by month,
+	// the last 7 days are always within the current and the previous months
+	digestCompletedMonths = 2
+
+	// digestOverdueGroups limits the number of lists with overdue tasks in the daily digest
+	digestOverdueGroups = 30
+)
+
+// digestEmailData is rendered by the digest templates
+type digestEmailData struct {
+	AppName        string
+	Date           time.Time
+	Today          []model.TaskResponseData
+	Overdue        []model.TaskResponseData
+	Completed      []model.TaskResponseData
+	Upcoming       []model.TaskResponseData
+	UnsubscribeURL string
+}
+
+// GetDigestSettings returns the defaults with both digests disabled for users who haven't saved their settings yet
+func (u *DigestUsecase) GetDigestSettings(ctx context.Context, userID string) (model.DigestSettingsResponseData, error) {
+	settings, err := u.getDigestSettings(ctx, userID)
+	if err != nil {
+		return model.DigestSettingsResponseData{}, err
+	}
+
+	return mapDigestSettingsToResponseData(settings), nil
+}
+
+func (u *DigestUsecase) UpdateDigestSettings(ctx context.Context, data *model.DigestSettingsRequestData) (model.DigestSettingsResponseData, error) {
+	settings, err := u.getDigestSettings(ctx, data.UserID)
+	if err != nil {
+		return model.DigestSettingsResponseData{}, err
+	}
+
+	if data.Daily != nil {
+		settings.Daily = *data.Daily
+	}
+	if data.Weekly != nil {
+		settings.Weekly = *data.Weekly
+	}
+	if data.SendTime != "" {
+		settings.SendTime = data.SendTime
+	}
+	if data.WeeklyDay != nil {
+		settings.WeeklyDay = time.Weekday(*data.WeeklyDay)
+	}
+
+	// The email is refreshed on every update, so the digests follow the changes of the email of the user
+	if data.Email != "" {
+		settings.Email = data.Email
+	}
+	if settings.Email == "" {
+		return model.DigestSettingsResponseData{}, le.ErrDigestEmailNotFound
+	}
+
+	if settings.UnsubscribeToken == "" {
+		if settings.UnsubscribeToken, err = newDigestUnsubscribeToken(); err != nil {
+			return model.DigestSettingsResponseData{}, err
+		}
+	}
+
+	currentTime := time.Now()
+
+	settings.CreatedAt = currentTime
+	settings.UpdatedAt = currentTime
+
+	if err = u.storage.UpsertDigestSettings(ctx, settings); err != nil {
+		return model.DigestSettingsResponseData{}, err
+	}
+
+	return mapDigestSettingsToResponseData(settings), nil
+}
+
+// UnsubscribeFromDigests disables both digests of the user the token from the email belongs to
+func (u *DigestUsecase) UnsubscribeFromDigests(ctx context.Context, unsubscribeToken string) error {
+	return u.storage.UnsubscribeFromDigests(ctx, unsubscribeToken, time.Now())
+}
+
+// SendDigestsInBackground checks for the due digests until the context is canceled
+func (u *DigestUsecase) SendDigestsInBackground(ctx context.Context) {
+	interval := u.cfg.Digest.CheckInterval
+	if interval <= 0 {
+		interval = defaultDigestCheckInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if err := u.SendDueDigests(ctx, now); err != nil {
+					u.log.Error("failed to send digests", logger.Err(err))
+				}
+			}
+		}
+	}()
+}
+
+// SendDueDigests sends the digests whose send time has passed today in the time zone of the user.
+// A digest is claimed only after it has been sent, so a digest that fails to be sent is retried on the next check
+func (u *DigestUsecase) SendDueDigests(ctx context.Context, now time.Time) error {
+	settings, err := u.storage.GetEnabledDigestSettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range settings {
+		log := u.log.With(slog.String(key.UserID, s.UserID))
+
+		location, err := u.UserUsecase.GetUserLocation(ctx, s.UserID)
+		if err != nil {
+			log.Error("failed to get location of the user", logger.Err(err))
+			continue
+		}
+
+		dueAt, err := digestDueAt(now, location, s.SendTime)
+		if err != nil {
+			log.Error("invalid digest send time", slog.String("send_time", s.SendTime))
+			continue
+		}
+
+		if now.Before(dueAt) {
+			continue
+		}
+
+		if s.Daily && s.DailySentAt.Before(dueAt) {
+			if err = u.sendDailyDigest(ctx, s, now, dueAt, location); err != nil {
+				log.Error("failed to send daily digest", logger.Err(err))
+			}
+		}
+
+		if s.Weekly && s.WeeklySentAt.Before(dueAt) && now.In(location).Weekday() == s.WeeklyDay {
+			if err = u.sendWeeklyDigest(ctx, s, now, dueAt, location); err != nil {
+				log.Error("failed to send weekly digest", logger.Err(err))
+			}
+		}
+	}
+
+	return nil
+}
+
+// sendDailyDigest sends the tasks for today and the overdue tasks, nothing is sent if there are none
+func (u *DigestUsecase) sendDailyDigest(ctx context.Context, s model.DigestSettings, now, dueAt time.Time, location *time.Location) error {
+	data := u.newDigestEmailData(s, now, location)
+
+	todayGroups, err := u.TaskUsecase.GetTasksForToday(ctx, s.UserID)
+	if err != nil && !errors.Is(err, le.ErrNoTasksFound) {
+		return err
+	}
+
+	for _, group := range todayGroups {
+		data.Today = append(data.Today, group.Tasks...)
+	}
+
+	overdueGroups, err := u.TaskUsecase.GetOverdueTasks(ctx, s.UserID, model.Pagination{Limit: digestOverdueGroups})
+	if err != nil && !errors.Is(err, le.ErrNoTasksFound) {
+		return err
+	}
+
+	for _, group := range overdueGroups {
+		data.Overdue = append(data.Overdue, group.Tasks...)
+	}
+
+	if len(data.Today) > 0 || len(data.Overdue) > 0 {
+		if err = u.sendDigest(ctx, s.Email, "Your plan for today", mailer.TemplateDailyDigest, data); err != nil {
+			return err
+		}
+	}
+
+	// The digest without tasks is claimed too, so the tasks are not checked again until the next day
+	_, err = u.storage.ClaimDailyDigest(ctx, s.UserID, now, dueAt)
+
+	return err
+}
+
+// sendWeeklyDigest sends the tasks completed in the last 7 days and the tasks starting in the next 7 days
+func (u *DigestUsecase) sendWeeklyDigest(ctx context.Context, s model.DigestSettings, now, dueAt time.Time, location *time.Location) error {
+	data := u.newDigestEmailData(s, now, location)
+
+	weekAgo := now.AddDate(0, 0, -digestUpcomingDays)
+
+	completedGroups, err := u.TaskUsecase.GetCompletedTasks(ctx, s.UserID, model.Pagination{Limit: digestCompletedMonths})
+	if err != nil && !errors.Is(err, le.ErrNoTasksFound) {
+		return err
+	}
+
+	for _, group := range completedGroups {
+		for _, task := range group.Tasks {
+			if task.CompletedAt.After(weekAgo) {
+				data.Completed = append(data.Completed, task)
+			}
+		}
+	}
+
+	weekAhead := dueAt.AddDate(0, 0, digestUpcomingDays+1)
+
+	upcomingGroups, err := u.TaskUsecase.GetUpcomingTasks(ctx, s.UserID, model.Pagination{Limit: digestUpcomingDays})
+	if err != nil && !errors.Is(err, le.ErrNoTasksFound) {
+		return err
+	}
+
+	for _, group := range upcomingGroups {
+		if group.StartDate.Before(weekAhead) {
+			data.Upcoming = append(data.Upcoming, group.Tasks...)
+		}
+	}
+
+	if len(data.Completed) > 0 || len(data.Upcoming) > 0 {
+		if err = u.sendDigest(ctx, s.Email, "Your weekly review", mailer.TemplateWeeklyDigest, data); err != nil {
+			return err
+		}
+	}
+
+	_, err = u.storage.ClaimWeeklyDigest(ctx, s.UserID, now, dueAt)
+
+	return err
+}
+
+func (u *DigestUsecase) newDigestEmailData(s model.DigestSettings, now time.Time, location *time.Location) digestEmailData {
+	return digestEmailData{
+		AppName:        u.cfg.AppData.Name,
+		Date:           now.In(location),
+		UnsubscribeURL: u.cfg.AppData.BaseURL + "/digest/unsubscribe?token=" + s.UnsubscribeToken,
+	}
+}
+
+// sendDigest adds the List-Unsubscribe headers, so the mail clients can unsubscribe with one click (RFC 8058)
+func (u *DigestUsecase) sendDigest(ctx context.Context, to, subject string, template mailer.Template, data digestEmailData) error {
+	email, err := mailer.NewEmail(to, subject, template, data)
+	if err != nil {
+		return err
+	}
+
+	email.Headers = map[string]string{
+		"List-Unsubscribe":      "<" + data.UnsubscribeURL + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+
+	return u.mailer.SendEmail(ctx, email)
+}
+
+// getDigestSettings returns default settings for users who haven't saved their own yet
+func (u *DigestUsecase) getDigestSettings(ctx context.Context, userID string) (model.DigestSettings, error) {
+	settings, err := u.storage.GetDigestSettings(ctx, userID)
+	if errors.Is(err, le.ErrDigestSettingsNotFound) {
+		return model.DigestSettings{
+			UserID:    userID,
+			SendTime:  model.DefaultDigestSendTime,
+			WeeklyDay: model.DefaultDigestWeeklyDay,
+		}, nil
+	}
+	if err != nil {
+		return model.DigestSettings{}, err
+	}
+
+	return settings, nil
+}
+
+// digestDueAt returns the send time of the digest today in the time zone of the user
+func digestDueAt(now time.Time, location *time.Location, sendTime string) (time.Time, error) {
+	parsed, err := time.Parse(model.DigestSendTimeLayout, sendTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	localNow := now.In(location)
+
+	return time.Date(localNow.Year(), localNow.Month(), localNow.Day(), parsed.Hour(), parsed.Minute(), 0, 0, location), nil
+}
+
+func newDigestUnsubscribeToken() (string, error) {
+	tokenBytes := make([]byte, digestUnsubscribeTokenSize)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
+
+func mapDigestSettingsToResponseData(settings model.DigestSettings) model.DigestSettingsResponseData {
+	return model.DigestSettingsResponseData{
+		Email:     settings.Email,
+		Daily:     settings.Daily,
+		Weekly:    settings.Weekly,
+		SendTime:  settings.SendTime,
+		WeeklyDay: int(settings.WeeklyDay),
+		UpdatedAt: settings.UpdatedAt,
+	}
+}
@@ -0,0 +1,336 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/config"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+const digestUserID = "user_id"
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("Failed to load location %s: %v", name, err)
+	}
+
+	return location
+}
+
+func TestDigestDueAt(t *testing.T) {
+	testCases := []struct {
+		name      string
+		now       time.Time
+		location  string
+		sendTime  string
+		expected  time.Time
+		expectErr bool
+	}{
+		{
+			name:     "UTC",
+			now:      time.Date(2024, time.May, 15, 10, 0, 0, 0, time.UTC),
+			location: "UTC",
+			sendTime: "08:00",
+			expected: time.Date(2024, time.May, 15, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Ahead of UTC on the next day",
+			now:      time.Date(2024, time.May, 15, 20, 0, 0, 0, time.UTC),
+			location: "Asia/Tokyo",
+			sendTime: "08:00",
+			expected: time.Date(2024, time.May, 15, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Behind UTC on the previous day",
+			now:      time.Date(2024, time.May, 15, 2, 0, 0, 0, time.UTC),
+			location: "America/New_York",
+			sendTime: "21:30",
+			expected: time.Date(2024, time.May, 15, 1, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "Daylight saving time starts",
+			now:      time.Date(2024, time.March, 31, 12, 0, 0, 0, time.UTC),
+			location: "Europe/Berlin",
+			sendTime: "08:00",
+			expected: time.Date(2024, time.March, 31, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "Invalid send time",
+			now:       time.Date(2024, time.May, 15, 10, 0, 0, 0, time.UTC),
+			location:  "UTC",
+			sendTime:  "8am",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dueAt, err := digestDueAt(tc.now, mustLoadLocation(t, tc.location), tc.sendTime)
+
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %v", dueAt)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !dueAt.Equal(tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, dueAt.UTC())
+			}
+		})
+	}
+}
+
+// digestStorage claims the digests with the same conditions as the UPDATE queries of the Postgres storage
+type digestStorage struct {
+	port.DigestStorage
+
+	settings model.DigestSettings
+}
+
+func (s *digestStorage) GetEnabledDigestSettings(_ context.Context) ([]model.DigestSettings, error) {
+	return []model.DigestSettings{s.settings}, nil
+}
+
+func (s *digestStorage) ClaimDailyDigest(_ context.Context, _ string, sentAt, dueAt time.Time) (bool, error) {
+	if !s.settings.Daily || !s.settings.DailySentAt.Before(dueAt) {
+		return false, nil
+	}
+	s.settings.DailySentAt = sentAt
+	return true, nil
+}
+
+func (s *digestStorage) ClaimWeeklyDigest(_ context.Context, _ string, sentAt, dueAt time.Time) (bool, error) {
+	if !s.settings.Weekly || !s.settings.WeeklySentAt.Before(dueAt) {
+		return false, nil
+	}
+	s.settings.WeeklySentAt = sentAt
+	return true, nil
+}
+
+// digestMailer records the sent emails, or fails to send them when err is set
+type digestMailer struct {
+	emails []model.Email
+	err    error
+}
+
+func (m *digestMailer) SendEmail(_ context.Context, email model.Email) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.emails = append(m.emails, email)
+	return nil
+}
+
+// digestTaskUsecase returns le.ErrNoTasksFound for the empty groups like the TaskUsecase
+type digestTaskUsecase struct {
+	port.TaskUsecase
+
+	today     []model.TodayTaskGroup
+	upcoming  []model.UpcomingTaskGroup
+	completed []model.CompletedTasksGroup
+}
+
+func (u *digestTaskUsecase) GetTasksForToday(_ context.Context, _ string) ([]model.TodayTaskGroup, error) {
+	if len(u.today) == 0 {
+		return nil, le.ErrNoTasksFound
+	}
+	return u.today, nil
+}
+
+func (u *digestTaskUsecase) GetOverdueTasks(_ context.Context, _ string, _ model.Pagination) ([]model.OverdueTaskGroup, error) {
+	return nil, le.ErrNoTasksFound
+}
+
+func (u *digestTaskUsecase) GetCompletedTasks(_ context.Context, _ string, _ model.Pagination) ([]model.CompletedTasksGroup, error) {
+	if len(u.completed) == 0 {
+		return nil, le.ErrNoTasksFound
+	}
+	return u.completed, nil
+}
+
+func (u *digestTaskUsecase) GetUpcomingTasks(_ context.Context, _ string, _ model.Pagination) ([]model.UpcomingTaskGroup, error) {
+	if len(u.upcoming) == 0 {
+		return nil, le.ErrNoTasksFound
+	}
+	return u.upcoming, nil
+}
+
+type digestUserUsecase struct {
+	port.UserUsecase
+
+	location *time.Location
+}
+
+func (u *digestUserUsecase) GetUserLocation(_ context.Context, _ string) (*time.Location, error) {
+	return u.location, nil
+}
+
+func newDigestUsecase(t *testing.T, settings model.DigestSettings, tasks *digestTaskUsecase) (*DigestUsecase, *digestStorage, *digestMailer) {
+	t.Helper()
+
+	storage := &digestStorage{settings: settings}
+	emailSender := &digestMailer{}
+
+	cfg := &config.ServerSettings{
+		AppData: config.AppDataSettings{Name: "reframed", BaseURL: "https://reframed.test"},
+	}
+
+	u := NewDigestUsecase(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)), storage, emailSender)
+	u.TaskUsecase = tasks
+	u.UserUsecase = &digestUserUsecase{location: mustLoadLocation(t, "Europe/Berlin")}
+
+	return u, storage, emailSender
+}
+
+func TestDigestUsecase_SendDueDigests(t *testing.T) {
+	// Wednesday, 09:00 in Berlin
+	now := time.Date(2024, time.May, 15, 7, 0, 0, 0, time.UTC)
+
+	today := []model.TodayTaskGroup{{Tasks: []model.TaskResponseData{{Title: "Write the report"}}}}
+	upcoming := []model.UpcomingTaskGroup{{StartDate: now.AddDate(0, 0, 2), Tasks: []model.TaskResponseData{{Title: "Call the bank"}}}}
+
+	// The task completed a month ago and edited yesterday isn't the work of the last week
+	completedEarlier := []model.CompletedTasksGroup{{Tasks: []model.TaskResponseData{{
+		Title:       "Renew the passport",
+		UpdatedAt:   now.AddDate(0, 0, -1),
+		CompletedAt: now.AddDate(0, -1, 0),
+	}}}}
+
+	testCases := []struct {
+		name             string
+		settings         model.DigestSettings
+		tasks            *digestTaskUsecase
+		now              time.Time
+		expectedSubjects []string
+	}{
+		{
+			name:             "Daily digest",
+			settings:         model.DigestSettings{Daily: true, SendTime: "08:00"},
+			tasks:            &digestTaskUsecase{today: today},
+			now:              now,
+			expectedSubjects: []string{"Your plan for today"},
+		},
+		{
+			name:     "Before the send time",
+			settings: model.DigestSettings{Daily: true, SendTime: "10:00"},
+			tasks:    &digestTaskUsecase{today: today},
+			now:      now,
+		},
+		{
+			name:     "No tasks",
+			settings: model.DigestSettings{Daily: true, Weekly: true, SendTime: "08:00", WeeklyDay: time.Wednesday},
+			tasks:    &digestTaskUsecase{},
+			now:      now,
+		},
+		{
+			name:             "Weekly digest on the weekly day",
+			settings:         model.DigestSettings{Weekly: true, SendTime: "08:00", WeeklyDay: time.Wednesday},
+			tasks:            &digestTaskUsecase{upcoming: upcoming},
+			now:              now,
+			expectedSubjects: []string{"Your weekly review"},
+		},
+		{
+			name:             "Weekly digest with the tasks completed this week",
+			settings:         model.DigestSettings{Weekly: true, SendTime: "08:00", WeeklyDay: time.Wednesday},
+			tasks:            &digestTaskUsecase{completed: []model.CompletedTasksGroup{{Tasks: []model.TaskResponseData{{Title: "Send the invoice", CompletedAt: now.AddDate(0, 0, -3)}}}}},
+			now:              now,
+			expectedSubjects: []string{"Your weekly review"},
+		},
+		{
+			name:     "Weekly digest with the tasks completed earlier",
+			settings: model.DigestSettings{Weekly: true, SendTime: "08:00", WeeklyDay: time.Wednesday},
+			tasks:    &digestTaskUsecase{completed: completedEarlier},
+			now:      now,
+		},
+		{
+			name:     "Weekly digest on another day",
+			settings: model.DigestSettings{Weekly: true, SendTime: "08:00", WeeklyDay: time.Monday},
+			tasks:    &digestTaskUsecase{upcoming: upcoming},
+			now:      now,
+		},
+		{
+			// It is still Tuesday in UTC, the weekly day is checked in the time zone of the user
+			name:             "Weekly day in the time zone of the user",
+			settings:         model.DigestSettings{Weekly: true, SendTime: "00:30", WeeklyDay: time.Wednesday},
+			tasks:            &digestTaskUsecase{upcoming: upcoming},
+			now:              time.Date(2024, time.May, 14, 22, 45, 0, 0, time.UTC),
+			expectedSubjects: []string{"Your weekly review"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.settings.UserID = digestUserID
+			tc.settings.Email = "alice@example.com"
+
+			u, _, emailSender := newDigestUsecase(t, tc.settings, tc.tasks)
+
+			// The second run for the same time must not send the digests again
+			for range 2 {
+				if err := u.SendDueDigests(context.Background(), tc.now); err != nil {
+					t.Fatalf("Failed to send digests: %v", err)
+				}
+			}
+
+			if len(emailSender.emails) != len(tc.expectedSubjects) {
+				t.Fatalf("Expected %d emails, got %d", len(tc.expectedSubjects), len(emailSender.emails))
+			}
+
+			for i, email := range emailSender.emails {
+				if email.Subject != tc.expectedSubjects[i] {
+					t.Errorf("Expected subject %q, got %q", tc.expectedSubjects[i], email.Subject)
+				}
+
+				if email.Headers["List-Unsubscribe-Post"] != "List-Unsubscribe=One-Click" {
+					t.Errorf("Expected the one-click unsubscribe header, got %v", email.Headers)
+				}
+			}
+		})
+	}
+}
+
+func TestDigestUsecase_SendDueDigestsRetriesFailedEmail(t *testing.T) {
+	now := time.Date(2024, time.May, 15, 7, 0, 0, 0, time.UTC)
+
+	u, storage, emailSender := newDigestUsecase(t, model.DigestSettings{
+		UserID:   digestUserID,
+		Email:    "alice@example.com",
+		Daily:    true,
+		SendTime: "08:00",
+	}, &digestTaskUsecase{today: []model.TodayTaskGroup{{Tasks: []model.TaskResponseData{{Title: "Write the report"}}}}})
+
+	emailSender.err = errors.New("smtp is down")
+
+	if err := u.SendDueDigests(context.Background(), now); err != nil {
+		t.Fatalf("Failed to send digests: %v", err)
+	}
+
+	if !storage.settings.DailySentAt.IsZero() {
+		t.Fatalf("Expected the failed digest not to be claimed")
+	}
+
+	emailSender.err = nil
+
+	if err := u.SendDueDigests(context.Background(), now.Add(5*time.Minute)); err != nil {
+		t.Fatalf("Failed to send digests: %v", err)
+	}
+
+	if len(emailSender.emails) != 1 {
+		t.Errorf("Expected the digest to be sent on the next check, got %d emails", len(emailSender.emails))
+	}
+}
@@ -0,0 +1,315 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+// FocusSessionUsecase keeps the timer state of focus sessions on the server.
+// Sessions are completed lazily: the reads show a running session whose time is up
+// as completed, and every change first records the completion of such sessions
+// at the moment they ended
+type FocusSessionUsecase struct {
+	storage     port.FocusSessionStorage
+	TaskUsecase port.TaskUsecase
+	UserUsecase port.UserUsecase
+}
+
+func NewFocusSessionUsecase(storage port.FocusSessionStorage) *FocusSessionUsecase {
+	return &FocusSessionUsecase{storage: storage}
+}
+
+func (u *FocusSessionUsecase) StartFocusSession(ctx context.Context, data *model.FocusSessionRequestData) (model.FocusSessionResponseData, error) {
+	_, err := u.TaskUsecase.GetTaskByID(ctx, model.TaskRequestData{
+		ID:     data.TaskID,
+		UserID: data.UserID,
+	})
+	if err != nil {
+		return model.FocusSessionResponseData{}, err
+	}
+
+	if err = u.completeDueSessions(ctx, data.UserID); err != nil {
+		return model.FocusSessionResponseData{}, err
+	}
+
+	currentTime := time.Now()
+
+	newSession := model.FocusSession{
+		ID:              ksuid.New().String(),
+		TaskID:          data.TaskID,
+		UserID:          data.UserID,
+		DurationMinutes: data.DurationMinutes,
+		Status:          model.FocusSessionRunning,
+		StartedAt:       currentTime,
+		EndsAt:          currentTime.Add(time.Duration(data.DurationMinutes) * time.Minute),
+		CreatedAt:       currentTime,
+		UpdatedAt:       currentTime,
+	}
+
+	if err = u.storage.Transaction(ctx, func(storage port.FocusSessionStorage) error {
+		_, err = storage.GetActiveFocusSession(ctx, data.UserID)
+		switch {
+		case err == nil:
+			return le.ErrFocusSessionAlreadyActive
+		case !errors.Is(err, le.ErrNoActiveFocusSession):
+			return err
+		}
+
+		if err = storage.CreateFocusSession(ctx, newSession); err != nil {
+			return err
+		}
+
+		return storage.CreateFocusSessionEvent(ctx, model.FocusSessionEvent{
+			SessionID: newSession.ID,
+			UserID:    newSession.UserID,
+			Event:     model.FocusEventStarted,
+			CreatedAt: currentTime,
+		})
+	}); err != nil {
+		return model.FocusSessionResponseData{}, err
+	}
+
+	return mapFocusSessionToResponseData(newSession, currentTime), nil
+}
+
+// completeDueSessions marks the running sessions whose time is up as completed
+func (u *FocusSessionUsecase) completeDueSessions(ctx context.Context, userID string) error {
+	return u.storage.Transaction(ctx, func(storage port.FocusSessionStorage) error {
+		completedSessions, err := storage.CompleteDueFocusSessions(ctx, userID, time.Now())
+		if err != nil {
+			return err
+		}
+
+		for _, session := range completedSessions {
+			if err = storage.CreateFocusSessionEvent(ctx, model.FocusSessionEvent{
+				SessionID: session.ID,
+				UserID:    session.UserID,
+				Event:     model.FocusEventCompleted,
+				CreatedAt: session.FinishedAt,
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (u *FocusSessionUsecase) GetActiveFocusSession(ctx context.Context, userID string) (model.FocusSessionResponseData, error) {
+	currentTime := time.Now()
+
+	session, err := u.getActiveFocusSession(ctx, userID, currentTime)
+	if err != nil {
+		return model.FocusSessionResponseData{}, err
+	}
+
+	return mapFocusSessionToResponseData(session, currentTime), nil
+}
+
+// getActiveFocusSession doesn't return the running session whose time is up at now
+func (u *FocusSessionUsecase) getActiveFocusSession(ctx context.Context, userID string, now time.Time) (model.FocusSession, error) {
+	session, err := u.storage.GetActiveFocusSession(ctx, userID)
+	if err != nil {
+		return model.FocusSession{}, err
+	}
+
+	if completeIfDue(&session, now) {
+		return model.FocusSession{}, le.ErrNoActiveFocusSession
+	}
+
+	return session, nil
+}
+
+func (u *FocusSessionUsecase) GetFocusSessionByID(ctx context.Context, data model.FocusSessionRequestData) (model.FocusSessionResponseData, error) {
+	session, err := u.storage.GetFocusSessionByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.FocusSessionResponseData{}, err
+	}
+
+	events, err := u.storage.GetFocusSessionEvents(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.FocusSessionResponseData{}, err
+	}
+
+	currentTime := time.Now()
+
+	if completeIfDue(&session, currentTime) {
+		// The completion is recorded on the next change of the user's sessions
+		events = append(events, model.FocusSessionEvent{
+			Event:     model.FocusEventCompleted,
+			CreatedAt: session.FinishedAt,
+		})
+	}
+
+	sessionResp := mapFocusSessionToResponseData(session, currentTime)
+
+	for _, event := range events {
+		sessionResp.Events = append(sessionResp.Events, model.FocusSessionEventResponse{
+			Event:     event.Event,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+
+	return sessionResp, nil
+}
+
+// completeIfDue marks the running session whose time is up at now as completed,
+// the same way completeDueSessions does it in the storage
+func completeIfDue(session *model.FocusSession, now time.Time) bool {
+	if session.Status != model.FocusSessionRunning || session.EndsAt.After(now) {
+		return false
+	}
+
+	session.Status = model.FocusSessionCompleted
+	session.FinishedAt = session.EndsAt
+
+	return true
+}
+
+func mapFocusSessionToResponseData(session model.FocusSession, now time.Time) model.FocusSessionResponseData {
+	var remaining time.Duration
+
+	switch session.Status {
+	case model.FocusSessionRunning:
+		remaining = session.EndsAt.Sub(now)
+	case model.FocusSessionPaused:
+		remaining = session.EndsAt.Sub(session.PausedAt)
+	}
+
+	return model.FocusSessionResponseData{
+		ID:               session.ID,
+		TaskID:           session.TaskID,
+		UserID:           session.UserID,
+		DurationMinutes:  session.DurationMinutes,
+		Status:           session.Status,
+		RemainingSeconds: int(max(remaining, 0).Seconds()),
+		StartedAt:        session.StartedAt,
+		EndsAt:           session.EndsAt,
+		PausedAt:         session.PausedAt,
+		FinishedAt:       session.FinishedAt,
+		UpdatedAt:        session.UpdatedAt,
+	}
+}
+
+func (u *FocusSessionUsecase) PauseFocusSession(ctx context.Context, data model.FocusSessionRequestData) (model.FocusSessionResponseData, error) {
+	return u.changeFocusSession(ctx, data, model.FocusEventPaused, func(session *model.FocusSession, now time.Time) error {
+		if session.Status != model.FocusSessionRunning {
+			return le.ErrInvalidFocusSessionTransition
+		}
+
+		session.Status = model.FocusSessionPaused
+		session.PausedAt = now
+
+		return nil
+	})
+}
+
+func (u *FocusSessionUsecase) ResumeFocusSession(ctx context.Context, data model.FocusSessionRequestData) (model.FocusSessionResponseData, error) {
+	return u.changeFocusSession(ctx, data, model.FocusEventResumed, func(session *model.FocusSession, now time.Time) error {
+		if session.Status != model.FocusSessionPaused {
+			return le.ErrInvalidFocusSessionTransition
+		}
+
+		// The time spent on pause doesn't count towards the session
+		session.EndsAt = session.EndsAt.Add(now.Sub(session.PausedAt))
+		session.Status = model.FocusSessionRunning
+		session.PausedAt = time.Time{}
+
+		return nil
+	})
+}
+
+func (u *FocusSessionUsecase) AbandonFocusSession(ctx context.Context, data model.FocusSessionRequestData) (model.FocusSessionResponseData, error) {
+	return u.changeFocusSession(ctx, data, model.FocusEventAbandoned, func(session *model.FocusSession, now time.Time) error {
+		if session.Status != model.FocusSessionRunning && session.Status != model.FocusSessionPaused {
+			return le.ErrInvalidFocusSessionTransition
+		}
+
+		session.Status = model.FocusSessionAbandoned
+		session.PausedAt = time.Time{}
+		session.FinishedAt = now
+
+		return nil
+	})
+}
+
+// changeFocusSession applies the state change to the session and records the event
+func (u *FocusSessionUsecase) changeFocusSession(
+	ctx context.Context,
+	data model.FocusSessionRequestData,
+	event model.FocusSessionEventName,
+	change func(session *model.FocusSession, now time.Time) error,
+) (model.FocusSessionResponseData, error) {
+	if err := u.completeDueSessions(ctx, data.UserID); err != nil {
+		return model.FocusSessionResponseData{}, err
+	}
+
+	session, err := u.storage.GetFocusSessionByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.FocusSessionResponseData{}, err
+	}
+
+	currentTime := time.Now()
+	expectedStatus := session.Status
+
+	if err = change(&session, currentTime); err != nil {
+		return model.FocusSessionResponseData{}, err
+	}
+
+	session.UpdatedAt = currentTime
+
+	if err = u.storage.Transaction(ctx, func(storage port.FocusSessionStorage) error {
+		if err = storage.UpdateFocusSession(ctx, session, expectedStatus); err != nil {
+			return err
+		}
+
+		return storage.CreateFocusSessionEvent(ctx, model.FocusSessionEvent{
+			SessionID: session.ID,
+			UserID:    session.UserID,
+			Event:     event,
+			CreatedAt: currentTime,
+		})
+	}); err != nil {
+		return model.FocusSessionResponseData{}, err
+	}
+
+	return mapFocusSessionToResponseData(session, currentTime), nil
+}
+
+// GetFocusSessionsSummaryForToday counts the sessions started today in the user's time zone
+func (u *FocusSessionUsecase) GetFocusSessionsSummaryForToday(ctx context.Context, userID string) (model.FocusSessionsSummary, error) {
+	location, err := u.UserUsecase.GetUserLocation(ctx, userID)
+	if err != nil {
+		return model.FocusSessionsSummary{}, err
+	}
+
+	currentTime := time.Now()
+
+	year, month, day := currentTime.In(location).Date()
+	from := time.Date(year, month, day, 0, 0, 0, 0, location)
+	to := from.AddDate(0, 0, 1)
+
+	summary, err := u.storage.GetFocusSessionsSummary(ctx, userID, from, to, currentTime)
+	if err != nil {
+		return model.FocusSessionsSummary{}, err
+	}
+
+	activeSession, err := u.getActiveFocusSession(ctx, userID, currentTime)
+	switch {
+	case errors.Is(err, le.ErrNoActiveFocusSession):
+	case err != nil:
+		return model.FocusSessionsSummary{}, err
+	default:
+		activeSessionResp := mapFocusSessionToResponseData(activeSession, currentTime)
+		summary.Active = &activeSessionResp
+	}
+
+	return summary, nil
+}
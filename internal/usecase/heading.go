@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/rshelekhov/reframed/internal/lib/constant/le"
@@ -80,12 +81,13 @@ func (u *HeadingUsecase) GetHeadingByID(ctx context.Context, data model.HeadingR
 	}
 
 	return model.HeadingResponseData{
-		ID:        heading.ID,
-		Title:     heading.Title,
-		ListID:    heading.ListID,
-		UserID:    heading.UserID,
-		CreatedAt: heading.CreatedAt,
-		UpdatedAt: heading.UpdatedAt,
+		ID:          heading.ID,
+		Title:       heading.Title,
+		ListID:      heading.ListID,
+		UserID:      heading.UserID,
+		CompletedAt: heading.CompletedAt,
+		CreatedAt:   heading.CreatedAt,
+		UpdatedAt:   heading.UpdatedAt,
 	}, nil
 }
 
@@ -93,6 +95,10 @@ func (u *HeadingUsecase) GetDefaultHeadingID(ctx context.Context, data model.Hea
 	return u.storage.GetDefaultHeadingID(ctx, data.ListID, data.UserID)
 }
 
+func (u *HeadingUsecase) GetHeadingIDByTitle(ctx context.Context, data model.HeadingRequestData) (string, error) {
+	return u.storage.GetHeadingIDByTitle(ctx, data.Title, data.ListID, data.UserID)
+}
+
 func (u *HeadingUsecase) GetHeadingsByListID(ctx context.Context, data model.HeadingRequestData) ([]model.HeadingResponseData, error) {
 	headings, err := u.storage.GetHeadingsByListID(ctx, data.ListID, data.UserID)
 	if err != nil {
@@ -176,6 +182,349 @@ func (u *HeadingUsecase) MoveHeadingToAnotherList(ctx context.Context, data *mod
 	}, nil
 }
 
+// PromoteHeadingToList turns the heading into a new list in the same area as its list,
+// the tasks of the heading are moved to the default heading of the new list
+func (u *HeadingUsecase) PromoteHeadingToList(ctx context.Context, data *model.HeadingRequestData) (model.ListResponseData, error) {
+	heading, err := u.getListHeading(ctx, data.ID, data.ListID, data.UserID)
+	if err != nil {
+		return model.ListResponseData{}, err
+	}
+	if heading.IsDefault {
+		return model.ListResponseData{}, le.ErrCannotPromoteDefaultHeading
+	}
+
+	list, err := u.ListUsecase.GetListByID(ctx, model.ListRequestData{
+		ID:     heading.ListID,
+		UserID: data.UserID,
+	})
+	if err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	currentTime := time.Now()
+
+	newList := model.List{
+		ID:        ksuid.New().String(),
+		Title:     heading.Title,
+		AreaID:    list.AreaID,
+		UserID:    data.UserID,
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+
+	defaultHeading := model.Heading{
+		ID:        ksuid.New().String(),
+		Title:     model.DefaultHeading.String(),
+		ListID:    newList.ID,
+		UserID:    data.UserID,
+		IsDefault: true,
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+
+	if err = u.storage.Transaction(ctx, func(storage port.HeadingStorage) error {
+		if err = storage.CreateList(ctx, newList); err != nil {
+			return err
+		}
+
+		if err = storage.CreateHeading(ctx, defaultHeading); err != nil {
+			return err
+		}
+
+		if err = moveHeadingToList(ctx, storage, heading.ID, model.Task{
+			ListID:    newList.ID,
+			UserID:    data.UserID,
+			UpdatedAt: currentTime,
+		}); err != nil {
+			return err
+		}
+
+		return mergeHeading(ctx, storage, heading.ID, model.Task{
+			HeadingID: defaultHeading.ID,
+			ListID:    newList.ID,
+			UserID:    data.UserID,
+			UpdatedAt: currentTime,
+		})
+	}); err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	return model.ListResponseData{
+		ID:        newList.ID,
+		Title:     newList.Title,
+		AreaID:    newList.AreaID,
+		UserID:    newList.UserID,
+		CreatedAt: newList.CreatedAt,
+		UpdatedAt: newList.UpdatedAt,
+	}, nil
+}
+
+// DemoteListToHeading turns the list into a heading of the target list titled by the list.
+// The tasks of all headings of the list are moved to the new heading, then the list is deleted
+func (u *HeadingUsecase) DemoteListToHeading(ctx context.Context, data *model.ListDemoteRequestData) (model.HeadingResponseData, error) {
+	if data.ID == data.TargetListID {
+		return model.HeadingResponseData{}, le.ErrCannotDemoteListIntoItself
+	}
+
+	list, err := u.ListUsecase.GetListByID(ctx, model.ListRequestData{
+		ID:     data.ID,
+		UserID: data.UserID,
+	})
+	if err != nil {
+		return model.HeadingResponseData{}, err
+	}
+	if list.IsDefault {
+		return model.HeadingResponseData{}, le.ErrCannotDemoteDefaultList
+	}
+
+	headings, err := u.storage.GetHeadingsByListID(ctx, data.ID, data.UserID)
+	if err != nil && !errors.Is(err, le.ErrNoHeadingsFound) {
+		return model.HeadingResponseData{}, err
+	}
+
+	// The target list is checked before the transaction, the writes below run on its storage only
+	if err = u.handleListID(ctx, &model.HeadingRequestData{
+		ListID: data.TargetListID,
+		UserID: data.UserID,
+	}); err != nil {
+		return model.HeadingResponseData{}, err
+	}
+
+	currentTime := time.Now()
+
+	newHeading := model.Heading{
+		ID:        ksuid.New().String(),
+		Title:     list.Title,
+		ListID:    data.TargetListID,
+		UserID:    data.UserID,
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+
+	if err = u.storage.Transaction(ctx, func(storage port.HeadingStorage) error {
+		if err = storage.CreateHeading(ctx, newHeading); err != nil {
+			return err
+		}
+
+		target := model.Task{
+			HeadingID: newHeading.ID,
+			ListID:    data.TargetListID,
+			UserID:    data.UserID,
+			UpdatedAt: currentTime,
+		}
+
+		for _, heading := range headings {
+			if err = moveHeadingToList(ctx, storage, heading.ID, target); err != nil {
+				return err
+			}
+
+			if err = mergeHeading(ctx, storage, heading.ID, target); err != nil {
+				return err
+			}
+		}
+
+		return deleteDemotedList(ctx, storage, data.ID, data.UserID, currentTime)
+	}); err != nil {
+		return model.HeadingResponseData{}, err
+	}
+
+	return model.HeadingResponseData{
+		ID:        newHeading.ID,
+		Title:     newHeading.Title,
+		ListID:    newHeading.ListID,
+		UserID:    newHeading.UserID,
+		CreatedAt: newHeading.CreatedAt,
+		UpdatedAt: newHeading.UpdatedAt,
+	}, nil
+}
+
+// moveHeadingToList moves the heading with its tasks to the list of the target
+func moveHeadingToList(ctx context.Context, storage port.HeadingStorage, headingID string, target model.Task) error {
+	return storage.MoveHeadingToAnotherList(ctx, model.Heading{
+		ID:        headingID,
+		ListID:    target.ListID,
+		UserID:    target.UserID,
+		UpdatedAt: target.UpdatedAt,
+	}, model.Task{
+		HeadingID: headingID,
+		ListID:    target.ListID,
+		UserID:    target.UserID,
+		UpdatedAt: target.UpdatedAt,
+	})
+}
+
+// mergeHeading moves the tasks of the heading to the target heading and deletes the heading
+func mergeHeading(ctx context.Context, storage port.HeadingStorage, headingID string, target model.Task) error {
+	if err := storage.MoveTasksToHeading(ctx, headingID, target); err != nil {
+		return err
+	}
+
+	return storage.DeleteHeading(ctx, model.Heading{
+		ID:        headingID,
+		UserID:    target.UserID,
+		DeletedAt: target.UpdatedAt,
+	})
+}
+
+// deleteDemotedList deletes the list with its headings, the tasks left in the list are archived
+func deleteDemotedList(ctx context.Context, storage port.HeadingStorage, listID, userID string, deletedAt time.Time) error {
+	if err := storage.DeleteList(ctx, model.List{
+		ID:        listID,
+		UserID:    userID,
+		DeletedAt: deletedAt,
+	}); err != nil {
+		return err
+	}
+
+	if err := storage.DeleteHeadingsByListID(ctx, model.Heading{
+		ListID:    listID,
+		UserID:    userID,
+		DeletedAt: deletedAt,
+	}); err != nil {
+		return err
+	}
+
+	statusArchived, err := storage.GetTaskStatusID(ctx, model.StatusArchived)
+	if err != nil {
+		return err
+	}
+
+	return storage.MarkTasksAsArchivedByListID(ctx, model.Task{
+		StatusID:  statusArchived,
+		ListID:    listID,
+		UserID:    userID,
+		UpdatedAt: deletedAt,
+		DeletedAt: deletedAt,
+	})
+}
+
+// CompleteHeading completes the heading with its open tasks completed or cancelled,
+// the heading is shown in the logbook with its tasks until it's restored
+func (u *HeadingUsecase) CompleteHeading(ctx context.Context, data model.HeadingCompleteRequestData) (model.HeadingResponseData, error) {
+	if data.Tasks != model.HeadingTasksComplete && data.Tasks != model.HeadingTasksCancel {
+		return model.HeadingResponseData{}, le.ErrInvalidHeadingTasksAction
+	}
+
+	heading, err := u.getListHeading(ctx, data.ID, data.ListID, data.UserID)
+	if err != nil {
+		return model.HeadingResponseData{}, err
+	}
+	if heading.IsDefault {
+		return model.HeadingResponseData{}, le.ErrCannotCompleteDefaultHeading
+	}
+	if !heading.CompletedAt.IsZero() {
+		return model.HeadingResponseData{}, le.ErrHeadingAlreadyCompleted
+	}
+
+	currentTime := time.Now()
+
+	completedHeading := model.Heading{
+		ID:          heading.ID,
+		UserID:      data.UserID,
+		CompletedAt: currentTime,
+		UpdatedAt:   currentTime,
+	}
+
+	if err = u.storage.Transaction(ctx, func(storage port.HeadingStorage) error {
+		if err = closeHeadingTasks(ctx, storage, completedHeading, data.Tasks); err != nil {
+			return err
+		}
+
+		return storage.CompleteHeading(ctx, completedHeading)
+	}); err != nil {
+		return model.HeadingResponseData{}, err
+	}
+
+	return model.HeadingResponseData{
+		ID:          heading.ID,
+		Title:       heading.Title,
+		ListID:      heading.ListID,
+		UserID:      heading.UserID,
+		CompletedAt: completedHeading.CompletedAt,
+		UpdatedAt:   completedHeading.UpdatedAt,
+	}, nil
+}
+
+// RestoreHeading brings the completed heading back to its list,
+// the tasks closed together with the heading are reopened
+func (u *HeadingUsecase) RestoreHeading(ctx context.Context, data model.HeadingRequestData) (model.HeadingResponseData, error) {
+	heading, err := u.getListHeading(ctx, data.ID, data.ListID, data.UserID)
+	if err != nil {
+		return model.HeadingResponseData{}, err
+	}
+	if heading.CompletedAt.IsZero() {
+		return model.HeadingResponseData{}, le.ErrHeadingNotCompleted
+	}
+
+	restoredHeading := model.Heading{
+		ID:        heading.ID,
+		UserID:    data.UserID,
+		UpdatedAt: time.Now(),
+	}
+
+	if err = u.storage.Transaction(ctx, func(storage port.HeadingStorage) error {
+		if err = storage.MarkTasksAsReopenedByHeadingID(ctx, model.Task{
+			HeadingID: restoredHeading.ID,
+			UserID:    restoredHeading.UserID,
+			UpdatedAt: restoredHeading.UpdatedAt,
+		}); err != nil {
+			return err
+		}
+
+		return storage.RestoreHeading(ctx, restoredHeading)
+	}); err != nil {
+		return model.HeadingResponseData{}, err
+	}
+
+	return model.HeadingResponseData{
+		ID:        heading.ID,
+		Title:     heading.Title,
+		ListID:    heading.ListID,
+		UserID:    heading.UserID,
+		UpdatedAt: restoredHeading.UpdatedAt,
+	}, nil
+}
+
+// closeHeadingTasks completes or cancels the open tasks of the completed heading
+func closeHeadingTasks(ctx context.Context, storage port.HeadingStorage, heading model.Heading, action model.HeadingTasksAction) error {
+	statusCompleted, err := storage.GetTaskStatusID(ctx, model.StatusCompleted)
+	if err != nil {
+		return err
+	}
+
+	closedTasks := model.Task{
+		StatusID:  statusCompleted,
+		UserID:    heading.UserID,
+		HeadingID: heading.ID,
+		UpdatedAt: heading.UpdatedAt,
+	}
+
+	if action == model.HeadingTasksCancel {
+		statusArchived, err := storage.GetTaskStatusID(ctx, model.StatusArchived)
+		if err != nil {
+			return err
+		}
+
+		closedTasks.StatusID = statusArchived
+		closedTasks.DeletedAt = heading.UpdatedAt
+	}
+
+	return storage.MarkTasksAsClosedByHeadingID(ctx, closedTasks, statusCompleted)
+}
+
+// getListHeading returns the heading if it belongs to the list
+func (u *HeadingUsecase) getListHeading(ctx context.Context, headingID, listID, userID string) (model.Heading, error) {
+	heading, err := u.storage.GetHeadingByID(ctx, headingID, userID)
+	if err != nil {
+		return model.Heading{}, err
+	}
+	if heading.ListID != listID {
+		return model.Heading{}, le.ErrHeadingNotFound
+	}
+
+	return heading, nil
+}
+
 func (u *HeadingUsecase) DeleteHeading(ctx context.Context, data *model.HeadingRequestData) error {
 	deletedHeading := model.Heading{
 		ID:        data.ID,
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"time"
 
 	"github.com/rshelekhov/reframed/internal/lib/constant/le"
@@ -14,6 +16,7 @@ import (
 
 type ListUsecase struct {
 	storage        port.ListStorage
+	AreaUsecase    port.AreaUsecase
 	HeadingUsecase port.HeadingUsecase
 	TaskUsecase    port.TaskUsecase
 }
@@ -23,15 +26,29 @@ func NewListUsecase(listStorage port.ListStorage) *ListUsecase {
 }
 
 func (u *ListUsecase) CreateList(ctx context.Context, data *model.ListRequestData) (model.ListResponseData, error) {
+	if data.AreaID != "" {
+		if _, err := u.AreaUsecase.GetAreaByID(ctx, model.AreaRequestData{
+			ID:     data.AreaID,
+			UserID: data.UserID,
+		}); err != nil {
+			return model.ListResponseData{}, err
+		}
+	}
+
 	currentTime := time.Now()
 
 	newList := model.List{
-		ID:        ksuid.New().String(),
-		Title:     data.Title,
-		IsDefault: false,
-		UserID:    data.UserID,
-		CreatedAt: currentTime,
-		UpdatedAt: currentTime,
+		ID:          ksuid.New().String(),
+		Title:       data.Title,
+		Description: data.Description,
+		Deadline:    data.DeadlineParsed,
+		Color:       data.Color,
+		Icon:        data.Icon,
+		IsDefault:   false,
+		AreaID:      data.AreaID,
+		UserID:      data.UserID,
+		CreatedAt:   currentTime,
+		UpdatedAt:   currentTime,
 	}
 
 	if err := u.storage.CreateList(ctx, newList); err != nil {
@@ -52,11 +69,16 @@ func (u *ListUsecase) CreateList(ctx context.Context, data *model.ListRequestDat
 	}
 
 	return model.ListResponseData{
-		ID:        newList.ID,
-		Title:     newList.Title,
-		UserID:    newList.UserID,
-		CreatedAt: newList.CreatedAt,
-		UpdatedAt: newList.UpdatedAt,
+		ID:          newList.ID,
+		Title:       newList.Title,
+		Description: newList.Description,
+		Deadline:    newList.Deadline,
+		Color:       newList.Color,
+		Icon:        newList.Icon,
+		AreaID:      newList.AreaID,
+		UserID:      newList.UserID,
+		CreatedAt:   newList.CreatedAt,
+		UpdatedAt:   newList.UpdatedAt,
 	}, nil
 }
 
@@ -97,12 +119,7 @@ func (u *ListUsecase) GetListByID(ctx context.Context, data model.ListRequestDat
 		return model.ListResponseData{}, err
 	}
 
-	return model.ListResponseData{
-		ID:        list.ID,
-		Title:     list.Title,
-		UserID:    list.UserID,
-		UpdatedAt: list.UpdatedAt,
-	}, nil
+	return mapListToResponseData(list), nil
 }
 
 func (u *ListUsecase) GetListsByUserID(ctx context.Context, userID string) ([]model.ListResponseData, error) {
@@ -111,15 +128,88 @@ func (u *ListUsecase) GetListsByUserID(ctx context.Context, userID string) ([]mo
 		return nil, err
 	}
 
+	var listsResp []model.ListResponseData
+
+	for _, list := range lists {
+		listsResp = append(listsResp, mapListToResponseData(list))
+	}
+
+	return listsResp, nil
+}
+
+// GetListsGroupedByArea returns the lists of the top level and the areas with their lists.
+// The default lists of the areas are returned as default_list_id of the areas
+func (u *ListUsecase) GetListsGroupedByArea(ctx context.Context, userID string) (model.ListsGroupByArea, error) {
+	lists, err := u.storage.GetListsByUserID(ctx, userID)
+	if err != nil {
+		return model.ListsGroupByArea{}, err
+	}
+
+	areas, err := u.AreaUsecase.GetAreasByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, le.ErrNoAreasFound) {
+		return model.ListsGroupByArea{}, err
+	}
+
+	var listsResp model.ListsGroupByArea
+
+	areaLists := make(map[string][]model.ListResponseData)
+
+	for _, list := range lists {
+		switch {
+		case list.AreaID == "":
+			listsResp.Lists = append(listsResp.Lists, mapListToResponseData(list))
+		case !list.IsDefault:
+			areaLists[list.AreaID] = append(areaLists[list.AreaID], mapListToResponseData(list))
+		}
+	}
+
+	for _, area := range areas {
+		area.Lists = areaLists[area.ID]
+		listsResp.Areas = append(listsResp.Areas, area)
+	}
+
+	return listsResp, nil
+}
+
+// GetListsByAreaID returns the lists of the area without its default list
+func (u *ListUsecase) GetListsByAreaID(ctx context.Context, data model.ListRequestData) ([]model.ListResponseData, error) {
+	lists, err := u.storage.GetListsByUserID(ctx, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
 	var listResp []model.ListResponseData
 
 	for _, list := range lists {
-		listResp = append(listResp, mapListToResponseData(list))
+		if list.AreaID == data.AreaID && !list.IsDefault {
+			listResp = append(listResp, mapListToResponseData(list))
+		}
 	}
 
 	return listResp, nil
 }
 
+// GetOverdueLists returns the lists with the deadline before today which still have open tasks
+func (u *ListUsecase) GetOverdueLists(ctx context.Context, userID string, pgn model.Pagination, today time.Time) ([]model.ListResponseData, error) {
+	lists, err := u.storage.GetOverdueLists(ctx, userID, pgn, today)
+	if err != nil {
+		return nil, err
+	}
+
+	var listsResp []model.ListResponseData
+
+	for _, list := range lists {
+		listsResp = append(listsResp, model.ListResponseData{
+			ID:       list.ID,
+			Title:    list.Title,
+			Deadline: list.Deadline,
+			UserID:   list.UserID,
+		})
+	}
+
+	return listsResp, nil
+}
+
 func (u *ListUsecase) GetDefaultListID(ctx context.Context, userID string) (string, error) {
 	listID, err := u.storage.GetDefaultListID(ctx, userID)
 	if err != nil {
@@ -129,33 +219,133 @@ func (u *ListUsecase) GetDefaultListID(ctx context.Context, userID string) (stri
 	return listID, nil
 }
 
+func (u *ListUsecase) GetListIDByTitle(ctx context.Context, data model.ListRequestData) (string, error) {
+	return u.storage.GetListIDByTitle(ctx, data.Title, data.UserID)
+}
+
 func mapListToResponseData(list model.List) model.ListResponseData {
 	return model.ListResponseData{
-		ID:        list.ID,
-		Title:     list.Title,
-		UserID:    list.UserID,
-		UpdatedAt: list.UpdatedAt,
+		ID:          list.ID,
+		Title:       list.Title,
+		Description: list.Description,
+		Deadline:    list.Deadline,
+		Color:       list.Color,
+		Icon:        list.Icon,
+		AreaID:      list.AreaID,
+		IsDefault:   list.IsDefault,
+		Progress:    listProgress(list),
+		UserID:      list.UserID,
+		UpdatedAt:   list.UpdatedAt,
+	}
+}
+
+func listProgress(list model.List) *model.ListProgress {
+	progress := &model.ListProgress{
+		CompletedTasks: list.CompletedTasks,
+		TotalTasks:     list.TotalTasks,
+	}
+
+	if list.TotalTasks > 0 {
+		progress.Percent = list.CompletedTasks * 100 / list.TotalTasks
 	}
+
+	return progress
 }
 
+// UpdateList keeps the optional fields that are not set in the request, the fields
+// from data.Clear are removed from the list
 func (u *ListUsecase) UpdateList(ctx context.Context, data *model.ListRequestData) (model.ListResponseData, error) {
 	updatedList := model.List{
+		ID:          data.ID,
+		Title:       data.Title,
+		Description: data.Description,
+		Deadline:    data.DeadlineParsed,
+		Color:       data.Color,
+		Icon:        data.Icon,
+		UserID:      data.UserID,
+		UpdatedAt:   time.Now(),
+	}
+
+	for _, field := range data.Clear {
+		switch field {
+		case model.ListFieldDescription:
+			updatedList.Description = ""
+		case model.ListFieldDeadline:
+			updatedList.Deadline = time.Time{}
+		case model.ListFieldColor:
+			updatedList.Color = ""
+		case model.ListFieldIcon:
+			updatedList.Icon = ""
+		}
+	}
+
+	if err := u.storage.UpdateList(ctx, updatedList, data.Clear); err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	return model.ListResponseData{
+		ID:          updatedList.ID,
+		Title:       updatedList.Title,
+		Description: updatedList.Description,
+		Deadline:    updatedList.Deadline,
+		Color:       updatedList.Color,
+		Icon:        updatedList.Icon,
+		UserID:      updatedList.UserID,
+		UpdatedAt:   updatedList.UpdatedAt,
+	}, nil
+}
+
+// MoveList moves the list into the area, or to the top level if AreaID is empty,
+// and places it at the position among the lists there
+func (u *ListUsecase) MoveList(ctx context.Context, data *model.ListMoveRequestData) (model.ListResponseData, error) {
+	list, err := u.storage.GetListByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.ListResponseData{}, err
+	}
+	if list.IsDefault {
+		return model.ListResponseData{}, le.ErrCannotMoveDefaultList
+	}
+
+	if data.AreaID != "" {
+		if _, err = u.AreaUsecase.GetAreaByID(ctx, model.AreaRequestData{
+			ID:     data.AreaID,
+			UserID: data.UserID,
+		}); err != nil {
+			return model.ListResponseData{}, err
+		}
+	}
+
+	movedList := model.List{
 		ID:        data.ID,
-		Title:     data.Title,
+		AreaID:    data.AreaID,
 		UserID:    data.UserID,
 		UpdatedAt: time.Now(),
 	}
 
-	if err := u.storage.UpdateList(ctx, updatedList); err != nil {
+	if err = u.storage.Transaction(ctx, func(storage port.ListStorage) error {
+		if err = storage.UpdateListArea(ctx, movedList); err != nil {
+			return err
+		}
+
+		listIDs, err := storage.GetListIDsByAreaID(ctx, data.AreaID, data.UserID)
+		if err != nil {
+			return err
+		}
+
+		listIDs = slices.DeleteFunc(listIDs, func(listID string) bool {
+			return listID == data.ID
+		})
+		listIDs = slices.Insert(listIDs, min(data.Position, len(listIDs)), data.ID)
+
+		return storage.UpdateListsPosition(ctx, data.UserID, listIDs)
+	}); err != nil {
 		return model.ListResponseData{}, err
 	}
 
-	return model.ListResponseData{
-		ID:        updatedList.ID,
-		Title:     updatedList.Title,
-		UserID:    updatedList.UserID,
-		UpdatedAt: updatedList.UpdatedAt,
-	}, nil
+	return u.GetListByID(ctx, model.ListRequestData{
+		ID:     data.ID,
+		UserID: data.UserID,
+	})
 }
 
 func (u *ListUsecase) DeleteList(ctx context.Context, data model.ListRequestData) error {
@@ -168,13 +358,17 @@ func (u *ListUsecase) DeleteList(ctx context.Context, data model.ListRequestData
 		return le.ErrCannotDeleteDefaultList
 	}
 
+	return u.deleteList(ctx, data)
+}
+
+func (u *ListUsecase) deleteList(ctx context.Context, data model.ListRequestData) error {
 	deletedList := model.List{
 		ID:        data.ID,
 		UserID:    data.UserID,
 		DeletedAt: time.Now(),
 	}
 
-	if err = u.storage.DeleteList(ctx, deletedList); err != nil {
+	if err := u.storage.DeleteList(ctx, deletedList); err != nil {
 		return err
 	}
 
@@ -184,7 +378,7 @@ func (u *ListUsecase) DeleteList(ctx context.Context, data model.ListRequestData
 		UserID: data.UserID,
 	}
 
-	if err = u.HeadingUsecase.DeleteHeadingsByListID(ctx, headingsData); err != nil {
+	if err := u.HeadingUsecase.DeleteHeadingsByListID(ctx, headingsData); err != nil {
 		return err
 	}
 
@@ -194,7 +388,7 @@ func (u *ListUsecase) DeleteList(ctx context.Context, data model.ListRequestData
 		UserID: data.UserID,
 	}
 
-	if err = u.TaskUsecase.ArchiveTasksByListID(ctx, tasksData); err != nil {
+	if err := u.TaskUsecase.ArchiveTasksByListID(ctx, tasksData); err != nil {
 		return err
 	}
 
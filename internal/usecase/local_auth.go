@@ -0,0 +1,680 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rshelekhov/reframed/internal/config"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/mailer"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+	ssov1 "github.com/rshelekhov/sso-protos/gen/go/sso"
+)
+
+const (
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+	defaultEmailTokenTTL   = 24 * time.Hour
+
+	// localAuthTokenSize is the number of random bytes in the refresh, verification and reset password tokens
+	localAuthTokenSize = 32
+
+	// localJWKSTTL is how long the token service may keep the key, the key changes only after restarts
+	localJWKSTTL = time.Hour
+)
+
+var errInvalidCredentials = status.Error(codes.Unauthenticated, "invalid credentials")
+
+// LocalAuthProvider implements port.AuthProvider and port.SessionProvider without the SSO service: the users, sessions and email tokens
+// are kept in the storage and the access tokens are signed by the service itself. The errors are returned
+// as gRPC statuses with the same codes as the SSO service, so the AuthUsecase handles both providers the same way
+type LocalAuthProvider struct {
+	cfg     *config.ServerSettings
+	log     *slog.Logger
+	storage port.LocalAuthStorage
+	mailer  port.Mailer
+	signer  *jwtoken.Signer
+	domain  string
+}
+
+func NewLocalAuthProvider(
+	cfg *config.ServerSettings,
+	log *slog.Logger,
+	storage port.LocalAuthStorage,
+	mailer port.Mailer,
+) (*LocalAuthProvider, error) {
+	var (
+		signer *jwtoken.Signer
+		err    error
+	)
+
+	if cfg.Auth.PrivateKeyPath != "" {
+		signer, err = jwtoken.LoadSigner(cfg.Auth.PrivateKeyPath)
+	} else {
+		log.Warn("auth private key path is not set, the tokens will not be valid after restarts")
+		signer, err = jwtoken.GenerateSigner()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Auth.AccessTokenTTL <= 0 {
+		cfg.Auth.AccessTokenTTL = defaultAccessTokenTTL
+	}
+	if cfg.Auth.RefreshTokenTTL <= 0 {
+		cfg.Auth.RefreshTokenTTL = defaultRefreshTokenTTL
+	}
+	if cfg.Auth.EmailTokenTTL <= 0 {
+		cfg.Auth.EmailTokenTTL = defaultEmailTokenTTL
+	}
+
+	var domain string
+	if baseURL, err := url.Parse(cfg.AppData.BaseURL); err == nil {
+		domain = baseURL.Hostname()
+	}
+
+	return &LocalAuthProvider{
+		cfg:     cfg,
+		log:     log,
+		storage: storage,
+		mailer:  mailer,
+		signer:  signer,
+		domain:  domain,
+	}, nil
+}
+
+func (p *LocalAuthProvider) RegisterUser(ctx context.Context, in *ssov1.RegisterUserRequest, _ ...grpc.CallOption) (*ssov1.RegisterUserResponse, error) {
+	if err := p.checkAppID(in.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	if in.GetEmail() == "" || in.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email and password are required")
+	}
+
+	passwordHash, err := p.passwordHash(in.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+
+	currentTime := time.Now()
+
+	user := model.AuthUser{
+		ID:           ksuid.New().String(),
+		Email:        in.GetEmail(),
+		PasswordHash: passwordHash,
+		CreatedAt:    currentTime,
+		UpdatedAt:    currentTime,
+	}
+
+	if err = p.storage.CreateUser(ctx, user); err != nil {
+		return nil, statusFromError(err)
+	}
+
+	tokenData, err := p.issueTokens(ctx, user, in.GetUserDeviceData())
+	if err != nil {
+		return nil, err
+	}
+
+	// The user and the session are already stored, so a failed email doesn't fail the registration
+	if err = p.sendVerificationEmail(ctx, user, in.GetVerificationURL()); err != nil {
+		p.log.Warn("user registered without the verification email", slog.String(key.UserID, user.ID), logger.Err(err))
+	}
+
+	return &ssov1.RegisterUserResponse{TokenData: tokenData}, nil
+}
+
+func (p *LocalAuthProvider) VerifyEmail(ctx context.Context, in *ssov1.VerifyEmailRequest, _ ...grpc.CallOption) (*ssov1.Empty, error) {
+	token, err := p.storage.GetToken(ctx, hashToken(in.GetVerificationToken()), model.AuthTokenTypeVerifyEmail)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	user, err := p.storage.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	if time.Now().After(token.ExpiresAt) {
+		if err = p.sendVerificationEmail(ctx, user, p.cfg.AppData.VerificationURL()); err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.FailedPrecondition, "verification token expired, a new one has been sent")
+	}
+
+	user.Verified = true
+	user.UpdatedAt = time.Now()
+
+	if err = p.storage.UpdateUser(ctx, user); err != nil {
+		return nil, statusFromError(err)
+	}
+
+	if err = p.storage.DeleteTokensByUserID(ctx, user.ID, model.AuthTokenTypeVerifyEmail); err != nil {
+		return nil, statusFromError(err)
+	}
+
+	return &ssov1.Empty{}, nil
+}
+
+func (p *LocalAuthProvider) Login(ctx context.Context, in *ssov1.LoginRequest, _ ...grpc.CallOption) (*ssov1.LoginResponse, error) {
+	if err := p.checkAppID(in.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	// An unknown email gets the same error as a wrong password, so the emails of the users can't be probed
+	user, err := p.storage.GetUserByEmail(ctx, in.GetEmail())
+	if errors.Is(err, le.ErrUserNotFound) {
+		return nil, errInvalidCredentials
+	}
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	match, err := jwtoken.PasswordMatch(user.PasswordHash, in.GetPassword(), []byte(p.cfg.AppData.Secret))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !match {
+		return nil, errInvalidCredentials
+	}
+
+	tokenData, err := p.issueTokens(ctx, user, in.GetUserDeviceData())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssov1.LoginResponse{TokenData: tokenData}, nil
+}
+
+// ResetPassword doesn't tell if the user exists, the reset password email is sent only to the registered emails
+func (p *LocalAuthProvider) ResetPassword(ctx context.Context, in *ssov1.ResetPasswordRequest, _ ...grpc.CallOption) (*ssov1.Empty, error) {
+	if err := p.checkAppID(in.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	user, err := p.storage.GetUserByEmail(ctx, in.GetEmail())
+	if errors.Is(err, le.ErrUserNotFound) {
+		return &ssov1.Empty{}, nil
+	}
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	if err = p.sendResetPasswordEmail(ctx, user, in.GetConfirmChangePasswordURL()); err != nil {
+		return nil, err
+	}
+
+	return &ssov1.Empty{}, nil
+}
+
+func (p *LocalAuthProvider) ChangePassword(ctx context.Context, in *ssov1.ChangePasswordRequest, _ ...grpc.CallOption) (*ssov1.Empty, error) {
+	if err := p.checkAppID(in.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	token, err := p.storage.GetToken(ctx, hashToken(in.GetResetPasswordToken()), model.AuthTokenTypeResetPassword)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	user, err := p.storage.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	if time.Now().After(token.ExpiresAt) {
+		if err = p.sendResetPasswordEmail(ctx, user, p.cfg.AppData.ConfirmChangePasswordURL()); err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.FailedPrecondition, "reset password token expired, a new one has been sent")
+	}
+
+	match, err := jwtoken.PasswordMatch(user.PasswordHash, in.GetUpdatedPassword(), []byte(p.cfg.AppData.Secret))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if match {
+		return nil, status.Error(codes.InvalidArgument, "updated password must not match the current password")
+	}
+
+	if user.PasswordHash, err = p.passwordHash(in.GetUpdatedPassword()); err != nil {
+		return nil, err
+	}
+
+	user.UpdatedAt = time.Now()
+
+	if err = p.storage.UpdateUser(ctx, user); err != nil {
+		return nil, statusFromError(err)
+	}
+
+	if err = p.storage.DeleteTokensByUserID(ctx, user.ID, model.AuthTokenTypeResetPassword); err != nil {
+		return nil, statusFromError(err)
+	}
+
+	// The password may have been reset because it leaked, so the user is logged out on all the devices
+	if err = p.storage.DeleteSessionsByUserID(ctx, user.ID); err != nil {
+		return nil, statusFromError(err)
+	}
+
+	return &ssov1.Empty{}, nil
+}
+
+func (p *LocalAuthProvider) Logout(ctx context.Context, in *ssov1.LogoutRequest, _ ...grpc.CallOption) (*ssov1.Empty, error) {
+	if err := p.checkAppID(in.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	user, err := p.userFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = p.storage.DeleteSessionsByDevice(ctx, model.AuthSession{
+		UserID:    user.ID,
+		UserAgent: in.GetUserDeviceData().GetUserAgent(),
+		IP:        in.GetUserDeviceData().GetIp(),
+	}); err != nil {
+		return nil, statusFromError(err)
+	}
+
+	return &ssov1.Empty{}, nil
+}
+
+// Refresh rotates the session, the refresh token can be used only once
+func (p *LocalAuthProvider) Refresh(ctx context.Context, in *ssov1.RefreshRequest, _ ...grpc.CallOption) (*ssov1.RefreshResponse, error) {
+	if err := p.checkAppID(in.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	tokenHash := hashToken(in.GetRefreshToken())
+
+	session, err := p.storage.GetSession(ctx, tokenHash)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		if err = p.storage.DeleteSession(ctx, tokenHash); err != nil {
+			return nil, statusFromError(err)
+		}
+		return nil, status.Error(codes.Unauthenticated, "session expired")
+	}
+
+	user, err := p.storage.GetUserByID(ctx, session.UserID)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	tokenData, newSession, err := p.newTokens(user, session.ID, in.GetUserDeviceData())
+	if err != nil {
+		return nil, err
+	}
+
+	// The session keeps its ID, the rotation fails if the refresh token has been used in the meantime
+	if err = p.storage.RotateSession(ctx, tokenHash, newSession); err != nil {
+		return nil, statusFromError(err)
+	}
+
+	return &ssov1.RefreshResponse{TokenData: tokenData}, nil
+}
+
+func (p *LocalAuthProvider) GetSessions(ctx context.Context) ([]model.Session, error) {
+	user, err := p.userFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	authSessions, err := p.storage.GetSessionsByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	sessions := make([]model.Session, 0, len(authSessions))
+
+	for _, session := range authSessions {
+		sessions = append(sessions, model.Session{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			LastSeenAt: session.LastSeenAt,
+			CreatedAt:  session.CreatedAt,
+		})
+	}
+
+	return sessions, nil
+}
+
+func (p *LocalAuthProvider) RevokeSession(ctx context.Context, sessionID string) error {
+	user, err := p.userFromMetadata(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.storage.DeleteSessionByID(ctx, model.AuthSession{
+		ID:     sessionID,
+		UserID: user.ID,
+	})
+	if errors.Is(err, le.ErrSessionNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return statusFromError(err)
+	}
+
+	return nil
+}
+
+// RevokeOtherSessions keeps only the session the request is sent from
+func (p *LocalAuthProvider) RevokeOtherSessions(ctx context.Context, currentSessionID string) error {
+	user, err := p.userFromMetadata(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = p.storage.DeleteOtherSessions(ctx, model.AuthSession{
+		ID:     currentSessionID,
+		UserID: user.ID,
+	}); err != nil {
+		return statusFromError(err)
+	}
+
+	return nil
+}
+
+func (p *LocalAuthProvider) GetJWKS(_ context.Context, in *ssov1.GetJWKSRequest, _ ...grpc.CallOption) (*ssov1.GetJWKSResponse, error) {
+	if err := p.checkAppID(in.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	return &ssov1.GetJWKSResponse{
+		Jwks: []*ssov1.JWK{p.signer.JWK()},
+		Ttl:  durationpb.New(localJWKSTTL),
+	}, nil
+}
+
+func (p *LocalAuthProvider) GetUser(ctx context.Context, in *ssov1.GetUserRequest, _ ...grpc.CallOption) (*ssov1.GetUserResponse, error) {
+	if err := p.checkAppID(in.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	user, err := p.userFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssov1.GetUserResponse{
+		Email:     user.Email,
+		Verified:  user.Verified,
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}, nil
+}
+
+func (p *LocalAuthProvider) UpdateUser(ctx context.Context, in *ssov1.UpdateUserRequest, _ ...grpc.CallOption) (*ssov1.Empty, error) {
+	if err := p.checkAppID(in.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	user, err := p.userFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.GetUpdatedPassword() != "" {
+		match, err := jwtoken.PasswordMatch(user.PasswordHash, in.GetCurrentPassword(), []byte(p.cfg.AppData.Secret))
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if !match {
+			return nil, status.Error(codes.InvalidArgument, "invalid current password")
+		}
+
+		if in.GetUpdatedPassword() == in.GetCurrentPassword() {
+			return nil, status.Error(codes.InvalidArgument, "updated password must not match the current password")
+		}
+
+		if user.PasswordHash, err = p.passwordHash(in.GetUpdatedPassword()); err != nil {
+			return nil, err
+		}
+	}
+
+	// The new email has to be verified again
+	emailChanged := in.GetEmail() != "" && in.GetEmail() != user.Email
+	if emailChanged {
+		user.Email = in.GetEmail()
+		user.Verified = false
+	}
+
+	user.UpdatedAt = time.Now()
+
+	if err = p.storage.UpdateUser(ctx, user); err != nil {
+		return nil, statusFromError(err)
+	}
+
+	if in.GetUpdatedPassword() != "" {
+		if err = p.storage.DeleteSessionsByUserID(ctx, user.ID); err != nil {
+			return nil, statusFromError(err)
+		}
+	}
+
+	if emailChanged {
+		if err = p.sendVerificationEmail(ctx, user, p.cfg.AppData.VerificationURL()); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ssov1.Empty{}, nil
+}
+
+func (p *LocalAuthProvider) DeleteUser(ctx context.Context, in *ssov1.DeleteUserRequest, _ ...grpc.CallOption) (*ssov1.Empty, error) {
+	if err := p.checkAppID(in.GetAppID()); err != nil {
+		return nil, err
+	}
+
+	user, err := p.userFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = p.storage.DeleteUser(ctx, user.ID); err != nil {
+		return nil, statusFromError(err)
+	}
+
+	return &ssov1.Empty{}, nil
+}
+
+func (p *LocalAuthProvider) checkAppID(appID string) error {
+	if appID != p.cfg.AppData.ID {
+		return status.Error(codes.Unauthenticated, "app ID does not exist")
+	}
+	return nil
+}
+
+func (p *LocalAuthProvider) passwordHash(password string) (string, error) {
+	passwordHash, err := jwtoken.PasswordHashBcrypt(password, 0, []byte(p.cfg.AppData.Secret))
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	return passwordHash, nil
+}
+
+// userFromMetadata returns the owner of the access token added to the outgoing metadata by the AuthUsecase
+func (p *LocalAuthProvider) userFromMetadata(ctx context.Context) (model.AuthUser, error) {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok || len(md.Get(jwtoken.AccessTokenKey)) == 0 {
+		return model.AuthUser{}, status.Error(codes.Unauthenticated, "access token not found in metadata")
+	}
+
+	userID, err := p.signer.ParseAccessToken(md.Get(jwtoken.AccessTokenKey)[0])
+	if err != nil {
+		return model.AuthUser{}, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	user, err := p.storage.GetUserByID(ctx, userID)
+	if err != nil {
+		return model.AuthUser{}, statusFromError(err)
+	}
+
+	return user, nil
+}
+
+// issueTokens signs a new access token and opens a new session for the device
+func (p *LocalAuthProvider) issueTokens(ctx context.Context, user model.AuthUser, device *ssov1.UserDeviceData) (*ssov1.TokenData, error) {
+	tokenData, session, err := p.newTokens(user, ksuid.New().String(), device)
+	if err != nil {
+		return nil, err
+	}
+
+	session.CreatedAt = session.LastSeenAt
+
+	if err = p.storage.CreateSession(ctx, session); err != nil {
+		return nil, statusFromError(err)
+	}
+
+	return tokenData, nil
+}
+
+// newTokens signs a new access token for the session and generates a new refresh token, the returned
+// session holds the hash of the refresh token and has to be stored by the caller
+func (p *LocalAuthProvider) newTokens(user model.AuthUser, sessionID string, device *ssov1.UserDeviceData) (*ssov1.TokenData, model.AuthSession, error) {
+	accessToken, err := p.signer.SignAccessToken(user.ID, p.cfg.AppData.ID, user.Email, sessionID, p.cfg.Auth.AccessTokenTTL)
+	if err != nil {
+		return nil, model.AuthSession{}, status.Error(codes.Internal, err.Error())
+	}
+
+	refreshToken, err := newLocalAuthToken()
+	if err != nil {
+		return nil, model.AuthSession{}, err
+	}
+
+	currentTime := time.Now()
+	expiresAt := currentTime.Add(p.cfg.Auth.RefreshTokenTTL)
+
+	session := model.AuthSession{
+		ID:         sessionID,
+		TokenHash:  hashToken(refreshToken),
+		UserID:     user.ID,
+		UserAgent:  device.GetUserAgent(),
+		IP:         device.GetIp(),
+		ExpiresAt:  expiresAt,
+		LastSeenAt: currentTime,
+	}
+
+	return &ssov1.TokenData{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		Domain:       p.domain,
+		Path:         "/",
+		ExpiresAt:    timestamppb.New(expiresAt),
+		HttpOnly:     true,
+	}, session, nil
+}
+
+func (p *LocalAuthProvider) sendVerificationEmail(ctx context.Context, user model.AuthUser, verificationURL string) error {
+	token, err := p.createEmailToken(ctx, user.ID, model.AuthTokenTypeVerifyEmail)
+	if err != nil {
+		return err
+	}
+
+	return p.sendEmail(ctx, user.Email, "Verify your email", mailer.TemplateVerifyEmail, verificationURL+token)
+}
+
+func (p *LocalAuthProvider) sendResetPasswordEmail(ctx context.Context, user model.AuthUser, confirmChangePasswordURL string) error {
+	token, err := p.createEmailToken(ctx, user.ID, model.AuthTokenTypeResetPassword)
+	if err != nil {
+		return err
+	}
+
+	return p.sendEmail(ctx, user.Email, "Reset your password", mailer.TemplateResetPassword, confirmChangePasswordURL+token)
+}
+
+// createEmailToken replaces the previous tokens of the same type, so only the last email sent is valid
+func (p *LocalAuthProvider) createEmailToken(ctx context.Context, userID string, tokenType model.AuthTokenType) (string, error) {
+	if err := p.storage.DeleteTokensByUserID(ctx, userID, tokenType); err != nil {
+		return "", statusFromError(err)
+	}
+
+	token, err := newLocalAuthToken()
+	if err != nil {
+		return "", err
+	}
+
+	currentTime := time.Now()
+
+	if err = p.storage.CreateToken(ctx, model.AuthToken{
+		TokenHash: hashToken(token),
+		UserID:    userID,
+		Type:      tokenType,
+		ExpiresAt: currentTime.Add(p.cfg.Auth.EmailTokenTTL),
+		CreatedAt: currentTime,
+	}); err != nil {
+		return "", statusFromError(err)
+	}
+
+	return token, nil
+}
+
+// sendEmail renders the template with the link the user has to follow
+func (p *LocalAuthProvider) sendEmail(ctx context.Context, to, subject string, template mailer.Template, url string) error {
+	email, err := mailer.NewEmail(to, subject, template, struct {
+		AppName string
+		URL     string
+	}{
+		AppName: p.cfg.AppData.Name,
+		URL:     url,
+	})
+	if err == nil {
+		err = p.mailer.SendEmail(ctx, email)
+	}
+	if err != nil {
+		p.log.Error(le.ErrFailedToSendEmail.Error(), slog.String(key.Email, to), logger.Err(err))
+		return status.Error(codes.Internal, le.ErrFailedToSendEmail.Error())
+	}
+	return nil
+}
+
+func newLocalAuthToken() (string, error) {
+	tokenBytes := make([]byte, localAuthTokenSize)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
+
+// hashToken is used for the random tokens only, they don't need a slow hash like the passwords
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// statusFromError converts the storage errors to the gRPC statuses the SSO service would return
+func statusFromError(err error) error {
+	switch {
+	case errors.Is(err, le.ErrUserAlreadyExists), errors.Is(err, le.ErrEmailAlreadyTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, le.ErrUserNotFound), errors.Is(err, le.ErrAuthTokenNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, le.ErrSessionNotFound):
+		return status.Error(codes.Unauthenticated, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,576 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	ssov1 "github.com/rshelekhov/sso-protos/gen/go/sso"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/rshelekhov/reframed/internal/config"
+	"github.com/rshelekhov/reframed/internal/lib/constant/key"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/usecase"
+)
+
+const (
+	appID           = "test_app"
+	verificationURL = "https://reframed.test/verify-email/?token="
+	resetURL        = "https://reframed.test/password/change?token="
+)
+
+var (
+	device       = &ssov1.UserDeviceData{UserAgent: "test", Ip: "127.0.0.1"}
+	emailTokenRe = regexp.MustCompile(`token=([A-Za-z0-9_-]+)`)
+)
+
+// localAuthStorage keeps the sessions and the email tokens by the hashes of the tokens like the Postgres storage
+type localAuthStorage struct {
+	users    map[string]model.AuthUser
+	sessions map[string]model.AuthSession
+	tokens   map[string]model.AuthToken
+}
+
+func newLocalAuthStorage() *localAuthStorage {
+	return &localAuthStorage{
+		users:    make(map[string]model.AuthUser),
+		sessions: make(map[string]model.AuthSession),
+		tokens:   make(map[string]model.AuthToken),
+	}
+}
+
+func (s *localAuthStorage) CreateUser(_ context.Context, user model.AuthUser) error {
+	if _, err := s.GetUserByEmail(context.Background(), user.Email); err == nil {
+		return le.ErrUserAlreadyExists
+	}
+	s.users[user.ID] = user
+	return nil
+}
+
+func (s *localAuthStorage) GetUserByEmail(_ context.Context, email string) (model.AuthUser, error) {
+	for _, user := range s.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return model.AuthUser{}, le.ErrUserNotFound
+}
+
+func (s *localAuthStorage) GetUserByID(_ context.Context, userID string) (model.AuthUser, error) {
+	user, ok := s.users[userID]
+	if !ok {
+		return model.AuthUser{}, le.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (s *localAuthStorage) UpdateUser(_ context.Context, user model.AuthUser) error {
+	s.users[user.ID] = user
+	return nil
+}
+
+func (s *localAuthStorage) DeleteUser(_ context.Context, userID string) error {
+	delete(s.users, userID)
+	return nil
+}
+
+func (s *localAuthStorage) CreateSession(_ context.Context, session model.AuthSession) error {
+	s.sessions[session.TokenHash] = session
+	return nil
+}
+
+func (s *localAuthStorage) GetSession(_ context.Context, tokenHash string) (model.AuthSession, error) {
+	session, ok := s.sessions[tokenHash]
+	if !ok {
+		return model.AuthSession{}, le.ErrSessionNotFound
+	}
+	return session, nil
+}
+
+func (s *localAuthStorage) GetSessionsByUserID(_ context.Context, userID string) ([]model.AuthSession, error) {
+	var sessions []model.AuthSession
+	for _, session := range s.sessions {
+		if session.UserID == userID && session.ExpiresAt.After(time.Now()) {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func (s *localAuthStorage) RotateSession(_ context.Context, tokenHash string, session model.AuthSession) error {
+	oldSession, ok := s.sessions[tokenHash]
+	if !ok {
+		return le.ErrSessionNotFound
+	}
+
+	delete(s.sessions, tokenHash)
+
+	session.ID = oldSession.ID
+	session.CreatedAt = oldSession.CreatedAt
+	s.sessions[session.TokenHash] = session
+
+	return nil
+}
+
+func (s *localAuthStorage) DeleteSession(_ context.Context, tokenHash string) error {
+	delete(s.sessions, tokenHash)
+	return nil
+}
+
+func (s *localAuthStorage) DeleteSessionByID(_ context.Context, session model.AuthSession) error {
+	for tokenHash, existing := range s.sessions {
+		if existing.ID == session.ID && existing.UserID == session.UserID {
+			delete(s.sessions, tokenHash)
+			return nil
+		}
+	}
+	return le.ErrSessionNotFound
+}
+
+func (s *localAuthStorage) DeleteSessionsByDevice(_ context.Context, session model.AuthSession) error {
+	for tokenHash, existing := range s.sessions {
+		if existing.UserID == session.UserID && existing.UserAgent == session.UserAgent && existing.IP == session.IP {
+			delete(s.sessions, tokenHash)
+		}
+	}
+	return nil
+}
+
+func (s *localAuthStorage) DeleteSessionsByUserID(_ context.Context, userID string) error {
+	for tokenHash, existing := range s.sessions {
+		if existing.UserID == userID {
+			delete(s.sessions, tokenHash)
+		}
+	}
+	return nil
+}
+
+func (s *localAuthStorage) DeleteOtherSessions(_ context.Context, session model.AuthSession) error {
+	for tokenHash, existing := range s.sessions {
+		if existing.UserID == session.UserID && existing.ID != session.ID {
+			delete(s.sessions, tokenHash)
+		}
+	}
+	return nil
+}
+
+func (s *localAuthStorage) CreateToken(_ context.Context, token model.AuthToken) error {
+	s.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (s *localAuthStorage) GetToken(_ context.Context, tokenHash string, tokenType model.AuthTokenType) (model.AuthToken, error) {
+	token, ok := s.tokens[tokenHash]
+	if !ok || token.Type != tokenType {
+		return model.AuthToken{}, le.ErrAuthTokenNotFound
+	}
+	return token, nil
+}
+
+func (s *localAuthStorage) DeleteTokensByUserID(_ context.Context, userID string, tokenType model.AuthTokenType) error {
+	for tokenHash, token := range s.tokens {
+		if token.UserID == userID && token.Type == tokenType {
+			delete(s.tokens, tokenHash)
+		}
+	}
+	return nil
+}
+
+// expireTokens moves the expiration time of all the sessions and the email tokens to the past
+func (s *localAuthStorage) expireTokens() {
+	expiredAt := time.Now().Add(-time.Minute)
+
+	for tokenHash, session := range s.sessions {
+		session.ExpiresAt = expiredAt
+		s.sessions[tokenHash] = session
+	}
+
+	for tokenHash, token := range s.tokens {
+		token.ExpiresAt = expiredAt
+		s.tokens[tokenHash] = token
+	}
+}
+
+// mailer records the sent emails, or fails to send them when err is set
+type mailer struct {
+	emails []model.Email
+	err    error
+}
+
+func (m *mailer) SendEmail(_ context.Context, email model.Email) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.emails = append(m.emails, email)
+	return nil
+}
+
+// lastEmailToken returns the token from the link in the last email sent
+func (m *mailer) lastEmailToken(t *testing.T) string {
+	t.Helper()
+
+	if len(m.emails) == 0 {
+		t.Fatalf("Expected an email to be sent")
+	}
+
+	match := emailTokenRe.FindStringSubmatch(m.emails[len(m.emails)-1].Text)
+	if match == nil {
+		t.Fatalf("Expected a token in the email, got %q", m.emails[len(m.emails)-1].Text)
+	}
+
+	return match[1]
+}
+
+func newLocalAuthProvider(t *testing.T) (*usecase.LocalAuthProvider, *localAuthStorage, *mailer) {
+	t.Helper()
+
+	cfg := &config.ServerSettings{
+		AppData: config.AppDataSettings{
+			ID:      appID,
+			Name:    "reframed",
+			Secret:  "secret",
+			BaseURL: "https://reframed.test",
+		},
+	}
+
+	storage := newLocalAuthStorage()
+	emailSender := &mailer{}
+
+	provider, err := usecase.NewLocalAuthProvider(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)), storage, emailSender)
+	if err != nil {
+		t.Fatalf("Failed to create local auth provider: %v", err)
+	}
+
+	return provider, storage, emailSender
+}
+
+func registerUser(t *testing.T, provider *usecase.LocalAuthProvider, email, password string) *ssov1.TokenData {
+	t.Helper()
+
+	resp, err := provider.RegisterUser(context.Background(), &ssov1.RegisterUserRequest{
+		Email:           email,
+		Password:        password,
+		AppID:           appID,
+		VerificationURL: verificationURL,
+		UserDeviceData:  device,
+	})
+	if err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+
+	return resp.GetTokenData()
+}
+
+func login(provider *usecase.LocalAuthProvider, email, password string) (*ssov1.TokenData, error) {
+	resp, err := provider.Login(context.Background(), &ssov1.LoginRequest{
+		Email:          email,
+		Password:       password,
+		AppID:          appID,
+		UserDeviceData: device,
+	})
+	return resp.GetTokenData(), err
+}
+
+func refresh(provider *usecase.LocalAuthProvider, refreshToken string) (*ssov1.TokenData, error) {
+	resp, err := provider.Refresh(context.Background(), &ssov1.RefreshRequest{
+		RefreshToken:   refreshToken,
+		AppID:          appID,
+		UserDeviceData: device,
+	})
+	return resp.GetTokenData(), err
+}
+
+func TestLocalAuthProvider_RegisterUser(t *testing.T) {
+	provider, storage, emailSender := newLocalAuthProvider(t)
+
+	registerUser(t, provider, "alice@example.com", "Password1!")
+
+	if len(emailSender.emails) != 1 || emailSender.emails[0].To != "alice@example.com" {
+		t.Errorf("Expected the verification email to alice@example.com, got %+v", emailSender.emails)
+	}
+
+	// The registration doesn't fail after the user is stored
+	emailSender.err = errors.New("smtp is down")
+
+	tokenData := registerUser(t, provider, "bob@example.com", "Password1!")
+
+	if tokenData.GetRefreshToken() == "" {
+		t.Errorf("Expected the tokens for the user registered without the email")
+	}
+
+	if _, err := storage.GetUserByEmail(context.Background(), "bob@example.com"); err != nil {
+		t.Errorf("Expected the user to be stored: %v", err)
+	}
+}
+
+func TestLocalAuthProvider_Login(t *testing.T) {
+	provider, _, _ := newLocalAuthProvider(t)
+
+	registerUser(t, provider, "alice@example.com", "Password1!")
+
+	testCases := []struct {
+		name         string
+		email        string
+		password     string
+		expectedCode codes.Code
+	}{
+		{
+			name:         "Valid credentials",
+			email:        "alice@example.com",
+			password:     "Password1!",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Wrong password",
+			email:        "alice@example.com",
+			password:     "wrong",
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "Unknown email",
+			email:        "unknown@example.com",
+			password:     "Password1!",
+			expectedCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokenData, err := login(provider, tc.email, tc.password)
+
+			if code := status.Code(err); code != tc.expectedCode {
+				t.Fatalf("Expected code %v, got %v", tc.expectedCode, err)
+			}
+
+			if tc.expectedCode == codes.OK && tokenData.GetAccessToken() == "" {
+				t.Errorf("Expected the access token")
+			}
+
+			// The unknown emails can't be told apart from the wrong passwords
+			if tc.expectedCode != codes.OK && status.Convert(err).Message() != "invalid credentials" {
+				t.Errorf("Expected the invalid credentials error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLocalAuthProvider_Refresh(t *testing.T) {
+	provider, storage, _ := newLocalAuthProvider(t)
+
+	tokenData := registerUser(t, provider, "alice@example.com", "Password1!")
+
+	var sessionID string
+	for _, session := range storage.sessions {
+		sessionID = session.ID
+	}
+
+	newTokenData, err := refresh(provider, tokenData.GetRefreshToken())
+	if err != nil {
+		t.Fatalf("Failed to refresh tokens: %v", err)
+	}
+
+	if newTokenData.GetRefreshToken() == tokenData.GetRefreshToken() {
+		t.Errorf("Expected a new refresh token")
+	}
+
+	if len(storage.sessions) != 1 {
+		t.Fatalf("Expected the session to be rotated, got %d sessions", len(storage.sessions))
+	}
+
+	for _, session := range storage.sessions {
+		if session.ID != sessionID {
+			t.Errorf("Expected the session to keep ID %s, got %s", sessionID, session.ID)
+		}
+	}
+
+	// The refresh token can be used only once
+	if _, err = refresh(provider, tokenData.GetRefreshToken()); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected code %v for the reused token, got %v", codes.Unauthenticated, err)
+	}
+
+	if _, err = refresh(provider, newTokenData.GetRefreshToken()); err != nil {
+		t.Errorf("Failed to refresh tokens with the rotated token: %v", err)
+	}
+}
+
+func TestLocalAuthProvider_RefreshExpired(t *testing.T) {
+	provider, storage, _ := newLocalAuthProvider(t)
+
+	tokenData := registerUser(t, provider, "alice@example.com", "Password1!")
+
+	storage.expireTokens()
+
+	if _, err := refresh(provider, tokenData.GetRefreshToken()); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected code %v for the expired token, got %v", codes.Unauthenticated, err)
+	}
+
+	if len(storage.sessions) != 0 {
+		t.Errorf("Expected the expired session to be deleted, got %d sessions", len(storage.sessions))
+	}
+}
+
+// sessionIDFromToken returns the session claim of the access token signed by the provider
+func sessionIDFromToken(t *testing.T, accessToken string) string {
+	t.Helper()
+
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(accessToken, claims); err != nil {
+		t.Fatalf("Failed to parse access token: %v", err)
+	}
+
+	sessionID, _ := claims[key.SessionID].(string)
+	return sessionID
+}
+
+func TestLocalAuthProvider_RevokeOtherSessions(t *testing.T) {
+	provider, storage, _ := newLocalAuthProvider(t)
+
+	registerUser(t, provider, "alice@example.com", "Password1!")
+
+	// The sessions are opened from the same device, they can be told apart only by the ID
+	tokenData, err := login(provider, "alice@example.com", "Password1!")
+	if err != nil {
+		t.Fatalf("Failed to login: %v", err)
+	}
+
+	if tokenData, err = refresh(provider, tokenData.GetRefreshToken()); err != nil {
+		t.Fatalf("Failed to refresh tokens: %v", err)
+	}
+
+	currentSessionID := sessionIDFromToken(t, tokenData.GetAccessToken())
+	if currentSessionID == "" {
+		t.Fatalf("Expected the access token to have the session ID")
+	}
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs(jwtoken.AccessTokenKey, tokenData.GetAccessToken()))
+
+	if err = provider.RevokeOtherSessions(ctx, currentSessionID); err != nil {
+		t.Fatalf("Failed to revoke other sessions: %v", err)
+	}
+
+	sessions, err := provider.GetSessions(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get sessions: %v", err)
+	}
+
+	if len(sessions) != 1 || sessions[0].ID != currentSessionID {
+		t.Errorf("Expected only the session %s to be kept, got %+v", currentSessionID, sessions)
+	}
+
+	if len(storage.sessions) != 1 {
+		t.Errorf("Expected 1 stored session, got %d", len(storage.sessions))
+	}
+}
+
+func TestLocalAuthProvider_VerifyEmail(t *testing.T) {
+	provider, storage, emailSender := newLocalAuthProvider(t)
+	ctx := context.Background()
+
+	registerUser(t, provider, "alice@example.com", "Password1!")
+
+	expiredToken := emailSender.lastEmailToken(t)
+	storage.expireTokens()
+
+	// The expired token is replaced with a new one
+	_, err := provider.VerifyEmail(ctx, &ssov1.VerifyEmailRequest{VerificationToken: expiredToken})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("Expected code %v for the expired token, got %v", codes.FailedPrecondition, err)
+	}
+
+	if len(emailSender.emails) != 2 {
+		t.Fatalf("Expected a new verification email, got %d emails", len(emailSender.emails))
+	}
+
+	if _, err = provider.VerifyEmail(ctx, &ssov1.VerifyEmailRequest{VerificationToken: expiredToken}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected code %v for the replaced token, got %v", codes.NotFound, err)
+	}
+
+	if _, err = provider.VerifyEmail(ctx, &ssov1.VerifyEmailRequest{VerificationToken: emailSender.lastEmailToken(t)}); err != nil {
+		t.Fatalf("Failed to verify email: %v", err)
+	}
+
+	user, err := storage.GetUserByEmail(ctx, "alice@example.com")
+	if err != nil || !user.Verified {
+		t.Errorf("Expected the user to be verified, got %+v, %v", user, err)
+	}
+}
+
+func TestLocalAuthProvider_ResetPasswordUnknownEmail(t *testing.T) {
+	provider, _, emailSender := newLocalAuthProvider(t)
+
+	// The unknown emails can't be told apart from the registered ones
+	if _, err := provider.ResetPassword(context.Background(), &ssov1.ResetPasswordRequest{
+		Email:                    "unknown@example.com",
+		AppID:                    appID,
+		ConfirmChangePasswordURL: resetURL,
+	}); err != nil {
+		t.Fatalf("Expected no error for the unknown email, got %v", err)
+	}
+
+	if len(emailSender.emails) != 0 {
+		t.Errorf("Expected no emails, got %d", len(emailSender.emails))
+	}
+}
+
+func TestLocalAuthProvider_ChangePassword(t *testing.T) {
+	provider, storage, emailSender := newLocalAuthProvider(t)
+	ctx := context.Background()
+
+	tokenData := registerUser(t, provider, "alice@example.com", "Password1!")
+
+	if _, err := provider.ResetPassword(ctx, &ssov1.ResetPasswordRequest{
+		Email:                    "alice@example.com",
+		AppID:                    appID,
+		ConfirmChangePasswordURL: resetURL,
+	}); err != nil {
+		t.Fatalf("Failed to request reset password: %v", err)
+	}
+
+	expiredToken := emailSender.lastEmailToken(t)
+
+	// Only the email token expires, the session has to be deleted by the password change
+	for tokenHash, token := range storage.tokens {
+		token.ExpiresAt = time.Now().Add(-time.Minute)
+		storage.tokens[tokenHash] = token
+	}
+
+	_, err := provider.ChangePassword(ctx, &ssov1.ChangePasswordRequest{
+		ResetPasswordToken: expiredToken,
+		AppID:              appID,
+		UpdatedPassword:    "NewPassword1!",
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("Expected code %v for the expired token, got %v", codes.FailedPrecondition, err)
+	}
+
+	if _, err = provider.ChangePassword(ctx, &ssov1.ChangePasswordRequest{
+		ResetPasswordToken: emailSender.lastEmailToken(t),
+		AppID:              appID,
+		UpdatedPassword:    "NewPassword1!",
+	}); err != nil {
+		t.Fatalf("Failed to change password: %v", err)
+	}
+
+	// The user is logged out on all the devices
+	if _, err = refresh(provider, tokenData.GetRefreshToken()); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected code %v for the session opened before the password change, got %v", codes.Unauthenticated, err)
+	}
+
+	if _, err = login(provider, "alice@example.com", "Password1!"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected code %v for the old password, got %v", codes.Unauthenticated, err)
+	}
+
+	if _, err = login(provider, "alice@example.com", "NewPassword1!"); err != nil {
+		t.Errorf("Failed to login with the changed password: %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/config"
+	"github.com/rshelekhov/reframed/internal/lib/cache"
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type PersonalTokenUsecase struct {
+	cfg     *config.ServerSettings
+	storage port.PersonalTokenStorage
+	cache   *cache.Cache
+}
+
+func NewPersonalTokenUsecase(cfg *config.ServerSettings, storage port.PersonalTokenStorage) *PersonalTokenUsecase {
+	return &PersonalTokenUsecase{
+		cfg:     cfg,
+		storage: storage,
+		cache:   cache.New(),
+	}
+}
+
+const (
+	// personalTokenSecretSize is the number of random bytes in the secret part of the token
+	personalTokenSecretSize = 32
+
+	// personalTokenCacheTTL is how long a verified token is trusted without running bcrypt again,
+	// a revoked token may still be accepted by the other instances of the service for this time
+	personalTokenCacheTTL = time.Minute
+)
+
+// verifiedPersonalToken is cached by the token ID together with the checksum of the whole token
+type verifiedPersonalToken struct {
+	checksum [sha256.Size]byte
+	token    model.PersonalToken
+}
+
+// CreatePersonalToken returns the token only once, the secret part of it is stored as a bcrypt hash.
+// The token is built as the prefix, the token ID and the secret separated by underscores
+func (u *PersonalTokenUsecase) CreatePersonalToken(ctx context.Context, data *model.PersonalTokenRequestData) (model.PersonalTokenResponseData, error) {
+	secretBytes := make([]byte, personalTokenSecretSize)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return model.PersonalTokenResponseData{}, err
+	}
+
+	secret := base64.RawURLEncoding.EncodeToString(secretBytes)
+
+	tokenHash, err := jwtoken.PasswordHashBcrypt(secret, 0, []byte(u.cfg.AppData.Secret))
+	if err != nil {
+		return model.PersonalTokenResponseData{}, err
+	}
+
+	scopes := slices.Clone(data.Scopes)
+	slices.Sort(scopes)
+
+	currentTime := time.Now()
+
+	newToken := model.PersonalToken{
+		ID:        ksuid.New().String(),
+		Name:      data.Name,
+		TokenHash: tokenHash,
+		Scopes:    slices.Compact(scopes),
+		UserID:    data.UserID,
+		ExpiresAt: currentTime.AddDate(0, 0, data.ExpiresInDays),
+		CreatedAt: currentTime,
+	}
+
+	if err = u.storage.CreatePersonalToken(ctx, newToken); err != nil {
+		return model.PersonalTokenResponseData{}, err
+	}
+
+	return model.PersonalTokenResponseData{
+		ID:        newToken.ID,
+		Name:      newToken.Name,
+		Token:     jwtoken.PersonalTokenPrefix + newToken.ID + "_" + secret,
+		Scopes:    newToken.Scopes,
+		ExpiresAt: newToken.ExpiresAt,
+		CreatedAt: newToken.CreatedAt,
+	}, nil
+}
+
+func (u *PersonalTokenUsecase) GetPersonalTokensByUserID(ctx context.Context, userID string) ([]model.PersonalTokenResponseData, error) {
+	tokens, err := u.storage.GetPersonalTokensByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokensResp []model.PersonalTokenResponseData
+
+	for _, token := range tokens {
+		tokensResp = append(tokensResp, model.PersonalTokenResponseData{
+			ID:         token.ID,
+			Name:       token.Name,
+			Scopes:     token.Scopes,
+			ExpiresAt:  token.ExpiresAt,
+			LastUsedAt: token.LastUsedAt,
+			CreatedAt:  token.CreatedAt,
+		})
+	}
+
+	return tokensResp, nil
+}
+
+// VerifyPersonalToken checks the secret of the token against its hash and records the usage of the token,
+// the verified token is cached for a short time to avoid running bcrypt on every request
+func (u *PersonalTokenUsecase) VerifyPersonalToken(ctx context.Context, tokenString string) (jwtoken.PersonalToken, error) {
+	tokenID, secret, ok := strings.Cut(strings.TrimPrefix(tokenString, jwtoken.PersonalTokenPrefix), "_")
+	if !ok || tokenID == "" || secret == "" {
+		return jwtoken.PersonalToken{}, le.ErrPersonalTokenNotFound
+	}
+
+	checksum := sha256.Sum256([]byte(tokenString))
+
+	token, found := u.cachedPersonalToken(tokenID, checksum)
+	if !found {
+		var err error
+
+		token, err = u.storage.GetPersonalTokenByID(ctx, tokenID)
+		if err != nil {
+			return jwtoken.PersonalToken{}, err
+		}
+
+		match, err := jwtoken.PasswordMatch(token.TokenHash, secret, []byte(u.cfg.AppData.Secret))
+		if err != nil {
+			return jwtoken.PersonalToken{}, err
+		}
+		if !match {
+			return jwtoken.PersonalToken{}, le.ErrPersonalTokenNotFound
+		}
+
+		token.LastUsedAt = time.Now()
+
+		if err = u.storage.UpdatePersonalTokenLastUsedAt(ctx, token); err != nil {
+			return jwtoken.PersonalToken{}, err
+		}
+
+		u.cache.Set(tokenID, verifiedPersonalToken{
+			checksum: checksum,
+			token:    token,
+		}, personalTokenCacheTTL)
+	}
+
+	if time.Now().After(token.ExpiresAt) {
+		return jwtoken.PersonalToken{}, le.ErrPersonalTokenExpired
+	}
+
+	return jwtoken.PersonalToken{
+		ID:     token.ID,
+		UserID: token.UserID,
+		Scopes: token.Scopes,
+	}, nil
+}
+
+func (u *PersonalTokenUsecase) cachedPersonalToken(tokenID string, checksum [sha256.Size]byte) (model.PersonalToken, bool) {
+	cacheValue, found := u.cache.Get(tokenID)
+	if !found {
+		return model.PersonalToken{}, false
+	}
+
+	verified, ok := cacheValue.(verifiedPersonalToken)
+	if !ok || subtle.ConstantTimeCompare(verified.checksum[:], checksum[:]) != 1 {
+		return model.PersonalToken{}, false
+	}
+
+	return verified.token, true
+}
+
+func (u *PersonalTokenUsecase) RevokePersonalToken(ctx context.Context, data model.PersonalTokenRequestData) error {
+	if err := u.storage.RevokePersonalToken(ctx, model.PersonalToken{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		RevokedAt: time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	u.cache.Delete(data.ID)
+
+	return nil
+}
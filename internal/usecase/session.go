@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type SessionUsecase struct {
+	provider port.SessionProvider
+}
+
+func NewSessionUsecase(provider port.SessionProvider) *SessionUsecase {
+	return &SessionUsecase{provider: provider}
+}
+
+// GetSessions marks the session the access token was issued for as current
+func (u *SessionUsecase) GetSessions(ctx context.Context, currentSessionID string) ([]model.SessionResponseData, error) {
+	ctx, err := jwtoken.AddAccessTokenToMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := u.provider.GetSessions(ctx)
+	if err != nil {
+		return nil, sessionError(err)
+	}
+
+	sessionsResp := make([]model.SessionResponseData, 0, len(sessions))
+
+	for _, session := range sessions {
+		sessionsResp = append(sessionsResp, model.SessionResponseData{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			LastSeenAt: session.LastSeenAt,
+			CreatedAt:  session.CreatedAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+
+	return sessionsResp, nil
+}
+
+func (u *SessionUsecase) RevokeSession(ctx context.Context, sessionID string) error {
+	ctx, err := jwtoken.AddAccessTokenToMetadata(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = u.provider.RevokeSession(ctx, sessionID); err != nil {
+		return sessionError(err)
+	}
+
+	return nil
+}
+
+func (u *SessionUsecase) RevokeOtherSessions(ctx context.Context, currentSessionID string) error {
+	ctx, err := jwtoken.AddAccessTokenToMetadata(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = u.provider.RevokeOtherSessions(ctx, currentSessionID); err != nil {
+		return sessionError(err)
+	}
+
+	return nil
+}
+
+// sessionError converts the statuses returned by the session provider to the local errors
+func sessionError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	switch st.Code() {
+	case codes.NotFound:
+		return le.ErrSessionNotFound
+	case codes.Unauthenticated:
+		return le.ErrUserUnauthenticated
+	default:
+		return err
+	}
+}
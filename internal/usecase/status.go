@@ -2,45 +2,276 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"time"
 
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
 	"github.com/rshelekhov/reframed/internal/model"
 	"github.com/rshelekhov/reframed/internal/port"
 )
 
+// StatusUsecase manages the custom statuses of the user and the allowed transitions between statuses.
+// If no transitions are configured from a status, the task can be moved from it to any status.
+// The transitions configured for a list replace the ones configured for all lists
 type StatusUsecase struct {
-	storage port.StatusStorage
+	storage     port.StatusStorage
+	ListUsecase port.ListUsecase
 }
 
 func NewStatusUsecase(storage port.StatusStorage) *StatusUsecase {
 	return &StatusUsecase{storage: storage}
 }
 
-func (u *StatusUsecase) GetStatuses(ctx context.Context) ([]model.StatusResponseData, error) {
-	statuses, err := u.storage.GetStatuses(ctx)
+func (u *StatusUsecase) CreateStatus(ctx context.Context, data *model.StatusRequestData) (model.StatusResponseData, error) {
+	category, err := u.storage.GetStatusByID(ctx, int32(data.CategoryID), data.UserID)
+	switch {
+	case errors.Is(err, le.ErrStatusNotFound):
+		return model.StatusResponseData{}, le.ErrInvalidStatusCategory
+	case err != nil:
+		return model.StatusResponseData{}, err
+	}
+
+	// Archived tasks are deleted from all views, so it can't be refined
+	if isCustomStatus(category) || category.Title == model.StatusArchived.String() {
+		return model.StatusResponseData{}, le.ErrInvalidStatusCategory
+	}
+
+	if data.ListID != "" {
+		if err = u.verifyListOwnership(ctx, data.ListID, data.UserID); err != nil {
+			return model.StatusResponseData{}, err
+		}
+	}
+
+	currentTime := time.Now()
+
+	newStatus := model.Status{
+		Title:      data.Title,
+		CategoryID: category.ID,
+		ListID:     data.ListID,
+		UserID:     data.UserID,
+		CreatedAt:  currentTime,
+		UpdatedAt:  currentTime,
+	}
+
+	newStatus.ID, err = u.storage.CreateStatus(ctx, newStatus)
+	if err != nil {
+		return model.StatusResponseData{}, err
+	}
+
+	return mapStatusToResponseData(newStatus, nil), nil
+}
+
+func (u *StatusUsecase) verifyListOwnership(ctx context.Context, listID, userID string) error {
+	_, err := u.ListUsecase.GetListByID(ctx, model.ListRequestData{
+		ID:     listID,
+		UserID: userID,
+	})
+	return err
+}
+
+// GetStatuses returns the statuses available in the list with the transitions allowed from them.
+// Without listID only the statuses and transitions shared by all lists are returned
+func (u *StatusUsecase) GetStatuses(ctx context.Context, userID, listID string) ([]model.StatusResponseData, error) {
+	if listID != "" {
+		if err := u.verifyListOwnership(ctx, listID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses, err := u.storage.GetStatuses(ctx, userID, listID)
 	if err != nil {
 		return nil, err
 	}
 
+	transitions, err := u.storage.GetStatusTransitions(ctx, userID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	nextStatuses := effectiveTransitions(transitions)
+
 	var statusesResp []model.StatusResponseData
 
 	for _, status := range statuses {
-		statusesResp = append(statusesResp, model.StatusResponseData{
-			ID:    int(status.ID),
-			Title: status.Title,
-		})
+		statusesResp = append(statusesResp, mapStatusToResponseData(status, nextStatuses[status.ID]))
 	}
 
 	return statusesResp, nil
 }
 
-func (u *StatusUsecase) GetStatusByID(ctx context.Context, statusID int) (model.StatusResponseData, error) {
-	statusName, err := u.storage.GetStatusByID(ctx, int32(statusID))
+func (u *StatusUsecase) GetStatusByID(ctx context.Context, data model.StatusRequestData) (model.StatusResponseData, error) {
+	status, err := u.storage.GetStatusByID(ctx, int32(data.ID), data.UserID)
+	if err != nil {
+		return model.StatusResponseData{}, err
+	}
+
+	transitions, err := u.storage.GetStatusTransitions(ctx, data.UserID, status.ListID)
 	if err != nil {
 		return model.StatusResponseData{}, err
 	}
 
-	return model.StatusResponseData{
-		ID:    statusID,
-		Title: statusName,
-	}, nil
+	return mapStatusToResponseData(status, effectiveTransitions(transitions)[status.ID]), nil
+}
+
+func (u *StatusUsecase) UpdateStatus(ctx context.Context, data *model.StatusRequestData) (model.StatusResponseData, error) {
+	updatedStatus := model.Status{
+		ID:        int32(data.ID),
+		Title:     data.Title,
+		UserID:    data.UserID,
+		UpdatedAt: time.Now(),
+	}
+
+	// Only the custom statuses belong to the user, the built-in ones are not found
+	if err := u.storage.UpdateStatus(ctx, updatedStatus); err != nil {
+		return model.StatusResponseData{}, err
+	}
+
+	return u.GetStatusByID(ctx, model.StatusRequestData{
+		ID:     data.ID,
+		UserID: data.UserID,
+	})
+}
+
+// UpdateStatusTransitions replaces the transitions of the list, or the transitions
+// shared by all lists if ListID is empty. An empty list of transitions removes the restrictions
+func (u *StatusUsecase) UpdateStatusTransitions(ctx context.Context, data *model.StatusTransitionsRequestData) ([]model.StatusResponseData, error) {
+	if data.ListID != "" {
+		if err := u.verifyListOwnership(ctx, data.ListID, data.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses, err := u.storage.GetStatuses(ctx, data.UserID, data.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make(map[int32]bool, len(statuses))
+	for _, status := range statuses {
+		available[status.ID] = true
+	}
+
+	var transitions []model.StatusTransition
+
+	for _, transition := range data.Transitions {
+		if !available[int32(transition.FromStatusID)] {
+			return nil, le.ErrStatusNotFound
+		}
+
+		for _, toStatusID := range transition.ToStatusIDs {
+			if !available[int32(toStatusID)] {
+				return nil, le.ErrStatusNotFound
+			}
+
+			transitions = append(transitions, model.StatusTransition{
+				FromStatusID: int32(transition.FromStatusID),
+				ToStatusID:   int32(toStatusID),
+				ListID:       data.ListID,
+			})
+		}
+	}
+
+	if err = u.storage.Transaction(ctx, func(storage port.StatusStorage) error {
+		if err = storage.DeleteStatusTransitions(ctx, data.UserID, data.ListID); err != nil {
+			return err
+		}
+
+		if len(transitions) == 0 {
+			return nil
+		}
+
+		return storage.CreateStatusTransitions(ctx, data.UserID, data.ListID, transitions)
+	}); err != nil {
+		return nil, err
+	}
+
+	return u.GetStatuses(ctx, data.UserID, data.ListID)
+}
+
+// CheckStatusTransition returns ErrStatusTransitionNotAllowed if the task of the list
+// can't be moved from one status to another
+func (u *StatusUsecase) CheckStatusTransition(ctx context.Context, userID, listID string, fromStatusID, toStatusID int) error {
+	if fromStatusID == toStatusID {
+		return nil
+	}
+
+	transitions, err := u.storage.GetStatusTransitions(ctx, userID, listID)
+	if err != nil {
+		return err
+	}
+
+	nextStatuses, ok := effectiveTransitions(transitions)[int32(fromStatusID)]
+	if !ok {
+		return nil
+	}
+
+	if !slices.Contains(nextStatuses, int32(toStatusID)) {
+		return le.ErrStatusTransitionNotAllowed
+	}
+
+	return nil
+}
+
+// DeleteStatus moves the tasks of the custom status back to its category
+func (u *StatusUsecase) DeleteStatus(ctx context.Context, data model.StatusRequestData) error {
+	deletedStatus := model.Status{
+		ID:        int32(data.ID),
+		UserID:    data.UserID,
+		DeletedAt: time.Now(),
+	}
+
+	return u.storage.Transaction(ctx, func(storage port.StatusStorage) error {
+		if err := storage.DeleteStatus(ctx, deletedStatus); err != nil {
+			return err
+		}
+
+		if err := storage.DeleteStatusTransitionsByStatusID(ctx, deletedStatus.ID, deletedStatus.UserID); err != nil {
+			return err
+		}
+
+		return storage.ResetTasksCustomStatus(ctx, deletedStatus)
+	})
+}
+
+// effectiveTransitions returns the statuses allowed after each status,
+// the transitions of the list replace the ones shared by all lists
+func effectiveTransitions(transitions []model.StatusTransition) map[int32][]int32 {
+	shared := make(map[int32][]int32)
+	listScoped := make(map[int32][]int32)
+
+	for _, transition := range transitions {
+		if transition.ListID != "" {
+			listScoped[transition.FromStatusID] = append(listScoped[transition.FromStatusID], transition.ToStatusID)
+			continue
+		}
+		shared[transition.FromStatusID] = append(shared[transition.FromStatusID], transition.ToStatusID)
+	}
+
+	for fromStatusID, toStatusIDs := range listScoped {
+		shared[fromStatusID] = toStatusIDs
+	}
+
+	return shared
+}
+
+// isCustomStatus reports whether the status is refining a built-in one
+func isCustomStatus(status model.Status) bool {
+	return status.CategoryID != status.ID
+}
+
+func mapStatusToResponseData(status model.Status, nextStatusIDs []int32) model.StatusResponseData {
+	statusResp := model.StatusResponseData{
+		ID:         int(status.ID),
+		Title:      status.Title,
+		CategoryID: int(status.CategoryID),
+		ListID:     status.ListID,
+		Custom:     isCustomStatus(status),
+	}
+
+	for _, statusID := range nextStatusIDs {
+		statusResp.NextStatusIDs = append(statusResp.NextStatusIDs, int(statusID))
+	}
+
+	return statusResp
 }
@@ -3,6 +3,8 @@ package usecase
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/segmentio/ksuid"
@@ -20,25 +22,129 @@ func NewTagUsecase(storage port.TagStorage) *TagUsecase {
 	return &TagUsecase{storage: storage}
 }
 
-func (u *TagUsecase) CreateTagIfNotExists(ctx context.Context, data model.TagRequestData) error {
-	_, err := u.storage.GetTagIDByTitle(ctx, data.Title, data.UserID)
-	if errors.Is(err, le.ErrTagNotFound) {
-		currentTime := time.Now()
-		newTag := model.Tag{
-			ID:        ksuid.New().String(),
-			Title:     data.Title,
-			UserID:    data.UserID,
-			CreatedAt: currentTime,
-			UpdatedAt: currentTime,
+// tagPathSeparator separates the titles of the parent and child tags, e.g. work/clients/acme
+const tagPathSeparator = "/"
+
+// CreateTag creates the tag with all missing parent tags from its path
+func (u *TagUsecase) CreateTag(ctx context.Context, data *model.TagRequestData) (model.TagResponseData, error) {
+	title := data.Title
+
+	if data.ParentID != "" {
+		parentTag, err := u.storage.GetTagByID(ctx, data.ParentID, data.UserID)
+		if err != nil {
+			return model.TagResponseData{}, err
 		}
 
-		return u.storage.CreateTag(ctx, newTag)
+		title = parentTag.Title + tagPathSeparator + data.Title
 	}
-	if err != nil {
+
+	if !validTagTitle(title) {
+		return model.TagResponseData{}, le.ErrInvalidTagTitle
+	}
+
+	_, err := u.storage.GetTagIDByTitle(ctx, title, data.UserID)
+	switch {
+	case err == nil:
+		return model.TagResponseData{}, le.ErrTagAlreadyExists
+	case !errors.Is(err, le.ErrTagNotFound):
+		return model.TagResponseData{}, err
+	}
+
+	var newTag model.Tag
+
+	if err = u.storage.Transaction(ctx, func(storage port.TagStorage) error {
+		newTag, err = u.createTag(ctx, storage, title, data.Color, data.UserID)
 		return err
+	}); err != nil {
+		return model.TagResponseData{}, err
+	}
+
+	return mapTagToTagResponseData(newTag), nil
+}
+
+// CreateTagIfNotExists creates the tag by the title from the task, a title like
+// work/clients/acme creates the tag acme under work/clients
+func (u *TagUsecase) CreateTagIfNotExists(ctx context.Context, data model.TagRequestData) error {
+	return u.storage.Transaction(ctx, func(storage port.TagStorage) error {
+		_, err := u.ensureTag(ctx, storage, data.Title, data.UserID)
+		return err
+	})
+}
+
+// ensureTag returns the id of the tag with the title, the tag is created if it doesn't exist
+func (u *TagUsecase) ensureTag(ctx context.Context, storage port.TagStorage, title, userID string) (string, error) {
+	tagID, err := storage.GetTagIDByTitle(ctx, title, userID)
+	if !errors.Is(err, le.ErrTagNotFound) {
+		return tagID, err
+	}
+
+	newTag, err := u.createTag(ctx, storage, title, "", userID)
+	if err != nil {
+		return "", err
+	}
+
+	return newTag.ID, nil
+}
+
+// createTag creates the tag and the parent tags from its path that don't exist yet
+func (u *TagUsecase) createTag(ctx context.Context, storage port.TagStorage, title, color, userID string) (model.Tag, error) {
+	var parentID string
+
+	if parentTitle := parentTagTitle(title); parentTitle != "" {
+		var err error
+
+		parentID, err = u.ensureTag(ctx, storage, parentTitle, userID)
+		if err != nil {
+			return model.Tag{}, err
+		}
+	}
+
+	currentTime := time.Now()
+
+	newTag := model.Tag{
+		ID:        ksuid.New().String(),
+		Title:     title,
+		ParentID:  parentID,
+		Color:     color,
+		UserID:    userID,
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+
+	if err := storage.CreateTag(ctx, newTag); err != nil {
+		return model.Tag{}, err
+	}
+
+	return newTag, nil
+}
+
+// parentTagTitle returns the path of the parent tag, it's empty for a top-level tag
+func parentTagTitle(title string) string {
+	i := strings.LastIndex(title, tagPathSeparator)
+	if i <= 0 {
+		return ""
+	}
+
+	return title[:i]
+}
+
+// tagName returns the title of the tag without the path of its parent
+func tagName(tag model.Tag) string {
+	if tag.ParentID == "" {
+		return tag.Title
+	}
+
+	return tag.Title[strings.LastIndex(tag.Title, tagPathSeparator)+1:]
+}
+
+func validTagTitle(title string) bool {
+	for _, name := range strings.Split(title, tagPathSeparator) {
+		if strings.TrimSpace(name) == "" {
+			return false
+		}
 	}
 
-	return nil
+	return true
 }
 
 func (u *TagUsecase) LinkTagsToTask(ctx context.Context, userID, taskID string, tags []string) error {
@@ -49,19 +155,56 @@ func (u *TagUsecase) UnlinkTagsFromTask(ctx context.Context, userID, taskID stri
 	return u.storage.UnlinkTagsFromTask(ctx, userID, taskID, tags)
 }
 
+func (u *TagUsecase) GetTagByID(ctx context.Context, data model.TagRequestData) (model.TagResponseData, error) {
+	tag, err := u.storage.GetTagByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.TagResponseData{}, err
+	}
+
+	return mapTagToTagResponseData(tag), nil
+}
+
+// GetTagsByUserID returns the top-level tags with their child tags nested in them
 func (u *TagUsecase) GetTagsByUserID(ctx context.Context, userID string) ([]model.TagResponseData, error) {
 	tags, err := u.storage.GetTagsByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	var tagsResp []model.TagResponseData
+	return buildTagTree(tags), nil
+}
 
+func buildTagTree(tags []model.Tag) []model.TagResponseData {
+	tagIDs := make(map[string]struct{}, len(tags))
 	for _, tag := range tags {
-		tagsResp = append(tagsResp, mapTagToTagResponseData(tag))
+		tagIDs[tag.ID] = struct{}{}
 	}
 
-	return tagsResp, nil
+	var topLevelTags []model.Tag
+	childTags := make(map[string][]model.Tag)
+
+	for _, tag := range tags {
+		if _, ok := tagIDs[tag.ParentID]; ok {
+			childTags[tag.ParentID] = append(childTags[tag.ParentID], tag)
+		} else {
+			topLevelTags = append(topLevelTags, tag)
+		}
+	}
+
+	var mapTags func(tags []model.Tag) []model.TagResponseData
+	mapTags = func(tags []model.Tag) []model.TagResponseData {
+		var tagsResp []model.TagResponseData
+
+		for _, tag := range tags {
+			tagResp := mapTagToTagResponseData(tag)
+			tagResp.Children = mapTags(childTags[tag.ID])
+			tagsResp = append(tagsResp, tagResp)
+		}
+
+		return tagsResp
+	}
+
+	return mapTags(topLevelTags)
 }
 
 func (u *TagUsecase) GetTagsByTaskID(ctx context.Context, taskID string) ([]model.TagResponseData, error) {
@@ -81,8 +224,238 @@ func (u *TagUsecase) GetTagsByTaskID(ctx context.Context, taskID string) ([]mode
 
 func mapTagToTagResponseData(tag model.Tag) model.TagResponseData {
 	return model.TagResponseData{
+		ID:         tag.ID,
+		Title:      tag.Title,
+		Name:       tagName(tag),
+		ParentID:   tag.ParentID,
+		Color:      tag.Color,
+		TasksCount: tag.TasksCount,
+		UpdatedAt:  tag.UpdatedAt,
+	}
+}
+
+// UpdateTag renames the tag, the new title is shown in all tasks with this tag.
+// The child tags are renamed with it, and if the path of the parent tag is changed,
+// the tag is moved under the new parent. The color is changed only if it's set
+func (u *TagUsecase) UpdateTag(ctx context.Context, data *model.TagRequestData) (model.TagResponseData, error) {
+	if !validTagTitle(data.Title) {
+		return model.TagResponseData{}, le.ErrInvalidTagTitle
+	}
+
+	tag, err := u.storage.GetTagByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.TagResponseData{}, err
+	}
+
+	if strings.HasPrefix(data.Title, tag.Title+tagPathSeparator) {
+		return model.TagResponseData{}, le.ErrInvalidTagParent
+	}
+
+	tagID, err := u.storage.GetTagIDByTitle(ctx, data.Title, data.UserID)
+	switch {
+	case errors.Is(err, le.ErrTagNotFound):
+	case err != nil:
+		return model.TagResponseData{}, err
+	case tagID != data.ID:
+		return model.TagResponseData{}, le.ErrTagAlreadyExists
+	}
+
+	updatedTag := model.Tag{
+		ID:        data.ID,
+		Title:     data.Title,
+		Color:     data.Color,
+		UserID:    data.UserID,
+		UpdatedAt: time.Now(),
+	}
+
+	if err = u.storage.Transaction(ctx, func(storage port.TagStorage) error {
+		if data.Title != tag.Title {
+			parentID, err := u.parentTagID(ctx, storage, data.Title, data.UserID)
+			if err != nil {
+				return err
+			}
+
+			if err = u.moveTag(ctx, storage, tag, parentID, data.Title); err != nil {
+				return err
+			}
+		}
+
+		return storage.UpdateTag(ctx, updatedTag)
+	}); err != nil {
+		return model.TagResponseData{}, err
+	}
+
+	tag, err = u.storage.GetTagByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.TagResponseData{}, err
+	}
+
+	return mapTagToTagResponseData(tag), nil
+}
+
+// parentTagID returns the id of the parent tag from the path, missing parent tags are created
+func (u *TagUsecase) parentTagID(ctx context.Context, storage port.TagStorage, title, userID string) (string, error) {
+	parentTitle := parentTagTitle(title)
+	if parentTitle == "" {
+		return "", nil
+	}
+
+	return u.ensureTag(ctx, storage, parentTitle, userID)
+}
+
+// MoveTag moves the tag with its child tags under another parent tag
+func (u *TagUsecase) MoveTag(ctx context.Context, data *model.TagMoveRequestData) (model.TagResponseData, error) {
+	tag, err := u.storage.GetTagByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.TagResponseData{}, err
+	}
+
+	title := tagName(tag)
+
+	if data.ParentID != "" {
+		parentTag, err := u.storage.GetTagByID(ctx, data.ParentID, data.UserID)
+		if err != nil {
+			return model.TagResponseData{}, err
+		}
+
+		title = parentTag.Title + tagPathSeparator + title
+	}
+
+	if title != tag.Title {
+		_, err = u.storage.GetTagIDByTitle(ctx, title, data.UserID)
+		switch {
+		case err == nil:
+			return model.TagResponseData{}, le.ErrTagAlreadyExists
+		case !errors.Is(err, le.ErrTagNotFound):
+			return model.TagResponseData{}, err
+		}
+	}
+
+	if err = u.storage.Transaction(ctx, func(storage port.TagStorage) error {
+		return u.moveTag(ctx, storage, tag, data.ParentID, title)
+	}); err != nil {
+		return model.TagResponseData{}, err
+	}
+
+	tag, err = u.storage.GetTagByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.TagResponseData{}, err
+	}
+
+	return mapTagToTagResponseData(tag), nil
+}
+
+// moveTag sets the parent of the tag and replaces the path in the titles of the tag and its descendants
+func (u *TagUsecase) moveTag(ctx context.Context, storage port.TagStorage, tag model.Tag, parentID, title string) error {
+	subtreeIDs, err := storage.GetTagSubtreeIDs(ctx, tag.ID, tag.UserID)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(subtreeIDs, parentID) {
+		return le.ErrInvalidTagParent
+	}
+
+	currentTime := time.Now()
+
+	if err = storage.RenameTagSubtree(ctx, tag.UserID, subtreeIDs, tag.Title, title, currentTime); err != nil {
+		return err
+	}
+
+	return storage.UpdateTagParent(ctx, model.Tag{
 		ID:        tag.ID,
-		Title:     tag.Title,
-		UpdatedAt: tag.UpdatedAt,
+		ParentID:  parentID,
+		UserID:    tag.UserID,
+		UpdatedAt: currentTime,
+	})
+}
+
+// MergeTags moves all tasks of the tag to the target tag and deletes the tag,
+// the child tags of the tag are moved under the target tag
+func (u *TagUsecase) MergeTags(ctx context.Context, data *model.TagMergeRequestData) (model.TagResponseData, error) {
+	if data.ID == data.TargetID {
+		return model.TagResponseData{}, le.ErrCannotMergeTagIntoItself
+	}
+
+	subtreeIDs, err := u.storage.GetTagSubtreeIDs(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.TagResponseData{}, err
+	}
+
+	targetTag, err := u.storage.GetTagByID(ctx, data.TargetID, data.UserID)
+	if err != nil {
+		return model.TagResponseData{}, err
+	}
+
+	if slices.Contains(subtreeIDs, data.TargetID) {
+		return model.TagResponseData{}, le.ErrCannotMergeTagIntoChild
+	}
+
+	childTags, err := u.storage.GetTagsByParentID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.TagResponseData{}, err
+	}
+
+	for _, childTag := range childTags {
+		_, err = u.storage.GetTagIDByTitle(ctx, targetTag.Title+tagPathSeparator+tagName(childTag), data.UserID)
+		switch {
+		case err == nil:
+			return model.TagResponseData{}, le.ErrTagAlreadyExists
+		case !errors.Is(err, le.ErrTagNotFound):
+			return model.TagResponseData{}, err
+		}
+	}
+
+	if err = u.storage.Transaction(ctx, func(storage port.TagStorage) error {
+		for _, childTag := range childTags {
+			if err = u.moveTag(ctx, storage, childTag, targetTag.ID, targetTag.Title+tagPathSeparator+tagName(childTag)); err != nil {
+				return err
+			}
+		}
+
+		if err = storage.CopyTaskLinksToTag(ctx, data.ID, data.TargetID); err != nil {
+			return err
+		}
+
+		return u.deleteTag(ctx, storage, data.ID, data.UserID)
+	}); err != nil {
+		return model.TagResponseData{}, err
+	}
+
+	targetTag, err = u.storage.GetTagByID(ctx, data.TargetID, data.UserID)
+	if err != nil {
+		return model.TagResponseData{}, err
+	}
+
+	return mapTagToTagResponseData(targetTag), nil
+}
+
+// DeleteTag removes the tag and its child tags from all tasks and deletes them
+func (u *TagUsecase) DeleteTag(ctx context.Context, data model.TagRequestData) error {
+	subtreeIDs, err := u.storage.GetTagSubtreeIDs(ctx, data.ID, data.UserID)
+	if err != nil {
+		return err
+	}
+
+	return u.storage.Transaction(ctx, func(storage port.TagStorage) error {
+		for _, tagID := range subtreeIDs {
+			if err = u.deleteTag(ctx, storage, tagID, data.UserID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (u *TagUsecase) deleteTag(ctx context.Context, storage port.TagStorage, tagID, userID string) error {
+	if err := storage.UnlinkTagFromAllTasks(ctx, tagID); err != nil {
+		return err
 	}
+
+	return storage.DeleteTag(ctx, model.Tag{
+		ID:        tagID,
+		UserID:    userID,
+		DeletedAt: time.Now(),
+	})
 }
@@ -3,21 +3,31 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/segmentio/ksuid"
 
 	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/timezone"
+	"github.com/rshelekhov/reframed/internal/lib/quickadd"
+	"github.com/rshelekhov/reframed/internal/lib/schedule"
 	"github.com/rshelekhov/reframed/internal/model"
 	"github.com/rshelekhov/reframed/internal/port"
 )
 
 type TaskUsecase struct {
-	storage        port.TaskStorage
-	HeadingUsecase port.HeadingUsecase
-	TagUsecase     port.TagUsecase
-	ListUsecase    port.ListUsecase
+	storage             port.TaskStorage
+	HeadingUsecase      port.HeadingUsecase
+	TagUsecase          port.TagUsecase
+	ListUsecase         port.ListUsecase
+	UserUsecase         port.UserUsecase
+	FocusSessionUsecase port.FocusSessionUsecase
+	StatusUsecase       port.StatusUsecase
 }
 
 func NewTaskUsecase(storage port.TaskStorage) *TaskUsecase {
@@ -56,11 +66,14 @@ func (u *TaskUsecase) CreateTask(ctx context.Context, data *model.TaskRequestDat
 		HeadingID:   data.HeadingID,
 		UserID:      data.UserID,
 		Tags:        data.Tags,
-		CreatedAt:   currentTime,
-		UpdatedAt:   currentTime,
+
+		EstimateMinutes: data.EstimateMinutes,
+
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
 	}
 
-	if err = u.storage.Transaction(ctx, func(_ port.TaskStorage) error {
+	if err = u.storage.Transaction(ctx, func(storage port.TaskStorage) error {
 		for _, tag := range newTask.Tags {
 			if err = u.TagUsecase.CreateTagIfNotExists(ctx, model.TagRequestData{
 				Title:  tag,
@@ -69,7 +82,7 @@ func (u *TaskUsecase) CreateTask(ctx context.Context, data *model.TaskRequestDat
 				return err
 			}
 		}
-		if err = u.storage.CreateTask(ctx, newTask); err != nil {
+		if err = storage.CreateTask(ctx, newTask); err != nil {
 			return err
 		}
 		if err = u.TagUsecase.LinkTagsToTask(ctx, newTask.UserID, newTask.ID, newTask.Tags); err != nil {
@@ -93,11 +106,95 @@ func (u *TaskUsecase) CreateTask(ctx context.Context, data *model.TaskRequestDat
 		HeadingID:   newTask.HeadingID,
 		UserID:      newTask.UserID,
 		Tags:        newTask.Tags,
-		CreatedAt:   newTask.CreatedAt,
-		UpdatedAt:   newTask.UpdatedAt,
+
+		EstimateMinutes: newTask.EstimateMinutes,
+
+		CreatedAt: newTask.CreatedAt,
+		UpdatedAt: newTask.UpdatedAt,
 	}, nil
 }
 
+func (u *TaskUsecase) QuickAddTask(ctx context.Context, data *model.TaskQuickAddRequestData) (model.TaskResponseData, error) {
+	location, err := u.location(ctx, data.UserID, data.TimeZone)
+	if err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	parsed, err := quickadd.Parse(data.Text, quickadd.Options{
+		Location: location,
+		Locale:   data.Locale,
+	})
+	switch {
+	case errors.Is(err, quickadd.ErrEmptyInput), errors.Is(err, quickadd.ErrEmptyTitle):
+		return model.TaskResponseData{}, le.ErrEmptyTaskTitle
+	case errors.Is(err, quickadd.ErrUnsupportedLocale):
+		return model.TaskResponseData{}, le.ErrUnsupportedLocale
+	case err != nil:
+		return model.TaskResponseData{}, err
+	}
+
+	task := &model.TaskRequestData{
+		Title:           parsed.Title,
+		StartDateParsed: parsed.StartDate,
+		DeadlineParsed:  parsed.Deadline,
+		StartTimeParsed: parsed.StartTime,
+		EndTimeParsed:   parsed.EndTime,
+		UserID:          data.UserID,
+		Tags:            parsed.Tags,
+	}
+
+	if parsed.List != "" {
+		task.ListID, err = u.ListUsecase.GetListIDByTitle(ctx, model.ListRequestData{
+			Title:  parsed.List,
+			UserID: data.UserID,
+		})
+		if err != nil {
+			return model.TaskResponseData{}, err
+		}
+	}
+
+	if parsed.Heading != "" {
+		if err = u.handleListID(ctx, task); err != nil {
+			return model.TaskResponseData{}, err
+		}
+
+		task.HeadingID, err = u.HeadingUsecase.GetHeadingIDByTitle(ctx, model.HeadingRequestData{
+			Title:  parsed.Heading,
+			ListID: task.ListID,
+			UserID: data.UserID,
+		})
+		if err != nil {
+			return model.TaskResponseData{}, err
+		}
+	}
+
+	return u.CreateTask(ctx, task)
+}
+
+// location returns the time zone from the request data if it's set, otherwise the user's time zone
+func (u *TaskUsecase) location(ctx context.Context, userID, timeZone string) (*time.Location, error) {
+	if timeZone == "" {
+		return u.UserUsecase.GetUserLocation(ctx, userID)
+	}
+
+	location, err := timezone.Load(timeZone)
+	if err != nil {
+		return nil, le.ErrInvalidTimeZone
+	}
+
+	return location, nil
+}
+
+// today returns the current date in the user's time zone
+func (u *TaskUsecase) today(ctx context.Context, userID string) (time.Time, error) {
+	location, err := u.UserUsecase.GetUserLocation(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return timezone.Today(location), nil
+}
+
 func (u *TaskUsecase) handleListID(ctx context.Context, data *model.TaskRequestData) error {
 	if data.ListID == "" {
 		return u.setDefaultListID(ctx, data)
@@ -167,29 +264,46 @@ func (u *TaskUsecase) verifyHeadingOwnership(ctx context.Context, data *model.Ta
 }
 
 func (u *TaskUsecase) GetTaskByID(ctx context.Context, data model.TaskRequestData) (model.TaskResponseData, error) {
-	task, err := u.storage.GetTaskByID(ctx, data.ID, data.UserID)
+	today, err := u.today(ctx, data.UserID)
+	if err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	task, err := u.storage.GetTaskByID(ctx, data.ID, data.UserID, today)
 	if err != nil {
 		return model.TaskResponseData{}, err
 	}
 
 	return model.TaskResponseData{
-		ID:        task.ID,
-		Title:     task.Title,
-		StartDate: task.StartDate,
-		Deadline:  task.Deadline,
-		StartTime: task.StartTime,
-		EndTime:   task.EndTime,
-		StatusID:  task.StatusID,
-		ListID:    task.ListID,
-		HeadingID: task.HeadingID,
-		UserID:    task.UserID,
-		Tags:      task.Tags,
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		StartDate:   task.StartDate,
+		Deadline:    task.Deadline,
+		StartTime:   task.StartTime,
+		EndTime:     task.EndTime,
+		StatusID:    task.StatusID,
+		ListID:      task.ListID,
+		HeadingID:   task.HeadingID,
+		UserID:      task.UserID,
+		Tags:        task.Tags,
+		Overdue:     task.Overdue,
+
+		CustomStatusID:  task.CustomStatusID,
+		EstimateMinutes: task.EstimateMinutes,
+		TrackedMinutes:  task.TrackedMinutes,
+
 		UpdatedAt: task.UpdatedAt,
 	}, nil
 }
 
 func (u *TaskUsecase) GetTasksByUserID(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskResponseData, error) {
-	tasks, err := u.storage.GetTasksByUserID(ctx, userID, pgn)
+	today, err := u.today(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := u.storage.GetTasksByUserID(ctx, userID, pgn, today)
 	if err != nil {
 		return nil, err
 	}
@@ -203,7 +317,12 @@ func (u *TaskUsecase) GetTasksByUserID(ctx context.Context, userID string, pgn m
 }
 
 func (u *TaskUsecase) GetTasksByListID(ctx context.Context, data model.TaskRequestData) ([]model.TaskResponseData, error) {
-	tasks, err := u.storage.GetTasksByListID(ctx, data.ListID, data.UserID)
+	today, err := u.today(ctx, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := u.storage.GetTasksByListID(ctx, data.ListID, data.UserID, today)
 	if err != nil {
 		return nil, err
 	}
@@ -218,17 +337,23 @@ func (u *TaskUsecase) GetTasksByListID(ctx context.Context, data model.TaskReque
 
 func mapTaskToResponseData(task model.Task) model.TaskResponseData {
 	return model.TaskResponseData{
-		ID:        task.ID,
-		Title:     task.Title,
-		StartDate: task.StartDate,
-		Deadline:  task.Deadline,
-		StartTime: task.StartTime,
-		EndTime:   task.EndTime,
-		StatusID:  task.StatusID,
-		ListID:    task.ListID,
-		HeadingID: task.HeadingID,
-		UserID:    task.UserID,
-		Tags:      task.Tags,
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		StartDate:   task.StartDate,
+		Deadline:    task.Deadline,
+		StartTime:   task.StartTime,
+		EndTime:     task.EndTime,
+		StatusID:    task.StatusID,
+		ListID:      task.ListID,
+		HeadingID:   task.HeadingID,
+		UserID:      task.UserID,
+		Tags:        task.Tags,
+		Overdue:     task.Overdue,
+
+		EstimateMinutes: task.EstimateMinutes,
+		TrackedMinutes:  task.TrackedMinutes,
+
 		UpdatedAt: task.UpdatedAt,
 	}
 }
@@ -236,7 +361,12 @@ func mapTaskToResponseData(task model.Task) model.TaskResponseData {
 func (u *TaskUsecase) GetTasksGroupedByHeading(ctx context.Context, data model.TaskRequestData) ([]model.TaskGroupWithHeading, error) {
 	const op = "task.usecase.GetTasksGroupedByHeading"
 
-	groupsRaw, err := u.storage.GetTasksGroupedByHeadings(ctx, data.ListID, data.UserID)
+	today, err := u.today(ctx, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupsRaw, err := u.storage.GetTasksGroupedByHeadings(ctx, data.ListID, data.UserID, today)
 	if err != nil {
 		return nil, err
 	}
@@ -262,10 +392,113 @@ func (u *TaskUsecase) GetTasksGroupedByHeading(ctx context.Context, data model.T
 	return taskGroups, nil
 }
 
+// GetTasksByTagID returns the tasks with the tag or any of its child tags, grouped by list
+// GetBoard returns the list as a board, the columns are the statuses available in the list
+// except Archived, the empty columns are returned too
+func (u *TaskUsecase) GetBoard(ctx context.Context, data model.TaskRequestData) ([]model.BoardColumn, error) {
+	const op = "task.usecase.GetBoard"
+
+	statuses, err := u.StatusUsecase.GetStatuses(ctx, data.UserID, data.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	statusArchived, err := u.storage.GetTaskStatusID(ctx, model.StatusArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	today, err := u.today(ctx, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupsRaw, err := u.storage.GetTasksGroupedByStatus(ctx, data.ListID, data.UserID, today)
+	if err != nil && !errors.Is(err, le.ErrNoTasksFound) {
+		return nil, err
+	}
+
+	tasksByStatus := make(map[int][]model.TaskResponseData, len(groupsRaw))
+
+	for _, group := range groupsRaw {
+		var tasks []model.TaskResponseData
+
+		err = json.Unmarshal(group.Tasks, &tasks)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to unmarshal tasks from postgres json object: %w", op, err)
+		}
+
+		tasksByStatus[group.StatusID] = tasks
+	}
+
+	columns := make([]model.BoardColumn, 0, len(statuses))
+
+	for _, status := range statuses {
+		if status.ID == statusArchived {
+			continue
+		}
+
+		tasks := tasksByStatus[status.ID]
+
+		columns = append(columns, model.BoardColumn{
+			StatusID:   status.ID,
+			Title:      status.Title,
+			CategoryID: status.CategoryID,
+			TasksCount: len(tasks),
+			Tasks:      tasks,
+		})
+	}
+
+	return columns, nil
+}
+
+func (u *TaskUsecase) GetTasksByTagID(ctx context.Context, data model.TagRequestData, pgn model.Pagination) ([]model.TaskGroupForTag, error) {
+	const op = "task.usecase.GetTasksByTagID"
+
+	if _, err := u.TagUsecase.GetTagByID(ctx, data); err != nil {
+		return nil, err
+	}
+
+	today, err := u.today(ctx, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupsRaw, err := u.storage.GetTasksByTagID(ctx, data.ID, data.UserID, pgn, today)
+	if err != nil {
+		return nil, err
+	}
+
+	var taskGroups []model.TaskGroupForTag
+
+	for _, group := range groupsRaw {
+		var taskGroup model.TaskGroupForTag
+
+		var tasks []model.TaskResponseData
+
+		err = json.Unmarshal(group.Tasks, &tasks)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to unmarshal tasks from postgres json object: %w", op, err)
+		}
+
+		taskGroup.ListID = group.ListID
+		taskGroup.Tasks = tasks
+
+		taskGroups = append(taskGroups, taskGroup)
+	}
+
+	return taskGroups, nil
+}
+
 func (u *TaskUsecase) GetTasksForToday(ctx context.Context, userID string) ([]model.TodayTaskGroup, error) {
 	const op = "task.usecase.GetTasksForToday"
 
-	groupsRaw, err := u.storage.GetTasksForToday(ctx, userID)
+	today, err := u.today(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupsRaw, err := u.storage.GetTasksForToday(ctx, userID, today)
 	if err != nil {
 		return nil, err
 	}
@@ -291,10 +524,67 @@ func (u *TaskUsecase) GetTasksForToday(ctx context.Context, userID string) ([]mo
 	return taskGroups, nil
 }
 
+func (u *TaskUsecase) GetTasksForTodayGroupedByTag(ctx context.Context, userID string) ([]model.TaskGroupByTag, error) {
+	const op = "task.usecase.GetTasksForTodayGroupedByTag"
+
+	today, err := u.today(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupsRaw, err := u.storage.GetTasksForTodayGroupedByTag(ctx, userID, today)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapTaskGroupsByTag(op, groupsRaw)
+}
+
+// mapTaskGroupsByTag unmarshals the tasks of the groups by tag
+func mapTaskGroupsByTag(op string, groupsRaw []model.TaskGroupRaw) ([]model.TaskGroupByTag, error) {
+	var taskGroups []model.TaskGroupByTag
+
+	for _, group := range groupsRaw {
+		var taskGroup model.TaskGroupByTag
+
+		var tasks []model.TaskResponseData
+
+		err := json.Unmarshal(group.Tasks, &tasks)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to unmarshal tasks from postgres json object: %w", op, err)
+		}
+
+		taskGroup.TagID = group.TagID
+		taskGroup.Title = group.TagTitle
+		taskGroup.Tasks = tasks
+
+		taskGroups = append(taskGroups, taskGroup)
+	}
+
+	return taskGroups, nil
+}
+
+// GetTodaySummary returns the information shown alongside the tasks for today
+func (u *TaskUsecase) GetTodaySummary(ctx context.Context, userID string) (model.TodaySummary, error) {
+	focusSessions, err := u.FocusSessionUsecase.GetFocusSessionsSummaryForToday(ctx, userID)
+	if err != nil {
+		return model.TodaySummary{}, err
+	}
+
+	return model.TodaySummary{
+		FocusSessions: focusSessions,
+	}, nil
+}
+
 func (u *TaskUsecase) GetUpcomingTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.UpcomingTaskGroup, error) {
 	const op = "task.usecase.GetUpcomingTasks"
 
-	groupsRaw, err := u.storage.GetUpcomingTasks(ctx, userID, pgn)
+	today, err := u.today(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupsRaw, err := u.storage.GetUpcomingTasks(ctx, userID, pgn, today)
 	if err != nil {
 		return nil, err
 	}
@@ -320,14 +610,35 @@ func (u *TaskUsecase) GetUpcomingTasks(ctx context.Context, userID string, pgn m
 	return taskGroups, nil
 }
 
+func (u *TaskUsecase) GetUpcomingTasksGroupedByTag(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroupByTag, error) {
+	const op = "task.usecase.GetUpcomingTasksGroupedByTag"
+
+	today, err := u.today(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupsRaw, err := u.storage.GetUpcomingTasksGroupedByTag(ctx, userID, pgn, today)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapTaskGroupsByTag(op, groupsRaw)
+}
+
 func (u *TaskUsecase) GetOverdueTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.OverdueTaskGroup, error) {
 	const op = "task.usecase.GetOverdueTasks"
 
-	groupsRaw, err := u.storage.GetOverdueTasks(ctx, userID, pgn)
+	today, err := u.today(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	groupsRaw, err := u.storage.GetOverdueTasks(ctx, userID, pgn, today)
+	if err != nil && !errors.Is(err, le.ErrNoTasksFound) {
+		return nil, err
+	}
+
 	var taskGroups []model.OverdueTaskGroup
 
 	for _, group := range groupsRaw {
@@ -346,6 +657,39 @@ func (u *TaskUsecase) GetOverdueTasks(ctx context.Context, userID string, pgn mo
 		taskGroups = append(taskGroups, taskGroup)
 	}
 
+	overdueLists, err := u.ListUsecase.GetOverdueLists(ctx, userID, pgn, today)
+	if err != nil {
+		return nil, err
+	}
+
+	// The groups are paginated by list_id, so the overdue lists after the last group
+	// of a full page belong to the next pages
+	pageIsFull := len(groupsRaw) > 0 && len(groupsRaw) == int(pgn.Limit)
+
+	for _, list := range overdueLists {
+		if pageIsFull && list.ID > groupsRaw[len(groupsRaw)-1].ListID {
+			break
+		}
+
+		i := slices.IndexFunc(taskGroups, func(group model.OverdueTaskGroup) bool {
+			return group.ListID == list.ID
+		})
+		if i == -1 {
+			taskGroups = append(taskGroups, model.OverdueTaskGroup{ListID: list.ID})
+			i = len(taskGroups) - 1
+		}
+
+		taskGroups[i].List = &list
+	}
+
+	if len(taskGroups) == 0 {
+		return nil, le.ErrNoTasksFound
+	}
+
+	slices.SortFunc(taskGroups, func(a, b model.OverdueTaskGroup) int {
+		return strings.Compare(a.ListID, b.ListID)
+	})
+
 	return taskGroups, nil
 }
 
@@ -378,14 +722,42 @@ func (u *TaskUsecase) GetTasksForSomeday(ctx context.Context, userID string, pgn
 	return taskGroups, nil
 }
 
+func (u *TaskUsecase) GetTasksForSomedayGroupedByTag(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroupByTag, error) {
+	const op = "task.usecase.GetTasksForSomedayGroupedByTag"
+
+	groupsRaw, err := u.storage.GetTasksForSomedayGroupedByTag(ctx, userID, pgn)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapTaskGroupsByTag(op, groupsRaw)
+}
+
+// GetCompletedTasks groups the completed tasks and headings by the month of the user's time zone.
+// Both storages return at most pgn.Limit months before the cursor, so the page is cut
+// only after the groups are merged
 func (u *TaskUsecase) GetCompletedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.CompletedTasksGroup, error) {
 	const op = "task.usecase.GetCompletedTasks"
 
-	groupsRaw, err := u.storage.GetCompletedTasks(ctx, userID, pgn)
+	location, err := u.UserUsecase.GetUserLocation(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	groupsRaw, err := u.storage.GetCompletedTasks(ctx, userID, pgn, location.String())
+	if err != nil && !errors.Is(err, le.ErrNoTasksFound) {
+		return nil, err
+	}
+
+	headingsRaw, err := u.storage.GetCompletedHeadings(ctx, userID, pgn, location.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groupsRaw) == 0 && len(headingsRaw) == 0 {
+		return nil, le.ErrNoTasksFound
+	}
+
 	var taskGroups []model.CompletedTasksGroup
 
 	for _, group := range groupsRaw {
@@ -404,6 +776,40 @@ func (u *TaskUsecase) GetCompletedTasks(ctx context.Context, userID string, pgn
 		taskGroups = append(taskGroups, taskGroup)
 	}
 
+	// The completed headings are shown as a unit in the month they were completed
+	for _, heading := range headingsRaw {
+		var tasks []model.TaskResponseData
+
+		err = json.Unmarshal(heading.Tasks, &tasks)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to unmarshal tasks from postgres json object: %w", op, err)
+		}
+
+		i := slices.IndexFunc(taskGroups, func(group model.CompletedTasksGroup) bool {
+			return group.Month.Equal(heading.Month)
+		})
+		if i == -1 {
+			taskGroups = append(taskGroups, model.CompletedTasksGroup{Month: heading.Month})
+			i = len(taskGroups) - 1
+		}
+
+		taskGroups[i].Headings = append(taskGroups[i].Headings, model.CompletedHeadingResponseData{
+			ID:          heading.ID,
+			Title:       heading.Title,
+			ListID:      heading.ListID,
+			CompletedAt: heading.CompletedAt,
+			Tasks:       tasks,
+		})
+	}
+
+	slices.SortFunc(taskGroups, func(a, b model.CompletedTasksGroup) int {
+		return b.Month.Compare(a.Month)
+	})
+
+	if pgn.Limit > 0 && len(taskGroups) > int(pgn.Limit) {
+		taskGroups = taskGroups[:pgn.Limit]
+	}
+
 	return taskGroups, nil
 }
 
@@ -447,10 +853,13 @@ func (u *TaskUsecase) UpdateTask(ctx context.Context, data *model.TaskRequestDat
 		ListID:    data.ListID,
 		HeadingID: data.HeadingID,
 		UserID:    data.UserID,
+
+		EstimateMinutes: data.EstimateMinutes,
+
 		UpdatedAt: time.Now(),
 	}
 
-	if err := u.storage.Transaction(ctx, func(_ port.TaskStorage) error {
+	if err := u.storage.Transaction(ctx, func(storage port.TaskStorage) error {
 		currentTags, err := u.TagUsecase.GetTagsByTaskID(ctx, updatedTask.ID)
 		if err != nil {
 			return err
@@ -467,7 +876,7 @@ func (u *TaskUsecase) UpdateTask(ctx context.Context, data *model.TaskRequestDat
 			}
 		}
 
-		if err = u.storage.UpdateTask(ctx, updatedTask); err != nil {
+		if err = storage.UpdateTask(ctx, updatedTask); err != nil {
 			return err
 		}
 		if err = u.TagUsecase.UnlinkTagsFromTask(ctx, updatedTask.UserID, updatedTask.ID, tagsToRemove); err != nil {
@@ -493,6 +902,9 @@ func (u *TaskUsecase) UpdateTask(ctx context.Context, data *model.TaskRequestDat
 		HeadingID: updatedTask.HeadingID,
 		UserID:    updatedTask.UserID,
 		Tags:      updatedTask.Tags,
+
+		EstimateMinutes: updatedTask.EstimateMinutes,
+
 		UpdatedAt: updatedTask.UpdatedAt,
 	}, nil
 }
@@ -519,6 +931,8 @@ func findTagsToAddAndRemove(currentTags []model.TagResponseData, updatedTags []s
 	return tagsToAdd, tagsToRemove
 }
 
+// UpdateTaskTime moves the task between Not started and Planned if the task has no custom status
+// and the transition is allowed, otherwise the status is kept
 func (u *TaskUsecase) UpdateTaskTime(ctx context.Context, data *model.TaskRequestTimeData) (model.TaskResponseTimeData, error) {
 	var statusID int
 
@@ -539,6 +953,23 @@ func (u *TaskUsecase) UpdateTaskTime(ctx context.Context, data *model.TaskReques
 		return model.TaskResponseTimeData{}, le.ErrInvalidTaskTimeRange
 	}
 
+	task, err := u.storage.GetTaskStatus(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.TaskResponseTimeData{}, err
+	}
+
+	if task.CustomStatusID != 0 {
+		statusID = task.StatusID
+	} else {
+		err = u.StatusUsecase.CheckStatusTransition(ctx, data.UserID, task.ListID, task.StatusID, statusID)
+		switch {
+		case errors.Is(err, le.ErrStatusTransitionNotAllowed):
+			statusID = task.StatusID
+		case err != nil:
+			return model.TaskResponseTimeData{}, err
+		}
+	}
+
 	updatedTaskTime := model.Task{
 		ID:        data.ID,
 		StartTime: data.StartTimeParsed,
@@ -548,7 +979,7 @@ func (u *TaskUsecase) UpdateTaskTime(ctx context.Context, data *model.TaskReques
 		UpdatedAt: time.Now(),
 	}
 
-	if err := u.storage.UpdateTaskTime(ctx, updatedTaskTime); err != nil {
+	if err = u.storage.UpdateTaskTime(ctx, updatedTaskTime); err != nil {
 		return model.TaskResponseTimeData{}, err
 	}
 
@@ -561,6 +992,254 @@ func (u *TaskUsecase) UpdateTaskTime(ctx context.Context, data *model.TaskReques
 	}, nil
 }
 
+const (
+	// workingHoursLayout is the format of the working hours in the schedule request
+	workingHoursLayout = "15:04"
+	defaultWorkStart   = "09:00"
+	defaultWorkEnd     = "18:00"
+
+	// defaultEstimateMinutes is used to schedule the tasks without an estimate
+	defaultEstimateMinutes = 30
+
+	// scheduleStep rounds the start of the today's schedule up from the current time
+	scheduleStep = 5 * time.Minute
+)
+
+// ProposeSchedule places the unscheduled tasks of the day into the free time of the working hours.
+// Nothing is saved, the proposal is applied with AcceptSchedule
+func (u *TaskUsecase) ProposeSchedule(ctx context.Context, data model.ScheduleRequestData) (model.ScheduleProposal, error) {
+	location, err := u.UserUsecase.GetUserLocation(ctx, data.UserID)
+	if err != nil {
+		return model.ScheduleProposal{}, err
+	}
+
+	period, err := schedulePeriod(data, location)
+	if err != nil {
+		return model.ScheduleProposal{}, err
+	}
+
+	statusCompleted, err := u.storage.GetTaskStatusID(ctx, model.StatusCompleted)
+	if err != nil {
+		return model.ScheduleProposal{}, err
+	}
+
+	tasks, err := u.storage.GetTasksForSchedule(ctx, data.UserID, statusCompleted, period)
+	if err != nil {
+		return model.ScheduleProposal{}, err
+	}
+
+	ranks := make(map[string]int, len(data.TaskIDs))
+	for i, taskID := range data.TaskIDs {
+		ranks[taskID] = i
+	}
+
+	titles := make(map[string]string, len(tasks))
+
+	var busy []schedule.Interval
+	var unscheduled []schedule.Task
+
+	for _, task := range tasks {
+		titles[task.ID] = task.Title
+
+		if !task.StartTime.IsZero() {
+			busy = append(busy, schedule.Interval{
+				Start: task.StartTime,
+				End:   task.EndTime,
+			})
+			continue
+		}
+
+		unscheduled = append(unscheduled, scheduleTask(task, ranks, location))
+	}
+
+	result := schedule.Plan(period.WorkStart, period.WorkEnd, busy, unscheduled)
+
+	proposal := model.ScheduleProposal{
+		Date:      period.Date,
+		WorkStart: period.WorkStart,
+		WorkEnd:   period.WorkEnd,
+		Slots:     make([]model.ScheduleSlot, 0, len(result.Slots)),
+	}
+
+	for _, slot := range result.Slots {
+		proposal.Slots = append(proposal.Slots, model.ScheduleSlot{
+			TaskID:    slot.TaskID,
+			Title:     titles[slot.TaskID],
+			StartTime: slot.Start,
+			EndTime:   slot.End,
+		})
+	}
+
+	for _, task := range result.Unplaced {
+		proposal.Unscheduled = append(proposal.Unscheduled, model.UnscheduledTask{
+			TaskID: task.TaskID,
+			Title:  titles[task.TaskID],
+			Reason: task.Reason,
+		})
+	}
+
+	return proposal, nil
+}
+
+// schedulePeriod returns the working hours of the day to plan, by default it's today.
+// The schedule for today starts from the current time, so there is nothing to plan
+// for the past days or after the working hours of today
+func schedulePeriod(data model.ScheduleRequestData, location *time.Location) (model.SchedulePeriod, error) {
+	date := timezone.Today(location)
+	if data.Date != "" {
+		parsedDate, err := time.Parse(time.DateOnly, data.Date)
+		if err != nil {
+			return model.SchedulePeriod{}, le.ErrInvalidScheduleDate
+		}
+		if parsedDate.Before(date) {
+			return model.SchedulePeriod{}, le.ErrScheduleDateInPast
+		}
+		date = parsedDate
+	}
+
+	workStart, err := workingTime(data.WorkStart, defaultWorkStart, date, location)
+	if err != nil {
+		return model.SchedulePeriod{}, err
+	}
+
+	workEnd, err := workingTime(data.WorkEnd, defaultWorkEnd, date, location)
+	if err != nil {
+		return model.SchedulePeriod{}, err
+	}
+
+	if !workEnd.After(workStart) {
+		return model.SchedulePeriod{}, le.ErrInvalidWorkingHours
+	}
+
+	now := time.Now().In(location)
+	if now.After(workStart) {
+		workStart = now.Truncate(scheduleStep).Add(scheduleStep)
+	}
+
+	if !workEnd.After(workStart) {
+		return model.SchedulePeriod{}, le.ErrWorkingHoursAreOver
+	}
+
+	return model.SchedulePeriod{
+		Date:      date,
+		WorkStart: workStart,
+		WorkEnd:   workEnd,
+	}, nil
+}
+
+// workingTime returns the instant of the working hours boundary on the date
+func workingTime(value, defaultValue string, date time.Time, location *time.Location) (time.Time, error) {
+	if value == "" {
+		value = defaultValue
+	}
+
+	clock, err := time.Parse(workingHoursLayout, value)
+	if err != nil {
+		return time.Time{}, le.ErrInvalidWorkingHours
+	}
+
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, location), nil
+}
+
+func scheduleTask(task model.Task, ranks map[string]int, location *time.Location) schedule.Task {
+	estimate := task.EstimateMinutes
+	if estimate == 0 {
+		estimate = defaultEstimateMinutes
+	}
+
+	rank, ok := ranks[task.ID]
+	if !ok {
+		rank = schedule.NoRank
+	}
+
+	// The deadline is a date, the task has to be finished by the end of that day
+	var deadline time.Time
+	if !task.Deadline.IsZero() {
+		deadline = time.Date(task.Deadline.Year(), task.Deadline.Month(), task.Deadline.Day()+1, 0, 0, 0, 0, location)
+	}
+
+	return schedule.Task{
+		ID:       task.ID,
+		Estimate: time.Duration(estimate) * time.Minute,
+		Deadline: deadline,
+		Rank:     rank,
+	}
+}
+
+// AcceptSchedule applies the time of all slots at once, if any of the tasks is not found nothing is changed
+func (u *TaskUsecase) AcceptSchedule(ctx context.Context, data *model.ScheduleAcceptRequestData) ([]model.TaskResponseTimeData, error) {
+	if err := validateScheduleSlots(data.Slots); err != nil {
+		return nil, err
+	}
+
+	statusPlanned, err := u.storage.GetTaskStatusID(ctx, model.StatusPlanned)
+	if err != nil {
+		return nil, err
+	}
+
+	currentTime := time.Now()
+
+	scheduledTasks := make([]model.Task, 0, len(data.Slots))
+
+	for _, slot := range data.Slots {
+		scheduledTasks = append(scheduledTasks, model.Task{
+			ID:        slot.ID,
+			StartTime: slot.StartTimeParsed,
+			EndTime:   slot.EndTimeParsed,
+			StatusID:  statusPlanned,
+			UserID:    data.UserID,
+			UpdatedAt: currentTime,
+		})
+	}
+
+	if err = u.storage.UpdateTasksTime(ctx, scheduledTasks); err != nil {
+		return nil, err
+	}
+
+	tasksResp := make([]model.TaskResponseTimeData, 0, len(scheduledTasks))
+
+	for _, task := range scheduledTasks {
+		tasksResp = append(tasksResp, model.TaskResponseTimeData{
+			ID:        task.ID,
+			StartTime: task.StartTime,
+			EndTime:   task.EndTime,
+			UserID:    task.UserID,
+			UpdatedAt: task.UpdatedAt,
+		})
+	}
+
+	return tasksResp, nil
+}
+
+func validateScheduleSlots(slots []model.TaskRequestTimeData) error {
+	sorted := make([]model.TaskRequestTimeData, len(slots))
+	copy(sorted, slots)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartTimeParsed.Before(sorted[j].StartTimeParsed)
+	})
+
+	scheduled := make(map[string]struct{}, len(sorted))
+
+	for i, slot := range sorted {
+		if slot.ID == "" {
+			return le.ErrTaskNotFound
+		}
+		if slot.StartTimeParsed.IsZero() || !slot.EndTimeParsed.After(slot.StartTimeParsed) {
+			return le.ErrInvalidTaskTimeRange
+		}
+		if i > 0 && slot.StartTimeParsed.Before(sorted[i-1].EndTimeParsed) {
+			return le.ErrScheduleSlotsOverlap
+		}
+		if _, ok := scheduled[slot.ID]; ok {
+			return le.ErrTaskScheduledTwice
+		}
+		scheduled[slot.ID] = struct{}{}
+	}
+
+	return nil
+}
+
 func (u *TaskUsecase) MoveTaskToAnotherList(ctx context.Context, data model.TaskRequestData) (model.TaskResponseData, error) {
 	// Check if list exists
 	_, err := u.ListUsecase.GetListByID(ctx, model.ListRequestData{
@@ -629,20 +1308,184 @@ func (u *TaskUsecase) MoveTaskToAnotherHeading(ctx context.Context, data model.T
 	}, nil
 }
 
+// MoveTaskOnBoard changes the status of the task and puts it at the position in the column of the status.
+// The positions of the other tasks in the column are shifted, the updated board is returned
+func (u *TaskUsecase) MoveTaskOnBoard(ctx context.Context, data *model.TaskBoardMoveRequestData) ([]model.BoardColumn, error) {
+	task, err := u.storage.GetTaskStatus(ctx, data.ID, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if task.ListID != data.ListID {
+		return nil, le.ErrTaskNotFound
+	}
+
+	statusArchived, err := u.storage.GetTaskStatusID(ctx, model.StatusArchived)
+	if err != nil {
+		return nil, err
+	}
+	if data.StatusID == statusArchived {
+		return nil, le.ErrStatusNotOnBoard
+	}
+
+	status, err := u.StatusUsecase.GetStatusByID(ctx, model.StatusRequestData{
+		ID:     data.StatusID,
+		UserID: data.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.storage.Transaction(ctx, func(storage port.TaskStorage) error {
+		if data.StatusID != currentStatusID(task) {
+			if _, err = u.changeTaskStatus(ctx, storage, &model.TaskStatusRequestData{
+				ID:       data.ID,
+				StatusID: data.StatusID,
+				UserID:   data.UserID,
+			}, status); err != nil {
+				return err
+			}
+		}
+
+		taskIDs, err := storage.GetTaskIDsByBoardColumn(ctx, data.ListID, data.UserID, data.StatusID)
+		if err != nil {
+			return err
+		}
+
+		taskIDs = slices.DeleteFunc(taskIDs, func(taskID string) bool {
+			return taskID == data.ID
+		})
+		taskIDs = slices.Insert(taskIDs, min(data.Position, len(taskIDs)), data.ID)
+
+		return storage.UpdateTasksPosition(ctx, data.UserID, taskIDs)
+	}); err != nil {
+		return nil, err
+	}
+
+	return u.GetBoard(ctx, model.TaskRequestData{
+		ListID: data.ListID,
+		UserID: data.UserID,
+	})
+}
+
+// ChangeTaskStatus moves the task to the status, a custom status sets the task to its category.
+// Moving the task to the built-in Archived status archives the task
+func (u *TaskUsecase) ChangeTaskStatus(ctx context.Context, data *model.TaskStatusRequestData) (model.TaskResponseData, error) {
+	status, err := u.StatusUsecase.GetStatusByID(ctx, model.StatusRequestData{
+		ID:     data.StatusID,
+		UserID: data.UserID,
+	})
+	if err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	statusArchived, err := u.storage.GetTaskStatusID(ctx, model.StatusArchived)
+	if err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	if status.ID == statusArchived {
+		return u.ArchiveTask(ctx, model.TaskRequestData{
+			ID:     data.ID,
+			UserID: data.UserID,
+		})
+	}
+
+	return u.changeTaskStatus(ctx, u.storage, data, status)
+}
+
+// changeTaskStatus moves the task to the status which isn't archived, the task is updated on the storage
+// the caller passes, so it can be a part of the caller's transaction
+func (u *TaskUsecase) changeTaskStatus(
+	ctx context.Context,
+	storage port.TaskStorage,
+	data *model.TaskStatusRequestData,
+	status model.StatusResponseData,
+) (model.TaskResponseData, error) {
+	task, err := storage.GetTaskStatus(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	if status.ListID != "" && status.ListID != task.ListID {
+		return model.TaskResponseData{}, le.ErrStatusNotAvailableForList
+	}
+
+	if err = u.StatusUsecase.CheckStatusTransition(ctx, data.UserID, task.ListID, currentStatusID(task), status.ID); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	statusCompleted, err := storage.GetTaskStatusID(ctx, model.StatusCompleted)
+	if err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	updatedTask := model.Task{
+		ID:        data.ID,
+		StatusID:  status.CategoryID,
+		UserID:    data.UserID,
+		UpdatedAt: time.Now(),
+	}
+	if status.Custom {
+		updatedTask.CustomStatusID = status.ID
+	}
+	// The task moved out of the completed category is reopened
+	if status.CategoryID == statusCompleted {
+		updatedTask.CompletedAt = updatedTask.UpdatedAt
+	}
+
+	if err = storage.UpdateTaskStatus(ctx, updatedTask); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	return model.TaskResponseData{
+		ID:             updatedTask.ID,
+		StatusID:       updatedTask.StatusID,
+		CustomStatusID: updatedTask.CustomStatusID,
+		UserID:         updatedTask.UserID,
+		UpdatedAt:      updatedTask.UpdatedAt,
+		CompletedAt:    updatedTask.CompletedAt,
+	}, nil
+}
+
+// checkStatusTransition returns ErrStatusTransitionNotAllowed if the task can't be moved to the status
+func (u *TaskUsecase) checkStatusTransition(ctx context.Context, taskID, userID string, toStatusID int) error {
+	task, err := u.storage.GetTaskStatus(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	return u.StatusUsecase.CheckStatusTransition(ctx, userID, task.ListID, currentStatusID(task), toStatusID)
+}
+
+// currentStatusID returns the custom status of the task if it's set, otherwise the built-in one
+func currentStatusID(task model.Task) int {
+	if task.CustomStatusID != 0 {
+		return task.CustomStatusID
+	}
+	return task.StatusID
+}
+
 func (u *TaskUsecase) CompleteTask(ctx context.Context, data model.TaskRequestData) (model.TaskResponseData, error) {
 	statusCompleted, err := u.storage.GetTaskStatusID(ctx, model.StatusCompleted)
 	if err != nil {
 		return model.TaskResponseData{}, err
 	}
 
+	if err = u.checkStatusTransition(ctx, data.ID, data.UserID, statusCompleted); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
 	// TODO: remove this and place it in struct below
 	data.StatusID = statusCompleted
 
+	completedAt := time.Now()
+
 	completedTask := model.Task{
-		ID:        data.ID,
-		StatusID:  data.StatusID,
-		UserID:    data.UserID,
-		UpdatedAt: time.Now(),
+		ID:          data.ID,
+		StatusID:    data.StatusID,
+		UserID:      data.UserID,
+		UpdatedAt:   completedAt,
+		CompletedAt: completedAt,
 	}
 
 	// TODO: rename to MarkTaskAsCompleted
@@ -651,10 +1494,11 @@ func (u *TaskUsecase) CompleteTask(ctx context.Context, data model.TaskRequestDa
 	}
 
 	return model.TaskResponseData{
-		ID:        completedTask.ID,
-		StatusID:  completedTask.StatusID,
-		UserID:    completedTask.UserID,
-		UpdatedAt: completedTask.UpdatedAt,
+		ID:          completedTask.ID,
+		StatusID:    completedTask.StatusID,
+		UserID:      completedTask.UserID,
+		UpdatedAt:   completedTask.UpdatedAt,
+		CompletedAt: completedTask.CompletedAt,
 	}, nil
 }
 
@@ -664,6 +1508,10 @@ func (u *TaskUsecase) ArchiveTask(ctx context.Context, data model.TaskRequestDat
 		return model.TaskResponseData{}, err
 	}
 
+	if err = u.checkStatusTransition(ctx, data.ID, data.UserID, statusArchived); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
 	// TODO: remove this and place it in struct below
 	data.StatusID = statusArchived
 
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/timezone"
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+func TestSchedulePeriod(t *testing.T) {
+	location := mustLoadLocation(t, "Asia/Tokyo")
+
+	today := timezone.Today(location)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	testCases := []struct {
+		name        string
+		data        model.ScheduleRequestData
+		expectedErr error
+	}{
+		{
+			name: "Tomorrow",
+			data: model.ScheduleRequestData{Date: tomorrow.Format(time.DateOnly)},
+		},
+		{
+			name:        "Past date",
+			data:        model.ScheduleRequestData{Date: today.AddDate(0, 0, -1).Format(time.DateOnly)},
+			expectedErr: le.ErrScheduleDateInPast,
+		},
+		{
+			name:        "Working hours ending before start",
+			data:        model.ScheduleRequestData{Date: tomorrow.Format(time.DateOnly), WorkStart: "18:00", WorkEnd: "09:00"},
+			expectedErr: le.ErrInvalidWorkingHours,
+		},
+		{
+			name:        "Working hours of today are over",
+			data:        model.ScheduleRequestData{WorkStart: "00:00", WorkEnd: "00:01"},
+			expectedErr: le.ErrWorkingHoursAreOver,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			period, err := schedulePeriod(tc.data, location)
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Errorf("Expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !period.WorkEnd.After(period.WorkStart) {
+				t.Errorf("Expected the working hours to end after the start, got %v - %v", period.WorkStart, period.WorkEnd)
+			}
+		})
+	}
+}
@@ -0,0 +1,492 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/timezone"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type TemplateUsecase struct {
+	storage        port.TemplateStorage
+	AreaUsecase    port.AreaUsecase
+	ListUsecase    port.ListUsecase
+	HeadingUsecase port.HeadingUsecase
+	TaskUsecase    port.TaskUsecase
+	TagUsecase     port.TagUsecase
+	UserUsecase    port.UserUsecase
+}
+
+func NewTemplateUsecase(storage port.TemplateStorage) *TemplateUsecase {
+	return &TemplateUsecase{storage: storage}
+}
+
+// CreateTemplate saves the list or the task from the request data as a template
+func (u *TemplateUsecase) CreateTemplate(ctx context.Context, data *model.TemplateRequestData) (model.TemplateResponseData, error) {
+	var (
+		template model.Template
+		err      error
+	)
+
+	if data.TaskID != "" {
+		template, err = u.templateFromTask(ctx, data)
+	} else {
+		template, err = u.templateFromList(ctx, data)
+	}
+	if err != nil {
+		return model.TemplateResponseData{}, err
+	}
+
+	currentTime := time.Now()
+
+	template.ID = ksuid.New().String()
+	template.UserID = data.UserID
+	template.CreatedAt = currentTime
+	template.UpdatedAt = currentTime
+
+	if data.Title != "" {
+		template.Title = data.Title
+	}
+
+	if err = u.storage.Transaction(ctx, func(storage port.TemplateStorage) error {
+		return storage.CreateTemplate(ctx, template)
+	}); err != nil {
+		return model.TemplateResponseData{}, err
+	}
+
+	return mapTemplateToResponseData(template), nil
+}
+
+// templateFromList keeps the headings of the list except the default one, and the tasks of these headings.
+// The tasks of the default heading are kept without heading
+func (u *TemplateUsecase) templateFromList(ctx context.Context, data *model.TemplateRequestData) (model.Template, error) {
+	list, err := u.ListUsecase.GetListByID(ctx, model.ListRequestData{
+		ID:     data.ListID,
+		UserID: data.UserID,
+	})
+	if err != nil {
+		return model.Template{}, err
+	}
+
+	headingData := model.HeadingRequestData{
+		ListID: list.ID,
+		UserID: data.UserID,
+	}
+
+	defaultHeadingID, err := u.HeadingUsecase.GetDefaultHeadingID(ctx, headingData)
+	if err != nil {
+		return model.Template{}, err
+	}
+
+	headings, err := u.HeadingUsecase.GetHeadingsByListID(ctx, headingData)
+	if err != nil && !errors.Is(err, le.ErrNoHeadingsFound) {
+		return model.Template{}, err
+	}
+
+	tasks, err := u.TaskUsecase.GetTasksByListID(ctx, model.TaskRequestData{
+		ListID: list.ID,
+		UserID: data.UserID,
+	})
+	if err != nil && !errors.Is(err, le.ErrNoTasksFound) {
+		return model.Template{}, err
+	}
+
+	anchorDate := data.AnchorDateParsed
+	if anchorDate.IsZero() {
+		anchorDate = earliestDate(list.Deadline, tasks)
+	}
+
+	template := model.Template{
+		Title:          list.Title,
+		Type:           model.TemplateTypeList,
+		Description:    list.Description,
+		Color:          list.Color,
+		Icon:           list.Icon,
+		DeadlineOffset: dayOffset(list.Deadline, anchorDate),
+	}
+
+	// Template heading IDs by the heading IDs of the list
+	headingIDs := map[string]string{defaultHeadingID: ""}
+
+	for _, heading := range headings {
+		if heading.ID == defaultHeadingID {
+			continue
+		}
+
+		templateHeading := model.TemplateHeading{
+			ID:    ksuid.New().String(),
+			Title: heading.Title,
+		}
+
+		headingIDs[heading.ID] = templateHeading.ID
+		template.Headings = append(template.Headings, templateHeading)
+	}
+
+	for _, task := range tasks {
+		// Skip the tasks of the completed headings
+		headingID, ok := headingIDs[task.HeadingID]
+		if !ok {
+			continue
+		}
+
+		template.Tasks = append(template.Tasks, templateTask(task, headingID, anchorDate))
+	}
+
+	return template, nil
+}
+
+func (u *TemplateUsecase) templateFromTask(ctx context.Context, data *model.TemplateRequestData) (model.Template, error) {
+	task, err := u.TaskUsecase.GetTaskByID(ctx, model.TaskRequestData{
+		ID:     data.TaskID,
+		UserID: data.UserID,
+	})
+	if err != nil {
+		return model.Template{}, err
+	}
+
+	anchorDate := data.AnchorDateParsed
+	if anchorDate.IsZero() {
+		anchorDate = earliestDate(time.Time{}, []model.TaskResponseData{task})
+	}
+
+	return model.Template{
+		Title: task.Title,
+		Type:  model.TemplateTypeTask,
+		Tasks: []model.TemplateTask{templateTask(task, "", anchorDate)},
+	}, nil
+}
+
+func templateTask(task model.TaskResponseData, headingID string, anchorDate time.Time) model.TemplateTask {
+	return model.TemplateTask{
+		ID:              ksuid.New().String(),
+		HeadingID:       headingID,
+		Title:           task.Title,
+		Description:     task.Description,
+		StartDateOffset: dayOffset(task.StartDate, anchorDate),
+		DeadlineOffset:  dayOffset(task.Deadline, anchorDate),
+		EstimateMinutes: task.EstimateMinutes,
+		Tags:            task.Tags,
+	}
+}
+
+// earliestDate returns the earliest of the deadline and the start dates and deadlines of the tasks
+func earliestDate(deadline time.Time, tasks []model.TaskResponseData) time.Time {
+	earliest := deadline
+
+	for _, task := range tasks {
+		for _, date := range []time.Time{task.StartDate, task.Deadline} {
+			if !date.IsZero() && (earliest.IsZero() || date.Before(earliest)) {
+				earliest = date
+			}
+		}
+	}
+
+	return earliest
+}
+
+// dayOffset returns the number of days from the anchor date to the date, or nil if the date is not set
+func dayOffset(date, anchorDate time.Time) *int {
+	if date.IsZero() {
+		return nil
+	}
+
+	days := int(dateOnly(date).Sub(dateOnly(anchorDate)).Hours() / 24)
+	return &days
+}
+
+// offsetDate returns the date the number of days after the anchor date, or zero time if the offset is not set
+func offsetDate(offset *int, anchorDate time.Time) time.Time {
+	if offset == nil {
+		return time.Time{}
+	}
+
+	return dateOnly(anchorDate).AddDate(0, 0, *offset)
+}
+
+// dateOnly drops the time of the date, the dates are stored as midnight in UTC
+func dateOnly(date time.Time) time.Time {
+	year, month, day := date.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func (u *TemplateUsecase) GetTemplateByID(ctx context.Context, data model.TemplateRequestData) (model.TemplateResponseData, error) {
+	template, err := u.storage.GetTemplateByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.TemplateResponseData{}, err
+	}
+
+	return mapTemplateToResponseData(template), nil
+}
+
+func (u *TemplateUsecase) GetTemplatesByUserID(ctx context.Context, userID string) ([]model.TemplateResponseData, error) {
+	templates, err := u.storage.GetTemplatesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var templatesResp []model.TemplateResponseData
+
+	for _, template := range templates {
+		templatesResp = append(templatesResp, model.TemplateResponseData{
+			ID:        template.ID,
+			Title:     template.Title,
+			Type:      template.Type,
+			UpdatedAt: template.UpdatedAt,
+		})
+	}
+
+	return templatesResp, nil
+}
+
+// mapTemplateToResponseData nests the tasks into their headings,
+// the tasks without heading are kept at the top level
+func mapTemplateToResponseData(template model.Template) model.TemplateResponseData {
+	templateResp := model.TemplateResponseData{
+		ID:             template.ID,
+		Title:          template.Title,
+		Type:           template.Type,
+		Description:    template.Description,
+		Color:          template.Color,
+		Icon:           template.Icon,
+		DeadlineOffset: template.DeadlineOffset,
+		UpdatedAt:      template.UpdatedAt,
+	}
+
+	headingIndexes := make(map[string]int, len(template.Headings))
+
+	for i, heading := range template.Headings {
+		headingIndexes[heading.ID] = i
+		templateResp.Headings = append(templateResp.Headings, model.TemplateHeadingResponseData{
+			ID:    heading.ID,
+			Title: heading.Title,
+		})
+	}
+
+	for _, task := range template.Tasks {
+		taskResp := model.TemplateTaskResponseData{
+			ID:              task.ID,
+			Title:           task.Title,
+			Description:     task.Description,
+			StartDateOffset: task.StartDateOffset,
+			DeadlineOffset:  task.DeadlineOffset,
+			EstimateMinutes: task.EstimateMinutes,
+			Tags:            task.Tags,
+		}
+
+		if i, ok := headingIndexes[task.HeadingID]; ok {
+			templateResp.Headings[i].Tasks = append(templateResp.Headings[i].Tasks, taskResp)
+		} else {
+			templateResp.Tasks = append(templateResp.Tasks, taskResp)
+		}
+	}
+
+	return templateResp
+}
+
+// InstantiateTemplate creates a new list with its headings and tasks from the list template,
+// or a new task from the task template, with the dates counted from the anchor date
+func (u *TemplateUsecase) InstantiateTemplate(ctx context.Context, data *model.TemplateInstantiateRequestData) (model.TemplateInstanceResponseData, error) {
+	template, err := u.storage.GetTemplateByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.TemplateInstanceResponseData{}, err
+	}
+
+	anchorDate := data.AnchorDateParsed
+	if anchorDate.IsZero() {
+		location, err := u.UserUsecase.GetUserLocation(ctx, data.UserID)
+		if err != nil {
+			return model.TemplateInstanceResponseData{}, err
+		}
+
+		anchorDate = timezone.Today(location)
+	}
+
+	title := template.Title
+	if data.Title != "" {
+		title = data.Title
+	}
+
+	if template.Type == model.TemplateTypeTask {
+		if len(template.Tasks) == 0 {
+			return model.TemplateInstanceResponseData{}, le.ErrNoTasksFound
+		}
+
+		taskData := newTaskFromTemplate(template.Tasks[0], anchorDate, data.UserID)
+		taskData.Title = title
+		taskData.ListID = data.ListID
+		taskData.HeadingID = data.HeadingID
+
+		task, err := u.TaskUsecase.CreateTask(ctx, taskData)
+		if err != nil {
+			return model.TemplateInstanceResponseData{}, err
+		}
+
+		return model.TemplateInstanceResponseData{Task: &task}, nil
+	}
+
+	if data.AreaID != "" {
+		if _, err = u.AreaUsecase.GetAreaByID(ctx, model.AreaRequestData{
+			ID:     data.AreaID,
+			UserID: data.UserID,
+		}); err != nil {
+			return model.TemplateInstanceResponseData{}, err
+		}
+	}
+
+	// The tags are shared by the tasks of the user, so they are created before the transaction
+	// and are kept even if the list is not created
+	for _, templateTask := range template.Tasks {
+		for _, tag := range templateTask.Tags {
+			if err = u.TagUsecase.CreateTagIfNotExists(ctx, model.TagRequestData{
+				Title:  tag,
+				UserID: data.UserID,
+			}); err != nil {
+				return model.TemplateInstanceResponseData{}, err
+			}
+		}
+	}
+
+	currentTime := time.Now()
+
+	newList := model.List{
+		ID:          ksuid.New().String(),
+		Title:       title,
+		Description: template.Description,
+		Deadline:    offsetDate(template.DeadlineOffset, anchorDate),
+		Color:       template.Color,
+		Icon:        template.Icon,
+		AreaID:      data.AreaID,
+		UserID:      data.UserID,
+		CreatedAt:   currentTime,
+		UpdatedAt:   currentTime,
+	}
+
+	if err = u.storage.Transaction(ctx, func(storage port.TemplateStorage) error {
+		return createListFromTemplate(ctx, storage, template, newList, anchorDate)
+	}); err != nil {
+		return model.TemplateInstanceResponseData{}, err
+	}
+
+	list := model.ListResponseData{
+		ID:          newList.ID,
+		Title:       newList.Title,
+		Description: newList.Description,
+		Deadline:    newList.Deadline,
+		Color:       newList.Color,
+		Icon:        newList.Icon,
+		AreaID:      newList.AreaID,
+		UserID:      newList.UserID,
+		CreatedAt:   newList.CreatedAt,
+		UpdatedAt:   newList.UpdatedAt,
+	}
+
+	return model.TemplateInstanceResponseData{List: &list}, nil
+}
+
+// createListFromTemplate creates the list with its default heading, the headings and the tasks of the template
+func createListFromTemplate(
+	ctx context.Context,
+	storage port.TemplateStorage,
+	template model.Template,
+	list model.List,
+	anchorDate time.Time,
+) error {
+	if err := storage.CreateList(ctx, list); err != nil {
+		return err
+	}
+
+	defaultHeading := model.Heading{
+		ID:        ksuid.New().String(),
+		Title:     model.DefaultHeading.String(),
+		ListID:    list.ID,
+		UserID:    list.UserID,
+		IsDefault: true,
+		CreatedAt: list.CreatedAt,
+		UpdatedAt: list.UpdatedAt,
+	}
+
+	if err := storage.CreateHeading(ctx, defaultHeading); err != nil {
+		return err
+	}
+
+	// Heading IDs of the new list by the template heading IDs, the tasks without heading go to the default one
+	headingIDs := map[string]string{"": defaultHeading.ID}
+
+	for _, templateHeading := range template.Headings {
+		heading := model.Heading{
+			ID:        ksuid.New().String(),
+			Title:     templateHeading.Title,
+			ListID:    list.ID,
+			UserID:    list.UserID,
+			CreatedAt: list.CreatedAt,
+			UpdatedAt: list.UpdatedAt,
+		}
+
+		if err := storage.CreateHeading(ctx, heading); err != nil {
+			return err
+		}
+
+		headingIDs[templateHeading.ID] = heading.ID
+	}
+
+	statusNotStarted, err := storage.GetTaskStatusID(ctx, model.StatusNotStarted)
+	if err != nil {
+		return err
+	}
+
+	for _, templateTask := range template.Tasks {
+		taskData := newTaskFromTemplate(templateTask, anchorDate, list.UserID)
+
+		task := model.Task{
+			ID:              ksuid.New().String(),
+			Title:           taskData.Title,
+			Description:     taskData.Description,
+			StartDate:       taskData.StartDateParsed,
+			Deadline:        taskData.DeadlineParsed,
+			StatusID:        statusNotStarted,
+			ListID:          list.ID,
+			HeadingID:       headingIDs[templateTask.HeadingID],
+			UserID:          list.UserID,
+			Tags:            taskData.Tags,
+			EstimateMinutes: taskData.EstimateMinutes,
+			CreatedAt:       list.CreatedAt,
+			UpdatedAt:       list.UpdatedAt,
+		}
+
+		if err = storage.CreateTask(ctx, task); err != nil {
+			return err
+		}
+
+		if err = storage.LinkTagsToTask(ctx, task.UserID, task.ID, task.Tags); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newTaskFromTemplate(task model.TemplateTask, anchorDate time.Time, userID string) *model.TaskRequestData {
+	return &model.TaskRequestData{
+		Title:           task.Title,
+		Description:     task.Description,
+		StartDateParsed: offsetDate(task.StartDateOffset, anchorDate),
+		DeadlineParsed:  offsetDate(task.DeadlineOffset, anchorDate),
+		EstimateMinutes: task.EstimateMinutes,
+		Tags:            task.Tags,
+		UserID:          userID,
+	}
+}
+
+func (u *TemplateUsecase) DeleteTemplate(ctx context.Context, data model.TemplateRequestData) error {
+	return u.storage.DeleteTemplate(ctx, model.Template{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		DeletedAt: time.Now(),
+	})
+}
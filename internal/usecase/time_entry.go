@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/timezone"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+// defaultReportDays is the length of the time report when the date range isn't set
+const defaultReportDays = 7
+
+type TimeEntryUsecase struct {
+	storage     port.TimeEntryStorage
+	TaskUsecase port.TaskUsecase
+	UserUsecase port.UserUsecase
+}
+
+func NewTimeEntryUsecase(storage port.TimeEntryStorage) *TimeEntryUsecase {
+	return &TimeEntryUsecase{storage: storage}
+}
+
+// StartTimer starts tracking time for the task, a timer running for another task is stopped
+func (u *TimeEntryUsecase) StartTimer(ctx context.Context, data model.TimeEntryRequestData) (model.TimeEntryResponseData, error) {
+	if err := u.verifyTaskOwnership(ctx, data.TaskID, data.UserID); err != nil {
+		return model.TimeEntryResponseData{}, err
+	}
+
+	currentTime := time.Now()
+
+	newEntry := model.TimeEntry{
+		ID:        ksuid.New().String(),
+		TaskID:    data.TaskID,
+		UserID:    data.UserID,
+		StartTime: currentTime,
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+
+	if err := u.storage.Transaction(ctx, func(storage port.TimeEntryStorage) error {
+		runningEntry, err := storage.GetRunningTimeEntry(ctx, data.UserID)
+
+		switch {
+		case errors.Is(err, le.ErrTimerNotRunning):
+		case err != nil:
+			return err
+		case runningEntry.TaskID == data.TaskID:
+			return le.ErrTimerAlreadyRunning
+		default:
+			runningEntry.EndTime = currentTime
+			runningEntry.UpdatedAt = currentTime
+
+			if err = storage.StopTimeEntry(ctx, runningEntry); err != nil {
+				return err
+			}
+		}
+
+		return storage.CreateTimeEntry(ctx, newEntry)
+	}); err != nil {
+		return model.TimeEntryResponseData{}, err
+	}
+
+	return mapTimeEntryToResponseData(newEntry), nil
+}
+
+// StopTimer stops the timer running for the task
+func (u *TimeEntryUsecase) StopTimer(ctx context.Context, data model.TimeEntryRequestData) (model.TimeEntryResponseData, error) {
+	runningEntry, err := u.storage.GetRunningTimeEntry(ctx, data.UserID)
+	if err != nil {
+		return model.TimeEntryResponseData{}, err
+	}
+
+	if runningEntry.TaskID != data.TaskID {
+		return model.TimeEntryResponseData{}, le.ErrTimerNotRunning
+	}
+
+	currentTime := time.Now()
+
+	runningEntry.EndTime = currentTime
+	runningEntry.UpdatedAt = currentTime
+
+	if err = u.storage.StopTimeEntry(ctx, runningEntry); err != nil {
+		return model.TimeEntryResponseData{}, err
+	}
+
+	return mapTimeEntryToResponseData(runningEntry), nil
+}
+
+// CreateTimeEntry adds the time tracked for the task without the timer
+func (u *TimeEntryUsecase) CreateTimeEntry(ctx context.Context, data *model.TimeEntryRequestData) (model.TimeEntryResponseData, error) {
+	if data.StartTimeParsed.IsZero() || !data.EndTimeParsed.After(data.StartTimeParsed) {
+		return model.TimeEntryResponseData{}, le.ErrInvalidTimeEntryRange
+	}
+
+	if err := u.verifyTaskOwnership(ctx, data.TaskID, data.UserID); err != nil {
+		return model.TimeEntryResponseData{}, err
+	}
+
+	currentTime := time.Now()
+
+	newEntry := model.TimeEntry{
+		ID:        ksuid.New().String(),
+		TaskID:    data.TaskID,
+		UserID:    data.UserID,
+		StartTime: data.StartTimeParsed,
+		EndTime:   data.EndTimeParsed,
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+
+	if err := u.storage.CreateTimeEntry(ctx, newEntry); err != nil {
+		return model.TimeEntryResponseData{}, err
+	}
+
+	return mapTimeEntryToResponseData(newEntry), nil
+}
+
+func (u *TimeEntryUsecase) verifyTaskOwnership(ctx context.Context, taskID, userID string) error {
+	_, err := u.TaskUsecase.GetTaskByID(ctx, model.TaskRequestData{
+		ID:     taskID,
+		UserID: userID,
+	})
+	return err
+}
+
+func (u *TimeEntryUsecase) GetTimeEntriesByTaskID(ctx context.Context, data model.TimeEntryRequestData) ([]model.TimeEntryResponseData, error) {
+	entries, err := u.storage.GetTimeEntriesByTaskID(ctx, data.TaskID, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var entriesResp []model.TimeEntryResponseData
+	for _, entry := range entries {
+		entriesResp = append(entriesResp, mapTimeEntryToResponseData(entry))
+	}
+
+	return entriesResp, nil
+}
+
+func mapTimeEntryToResponseData(entry model.TimeEntry) model.TimeEntryResponseData {
+	endTime := entry.EndTime
+	if endTime.IsZero() {
+		endTime = time.Now()
+	}
+
+	return model.TimeEntryResponseData{
+		ID:        entry.ID,
+		TaskID:    entry.TaskID,
+		StartTime: entry.StartTime,
+		EndTime:   entry.EndTime,
+		Minutes:   int(endTime.Sub(entry.StartTime).Minutes()),
+		Running:   entry.EndTime.IsZero(),
+		UserID:    entry.UserID,
+		CreatedAt: entry.CreatedAt,
+		UpdatedAt: entry.UpdatedAt,
+	}
+}
+
+// UpdateTimeEntry changes the start or end time of the entry,
+// the end time of a running entry can only be set by stopping the timer
+func (u *TimeEntryUsecase) UpdateTimeEntry(ctx context.Context, data *model.TimeEntryRequestData) (model.TimeEntryResponseData, error) {
+	entry, err := u.storage.GetTimeEntryByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.TimeEntryResponseData{}, err
+	}
+
+	if !data.StartTimeParsed.IsZero() {
+		entry.StartTime = data.StartTimeParsed
+	}
+	if !data.EndTimeParsed.IsZero() {
+		if entry.EndTime.IsZero() {
+			return model.TimeEntryResponseData{}, le.ErrInvalidTimeEntryRange
+		}
+		entry.EndTime = data.EndTimeParsed
+	}
+
+	if !entry.EndTime.IsZero() && !entry.EndTime.After(entry.StartTime) {
+		return model.TimeEntryResponseData{}, le.ErrInvalidTimeEntryRange
+	}
+
+	entry.UpdatedAt = time.Now()
+
+	if err = u.storage.UpdateTimeEntry(ctx, entry); err != nil {
+		return model.TimeEntryResponseData{}, err
+	}
+
+	return mapTimeEntryToResponseData(entry), nil
+}
+
+func (u *TimeEntryUsecase) DeleteTimeEntry(ctx context.Context, data model.TimeEntryRequestData) error {
+	deletedEntry := model.TimeEntry{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		DeletedAt: time.Now(),
+	}
+
+	return u.storage.DeleteTimeEntry(ctx, deletedEntry)
+}
+
+// GetTimeReport returns the time tracked against the time estimated per list, per tag and per day.
+// Tasks are counted if time was tracked for them or if they start within the date range,
+// both ends of the range are inclusive and taken in the user's time zone
+func (u *TimeEntryUsecase) GetTimeReport(ctx context.Context, data model.TimeReportRequestData) (model.TimeReportResponseData, error) {
+	location, err := u.UserUsecase.GetUserLocation(ctx, data.UserID)
+	if err != nil {
+		return model.TimeReportResponseData{}, err
+	}
+
+	period, err := reportPeriod(data, location)
+	if err != nil {
+		return model.TimeReportResponseData{}, err
+	}
+
+	byList, err := u.storage.GetTimeReportByList(ctx, data.UserID, period)
+	if err != nil {
+		return model.TimeReportResponseData{}, err
+	}
+
+	byTag, err := u.storage.GetTimeReportByTag(ctx, data.UserID, period)
+	if err != nil {
+		return model.TimeReportResponseData{}, err
+	}
+
+	byDay, err := u.storage.GetTimeReportByDay(ctx, data.UserID, period)
+	if err != nil {
+		return model.TimeReportResponseData{}, err
+	}
+
+	return model.TimeReportResponseData{
+		From:   period.FromDate,
+		To:     period.ToDate.AddDate(0, 0, -1),
+		ByList: byList,
+		ByTag:  byTag,
+		ByDay:  byDay,
+	}, nil
+}
+
+// reportPeriod parses the date range of the report, by default it's the last week up to today
+func reportPeriod(data model.TimeReportRequestData, location *time.Location) (model.TimeReportPeriod, error) {
+	to := timezone.Today(location)
+	if data.To != "" {
+		date, err := time.Parse(time.DateOnly, data.To)
+		if err != nil {
+			return model.TimeReportPeriod{}, le.ErrInvalidReportDateRange
+		}
+		to = date
+	}
+
+	from := to.AddDate(0, 0, 1-defaultReportDays)
+	if data.From != "" {
+		date, err := time.Parse(time.DateOnly, data.From)
+		if err != nil {
+			return model.TimeReportPeriod{}, le.ErrInvalidReportDateRange
+		}
+		from = date
+	}
+
+	if to.Before(from) {
+		return model.TimeReportPeriod{}, le.ErrInvalidReportDateRange
+	}
+
+	return model.TimeReportPeriod{
+		FromDate: from,
+		ToDate:   to.AddDate(0, 0, 1),
+		FromTime: time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, location),
+		ToTime:   time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, location),
+		TimeZone: location.String(),
+	}, nil
+}
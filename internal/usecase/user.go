@@ -2,7 +2,12 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/rshelekhov/reframed/internal/lib/constant/le"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/timezone"
+	"github.com/rshelekhov/reframed/internal/model"
 	"github.com/rshelekhov/reframed/internal/port"
 )
 
@@ -21,3 +26,76 @@ func (u *UserUsecase) DeleteUserRelatedData(ctx context.Context, userID string)
 
 	return nil
 }
+
+func (u *UserUsecase) GetUserSettings(ctx context.Context, userID string) (model.UserSettingsResponseData, error) {
+	settings, err := u.getUserSettings(ctx, userID)
+	if err != nil {
+		return model.UserSettingsResponseData{}, err
+	}
+
+	return mapUserSettingsToResponseData(settings), nil
+}
+
+func (u *UserUsecase) UpdateUserSettings(ctx context.Context, data *model.UserSettingsRequestData) (model.UserSettingsResponseData, error) {
+	if _, err := timezone.Load(data.TimeZone); err != nil {
+		return model.UserSettingsResponseData{}, le.ErrInvalidTimeZone
+	}
+
+	currentTime := time.Now()
+
+	updatedSettings := model.UserSettings{
+		UserID:    data.UserID,
+		TimeZone:  data.TimeZone,
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+
+	if err := u.storage.UpdateUserSettings(ctx, updatedSettings); err != nil {
+		return model.UserSettingsResponseData{}, err
+	}
+
+	return mapUserSettingsToResponseData(updatedSettings), nil
+}
+
+// GetUserLocation returns the time zone from the request header if it was set,
+// otherwise the time zone from the user settings
+func (u *UserUsecase) GetUserLocation(ctx context.Context, userID string) (*time.Location, error) {
+	if location, ok := timezone.FromContext(ctx); ok {
+		return location, nil
+	}
+
+	settings, err := u.getUserSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := timezone.Load(settings.TimeZone)
+	if err != nil {
+		return nil, le.ErrInvalidTimeZone
+	}
+
+	return location, nil
+}
+
+// getUserSettings returns default settings for users who haven't saved their own yet
+func (u *UserUsecase) getUserSettings(ctx context.Context, userID string) (model.UserSettings, error) {
+	settings, err := u.storage.GetUserSettings(ctx, userID)
+	if errors.Is(err, le.ErrUserSettingsNotFound) {
+		return model.UserSettings{
+			UserID:   userID,
+			TimeZone: timezone.Default,
+		}, nil
+	}
+	if err != nil {
+		return model.UserSettings{}, err
+	}
+
+	return settings, nil
+}
+
+func mapUserSettingsToResponseData(settings model.UserSettings) model.UserSettingsResponseData {
+	return model.UserSettingsResponseData{
+		TimeZone:  settings.TimeZone,
+		UpdatedAt: settings.UpdatedAt,
+	}
+}
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS user_settings
+(
+    user_id    character varying PRIMARY KEY,
+    time_zone  character varying NOT NULL DEFAULT 'UTC',
+    created_at timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at timestamp WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE OR REPLACE FUNCTION delete_user_related_data(deleting_user_id varchar) RETURNS void AS $$
+BEGIN
+    DELETE FROM reminders WHERE user_id = deleting_user_id;
+    DELETE FROM tags WHERE user_id = deleting_user_id;
+    DELETE FROM tasks WHERE user_id = deleting_user_id;
+    DELETE FROM headings WHERE user_id = deleting_user_id;
+    DELETE FROM lists WHERE user_id = deleting_user_id;
+    DELETE FROM user_settings WHERE user_id = deleting_user_id;
+END;
+$$ LANGUAGE plpgsql;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE OR REPLACE FUNCTION delete_user_related_data(deleting_user_id varchar) RETURNS void AS $$
+BEGIN
+    DELETE FROM reminders WHERE user_id = deleting_user_id;
+    DELETE FROM tags WHERE user_id = deleting_user_id;
+    DELETE FROM tasks WHERE user_id = deleting_user_id;
+    DELETE FROM headings WHERE user_id = deleting_user_id;
+    DELETE FROM lists WHERE user_id = deleting_user_id;
+    DELETE FROM user_settings WHERE user_id = deleting_user_id;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP VIEW IF EXISTS task_time_view;
+
+DROP TABLE IF EXISTS time_entries;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimate_minutes;
\ No newline at end of file
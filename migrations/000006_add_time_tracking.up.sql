@@ -0,0 +1,41 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS estimate_minutes int;
+
+CREATE TABLE IF NOT EXISTS time_entries
+(
+    id         character varying PRIMARY KEY,
+    task_id    character varying NOT NULL,
+    user_id    character varying NOT NULL,
+    start_time timestamp WITH TIME ZONE NOT NULL,
+    end_time   timestamp WITH TIME ZONE,
+    created_at timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at timestamp WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_time_entry_task_id ON time_entries(task_id);
+CREATE INDEX IF NOT EXISTS idx_time_entry_user_id_start_time ON time_entries(user_id, start_time);
+
+-- Only one timer can be running for a user at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entry_running ON time_entries(user_id)
+    WHERE end_time IS NULL AND deleted_at IS NULL;
+
+ALTER TABLE time_entries ADD FOREIGN KEY (task_id) REFERENCES tasks(id);
+
+CREATE VIEW task_time_view AS
+SELECT task_id,
+       (EXTRACT(EPOCH FROM SUM(COALESCE(end_time, now()) - start_time)) / 60)::int AS tracked_minutes
+FROM time_entries
+WHERE deleted_at IS NULL
+GROUP BY task_id;
+
+CREATE OR REPLACE FUNCTION delete_user_related_data(deleting_user_id varchar) RETURNS void AS $$
+BEGIN
+    DELETE FROM reminders WHERE user_id = deleting_user_id;
+    DELETE FROM time_entries WHERE user_id = deleting_user_id;
+    DELETE FROM tags WHERE user_id = deleting_user_id;
+    DELETE FROM tasks WHERE user_id = deleting_user_id;
+    DELETE FROM headings WHERE user_id = deleting_user_id;
+    DELETE FROM lists WHERE user_id = deleting_user_id;
+    DELETE FROM user_settings WHERE user_id = deleting_user_id;
+END;
+$$ LANGUAGE plpgsql;
\ No newline at end of file
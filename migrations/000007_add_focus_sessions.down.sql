@@ -0,0 +1,15 @@
+CREATE OR REPLACE FUNCTION delete_user_related_data(deleting_user_id varchar) RETURNS void AS $$
+BEGIN
+    DELETE FROM reminders WHERE user_id = deleting_user_id;
+    DELETE FROM time_entries WHERE user_id = deleting_user_id;
+    DELETE FROM tags WHERE user_id = deleting_user_id;
+    DELETE FROM tasks WHERE user_id = deleting_user_id;
+    DELETE FROM headings WHERE user_id = deleting_user_id;
+    DELETE FROM lists WHERE user_id = deleting_user_id;
+    DELETE FROM user_settings WHERE user_id = deleting_user_id;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS focus_session_events;
+
+DROP TABLE IF EXISTS focus_sessions;
\ No newline at end of file
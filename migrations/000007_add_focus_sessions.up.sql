@@ -0,0 +1,48 @@
+CREATE TABLE IF NOT EXISTS focus_sessions
+(
+    id               character varying PRIMARY KEY,
+    task_id          character varying NOT NULL,
+    user_id          character varying NOT NULL,
+    duration_minutes int NOT NULL,
+    status           character varying NOT NULL,
+    started_at       timestamp WITH TIME ZONE NOT NULL,
+    ends_at          timestamp WITH TIME ZONE NOT NULL,
+    paused_at        timestamp WITH TIME ZONE,
+    finished_at      timestamp WITH TIME ZONE,
+    created_at       timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at       timestamp WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_focus_session_user_id_started_at ON focus_sessions(user_id, started_at);
+
+-- Only one session can be active for a user at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_focus_session_active ON focus_sessions(user_id)
+    WHERE status IN ('running', 'paused');
+
+CREATE TABLE IF NOT EXISTS focus_session_events
+(
+    id         int PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    session_id character varying NOT NULL,
+    user_id    character varying NOT NULL,
+    event      character varying NOT NULL,
+    created_at timestamp WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_focus_session_event_session_id ON focus_session_events(session_id);
+
+ALTER TABLE focus_sessions ADD FOREIGN KEY (task_id) REFERENCES tasks(id);
+ALTER TABLE focus_session_events ADD FOREIGN KEY (session_id) REFERENCES focus_sessions(id);
+
+CREATE OR REPLACE FUNCTION delete_user_related_data(deleting_user_id varchar) RETURNS void AS $$
+BEGIN
+    DELETE FROM reminders WHERE user_id = deleting_user_id;
+    DELETE FROM time_entries WHERE user_id = deleting_user_id;
+    DELETE FROM focus_session_events WHERE user_id = deleting_user_id;
+    DELETE FROM focus_sessions WHERE user_id = deleting_user_id;
+    DELETE FROM tags WHERE user_id = deleting_user_id;
+    DELETE FROM tasks WHERE user_id = deleting_user_id;
+    DELETE FROM headings WHERE user_id = deleting_user_id;
+    DELETE FROM lists WHERE user_id = deleting_user_id;
+    DELETE FROM user_settings WHERE user_id = deleting_user_id;
+END;
+$$ LANGUAGE plpgsql;
\ No newline at end of file
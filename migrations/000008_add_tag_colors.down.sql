@@ -0,0 +1 @@
+ALTER TABLE tags DROP COLUMN IF EXISTS color;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE tags ADD COLUMN IF NOT EXISTS color character varying;
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tag_parent_id;
+
+ALTER TABLE tags DROP COLUMN IF EXISTS parent_id;
\ No newline at end of file
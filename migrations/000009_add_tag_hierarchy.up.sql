@@ -0,0 +1,15 @@
+ALTER TABLE tags ADD COLUMN IF NOT EXISTS parent_id character varying;
+
+CREATE INDEX IF NOT EXISTS idx_tag_parent_id ON tags(parent_id);
+
+ALTER TABLE tags ADD FOREIGN KEY (parent_id) REFERENCES tags(id);
+
+-- Tags are titled by their path (work/clients/acme), link the existing ones to their parents
+UPDATE tags
+SET parent_id = parents.id
+FROM tags parents
+WHERE strpos(tags.title, '/') > 0
+  AND parents.title = left(tags.title, length(tags.title) - strpos(reverse(tags.title), '/'))
+  AND parents.user_id = tags.user_id
+  AND parents.deleted_at IS NULL
+  AND tags.deleted_at IS NULL;
\ No newline at end of file
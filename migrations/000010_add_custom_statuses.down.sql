@@ -0,0 +1,28 @@
+CREATE OR REPLACE FUNCTION delete_user_related_data(deleting_user_id varchar) RETURNS void AS $$
+BEGIN
+    DELETE FROM reminders WHERE user_id = deleting_user_id;
+    DELETE FROM time_entries WHERE user_id = deleting_user_id;
+    DELETE FROM focus_session_events WHERE user_id = deleting_user_id;
+    DELETE FROM focus_sessions WHERE user_id = deleting_user_id;
+    DELETE FROM tags WHERE user_id = deleting_user_id;
+    DELETE FROM tasks WHERE user_id = deleting_user_id;
+    DELETE FROM headings WHERE user_id = deleting_user_id;
+    DELETE FROM lists WHERE user_id = deleting_user_id;
+    DELETE FROM user_settings WHERE user_id = deleting_user_id;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS status_transitions;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS custom_status_id;
+
+DELETE FROM statuses WHERE user_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_status_user_id;
+
+ALTER TABLE statuses DROP COLUMN IF EXISTS category_id;
+ALTER TABLE statuses DROP COLUMN IF EXISTS user_id;
+ALTER TABLE statuses DROP COLUMN IF EXISTS list_id;
+ALTER TABLE statuses DROP COLUMN IF EXISTS created_at;
+ALTER TABLE statuses DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE statuses DROP COLUMN IF EXISTS deleted_at;
\ No newline at end of file
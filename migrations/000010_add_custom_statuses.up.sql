@@ -0,0 +1,54 @@
+ALTER TABLE statuses ADD COLUMN IF NOT EXISTS category_id int;
+ALTER TABLE statuses ADD COLUMN IF NOT EXISTS user_id character varying;
+ALTER TABLE statuses ADD COLUMN IF NOT EXISTS list_id character varying;
+ALTER TABLE statuses ADD COLUMN IF NOT EXISTS created_at timestamp WITH TIME ZONE NOT NULL DEFAULT now();
+ALTER TABLE statuses ADD COLUMN IF NOT EXISTS updated_at timestamp WITH TIME ZONE NOT NULL DEFAULT now();
+ALTER TABLE statuses ADD COLUMN IF NOT EXISTS deleted_at timestamp WITH TIME ZONE DEFAULT NULL;
+
+-- The built-in statuses are the categories of the custom ones
+UPDATE statuses SET category_id = id WHERE user_id IS NULL;
+
+ALTER TABLE statuses ALTER COLUMN category_id SET NOT NULL;
+
+-- The built-in statuses were inserted with explicit ids
+SELECT setval(pg_get_serial_sequence('statuses', 'id'), (SELECT MAX(id) FROM statuses));
+
+CREATE INDEX IF NOT EXISTS idx_status_user_id ON statuses(user_id);
+
+ALTER TABLE statuses ADD FOREIGN KEY (category_id) REFERENCES statuses(id);
+ALTER TABLE statuses ADD FOREIGN KEY (list_id) REFERENCES lists(id);
+
+-- status_id of a task is always a built-in status, custom_status_id refines it
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS custom_status_id int;
+
+ALTER TABLE tasks ADD FOREIGN KEY (custom_status_id) REFERENCES statuses(id);
+
+CREATE TABLE IF NOT EXISTS status_transitions
+(
+    user_id        character varying NOT NULL,
+    list_id        character varying,
+    from_status_id int NOT NULL,
+    to_status_id   int NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_status_transition_user_id ON status_transitions(user_id);
+
+ALTER TABLE status_transitions ADD FOREIGN KEY (list_id) REFERENCES lists(id);
+ALTER TABLE status_transitions ADD FOREIGN KEY (from_status_id) REFERENCES statuses(id);
+ALTER TABLE status_transitions ADD FOREIGN KEY (to_status_id) REFERENCES statuses(id);
+
+CREATE OR REPLACE FUNCTION delete_user_related_data(deleting_user_id varchar) RETURNS void AS $$
+BEGIN
+    DELETE FROM reminders WHERE user_id = deleting_user_id;
+    DELETE FROM time_entries WHERE user_id = deleting_user_id;
+    DELETE FROM focus_session_events WHERE user_id = deleting_user_id;
+    DELETE FROM focus_sessions WHERE user_id = deleting_user_id;
+    DELETE FROM tags WHERE user_id = deleting_user_id;
+    DELETE FROM tasks WHERE user_id = deleting_user_id;
+    DELETE FROM status_transitions WHERE user_id = deleting_user_id;
+    DELETE FROM statuses WHERE user_id = deleting_user_id;
+    DELETE FROM headings WHERE user_id = deleting_user_id;
+    DELETE FROM lists WHERE user_id = deleting_user_id;
+    DELETE FROM user_settings WHERE user_id = deleting_user_id;
+END;
+$$ LANGUAGE plpgsql;
\ No newline at end of file
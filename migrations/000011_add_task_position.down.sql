@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_list_id_position;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS position;
\ No newline at end of file
@@ -0,0 +1,15 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS position int NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_task_list_id_position ON tasks(list_id, position);
+
+-- Cards of a board column keep the order in which the tasks were created
+UPDATE tasks
+SET position = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (
+        PARTITION BY list_id, COALESCE(custom_status_id, status_id)
+        ORDER BY created_at
+    ) AS position
+    FROM tasks
+) ordered
+WHERE tasks.id = ordered.id;
\ No newline at end of file
@@ -0,0 +1,25 @@
+CREATE OR REPLACE FUNCTION delete_user_related_data(deleting_user_id varchar) RETURNS void AS $$
+BEGIN
+    DELETE FROM reminders WHERE user_id = deleting_user_id;
+    DELETE FROM time_entries WHERE user_id = deleting_user_id;
+    DELETE FROM focus_session_events WHERE user_id = deleting_user_id;
+    DELETE FROM focus_sessions WHERE user_id = deleting_user_id;
+    DELETE FROM tags WHERE user_id = deleting_user_id;
+    DELETE FROM tasks WHERE user_id = deleting_user_id;
+    DELETE FROM status_transitions WHERE user_id = deleting_user_id;
+    DELETE FROM statuses WHERE user_id = deleting_user_id;
+    DELETE FROM headings WHERE user_id = deleting_user_id;
+    DELETE FROM lists WHERE user_id = deleting_user_id;
+    DELETE FROM user_settings WHERE user_id = deleting_user_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- The default lists of areas become regular lists
+UPDATE lists SET is_default = FALSE WHERE area_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_list_area_id;
+
+ALTER TABLE lists DROP COLUMN IF EXISTS area_id;
+ALTER TABLE lists DROP COLUMN IF EXISTS position;
+
+DROP TABLE IF EXISTS areas;
\ No newline at end of file
@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS areas
+(
+    id         character varying PRIMARY KEY,
+    title      character varying NOT NULL,
+    position   int NOT NULL DEFAULT 0,
+    user_id    character varying NOT NULL,
+    created_at timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at timestamp WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_area_user_id ON areas(user_id);
+
+-- The tasks kept directly in an area belong to its default list (is_default with area_id),
+-- in the same way as the tasks kept directly in a list belong to its default heading
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS area_id character varying;
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS position int NOT NULL DEFAULT 0;
+
+ALTER TABLE lists ADD FOREIGN KEY (area_id) REFERENCES areas(id);
+
+CREATE INDEX IF NOT EXISTS idx_list_area_id ON lists(area_id);
+
+-- Lists keep the order in which they were created
+UPDATE lists
+SET position = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at) AS position
+    FROM lists
+) ordered
+WHERE lists.id = ordered.id;
+
+CREATE OR REPLACE FUNCTION delete_user_related_data(deleting_user_id varchar) RETURNS void AS $$
+BEGIN
+    DELETE FROM reminders WHERE user_id = deleting_user_id;
+    DELETE FROM time_entries WHERE user_id = deleting_user_id;
+    DELETE FROM focus_session_events WHERE user_id = deleting_user_id;
+    DELETE FROM focus_sessions WHERE user_id = deleting_user_id;
+    DELETE FROM tags WHERE user_id = deleting_user_id;
+    DELETE FROM tasks WHERE user_id = deleting_user_id;
+    DELETE FROM status_transitions WHERE user_id = deleting_user_id;
+    DELETE FROM statuses WHERE user_id = deleting_user_id;
+    DELETE FROM headings WHERE user_id = deleting_user_id;
+    DELETE FROM lists WHERE user_id = deleting_user_id;
+    DELETE FROM areas WHERE user_id = deleting_user_id;
+    DELETE FROM user_settings WHERE user_id = deleting_user_id;
+END;
+$$ LANGUAGE plpgsql;
\ No newline at end of file
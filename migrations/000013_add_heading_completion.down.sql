@@ -0,0 +1,4 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS previous_custom_status_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS previous_status_id;
+
+ALTER TABLE headings DROP COLUMN IF EXISTS completed_at;
\ No newline at end of file
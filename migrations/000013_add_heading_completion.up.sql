@@ -0,0 +1,6 @@
+ALTER TABLE headings ADD COLUMN IF NOT EXISTS completed_at timestamp WITH TIME ZONE DEFAULT NULL;
+
+-- The tasks closed by completing their heading keep the status they had before,
+-- restoring the heading reopens exactly these tasks
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS previous_status_id int DEFAULT NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS previous_custom_status_id int DEFAULT NULL;
\ No newline at end of file
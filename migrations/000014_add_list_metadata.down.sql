@@ -0,0 +1,4 @@
+ALTER TABLE lists DROP COLUMN IF EXISTS icon;
+ALTER TABLE lists DROP COLUMN IF EXISTS color;
+ALTER TABLE lists DROP COLUMN IF EXISTS deadline;
+ALTER TABLE lists DROP COLUMN IF EXISTS description;
\ No newline at end of file
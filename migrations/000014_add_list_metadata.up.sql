@@ -0,0 +1,4 @@
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS description character varying;
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS deadline timestamp WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS color character varying;
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS icon character varying;
\ No newline at end of file
@@ -0,0 +1,71 @@
+CREATE TABLE IF NOT EXISTS templates
+(
+    id              character varying PRIMARY KEY,
+    title           character varying NOT NULL,
+    type            character varying NOT NULL,
+    description     character varying,
+    color           character varying,
+    icon            character varying,
+    deadline_offset int,
+    user_id         character varying NOT NULL,
+    created_at      timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at      timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at      timestamp WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_template_user_id ON templates(user_id);
+
+CREATE TABLE IF NOT EXISTS template_headings
+(
+    id          character varying PRIMARY KEY,
+    template_id character varying NOT NULL,
+    title       character varying NOT NULL,
+    position    int NOT NULL DEFAULT 0,
+    user_id     character varying NOT NULL
+);
+
+ALTER TABLE template_headings ADD FOREIGN KEY (template_id) REFERENCES templates(id);
+
+CREATE INDEX IF NOT EXISTS idx_template_heading_template_id ON template_headings(template_id);
+
+-- Dates of the template tasks are kept as the number of days from the anchor date,
+-- the tasks without heading_id go to the default heading of the new list
+CREATE TABLE IF NOT EXISTS template_tasks
+(
+    id                character varying PRIMARY KEY,
+    template_id       character varying NOT NULL,
+    heading_id        character varying,
+    title             character varying NOT NULL,
+    description       character varying,
+    start_date_offset int,
+    deadline_offset   int,
+    estimate_minutes  int,
+    tags              character varying[] NOT NULL DEFAULT '{}',
+    position          int NOT NULL DEFAULT 0,
+    user_id           character varying NOT NULL
+);
+
+ALTER TABLE template_tasks ADD FOREIGN KEY (template_id) REFERENCES templates(id);
+ALTER TABLE template_tasks ADD FOREIGN KEY (heading_id) REFERENCES template_headings(id);
+
+CREATE INDEX IF NOT EXISTS idx_template_task_template_id ON template_tasks(template_id);
+
+CREATE OR REPLACE FUNCTION delete_user_related_data(deleting_user_id varchar) RETURNS void AS $$
+BEGIN
+    DELETE FROM template_tasks WHERE user_id = deleting_user_id;
+    DELETE FROM template_headings WHERE user_id = deleting_user_id;
+    DELETE FROM templates WHERE user_id = deleting_user_id;
+    DELETE FROM reminders WHERE user_id = deleting_user_id;
+    DELETE FROM time_entries WHERE user_id = deleting_user_id;
+    DELETE FROM focus_session_events WHERE user_id = deleting_user_id;
+    DELETE FROM focus_sessions WHERE user_id = deleting_user_id;
+    DELETE FROM tags WHERE user_id = deleting_user_id;
+    DELETE FROM tasks WHERE user_id = deleting_user_id;
+    DELETE FROM status_transitions WHERE user_id = deleting_user_id;
+    DELETE FROM statuses WHERE user_id = deleting_user_id;
+    DELETE FROM headings WHERE user_id = deleting_user_id;
+    DELETE FROM lists WHERE user_id = deleting_user_id;
+    DELETE FROM areas WHERE user_id = deleting_user_id;
+    DELETE FROM user_settings WHERE user_id = deleting_user_id;
+END;
+$$ LANGUAGE plpgsql;
\ No newline at end of file
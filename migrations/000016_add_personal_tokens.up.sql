@@ -0,0 +1,37 @@
+-- Personal access tokens are kept as bcrypt hashes of their secret part,
+-- the token itself is shown only once when it is created
+CREATE TABLE IF NOT EXISTS personal_tokens
+(
+    id           character varying PRIMARY KEY,
+    name         character varying NOT NULL,
+    token_hash   character varying NOT NULL,
+    scopes       character varying[] NOT NULL DEFAULT '{}',
+    user_id      character varying NOT NULL,
+    expires_at   timestamp WITH TIME ZONE NOT NULL,
+    last_used_at timestamp WITH TIME ZONE DEFAULT NULL,
+    created_at   timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    revoked_at   timestamp WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_personal_token_user_id ON personal_tokens(user_id);
+
+CREATE OR REPLACE FUNCTION delete_user_related_data(deleting_user_id varchar) RETURNS void AS $$
+BEGIN
+    DELETE FROM personal_tokens WHERE user_id = deleting_user_id;
+    DELETE FROM template_tasks WHERE user_id = deleting_user_id;
+    DELETE FROM template_headings WHERE user_id = deleting_user_id;
+    DELETE FROM templates WHERE user_id = deleting_user_id;
+    DELETE FROM reminders WHERE user_id = deleting_user_id;
+    DELETE FROM time_entries WHERE user_id = deleting_user_id;
+    DELETE FROM focus_session_events WHERE user_id = deleting_user_id;
+    DELETE FROM focus_sessions WHERE user_id = deleting_user_id;
+    DELETE FROM tags WHERE user_id = deleting_user_id;
+    DELETE FROM tasks WHERE user_id = deleting_user_id;
+    DELETE FROM status_transitions WHERE user_id = deleting_user_id;
+    DELETE FROM statuses WHERE user_id = deleting_user_id;
+    DELETE FROM headings WHERE user_id = deleting_user_id;
+    DELETE FROM lists WHERE user_id = deleting_user_id;
+    DELETE FROM areas WHERE user_id = deleting_user_id;
+    DELETE FROM user_settings WHERE user_id = deleting_user_id;
+END;
+$$ LANGUAGE plpgsql;
\ No newline at end of file
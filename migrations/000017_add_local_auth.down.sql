@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS auth_tokens;
+DROP TABLE IF EXISTS auth_sessions;
+DROP TABLE IF EXISTS auth_users;
\ No newline at end of file
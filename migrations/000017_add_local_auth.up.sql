@@ -0,0 +1,35 @@
+-- Users of the local authentication provider, used instead of the SSO service when AUTH_PROVIDER=local.
+-- Refresh tokens and one-time tokens are kept as sha256 hashes
+CREATE TABLE IF NOT EXISTS auth_users
+(
+    id            character varying PRIMARY KEY,
+    email         character varying NOT NULL UNIQUE,
+    password_hash character varying NOT NULL,
+    verified      boolean NOT NULL DEFAULT false,
+    created_at    timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at    timestamp WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS auth_sessions
+(
+    token_hash character varying PRIMARY KEY,
+    user_id    character varying NOT NULL REFERENCES auth_users(id) ON DELETE CASCADE,
+    user_agent character varying NOT NULL,
+    ip         character varying NOT NULL,
+    expires_at timestamp WITH TIME ZONE NOT NULL,
+    created_at timestamp WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_session_user_id ON auth_sessions(user_id);
+
+-- Tokens sent by email for the verification of the email and for the reset of the password
+CREATE TABLE IF NOT EXISTS auth_tokens
+(
+    token_hash character varying PRIMARY KEY,
+    user_id    character varying NOT NULL REFERENCES auth_users(id) ON DELETE CASCADE,
+    type       character varying NOT NULL,
+    expires_at timestamp WITH TIME ZONE NOT NULL,
+    created_at timestamp WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_token_user_id ON auth_tokens(user_id);
\ No newline at end of file
@@ -0,0 +1,38 @@
+-- Digest settings keep the email of the user, the SSO service is not available to the job sending the digests.
+-- The send time is the local time of the user in the time zone from the user settings
+CREATE TABLE IF NOT EXISTS digest_settings
+(
+    user_id           character varying PRIMARY KEY,
+    email             character varying NOT NULL,
+    daily             boolean NOT NULL DEFAULT false,
+    weekly            boolean NOT NULL DEFAULT false,
+    send_time         character varying NOT NULL DEFAULT '08:00',
+    weekly_day        integer NOT NULL DEFAULT 1,
+    unsubscribe_token character varying NOT NULL UNIQUE,
+    daily_sent_at     timestamp WITH TIME ZONE DEFAULT NULL,
+    weekly_sent_at    timestamp WITH TIME ZONE DEFAULT NULL,
+    created_at        timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at        timestamp WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE OR REPLACE FUNCTION delete_user_related_data(deleting_user_id varchar) RETURNS void AS $$
+BEGIN
+    DELETE FROM digest_settings WHERE user_id = deleting_user_id;
+    DELETE FROM personal_tokens WHERE user_id = deleting_user_id;
+    DELETE FROM template_tasks WHERE user_id = deleting_user_id;
+    DELETE FROM template_headings WHERE user_id = deleting_user_id;
+    DELETE FROM templates WHERE user_id = deleting_user_id;
+    DELETE FROM reminders WHERE user_id = deleting_user_id;
+    DELETE FROM time_entries WHERE user_id = deleting_user_id;
+    DELETE FROM focus_session_events WHERE user_id = deleting_user_id;
+    DELETE FROM focus_sessions WHERE user_id = deleting_user_id;
+    DELETE FROM tags WHERE user_id = deleting_user_id;
+    DELETE FROM tasks WHERE user_id = deleting_user_id;
+    DELETE FROM status_transitions WHERE user_id = deleting_user_id;
+    DELETE FROM statuses WHERE user_id = deleting_user_id;
+    DELETE FROM headings WHERE user_id = deleting_user_id;
+    DELETE FROM lists WHERE user_id = deleting_user_id;
+    DELETE FROM areas WHERE user_id = deleting_user_id;
+    DELETE FROM user_settings WHERE user_id = deleting_user_id;
+END;
+$$ LANGUAGE plpgsql;
\ No newline at end of file
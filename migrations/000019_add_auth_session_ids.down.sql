@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_auth_session_id;
+
+ALTER TABLE auth_sessions DROP COLUMN IF EXISTS last_seen_at;
+ALTER TABLE auth_sessions DROP COLUMN IF EXISTS id;
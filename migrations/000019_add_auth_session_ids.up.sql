@@ -0,0 +1,15 @@
+-- The sessions of the local authentication provider get an ID, which is kept when the refresh token is rotated,
+-- so that the user can see the sessions with the last seen time and revoke them
+ALTER TABLE auth_sessions ADD COLUMN IF NOT EXISTS id character varying;
+ALTER TABLE auth_sessions ADD COLUMN IF NOT EXISTS last_seen_at timestamp WITH TIME ZONE;
+
+UPDATE auth_sessions
+SET id = md5(random()::text || token_hash),
+    last_seen_at = created_at
+WHERE id IS NULL;
+
+ALTER TABLE auth_sessions ALTER COLUMN id SET NOT NULL;
+ALTER TABLE auth_sessions ALTER COLUMN last_seen_at SET NOT NULL;
+ALTER TABLE auth_sessions ALTER COLUMN last_seen_at SET DEFAULT now();
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_auth_session_id ON auth_sessions(id);
@@ -0,0 +1,10 @@
+-- The completed tasks are grouped by the time they were completed, the updated_at of a task
+-- changes with any edit. The tasks completed before have only the time of their last update
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completed_at timestamp WITH TIME ZONE DEFAULT NULL;
+
+UPDATE tasks
+SET completed_at = updated_at
+WHERE status_id = (SELECT id FROM statuses WHERE title = 'Completed' AND user_id IS NULL)
+  AND completed_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_user_completed_at ON tasks(user_id, completed_at) WHERE completed_at IS NOT NULL;